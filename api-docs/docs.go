@@ -24,7 +24,7 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/admin/queue/stats": {
+        "/api/v1/admin/custom-models": {
             "get": {
                 "security": [
                     {
@@ -34,56 +34,36 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get current queue statistics",
+                "description": "Get the fine-tuned checkpoints registered as model variants of local adapters",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Get queue statistics",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/api-keys": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all API keys for the current user (without exposing the actual keys)",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "api-keys"
-                ],
-                "summary": "List API keys",
+                "summary": "List custom models",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.APIKeysWrapper"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CustomModel"
+                            }
                         }
                     }
                 }
             },
             "post": {
                 "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new API key for external API access",
+                "description": "Register a fine-tuned checkpoint, given as a local path or Hugging Face repo ID, as a model variant of a local adapter. Profiles can then select it by name.",
                 "consumes": [
                     "application/json"
                 ],
@@ -91,17 +71,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "api-keys"
+                    "admin"
                 ],
-                "summary": "Create API key",
+                "summary": "Register a custom model",
                 "parameters": [
                     {
-                        "description": "API key creation details",
+                        "description": "Custom model",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.CreateAPIKeyRequest"
+                            "$ref": "#/definitions/api.CustomModelRequest"
                         }
                     }
                 ],
@@ -109,7 +89,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.CreateAPIKeyResponse"
+                            "$ref": "#/definitions/models.CustomModel"
                         }
                     },
                     "400": {
@@ -120,42 +100,63 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/v1/api-keys/{id}": {
-            "delete": {
+        "/api/v1/admin/custom-models/{id}": {
+            "put": {
                 "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete an API key",
+                "description": "Change a custom model's source, languages or names. A model that profiles use cannot be renamed or moved to another adapter.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "api-keys"
+                    "admin"
                 ],
-                "summary": "Delete API key",
+                "summary": "Update a custom model",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "API Key ID",
+                        "type": "string",
+                        "description": "Custom model ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Custom model",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.CustomModelRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.CustomModel"
                         }
                     },
                     "400": {
@@ -175,37 +176,42 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/auth/change-password": {
-            "post": {
+            },
+            "delete": {
                 "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Change the current user's password",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Unregister a custom model. The checkpoint itself is left on disk. A model that profiles use cannot be deleted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Change user password",
+                "summary": "Delete a custom model",
                 "parameters": [
                     {
-                        "description": "Password change details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.ChangePasswordRequest"
-                        }
+                        "type": "string",
+                        "description": "Custom model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -218,8 +224,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -227,8 +233,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -239,44 +245,126 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/auth/change-username": {
-            "post": {
+        "/api/v1/admin/models": {
+            "get": {
                 "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Change the current user's username",
-                "consumes": [
+                "description": "Get each adapter's environment path, readiness, installed model variants, disk usage and latest install task",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List model environments",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/environments.Environment"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/models/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one adapter's environment state, installed model variants and disk usage",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Change username",
+                "summary": "Get a model environment",
                 "parameters": [
                     {
-                        "description": "Username change details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.ChangeUsernameRequest"
-                        }
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/environments.Environment"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an adapter's environment, or one of its model variants. The model is unavailable until installed again. Environments shared with other models need force. Models that queued or running jobs may use are not removed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Remove a model environment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model variant to remove instead of the environment",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remove an environment shared with other models",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/environments.Environment"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -287,8 +375,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -308,9 +396,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/auth/login": {
+        "/api/v1/admin/models/{id}/install": {
             "post": {
-                "description": "Authenticate user and return JWT token",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Prepare an adapter's environment, or download one of its model variants, in the background. Progress is sent as model_install_* events on the \"models\" SSE channel. A reinstall is refused while queued or running jobs may use the environment.",
                 "consumes": [
                     "application/json"
                 ],
@@ -318,25 +414,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Login",
+                "summary": "Install a model environment",
                 "parameters": [
                     {
-                        "description": "User credentials",
-                        "name": "credentials",
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "What to install",
+                        "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.LoginRequest"
+                            "$ref": "#/definitions/api.InstallModelRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/api.LoginResponse"
+                            "$ref": "#/definitions/environments.Task"
                         }
                     },
                     "400": {
@@ -348,36 +450,77 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/auth/logout": {
-            "post": {
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/queue/cancel": {
+            "post": {
                 "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Logout user and invalidate token (client-side action)",
+                "description": "Cancel jobs that have not started yet. An empty job_ids list cancels every pending job.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
+                ],
+                "summary": "Cancel pending jobs",
+                "parameters": [
+                    {
+                        "description": "Jobs to cancel",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.QueueJobsRequest"
+                        }
+                    }
                 ],
-                "summary": "Logout user",
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -388,38 +531,101 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/auth/refresh": {
+        "/api/v1/admin/queue/drain": {
             "post": {
-                "description": "Rotate refresh token and return new access token",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Let running jobs finish without starting new ones. A queue_drained event is sent when no job is running.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Refresh access token",
+                "summary": "Drain the queue",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.RefreshTokenResponse"
+                            "$ref": "#/definitions/api.QueueStateResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/queue/pause": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop starting pending jobs. Running jobs continue.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Pause the queue",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.QueueStateResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/queue/pending": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the queue state and the IDs of pending jobs in dispatch order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List pending jobs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/api.QueueStateResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/auth/register": {
+        "/api/v1/admin/queue/reorder": {
             "post": {
-                "description": "Register the initial admin user (only allowed when no users exist)",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move the listed pending jobs to the front of the queue in the given order",
                 "consumes": [
                     "application/json"
                 ],
@@ -427,25 +633,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Register initial admin user",
+                "summary": "Reorder pending jobs",
                 "parameters": [
                     {
-                        "description": "Registration details",
+                        "description": "Jobs in their new order",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.RegisterRequest"
+                            "$ref": "#/definitions/api.QueueJobsRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.LoginResponse"
+                            "$ref": "#/definitions/api.QueueStateResponse"
                         }
                     },
                     "400": {
@@ -469,27 +675,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/auth/registration-status": {
-            "get": {
-                "description": "Check if the application requires initial user registration",
+        "/api/v1/admin/queue/resume": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Resume starting pending jobs after a pause or drain",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Check registration status",
+                "summary": "Resume the queue",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.RegistrationStatusResponse"
+                            "$ref": "#/definitions/api.QueueStateResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/chat/models": {
+        "/api/v1/admin/queue/stats": {
             "get": {
                 "security": [
                     {
@@ -499,43 +713,55 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of available OpenAI chat models",
+                "description": "Get current queue statistics",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "admin"
                 ],
-                "summary": "Get available chat models",
+                "summary": "Get queue statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.ChatModelsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/workers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all active remote workers with their last heartbeat and current job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List remote workers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Worker"
                             }
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/chat/sessions": {
+            },
             "post": {
                 "security": [
                     {
@@ -545,7 +771,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new chat session for a transcription",
+                "description": "Create a worker token for a remote transcription worker. The token is only shown once.",
                 "consumes": [
                     "application/json"
                 ],
@@ -553,17 +779,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "admin"
                 ],
-                "summary": "Create a new chat session",
+                "summary": "Register a remote worker",
                 "parameters": [
                     {
-                        "description": "Chat session creation request",
+                        "description": "Worker details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.ChatCreateRequest"
+                            "$ref": "#/definitions/api.CreateWorkerRequest"
                         }
                     }
                 ],
@@ -571,7 +797,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.ChatSessionResponse"
+                            "$ref": "#/definitions/api.CreateWorkerResponse"
                         }
                     },
                     "400": {
@@ -582,30 +808,12 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             }
         },
-        "/api/v1/chat/sessions/{session_id}": {
-            "get": {
+        "/api/v1/admin/workers/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -614,19 +822,19 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a specific chat session with all its messages",
+                "description": "Revoke a worker token. Jobs it holds are requeued once their lease expires.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "admin"
                 ],
-                "summary": "Get a chat session with messages",
+                "summary": "Revoke a remote worker",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Chat Session ID",
-                        "name": "session_id",
+                        "description": "Worker ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -634,12 +842,6 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.ChatSessionWithMessages"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -647,8 +849,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -657,102 +859,65 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/api-keys": {
+            "get": {
                 "security": [
-                    {
-                        "ApiKeyAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a chat session and all its messages",
+                "description": "Get all API keys for the current user (without exposing the actual keys)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
-                ],
-                "summary": "Delete a chat session",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Chat Session ID",
-                        "name": "session_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "api-keys"
                 ],
+                "summary": "List API keys",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/api.APIKeysWrapper"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/chat/sessions/{session_id}/messages": {
+            },
             "post": {
                 "security": [
-                    {
-                        "ApiKeyAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send a message to a chat session and get streaming response",
+                "description": "Create a new API key for external API access",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "api-keys"
                 ],
-                "summary": "Send a message to a chat session",
+                "summary": "Create API key",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Chat Session ID",
-                        "name": "session_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Message content",
-                        "name": "message",
+                        "description": "API key creation details",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.ChatMessageRequest"
+                            "$ref": "#/definitions/api.CreateAPIKeyRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Streaming response",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.CreateAPIKeyResponse"
                         }
                     },
                     "400": {
@@ -763,79 +928,37 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             }
         },
-        "/api/v1/chat/sessions/{session_id}/title": {
-            "put": {
+        "/api/v1/api-keys/{id}": {
+            "delete": {
                 "security": [
-                    {
-                        "ApiKeyAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the title of a chat session",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete an API key",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "api-keys"
                 ],
-                "summary": "Update chat session title",
+                "summary": "Delete API key",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Chat Session ID",
-                        "name": "session_id",
+                        "type": "integer",
+                        "description": "API Key ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Title update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.ChatSessionResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -843,8 +966,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -852,8 +975,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -864,38 +987,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/chat/sessions/{session_id}/title/auto": {
+        "/api/v1/auth/change-password": {
             "post": {
                 "security": [
-                    {
-                        "ApiKeyAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Uses the configured LLM to summarize the first exchange into a concise title. Only updates if the current title appears default/user-unset.",
+                "description": "Change the current user's password",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "auth"
                 ],
-                "summary": "Auto-generate chat session title",
+                "summary": "Change user password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Chat Session ID",
-                        "name": "session_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Password change details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ChangePasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.ChatSessionResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -907,8 +1035,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -919,40 +1047,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/chat/transcriptions/{transcription_id}/sessions": {
-            "get": {
+        "/api/v1/auth/change-username": {
+            "post": {
                 "security": [
-                    {
-                        "ApiKeyAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all chat sessions for a specific transcription",
+                "description": "Change the current user's username",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "auth"
                 ],
-                "summary": "Get chat sessions for a transcription",
+                "summary": "Change username",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Transcription ID",
-                        "name": "transcription_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Username change details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ChangeUsernameRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/api.ChatSessionResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -965,8 +1095,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -977,17 +1116,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/config/openai/validate": {
+        "/api/v1/auth/login": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    },
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Validate the provided OpenAI API key and return available Whisper models",
+                "description": "Authenticate user and return JWT token",
                 "consumes": [
                     "application/json"
                 ],
@@ -995,17 +1126,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "config"
+                    "auth"
                 ],
-                "summary": "Validate OpenAI API Key",
+                "summary": "Login",
                 "parameters": [
                     {
-                        "description": "API Key",
-                        "name": "request",
+                        "description": "User credentials",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.ValidateOpenAIKeyRequest"
+                            "$ref": "#/definitions/api.LoginRequest"
                         }
                     }
                 ],
@@ -1013,8 +1144,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/api.LoginResponse"
                         }
                     },
                     "400": {
@@ -1034,63 +1164,57 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             }
         },
-        "/api/v1/events": {
-            "get": {
-                "description": "Subscribe to server-sent events",
-                "produces": [
-                    "text/event-stream"
-                ],
-                "tags": [
-                    "events"
+        "/api/v1/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
-                "summary": "SSE Events",
+                "description": "Logout user and invalidate token (client-side action)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Logout user",
                 "responses": {
                     "200": {
-                        "description": "stream",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/v1/llm/config": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get the current active LLM configuration",
+        "/api/v1/auth/refresh": {
+            "post": {
+                "description": "Rotate refresh token and return new access token",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "llm"
+                    "auth"
                 ],
-                "summary": "Get LLM configuration",
+                "summary": "Refresh access token",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.LLMConfigResponse"
+                            "$ref": "#/definitions/api.RefreshTokenResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1099,14 +1223,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/auth/register": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create or update LLM configuration settings",
+                "description": "Register the initial admin user (only allowed when no users exist)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1114,25 +1235,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "llm"
+                    "auth"
                 ],
-                "summary": "Create or update LLM configuration",
+                "summary": "Register initial admin user",
                 "parameters": [
                     {
-                        "description": "LLM configuration details",
+                        "description": "Registration details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.LLMConfigRequest"
+                            "$ref": "#/definitions/api.RegisterRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.LLMConfigResponse"
+                            "$ref": "#/definitions/api.LoginResponse"
                         }
                     },
                     "400": {
@@ -1143,11 +1264,40 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/v1/notes/{note_id}": {
+        "/api/v1/auth/registration-status": {
+            "get": {
+                "description": "Check if the application requires initial user registration",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Check registration status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.RegistrationStatusResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/chat/models": {
             "get": {
                 "security": [
                     {
@@ -1157,32 +1307,32 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a note by its ID",
+                "description": "Get list of available OpenAI chat models",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notes"
-                ],
-                "summary": "Get a note",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Note ID",
-                        "name": "note_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "chat"
                 ],
+                "summary": "Get available chat models",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Note"
+                            "$ref": "#/definitions/api.ChatModelsResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1191,8 +1341,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v1/chat/sessions": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -1201,7 +1353,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the content of a note",
+                "description": "Create a new chat session for a transcription",
                 "consumes": [
                     "application/json"
                 ],
@@ -1209,32 +1361,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notes"
+                    "chat"
                 ],
-                "summary": "Update a note",
+                "summary": "Create a new chat session",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Note ID",
-                        "name": "note_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Note update payload",
+                        "description": "Chat session creation request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.NoteUpdateRequest"
+                            "$ref": "#/definitions/api.ChatCreateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Note"
+                            "$ref": "#/definitions/api.ChatSessionResponse"
                         }
                     },
                     "400": {
@@ -1254,37 +1399,60 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/chat/sessions/{session_id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Delete a note by its ID",
+                "description": "Get a specific chat session with all its messages",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notes"
+                    "chat"
                 ],
-                "summary": "Delete a note",
+                "summary": "Get a chat session with messages",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Note ID",
-                        "name": "note_id",
+                        "description": "Chat Session ID",
+                        "name": "session_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ChatSessionWithMessages"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
@@ -1297,10 +1465,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/profiles": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -1309,26 +1475,49 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of all transcription profiles",
+                "description": "Delete a chat session and all its messages",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "profiles"
+                    "chat"
+                ],
+                "summary": "Delete a chat session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chat Session ID",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List transcription profiles",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.TranscriptionProfile"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/chat/sessions/{session_id}/messages": {
             "post": {
                 "security": [
                     {
@@ -1338,33 +1527,40 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new transcription profile",
+                "description": "Send a message to a chat session and get streaming response",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "profiles"
+                    "chat"
                 ],
-                "summary": "Create transcription profile",
+                "summary": "Send a message to a chat session",
                 "parameters": [
                     {
-                        "description": "Profile data",
-                        "name": "profile",
+                        "type": "string",
+                        "description": "Chat Session ID",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Message content",
+                        "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionProfile"
+                            "$ref": "#/definitions/api.ChatMessageRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Streaming response",
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionProfile"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1375,46 +1571,18 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/profiles/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
                     },
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get a transcription profile by ID",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "profiles"
-                ],
-                "summary": "Get transcription profile",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Profile ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionProfile"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1423,7 +1591,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/chat/sessions/{session_id}/title": {
             "put": {
                 "security": [
                     {
@@ -1433,7 +1603,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a transcription profile",
+                "description": "Update the title of a chat session",
                 "consumes": [
                     "application/json"
                 ],
@@ -1441,24 +1611,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "profiles"
+                    "chat"
                 ],
-                "summary": "Update transcription profile",
+                "summary": "Update chat session title",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Profile ID",
-                        "name": "id",
+                        "description": "Chat Session ID",
+                        "name": "session_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated profile data",
-                        "name": "profile",
+                        "description": "Title update request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionProfile"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 ],
@@ -1466,7 +1639,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionProfile"
+                            "$ref": "#/definitions/api.ChatSessionResponse"
                         }
                     },
                     "400": {
@@ -1486,10 +1659,21 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/chat/sessions/{session_id}/title/auto": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -1498,19 +1682,19 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a transcription profile",
+                "description": "Uses the configured LLM to summarize the first exchange into a concise title. Only updates if the current title appears default/user-unset.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "profiles"
+                    "chat"
                 ],
-                "summary": "Delete transcription profile",
+                "summary": "Auto-generate chat session title",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Profile ID",
-                        "name": "id",
+                        "description": "Chat Session ID",
+                        "name": "session_id",
                         "in": "path",
                         "required": true
                     }
@@ -1518,6 +1702,12 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ChatSessionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1537,8 +1727,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/profiles/{id}/set-default": {
-            "post": {
+        "/api/v1/chat/transcriptions/{transcription_id}/sessions": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -1547,19 +1737,19 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mark the specified profile as the default profile",
+                "description": "Get all chat sessions for a specific transcription",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "profiles"
+                    "chat"
                 ],
-                "summary": "Set default transcription profile",
+                "summary": "Get chat sessions for a transcription",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Profile ID",
-                        "name": "id",
+                        "description": "Transcription ID",
+                        "name": "transcription_id",
                         "in": "path",
                         "required": true
                     }
@@ -1568,8 +1758,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.ChatSessionResponse"
+                            }
                         }
                     },
                     "400": {
@@ -1581,15 +1773,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1602,52 +1785,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/summaries": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    },
-                    {
-                        "BearerAuth": []
-                    },
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all summarization templates",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "summaries"
-                ],
-                "summary": "List summarization templates",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.SummaryTemplate"
-                            }
-                        }
-                    }
-                }
-            },
+        "/api/v1/config/openai/validate": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     },
-                    {
-                        "BearerAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new summarization template",
+                "description": "Validate the provided OpenAI API key and return available Whisper models",
                 "consumes": [
                     "application/json"
                 ],
@@ -1655,25 +1803,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "summaries"
+                    "config"
                 ],
-                "summary": "Create summarization template",
+                "summary": "Validate OpenAI API Key",
                 "parameters": [
                     {
-                        "description": "Template payload",
+                        "description": "API Key",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.SummaryTemplateRequest"
+                            "$ref": "#/definitions/api.ValidateOpenAIKeyRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SummaryTemplate"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -1685,6 +1834,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1697,30 +1855,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/summaries/settings": {
+        "/api/v1/events": {
+            "get": {
+                "description": "Subscribe to server-sent events",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "SSE Events",
+                "responses": {
+                    "200": {
+                        "description": "stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/llm/config": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Get global summarization settings",
+                "description": "Get the current active LLM configuration",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "summaries"
+                    "llm"
                 ],
-                "summary": "Get summary settings",
+                "summary": "Get LLM configuration",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.SummarySettingsResponse"
+                            "$ref": "#/definitions/api.LLMConfigResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1733,10 +1911,10 @@ const docTemplate = `{
             "post": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Create or update global summarization settings",
+                "description": "Create or update LLM configuration settings",
                 "consumes": [
                     "application/json"
                 ],
@@ -1744,17 +1922,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "summaries"
+                    "llm"
                 ],
-                "summary": "Save summary settings",
+                "summary": "Create or update LLM configuration",
                 "parameters": [
                     {
-                        "description": "Settings payload",
+                        "description": "LLM configuration details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.SummarySettingsRequest"
+                            "$ref": "#/definitions/api.LLMConfigRequest"
                         }
                     }
                 ],
@@ -1762,7 +1940,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.SummarySettingsResponse"
+                            "$ref": "#/definitions/api.LLMConfigResponse"
                         }
                     },
                     "400": {
@@ -1773,45 +1951,33 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             }
         },
-        "/api/v1/summaries/{id}": {
+        "/api/v1/notes/{note_id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     },
-                    {
-                        "BearerAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a summarization template by ID",
+                "description": "Get a note by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "summaries"
+                    "notes"
                 ],
-                "summary": "Get summarization template",
+                "summary": "Get a note",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Template ID",
-                        "name": "id",
+                        "description": "Note ID",
+                        "name": "note_id",
                         "in": "path",
                         "required": true
                     }
@@ -1820,7 +1986,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SummaryTemplate"
+                            "$ref": "#/definitions/models.Note"
                         }
                     },
                     "404": {
@@ -1831,15 +1997,6 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             },
@@ -1848,14 +2005,11 @@ const docTemplate = `{
                     {
                         "ApiKeyAuth": []
                     },
-                    {
-                        "BearerAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a summarization template by ID",
+                "description": "Update the content of a note",
                 "consumes": [
                     "application/json"
                 ],
@@ -1863,24 +2017,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "summaries"
+                    "notes"
                 ],
-                "summary": "Update summarization template",
+                "summary": "Update a note",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Template ID",
-                        "name": "id",
+                        "description": "Note ID",
+                        "name": "note_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Template payload",
+                        "description": "Note update payload",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.SummaryTemplateRequest"
+                            "$ref": "#/definitions/api.NoteUpdateRequest"
                         }
                     }
                 ],
@@ -1888,7 +2042,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SummaryTemplate"
+                            "$ref": "#/definitions/models.Note"
                         }
                     },
                     "400": {
@@ -1908,15 +2062,6 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             },
@@ -1926,19 +2071,19 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete a summarization template by ID",
+                "description": "Delete a note by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "summaries"
+                    "notes"
                 ],
-                "summary": "Delete summarization template",
+                "summary": "Delete a note",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Template ID",
-                        "name": "id",
+                        "description": "Note ID",
+                        "name": "note_id",
                         "in": "path",
                         "required": true
                     }
@@ -1962,7 +2107,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/summarize": {
+        "/api/v1/profiles": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of all transcription profiles",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profiles"
+                ],
+                "summary": "List transcription profiles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TranscriptionProfile"
+                            }
+                        }
+                    }
+                }
+            },
             "post": {
                 "security": [
                     {
@@ -1972,33 +2146,33 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Stream an LLM-generated summary for provided content; persists latest summary for the transcription",
+                "description": "Create a new transcription profile",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/event-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "summarize"
+                    "profiles"
                 ],
-                "summary": "Summarize content",
+                "summary": "Create transcription profile",
                 "parameters": [
                     {
-                        "description": "Summarize request",
-                        "name": "request",
+                        "description": "Profile data",
+                        "name": "profile",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.SummarizeRequest"
+                            "$ref": "#/definitions/models.TranscriptionProfile"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Event stream",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.TranscriptionProfile"
                         }
                     },
                     "400": {
@@ -2009,20 +2183,11 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             }
         },
-        "/api/v1/transcription/list": {
+        "/api/v1/profiles/{id}": {
             "get": {
                 "security": [
                     {
@@ -2032,70 +2197,97 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of all transcription jobs with optional search and filtering",
+                "description": "Get a transcription profile by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transcription"
+                    "profiles"
                 ],
-                "summary": "List all transcription records",
+                "summary": "Get transcription profile",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Sort By",
-                        "name": "sort_by",
-                        "in": "query"
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TranscriptionProfile"
+                        }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Sort Order (asc/desc)",
-                        "name": "sort_order",
-                        "in": "query"
+                        "ApiKeyAuth": []
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
-                        "in": "query"
-                    },
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a transcription profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profiles"
+                ],
+                "summary": "Update transcription profile",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Search in title and audio filename",
-                        "name": "q",
-                        "in": "query"
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by updated_at \u003e timestamp (RFC3339)",
-                        "name": "updated_after",
-                        "in": "query"
+                        "description": "Updated profile data",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TranscriptionProfile"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TranscriptionProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2104,10 +2296,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/transcription/models": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -2116,14 +2306,72 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of supported WhisperX models",
+                "description": "Delete a transcription profile",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transcription"
+                    "profiles"
+                ],
+                "summary": "Delete transcription profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/profiles/{id}/set-default": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark the specified profile as the default profile",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profiles"
+                ],
+                "summary": "Set default transcription profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get supported models",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2131,57 +2379,198 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/v1/transcription/quick": {
+        "/api/v1/summaries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all summarization templates",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "summaries"
+                ],
+                "summary": "List summarization templates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SummaryTemplate"
+                            }
+                        }
+                    }
+                }
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     },
+                    {
+                        "BearerAuth": []
+                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Submit an audio file for temporary transcription (data discarded after 6 hours)",
+                "description": "Create a new summarization template",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transcription"
+                    "summaries"
                 ],
-                "summary": "Submit quick transcription job",
+                "summary": "Create summarization template",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Audio file",
-                        "name": "audio",
-                        "in": "formData",
-                        "required": true
+                        "description": "Template payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SummaryTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SummaryTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/summaries/settings": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "JSON string of transcription parameters",
-                        "name": "parameters",
-                        "in": "formData"
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get global summarization settings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "summaries"
+                ],
+                "summary": "Get summary settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SummarySettingsResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Profile name to use for transcription",
-                        "name": "profile_name",
-                        "in": "formData"
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create or update global summarization settings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "summaries"
+                ],
+                "summary": "Save summary settings",
+                "parameters": [
+                    {
+                        "description": "Settings payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SummarySettingsRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/transcription.QuickTranscriptionJob"
+                            "$ref": "#/definitions/api.SummarySettingsResponse"
                         }
                     },
                     "400": {
@@ -2205,28 +2594,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transcription/quick/{id}": {
+        "/api/v1/summaries/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     },
+                    {
+                        "BearerAuth": []
+                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the current status of a quick transcription job",
+                "description": "Get a summarization template by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transcription"
+                    "summaries"
                 ],
-                "summary": "Get quick transcription status",
+                "summary": "Get summarization template",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
+                        "description": "Template ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2236,7 +2628,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/transcription.QuickTranscriptionJob"
+                            "$ref": "#/definitions/models.SummaryTemplate"
                         }
                     },
                     "404": {
@@ -2247,23 +2639,1674 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a summarization template by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "summaries"
+                ],
+                "summary": "Update summarization template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Template payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SummaryTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SummaryTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a summarization template by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "summaries"
+                ],
+                "summary": "Delete summarization template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/summarize": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stream an LLM-generated summary for provided content; persists latest summary for the transcription",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "summarize"
+                ],
+                "summary": "Summarize content",
+                "parameters": [
+                    {
+                        "description": "Summarize request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SummarizeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all transcription jobs with optional search and filtering",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "List all transcription records",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort By",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort Order (asc/desc)",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search in title and audio filename",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by updated_at \u003e timestamp (RFC3339)",
+                        "name": "updated_after",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/models": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of supported WhisperX models",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Get supported models",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/quick": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Submit an audio file for temporary transcription (data discarded after 6 hours)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Submit quick transcription job",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Audio file",
+                        "name": "audio",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON string of transcription parameters",
+                        "name": "parameters",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Profile name to use for transcription",
+                        "name": "profile_name",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transcription.QuickTranscriptionJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/quick/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current status of a quick transcription job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Get quick transcription status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transcription.QuickTranscriptionJob"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/submit": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Submit an audio file for transcription with WhisperX",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Submit a transcription job",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Audio file",
+                        "name": "audio",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job title",
+                        "name": "title",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Enable speaker diarization",
+                        "name": "diarization",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "default": "base",
+                        "description": "Whisper model",
+                        "name": "model",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code",
+                        "name": "language",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 16,
+                        "description": "Batch size",
+                        "name": "batch_size",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "default": "float16",
+                        "description": "Compute type",
+                        "name": "compute_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "default": "auto",
+                        "description": "Device",
+                        "name": "device",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Enable VAD filter",
+                        "name": "vad_filter",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "default": 0.5,
+                        "description": "VAD onset",
+                        "name": "vad_onset",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "default": 0.363,
+                        "description": "VAD offset",
+                        "name": "vad_offset",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum speakers for diarization",
+                        "name": "min_speakers",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum speakers for diarization",
+                        "name": "max_speakers",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TranscriptionJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/upload": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload an audio file without starting transcription",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Upload audio file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Audio file",
+                        "name": "audio",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job title",
+                        "name": "title",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TranscriptionJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/upload-multitrack": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload multiple audio files for multi-track transcription",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Upload multi-track audio files",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job title",
+                        "name": "title",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Audio track files",
+                        "name": "files",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TranscriptionJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/upload-video": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a video file, extract audio from it using ffmpeg, and create a transcription job",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Upload video file for transcription",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Video file",
+                        "name": "video",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job title",
+                        "name": "title",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TranscriptionJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/youtube": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download audio from a YouTube video URL and prepare it for transcription",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Download audio from YouTube URL",
+                "parameters": [
+                    {
+                        "description": "YouTube download request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.YouTubeDownloadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TranscriptionJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get details of a specific transcription job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Get transcription job details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TranscriptionJob"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a transcription job and its associated files",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Delete transcription job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/{id}/align": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a word alignment of a completed job's current transcript with the WhisperX alignment model, for models without word timings or after the text was edited. Segments, their text and speakers are kept, the words are replaced and notes move to the words in their time span. The aligned transcript is saved as a new revision, which the task in the response holds once done.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Align a transcript's words",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alignment settings",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.AlignTranscriptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.TranscriptTask"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/{id}/audio": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Serve the audio file for a transcription job",
+                "produces": [
+                    "audio/mpeg",
+                    "audio/wav",
+                    "audio/mp4"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Get audio file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/{id}/diarize": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a diarization of a completed job's audio. Its speakers are merged into the current transcript without changing the text, the result is saved as a new transcript revision and the new speakers replace the job's speaker mappings. The task in the response holds the revision once the diarization is done.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Diarize a finished transcript",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Diarization settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.DiarizeTranscriptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.TranscriptTask"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/{id}/execution": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get execution parameters and timing for a transcription job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Get transcription job execution data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TranscriptionJobExecution"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/{id}/kill": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a currently running transcription job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Kill running transcription job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/{id}/merge-status": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current merge status for a multi-track job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Get multi-track merge status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/{id}/notes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all notes attached to a transcription, ordered by time and creation",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "List notes for a transcription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transcription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Note"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new note attached to the specified transcription",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Create a note for a transcription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transcription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note create payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.NoteCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Note"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/{id}/reprocess": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Run AI post-processing on an existing transcript to add punctuation and clean up text",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Reprocess transcript with AI post-processor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/{id}/retranscribe": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a new transcription of part of a completed job's audio, e.g. with another model or language. The range is widened to the segments it overlaps and only those segments of the current transcript are replaced; the new speech gets the speakers of the speech it replaces and notes move to its words. Once done, the task in the response holds the widened range and the new transcript revision.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Transcribe a time range again",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Time range and transcription settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RetranscribeRangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.TranscriptTask"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the saved versions of a job's transcript, oldest first. Revisions are stored once a finished transcript is changed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "List transcript revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TranscriptRevision"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transcription/{id}/speakers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves all custom speaker names for a transcription job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcription"
+                ],
+                "summary": "Get speaker mappings for a transcription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transcription Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.SpeakerMappingResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/transcription/submit": {
+            },
             "post": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     },
                     {
-                        "BearerAuth": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Submit an audio file for transcription with WhisperX",
+                "description": "Updates or creates custom speaker names for a transcription job",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -2271,99 +4314,33 @@ const docTemplate = `{
                 "tags": [
                     "transcription"
                 ],
-                "summary": "Submit a transcription job",
+                "summary": "Update speaker mappings for a transcription",
                 "parameters": [
-                    {
-                        "type": "file",
-                        "description": "Audio file",
-                        "name": "audio",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Job title",
-                        "name": "title",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Enable speaker diarization",
-                        "name": "diarization",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "default": "base",
-                        "description": "Whisper model",
-                        "name": "model",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Language code",
-                        "name": "language",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 16,
-                        "description": "Batch size",
-                        "name": "batch_size",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "default": "float16",
-                        "description": "Compute type",
-                        "name": "compute_type",
-                        "in": "formData"
-                    },
                     {
                         "type": "string",
-                        "default": "auto",
-                        "description": "Device",
-                        "name": "device",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Enable VAD filter",
-                        "name": "vad_filter",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "number",
-                        "default": 0.5,
-                        "description": "VAD onset",
-                        "name": "vad_onset",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "number",
-                        "default": 0.363,
-                        "description": "VAD offset",
-                        "name": "vad_offset",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Minimum speakers for diarization",
-                        "name": "min_speakers",
-                        "in": "formData"
+                        "description": "Transcription Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Maximum speakers for diarization",
-                        "name": "max_speakers",
-                        "in": "formData"
+                        "description": "Speaker mappings to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SpeakerMappingsUpdateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionJob"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.SpeakerMappingResponse"
+                            }
                         }
                     },
                     "400": {
@@ -2375,6 +4352,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2387,7 +4373,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transcription/upload": {
+        "/api/v1/transcription/{id}/speakers/merge": {
             "post": {
                 "security": [
                     {
@@ -2397,9 +4383,9 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload an audio file without starting transcription",
+                "description": "Relabel everything one speaker said as another speaker, for diarizations that split one person in two. The result is saved as a new transcript revision and the merged speaker's name mapping is removed.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -2407,27 +4393,30 @@ const docTemplate = `{
                 "tags": [
                     "transcription"
                 ],
-                "summary": "Upload audio file",
+                "summary": "Merge two speakers",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Audio file",
-                        "name": "audio",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Job title",
-                        "name": "title",
-                        "in": "formData"
+                        "description": "Speakers to merge",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.MergeSpeakersRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionJob"
+                            "$ref": "#/definitions/api.TranscriptRevisionResponse"
                         }
                     },
                     "400": {
@@ -2439,8 +4428,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2451,7 +4449,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transcription/upload-multitrack": {
+        "/api/v1/transcription/{id}/speakers/reassign": {
             "post": {
                 "security": [
                     {
@@ -2461,9 +4459,9 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload multiple audio files for multi-track transcription",
+                "description": "Give selected segments, or the words of a time range, to another speaker of the transcript. Segments are split where only some of their words change speaker. The result is saved as a new transcript revision.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -2471,27 +4469,30 @@ const docTemplate = `{
                 "tags": [
                     "transcription"
                 ],
-                "summary": "Upload multi-track audio files",
+                "summary": "Reassign part of a transcript",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job title",
-                        "name": "title",
-                        "in": "formData"
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Audio track files",
-                        "name": "files",
-                        "in": "formData",
-                        "required": true
+                        "description": "Selection and its speaker",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ReassignSpeakerRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionJob"
+                            "$ref": "#/definitions/api.TranscriptRevisionResponse"
                         }
                     },
                     "400": {
@@ -2503,8 +4504,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2515,7 +4525,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transcription/upload-video": {
+        "/api/v1/transcription/{id}/speakers/split": {
             "post": {
                 "security": [
                     {
@@ -2525,9 +4535,9 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload a video file, extract audio from it using ffmpeg, and create a transcription job",
+                "description": "Give selected segments, or the words of a time range, to a new speaker, for diarizations that merged two people. The new speaker gets the next free SPEAKER_NN label and, if given, a custom name. The result is saved as a new transcript revision.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -2535,27 +4545,30 @@ const docTemplate = `{
                 "tags": [
                     "transcription"
                 ],
-                "summary": "Upload video file for transcription",
+                "summary": "Split off a new speaker",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Video file",
-                        "name": "video",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Job title",
-                        "name": "title",
-                        "in": "formData"
+                        "description": "Selection of the new speaker",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SplitSpeakerRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionJob"
+                            "$ref": "#/definitions/api.TranscriptRevisionResponse"
                         }
                     },
                     "400": {
@@ -2567,8 +4580,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2579,7 +4601,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transcription/youtube": {
+        "/api/v1/transcription/{id}/speakers/suggestions": {
             "post": {
                 "security": [
                     {
@@ -2589,7 +4611,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Download audio from a YouTube video URL and prepare it for transcription",
+                "description": "Send the diarized transcript to the configured LLM and get suggested names and roles for its speakers, with supporting quotes and a confidence. Nothing is saved; the returned mappings can be posted to the speaker mappings endpoint to accept the suggestions. Speakers confirmed from the speaker library keep their names.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2599,15 +4621,22 @@ const docTemplate = `{
                 "tags": [
                     "transcription"
                 ],
-                "summary": "Download audio from YouTube URL",
+                "summary": "Suggest speaker names",
                 "parameters": [
                     {
-                        "description": "YouTube download request",
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "LLM model",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.YouTubeDownloadRequest"
+                            "$ref": "#/definitions/api.SpeakerSuggestionRequest"
                         }
                     }
                 ],
@@ -2615,7 +4644,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionJob"
+                            "$ref": "#/definitions/api.SpeakerSuggestionsResponse"
                         }
                     },
                     "400": {
@@ -2627,6 +4656,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2639,8 +4686,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transcription/{id}": {
-            "get": {
+        "/api/v1/transcription/{id}/speakers/{speaker}/voice": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -2649,14 +4696,17 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get details of a specific transcription job",
+                "description": "Confirm the voice a job's speaker was matched to, or correct it by choosing another voice or naming a new one. The speaker's embedding is added to the voice, so later recordings are matched better.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "transcription"
                 ],
-                "summary": "Get transcription job details",
+                "summary": "Confirm a speaker's voice",
                 "parameters": [
                     {
                         "type": "string",
@@ -2664,13 +4714,38 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Diarized speaker label",
+                        "name": "speaker",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Voice of the speaker",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SpeakerVoiceRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionJob"
+                            "$ref": "#/definitions/api.SpeakerVoiceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
@@ -2693,14 +4768,14 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a transcription job and its associated files",
+                "description": "Remove the voice a job's speaker was matched to. The speaker goes back to its diarized label and its embedding is taken out of the voice's library.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "transcription"
                 ],
-                "summary": "Delete transcription job",
+                "summary": "Reject a speaker's voice",
                 "parameters": [
                     {
                         "type": "string",
@@ -2708,6 +4783,13 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Diarized speaker label",
+                        "name": "speaker",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2720,15 +4802,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2741,23 +4814,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transcription/{id}/audio": {
-            "get": {
+        "/api/v1/transcription/{id}/start": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Serve the audio file for a transcription job",
+                "description": "Start transcription for an already uploaded audio file",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
-                    "audio/mpeg",
-                    "audio/wav",
-                    "audio/mp4"
+                    "application/json"
                 ],
                 "tags": [
                     "transcription"
                 ],
-                "summary": "Get audio file",
+                "summary": "Start transcription for uploaded file",
                 "parameters": [
                     {
                         "type": "string",
@@ -2765,13 +4842,31 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Transcription parameters",
+                        "name": "parameters",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WhisperXParams"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/models.TranscriptionJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
@@ -2786,7 +4881,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transcription/{id}/execution": {
+        "/api/v1/transcription/{id}/status": {
             "get": {
                 "security": [
                     {
@@ -2796,14 +4891,14 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get execution parameters and timing for a transcription job",
+                "description": "Get the current status of a transcription job",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "transcription"
                 ],
-                "summary": "Get transcription job execution data",
+                "summary": "Get job status",
                 "parameters": [
                     {
                         "type": "string",
@@ -2817,7 +4912,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionJobExecution"
+                            "$ref": "#/definitions/models.TranscriptionJob"
                         }
                     },
                     "404": {
@@ -2832,8 +4927,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transcription/{id}/kill": {
-            "post": {
+        "/api/v1/transcription/{id}/summary": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -2842,18 +4937,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Cancel a currently running transcription job",
+                "description": "Get the most recent saved summary for the given transcription",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transcription"
+                    "summarize"
                 ],
-                "summary": "Kill running transcription job",
+                "summary": "Get latest summary for transcription",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
+                        "description": "Transcription ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2863,10 +4958,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.Summary"
                         }
                     },
                     "400": {
@@ -2886,11 +4978,20 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/v1/transcription/{id}/merge-status": {
+        "/api/v1/transcription/{id}/transcript-task": {
             "get": {
                 "security": [
                     {
@@ -2900,14 +5001,14 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the current merge status for a multi-track job",
+                "description": "Get the state of the latest diarization, alignment or range transcription queued for a job since the server started. Changes are also sent as transcript_task events on the job's SSE channel.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "transcription"
                 ],
-                "summary": "Get multi-track merge status",
+                "summary": "Get a job's transcript task",
                 "parameters": [
                     {
                         "type": "string",
@@ -2921,8 +5022,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/api.TranscriptTask"
                         }
                     },
                     "404": {
@@ -2937,41 +5037,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transcription/{id}/notes": {
-            "get": {
+        "/api/v1/transcription/{id}/title": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     },
+                    {
+                        "BearerAuth": []
+                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all notes attached to a transcription, ordered by time and creation",
+                "description": "Update the title of an audio file / transcription",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notes"
+                    "transcription"
                 ],
-                "summary": "List notes for a transcription",
+                "summary": "Update transcription title",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Transcription ID",
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Title update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Note"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -2993,8 +5109,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/transcription/{id}/track-progress": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -3003,49 +5121,29 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new note attached to the specified transcription",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get real-time progress information for individual tracks in a multi-track job",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notes"
+                    "transcription"
                 ],
-                "summary": "Create a note for a transcription",
+                "summary": "Get multi-track job progress",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Transcription ID",
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Note create payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.NoteCreateRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.Note"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
                     "404": {
@@ -3060,28 +5158,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transcription/{id}/speakers": {
+        "/api/v1/transcription/{id}/transcript": {
             "get": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "ApiKeyAuth": []
                     },
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves all custom speaker names for a transcription job",
+                "description": "Get the transcript for a completed transcription job",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "transcription"
                 ],
-                "summary": "Get speaker mappings for a transcription",
+                "summary": "Get transcript",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Transcription Job ID",
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3091,10 +5189,8 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/api.SpeakerMappingResponse"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -3114,9 +5210,34 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/default-profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the default transcription profile for the current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profiles"
+                ],
+                "summary": "Get user's default profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TranscriptionProfile"
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3130,12 +5251,9 @@ const docTemplate = `{
                 "security": [
                     {
                         "BearerAuth": []
-                    },
-                    {
-                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Updates or creates custom speaker names for a transcription job",
+                "description": "Set the default transcription profile for the current user",
                 "consumes": [
                     "application/json"
                 ],
@@ -3143,24 +5261,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transcription"
+                    "profiles"
                 ],
-                "summary": "Update speaker mappings for a transcription",
+                "summary": "Set user's default profile",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Transcription Job ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Speaker mappings to update",
+                        "description": "Default profile request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.SpeakerMappingsUpdateRequest"
+                            "$ref": "#/definitions/api.SetUserDefaultProfileRequest"
                         }
                     }
                 ],
@@ -3168,9 +5279,9 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/api.SpeakerMappingResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -3191,6 +5302,40 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current user's settings including auto-transcription preference",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserSettingsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -3202,19 +5347,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/transcription/{id}/start": {
-            "post": {
+            },
+            "put": {
                 "security": [
-                    {
-                        "ApiKeyAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Start transcription for an already uploaded audio file",
+                "description": "Update the current user's settings",
                 "consumes": [
                     "application/json"
                 ],
@@ -3222,24 +5362,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transcription"
+                    "user"
                 ],
-                "summary": "Start transcription for uploaded file",
+                "summary": "Update user settings",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Job ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Transcription parameters",
-                        "name": "parameters",
+                        "description": "Settings update request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.WhisperXParams"
+                            "$ref": "#/definitions/api.UpdateUserSettingsRequest"
                         }
                     }
                 ],
@@ -3247,7 +5380,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionJob"
+                            "$ref": "#/definitions/api.UserSettingsResponse"
                         }
                     },
                     "400": {
@@ -3259,8 +5392,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3271,7 +5413,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transcription/{id}/status": {
+        "/api/v1/voices": {
             "get": {
                 "security": [
                     {
@@ -3281,32 +5423,26 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the current status of a transcription job",
+                "description": "Get the enrolled voices that diarized speakers are matched against",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transcription"
-                ],
-                "summary": "Get job status",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Job ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "voices"
                 ],
+                "summary": "List the speaker library",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionJob"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.VoiceResponse"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3315,10 +5451,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/transcription/{id}/summary": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -3327,28 +5461,44 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the most recent saved summary for the given transcription",
+                "description": "Add a named person to the speaker library from a recording of them speaking alone. The voice embedding is computed with the diarization environment.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "summarize"
+                    "voices"
                 ],
-                "summary": "Get latest summary for transcription",
+                "summary": "Enroll a voice",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Transcription ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Name of the person",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Recording of the person speaking",
+                        "name": "audio",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HuggingFace token for the embedding model",
+                        "name": "hf_token",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Summary"
+                            "$ref": "#/definitions/api.VoiceResponse"
                         }
                     },
                     "400": {
@@ -3360,8 +5510,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3369,8 +5519,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3381,20 +5531,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transcription/{id}/title": {
+        "/api/v1/voices/{id}": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     },
-                    {
-                        "BearerAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the title of an audio file / transcription",
+                "description": "Rename a person of the speaker library, along with the speakers matched to them",
                 "consumes": [
                     "application/json"
                 ],
@@ -3402,27 +5549,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transcription"
+                    "voices"
                 ],
-                "summary": "Update transcription title",
+                "summary": "Rename a voice",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
+                        "description": "Voice ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Title update request",
+                        "description": "New name",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/api.VoiceRequest"
                         }
                     }
                 ],
@@ -3430,8 +5574,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.Voice"
                         }
                     },
                     "400": {
@@ -3451,12 +5594,19 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/transcription/{id}/track-progress": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -3465,18 +5615,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get real-time progress information for individual tracks in a multi-track job",
+                "description": "Remove a person and their enrolled samples from the speaker library. Speakers matched to them keep their names.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transcription"
+                    "voices"
                 ],
-                "summary": "Get multi-track job progress",
+                "summary": "Delete a voice",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
+                        "description": "Voice ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3487,7 +5637,9 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
@@ -3502,8 +5654,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transcription/{id}/transcript": {
-            "get": {
+        "/api/v1/voices/{id}/samples": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -3512,29 +5664,44 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the transcript for a completed transcription job",
+                "description": "Add another recording of an enrolled person to improve matching",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transcription"
+                    "voices"
                 ],
-                "summary": "Get transcript",
+                "summary": "Add a voice sample",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
+                        "description": "Voice ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Recording of the person speaking",
+                        "name": "audio",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HuggingFace token for the embedding model",
+                        "name": "hf_token",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/api.VoiceResponse"
                         }
                     },
                     "400": {
@@ -3554,30 +5721,43 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/v1/user/default-profile": {
+        "/api/v1/worker/jobs/{id}/audio": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get the default transcription profile for the current user",
+                "description": "Download the audio file of a job leased to the calling worker",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "profiles"
+                    "worker"
+                ],
+                "summary": "Download job audio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user's default profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TranscriptionProfile"
+                            "type": "file"
                         }
                     },
                     "404": {
@@ -3588,16 +5768,22 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Set the default transcription profile for the current user",
+                }
+            }
+        },
+        "/api/v1/worker/jobs/{id}/complete": {
+            "post": {
+                "description": "Upload the outcome of a leased job: transcript, execution data and logs. The job stays leased until the result is stored.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3605,17 +5791,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "profiles"
+                    "worker"
                 ],
-                "summary": "Set user's default profile",
+                "summary": "Complete a leased job",
                 "parameters": [
                     {
-                        "description": "Default profile request",
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Job outcome",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.SetUserDefaultProfileRequest"
+                            "$ref": "#/definitions/worker.CompleteRequest"
                         }
                     }
                 ],
@@ -3638,8 +5831,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3650,39 +5852,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/settings": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+        "/api/v1/worker/jobs/{id}/heartbeat": {
+            "post": {
+                "description": "Keep the lease on a job alive and report progress. The response tells the worker to stop if the job was killed.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Get the current user's settings including auto-transcription preference",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "worker"
+                ],
+                "summary": "Send a job heartbeat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Progress",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/worker.HeartbeatRequest"
+                        }
+                    }
                 ],
-                "summary": "Get user settings",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.UserSettingsResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/worker.HeartbeatResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3691,44 +5899,30 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update the current user's settings",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/v1/worker/jobs/{id}/release": {
+            "post": {
+                "description": "Give a leased job back to the queue without finishing it, e.g. when the worker shuts down",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "worker"
                 ],
-                "summary": "Update user settings",
+                "summary": "Release a leased job",
                 "parameters": [
                     {
-                        "description": "Settings update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.UpdateUserSettingsRequest"
-                        }
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.UserSettingsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3736,17 +5930,40 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/api/v1/worker/lease": {
+            "post": {
+                "description": "Hand the next pending job to the calling remote worker, along with the custom models registered on the server. Returns 204 when there is nothing to do.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "worker"
+                ],
+                "summary": "Lease a job",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/worker.LeaseResponse"
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "204": {
+                        "description": "No job available"
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3863,6 +6080,19 @@ const docTemplate = `{
                 }
             }
         },
+        "api.AlignTranscriptRequest": {
+            "type": "object",
+            "properties": {
+                "align_model": {
+                    "description": "e.g. KBLab/wav2vec2-large-voxrex-swedish",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "defaults to the transcript's language",
+                    "type": "string"
+                }
+            }
+        },
         "api.ChangePasswordRequest": {
             "type": "object",
             "required": [
@@ -4072,6 +6302,92 @@ const docTemplate = `{
                 }
             }
         },
+        "api.CreateWorkerRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "api.CreateWorkerResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "worker": {
+                    "$ref": "#/definitions/models.Worker"
+                }
+            }
+        },
+        "api.CustomModelRequest": {
+            "type": "object",
+            "required": [
+                "base_adapter",
+                "name",
+                "source"
+            ],
+            "properties": {
+                "base_adapter": {
+                    "description": "whisperx, whisper_cpp, parakeet or canary",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "description": "name shown in the UI, defaults to name",
+                    "type": "string"
+                },
+                "languages": {
+                    "description": "language codes the model supports, empty for all",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "name profiles select, e.g. \"whisper-medical\"",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "absolute local path or Hugging Face repo ID",
+                    "type": "string"
+                }
+            }
+        },
+        "api.DiarizeTranscriptRequest": {
+            "type": "object",
+            "required": [
+                "diarize_model"
+            ],
+            "properties": {
+                "diarize_model": {
+                    "description": "pyannote, nvidia_sortformer or a diarization plugin",
+                    "type": "string"
+                },
+                "hf_token": {
+                    "description": "defaults to the job's token",
+                    "type": "string"
+                },
+                "max_speakers": {
+                    "type": "integer"
+                },
+                "min_speakers": {
+                    "type": "integer"
+                },
+                "speaker_assignment": {
+                    "description": "'word' (default) or 'segment'",
+                    "type": "string"
+                }
+            }
+        },
         "api.ErrorResponse": {
             "type": "object",
             "properties": {
@@ -4080,6 +6396,19 @@ const docTemplate = `{
                 }
             }
         },
+        "api.InstallModelRequest": {
+            "type": "object",
+            "properties": {
+                "model": {
+                    "description": "model variant to download, e.g. \"large-v3\"",
+                    "type": "string"
+                },
+                "reinstall": {
+                    "description": "delete and rebuild the environment",
+                    "type": "boolean"
+                }
+            }
+        },
         "api.LLMConfigRequest": {
             "type": "object",
             "required": [
@@ -4171,6 +6500,23 @@ const docTemplate = `{
                 }
             }
         },
+        "api.MergeSpeakersRequest": {
+            "type": "object",
+            "required": [
+                "source",
+                "target"
+            ],
+            "properties": {
+                "source": {
+                    "description": "Speaker label that disappears",
+                    "type": "string"
+                },
+                "target": {
+                    "description": "Speaker label that takes over its speech",
+                    "type": "string"
+                }
+            }
+        },
         "api.NoteCreateRequest": {
             "type": "object",
             "required": [
@@ -4217,6 +6563,54 @@ const docTemplate = `{
                 }
             }
         },
+        "api.QueueJobsRequest": {
+            "type": "object",
+            "properties": {
+                "job_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.QueueStateResponse": {
+            "type": "object",
+            "properties": {
+                "pending": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "state": {
+                    "$ref": "#/definitions/queue.State"
+                }
+            }
+        },
+        "api.ReassignSpeakerRequest": {
+            "type": "object",
+            "required": [
+                "speaker"
+            ],
+            "properties": {
+                "end": {
+                    "type": "number"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "speaker": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "number"
+                }
+            }
+        },
         "api.RefreshTokenResponse": {
             "type": "object",
             "properties": {
@@ -4256,6 +6650,39 @@ const docTemplate = `{
                 }
             }
         },
+        "api.RetranscribeRangeRequest": {
+            "type": "object",
+            "required": [
+                "end"
+            ],
+            "properties": {
+                "end": {
+                    "description": "seconds",
+                    "type": "number"
+                },
+                "hf_token": {
+                    "description": "defaults to the job's token",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "defaults to the job's",
+                    "type": "string"
+                },
+                "model": {
+                    "description": "required when the model family changes",
+                    "type": "string"
+                },
+                "model_family": {
+                    "description": "defaults to the job's",
+                    "type": "string"
+                },
+                "start": {
+                    "description": "seconds",
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
         "api.SetUserDefaultProfileRequest": {
             "type": "object",
             "required": [
@@ -4285,14 +6712,24 @@ const docTemplate = `{
         "api.SpeakerMappingResponse": {
             "type": "object",
             "properties": {
+                "confirmed": {
+                    "type": "boolean"
+                },
                 "custom_name": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "match_score": {
+                    "type": "number"
+                },
                 "original_speaker": {
                     "type": "string"
+                },
+                "voice_id": {
+                    "description": "Set when the speaker was recognised from the speaker library",
+                    "type": "string"
                 }
             }
         },
@@ -4310,6 +6747,119 @@ const docTemplate = `{
                 }
             }
         },
+        "api.SpeakerSuggestion": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "type": "number"
+                },
+                "current_name": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "original_speaker": {
+                    "type": "string"
+                },
+                "quotes": {
+                    "description": "Transcript passages supporting the name",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.SpeakerSuggestionRequest": {
+            "type": "object",
+            "required": [
+                "model"
+            ],
+            "properties": {
+                "min_confidence": {
+                    "description": "Leave out less certain suggestions",
+                    "type": "number"
+                },
+                "model": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.SpeakerSuggestionsResponse": {
+            "type": "object",
+            "properties": {
+                "mappings": {
+                    "description": "The job's speaker mappings with the suggestions applied. Posting them to\n/api/v1/transcription/{id}/speakers accepts all suggestions at once.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.SpeakerMappingRequest"
+                    }
+                },
+                "model": {
+                    "type": "string"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.SpeakerSuggestion"
+                    }
+                },
+                "truncated": {
+                    "description": "Only the start of a long transcript was sent",
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.SpeakerVoiceRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Or the name of a voice, enrolled if new",
+                    "type": "string"
+                },
+                "voice_id": {
+                    "description": "An existing voice",
+                    "type": "string"
+                }
+            }
+        },
+        "api.SpeakerVoiceResponse": {
+            "type": "object",
+            "properties": {
+                "library_updated": {
+                    "description": "The speaker's embedding was added to the voice",
+                    "type": "boolean"
+                },
+                "mapping": {
+                    "$ref": "#/definitions/models.SpeakerMapping"
+                }
+            }
+        },
+        "api.SplitSpeakerRequest": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "number"
+                },
+                "name": {
+                    "description": "Custom name of the new speaker",
+                    "type": "string"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "start": {
+                    "type": "number"
+                }
+            }
+        },
         "api.SummarizeRequest": {
             "type": "object",
             "required": [
@@ -4371,49 +6921,261 @@ const docTemplate = `{
                     "type": "string",
                     "minLength": 1
                 },
-                "prompt": {
-                    "type": "string",
-                    "minLength": 1
+                "prompt": {
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
+        },
+        "api.TranscriptRevisionResponse": {
+            "type": "object",
+            "properties": {
+                "revision": {
+                    "$ref": "#/definitions/models.TranscriptRevision"
+                },
+                "speakers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.SpeakerMappingResponse"
+                    }
+                }
+            }
+        },
+        "api.TranscriptTask": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "number"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "diarize, align or retranscribe",
+                    "type": "string"
+                },
+                "queued_at": {
+                    "type": "string"
+                },
+                "revision": {
+                    "description": "The saved transcript revision once completed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TranscriptRevision"
+                        }
+                    ]
+                },
+                "start": {
+                    "description": "The range transcribed again, widened to the segments it overlaps",
+                    "type": "number"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "queued, running, completed or failed",
+                    "type": "string"
+                }
+            }
+        },
+        "api.UpdateUserSettingsRequest": {
+            "type": "object",
+            "properties": {
+                "auto_transcription_enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.UserSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "auto_transcription_enabled": {
+                    "type": "boolean"
+                },
+                "default_profile_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ValidateOpenAIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.VoiceRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.VoiceResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "embedding_count": {
+                    "description": "Enrolled samples and confirmed speakers",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.YouTubeDownloadRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "environments.Environment": {
+            "type": "object",
+            "properties": {
+                "disk_usage_bytes": {
+                    "type": "integer"
+                },
+                "exists": {
+                    "type": "boolean"
+                },
+                "installed_models": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/environments.InstalledModel"
+                    }
+                },
+                "model_id": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "ready": {
+                    "type": "boolean"
+                },
+                "shared_with": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "supported_models": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "task": {
+                    "$ref": "#/definitions/environments.Task"
+                },
+                "type": {
+                    "description": "transcription or diarization",
+                    "type": "string"
                 }
             }
         },
-        "api.UpdateUserSettingsRequest": {
+        "environments.InstalledModel": {
             "type": "object",
             "properties": {
-                "auto_transcription_enabled": {
-                    "type": "boolean"
+                "disk_usage_bytes": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
                 }
             }
         },
-        "api.UserSettingsResponse": {
+        "environments.Task": {
             "type": "object",
             "properties": {
-                "auto_transcription_enabled": {
+                "bytes_on_disk": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "model_id": {
+                    "type": "string"
+                },
+                "reinstall": {
                     "type": "boolean"
                 },
-                "default_profile_id": {
+                "started_at": {
                     "type": "string"
-                }
-            }
-        },
-        "api.ValidateOpenAIKeyRequest": {
-            "type": "object",
-            "properties": {
-                "api_key": {
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "api.YouTubeDownloadRequest": {
+        "models.CustomModel": {
             "type": "object",
-            "required": [
-                "url"
-            ],
             "properties": {
-                "title": {
+                "base_adapter": {
+                    "description": "whisperx, whisper_cpp, parakeet or canary",
                     "type": "string"
                 },
-                "url": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "languages": {
+                    "description": "Comma-separated language codes",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Local path or Hugging Face repo ID",
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
@@ -4535,6 +7297,51 @@ const docTemplate = `{
                 }
             }
         },
+        "models.SpeakerMapping": {
+            "type": "object",
+            "properties": {
+                "confirmed": {
+                    "description": "The user confirmed or chose the voice",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "custom_name": {
+                    "description": "e.g., \"John Doe\"",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "match_score": {
+                    "description": "Cosine similarity of an automatic match",
+                    "type": "number"
+                },
+                "original_speaker": {
+                    "description": "e.g., \"speaker_00\"",
+                    "type": "string"
+                },
+                "transcription_job": {
+                    "description": "Relationships",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TranscriptionJob"
+                        }
+                    ]
+                },
+                "transcription_job_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "voice_id": {
+                    "description": "Voice of the speaker library the speaker was matched to",
+                    "type": "string"
+                }
+            }
+        },
         "models.Summary": {
             "type": "object",
             "properties": {
@@ -4595,6 +7402,36 @@ const docTemplate = `{
                 }
             }
         },
+        "models.TranscriptRevision": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "description": "e.g. the model used",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "revision": {
+                    "description": "1 for the first version, increasing per job",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "What produced the revision, e.g. 'diarization'",
+                    "type": "string"
+                },
+                "transcript": {
+                    "description": "Transcript JSON",
+                    "type": "string"
+                },
+                "transcription_job_id": {
+                    "type": "string"
+                }
+            }
+        },
         "models.TranscriptionJob": {
             "type": "object",
             "properties": {
@@ -4646,6 +7483,10 @@ const docTemplate = `{
                 "multi_track_folder": {
                     "type": "string"
                 },
+                "original_transcript": {
+                    "description": "Raw STT output before AI post-processing",
+                    "type": "string"
+                },
                 "parameters": {
                     "description": "WhisperX parameters",
                     "allOf": [
@@ -4675,7 +7516,7 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "actual_parameters": {
-                    "description": "Parameters used for this execution (may differ from job parameters due to profiles)",
+                    "description": "Parameters used for this execution (may differ from job parameters due to profiles and model fallbacks)",
                     "allOf": [
                         {
                             "$ref": "#/definitions/models.WhisperXParams"
@@ -4689,6 +7530,9 @@ const docTemplate = `{
                     "description": "Metadata",
                     "type": "string"
                 },
+                "diarization_model": {
+                    "type": "string"
+                },
                 "error_message": {
                     "type": "string"
                 },
@@ -4705,10 +7549,22 @@ const docTemplate = `{
                 "merge_start_time": {
                     "type": "string"
                 },
+                "model_attempts": {
+                    "description": "JSON-serialized []transcription.ModelAttempt",
+                    "type": "string"
+                },
+                "model_routing": {
+                    "description": "JSON-serialized transcription.RoutingDecision for 'auto'",
+                    "type": "string"
+                },
                 "multi_track_timings": {
                     "description": "Multi-track specific timing data",
                     "type": "string"
                 },
+                "preprocessing": {
+                    "description": "Preprocessors that ran on the audio",
+                    "type": "string"
+                },
                 "processing_duration": {
                     "description": "Duration in milliseconds",
                     "type": "integer"
@@ -4736,6 +7592,10 @@ const docTemplate = `{
                 "transcription_job_id": {
                     "type": "string"
                 },
+                "transcription_model": {
+                    "description": "Models that produced the result, and every model tried along the fallback chains",
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 }
@@ -4767,6 +7627,23 @@ const docTemplate = `{
                 }
             }
         },
+        "models.Voice": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
         "models.WhisperXParams": {
             "type": "object",
             "properties": {
@@ -4785,6 +7662,10 @@ const docTemplate = `{
                 "attention_context_right": {
                     "type": "integer"
                 },
+                "auto_quality": {
+                    "description": "Quality tier for 'auto': 'fast', 'good', 'best'",
+                    "type": "string"
+                },
                 "batch_size": {
                     "type": "integer"
                 },
@@ -4810,6 +7691,14 @@ const docTemplate = `{
                 "condition_on_previous_text": {
                     "type": "boolean"
                 },
+                "denoise_method": {
+                    "description": "Options: 'afftdn', 'arnndn'",
+                    "type": "string"
+                },
+                "denoise_model": {
+                    "description": "RNNoise model file, required by 'arnndn'",
+                    "type": "string"
+                },
                 "device": {
                     "description": "Device and computation",
                     "type": "string"
@@ -4825,6 +7714,14 @@ const docTemplate = `{
                     "description": "Options: 'pyannote', 'nvidia_sortformer'",
                     "type": "string"
                 },
+                "fallback_diarize_models": {
+                    "description": "Comma-separated diarization models, e.g. 'nvidia_sortformer'",
+                    "type": "string"
+                },
+                "fallback_models": {
+                    "description": "Models tried in order when the selected one is not ready or fails",
+                    "type": "string"
+                },
                 "fp16": {
                     "type": "boolean"
                 },
@@ -4835,6 +7732,10 @@ const docTemplate = `{
                 "highlight_words": {
                     "type": "boolean"
                 },
+                "highpass_frequency": {
+                    "description": "Cutoff in Hz for 'highpass'",
+                    "type": "integer"
+                },
                 "initial_prompt": {
                     "type": "string"
                 },
@@ -4854,6 +7755,10 @@ const docTemplate = `{
                 "logprob_threshold": {
                     "type": "number"
                 },
+                "loudness_target": {
+                    "description": "Integrated loudness in LUFS for 'loudnorm'",
+                    "type": "number"
+                },
                 "max_line_count": {
                     "type": "integer"
                 },
@@ -4882,7 +7787,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "model_family": {
-                    "description": "Model family (whisper or nvidia)",
+                    "description": "Model family (whisper or nvidia), or 'auto' to route by the detected language",
                     "type": "string"
                 },
                 "no_align": {
@@ -4898,6 +7803,10 @@ const docTemplate = `{
                 "patience": {
                     "type": "number"
                 },
+                "preprocessors": {
+                    "description": "Audio enhancement applied before transcription",
+                    "type": "string"
+                },
                 "print_progress": {
                     "type": "boolean"
                 },
@@ -4907,9 +7816,37 @@ const docTemplate = `{
                 "segment_resolution": {
                     "type": "string"
                 },
+                "speaker_assignment": {
+                    "description": "Merging a separate diarization: 'word' splits segments at speaker changes, 'segment' labels whole segments",
+                    "type": "string"
+                },
                 "speaker_embeddings": {
                     "type": "boolean"
                 },
+                "split_chunk_duration": {
+                    "description": "Chunk length in seconds, 0 uses the model's preference",
+                    "type": "number"
+                },
+                "split_max_duration": {
+                    "description": "Split longer audio (seconds), 0 uses the model's limit",
+                    "type": "number"
+                },
+                "split_max_file_size_mb": {
+                    "description": "Split larger files, 0 uses the model's limit",
+                    "type": "number"
+                },
+                "split_overlap": {
+                    "description": "Seconds each chunk shares with the previous one",
+                    "type": "number"
+                },
+                "split_silence_window": {
+                    "description": "Seconds around each cut searched for a pause",
+                    "type": "number"
+                },
+                "split_strategy": {
+                    "description": "Long audio splitting",
+                    "type": "string"
+                },
                 "suppress_numerals": {
                     "type": "boolean"
                 },
@@ -4945,6 +7882,58 @@ const docTemplate = `{
                 }
             }
         },
+        "models.Worker": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current_job": {
+                    "type": "string"
+                },
+                "hostname": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "queue.State": {
+            "type": "string",
+            "enum": [
+                "running",
+                "paused",
+                "draining"
+            ],
+            "x-enum-comments": {
+                "StateDraining": "Paused, waiting for running jobs to finish",
+                "StatePaused": "No new jobs are started; running jobs continue",
+                "StateRunning": "Pending jobs are dispatched to workers"
+            },
+            "x-enum-descriptions": [
+                "Pending jobs are dispatched to workers",
+                "No new jobs are started; running jobs continue",
+                "Paused, waiting for running jobs to finish"
+            ],
+            "x-enum-varnames": [
+                "StateRunning",
+                "StatePaused",
+                "StateDraining"
+            ]
+        },
         "transcription.QuickTranscriptionJob": {
             "type": "object",
             "properties": {
@@ -4973,6 +7962,68 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "worker.CompleteRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "error_message": {
+                    "type": "string"
+                },
+                "execution": {
+                    "$ref": "#/definitions/models.TranscriptionJobExecution"
+                },
+                "logs": {
+                    "type": "string"
+                },
+                "original_transcript": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.JobStatus"
+                },
+                "transcript": {
+                    "type": "string"
+                }
+            }
+        },
+        "worker.HeartbeatRequest": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "Latest progress line from the job log",
+                    "type": "string"
+                }
+            }
+        },
+        "worker.HeartbeatResponse": {
+            "type": "object",
+            "properties": {
+                "cancel": {
+                    "description": "The job was killed on the server",
+                    "type": "boolean"
+                }
+            }
+        },
+        "worker.LeaseResponse": {
+            "type": "object",
+            "properties": {
+                "custom_models": {
+                    "description": "Custom models registered on the server",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CustomModel"
+                    }
+                },
+                "job": {
+                    "$ref": "#/definitions/models.TranscriptionJob"
+                },
+                "lease_ttl_seconds": {
+                    "type": "integer"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -22,6 +22,7 @@ import (
 	"scriberr/internal/sse"
 	"scriberr/internal/transcription"
 	"scriberr/internal/transcription/adapters"
+	"scriberr/internal/transcription/modelserver"
 	"scriberr/internal/transcription/registry"
 	"scriberr/pkg/logger"
 )
@@ -60,6 +61,7 @@ var (
 func main() {
 	// Handle version flag
 	var showVersion = flag.Bool("version", false, "Show version information")
+	var workerMode = flag.Bool("worker", false, "Run as a remote worker that leases jobs from SCRIBERR_SERVER_URL")
 	flag.Parse()
 
 	if *showVersion {
@@ -79,6 +81,12 @@ func main() {
 
 	// Register adapters with config-based paths
 	registerAdapters(cfg)
+	defer adapters.StopModelServers()
+
+	if *workerMode {
+		runWorker(cfg)
+		return
+	}
 
 	// Initialize database
 	logger.Startup("database", "Connecting to database")
@@ -108,6 +116,16 @@ func main() {
 	noteRepo := repository.NewNoteRepository(database.DB)
 	speakerMappingRepo := repository.NewSpeakerMappingRepository(database.DB)
 	refreshTokenRepo := repository.NewRefreshTokenRepository(database.DB)
+	workerRepo := repository.NewWorkerRepository(database.DB)
+	customModelRepo := repository.NewCustomModelRepository(database.DB)
+	voiceRepo := repository.NewVoiceRepository(database.DB)
+
+	// Hand registered custom models to their adapters
+	if customModels, err := customModelRepo.ListAll(context.Background()); err != nil {
+		logger.Warn("Failed to load custom models", "error", err)
+	} else {
+		transcription.ApplyCustomModels(customModels)
+	}
 
 	// Initialize services
 	logger.Startup("service", "Initializing services")
@@ -125,6 +143,9 @@ func main() {
 		unifiedProcessor.SetAIPostprocessor(cfg.OpenAIAPIKey, cfg.PostProcessingModel, cfg.EnableAIPostProcessing)
 	}
 
+	// Name diarized speakers after the voices of the speaker library
+	unifiedProcessor.SetVoiceLibrary(voiceRepo, speakerMappingRepo, cfg.VoiceMatchThreshold)
+
 	// Bootstrap embedded Python environment (for all adapters)
 	logger.Startup("python", "Preparing Python environment")
 	if err := unifiedProcessor.InitEmbeddedPythonEnv(); err != nil {
@@ -143,6 +164,21 @@ func main() {
 	// Initialize task queue
 	logger.Startup("queue", "Starting background processing")
 	taskQueue := queue.NewTaskQueue(2, unifiedProcessor, jobRepo) // 2 workers
+	taskQueue.SetBroadcaster(broadcaster)
+	if cfg.DisableLocalWorkers {
+		logger.Info("Local workers disabled, only multi-track jobs are processed locally")
+		taskQueue.DisableLocalWorkers()
+	}
+
+	// Tracks of a multi-track job run in parallel. They run on top of the
+	// queue's workers, so all multi-track jobs together share one track limit.
+	trackLimit := cfg.TrackLimit
+	if trackLimit <= 0 {
+		trackLimit = taskQueue.MaxWorkers()
+	}
+	unifiedProcessor.SetTrackConcurrency(min(cfg.TrackConcurrency, trackLimit))
+	unifiedProcessor.SetTrackLimit(trackLimit)
+
 	taskQueue.Start()
 	defer taskQueue.Stop()
 
@@ -165,6 +201,9 @@ func main() {
 		noteRepo,
 		speakerMappingRepo,
 		refreshTokenRepo,
+		workerRepo,
+		customModelRepo,
+		voiceRepo,
 		taskQueue,
 		unifiedProcessor,
 		quickTranscriptionService,
@@ -234,6 +273,14 @@ func registerAdapters(cfg *config.Config) {
 	// Dedicated environment path for Voxtral (Mistral AI model)
 	voxtralEnvPath := filepath.Join(cfg.WhisperXEnv, "voxtral")
 
+	// Keep local models loaded between jobs in long-lived processes
+	if cfg.ModelServers {
+		opts := modelserver.DefaultOptions()
+		opts.IdleTimeout = time.Duration(cfg.ModelServerIdleMinutes) * time.Minute
+		adapters.EnableModelServers(opts)
+		logger.Info("Model servers enabled", "idle_timeout", opts.IdleTimeout)
+	}
+
 	// Check if only OpenAI should be used (no local models)
 	// Set OPENAI_ONLY=true to skip all local Python models (whisperx, pyannote, nvidia)
 	openaiOnly := os.Getenv("OPENAI_ONLY") == "true"
@@ -259,8 +306,13 @@ func registerAdapters(cfg *config.Config) {
 	} else if skipNvidia {
 		logger.Info("Skipping NVIDIA models (SKIP_NVIDIA_MODELS=true)")
 	}
+	// whisper.cpp needs no Python environment, so it is available in every mode
+	registry.RegisterTranscriptionAdapter("whisper_cpp",
+		adapters.NewWhisperCppAdapter(cfg.WhisperCppBinary, cfg.WhisperCppModelsDir))
 	registry.RegisterTranscriptionAdapter("openai_whisper",
 		adapters.NewOpenAIAdapter(cfg.OpenAIAPIKey))
+	registerOpenAICompatibleServers(cfg)
+	registerPlugins(cfg)
 
 	// Register diarization adapters
 	if !openaiOnly {
@@ -274,3 +326,51 @@ func registerAdapters(cfg *config.Config) {
 
 	logger.Info("Adapter registration complete")
 }
+
+// registerPlugins registers the adapters described by manifests in the plugins
+// directory. Plugins cannot replace built-in adapters.
+func registerPlugins(cfg *config.Config) {
+	manifests, errs := adapters.LoadPluginManifests(cfg.PluginsDir)
+	for _, err := range errs {
+		logger.Error("Skipping plugin", "error", err)
+	}
+
+	for _, manifest := range manifests {
+		if _, err := registry.GetRegistry().GetCapabilities(manifest.ID); err == nil {
+			logger.Error("Plugin ID is already taken", "id", manifest.ID, "dir", manifest.Dir)
+			continue
+		}
+
+		adapter := adapters.NewPluginAdapter(manifest)
+		if manifest.Type == adapters.PluginTypeDiarization {
+			registry.RegisterDiarizationAdapter(manifest.ID, adapter)
+		} else {
+			registry.RegisterTranscriptionAdapter(manifest.ID, adapter)
+		}
+		logger.Info("Registered plugin", "id", manifest.ID, "type", manifest.Type, "dir", manifest.Dir)
+	}
+}
+
+// registerOpenAICompatibleServers registers every configured self-hosted server
+// under its own model ID. A broken entry is skipped so the others still work.
+func registerOpenAICompatibleServers(cfg *config.Config) {
+	servers, err := adapters.LoadOpenAICompatibleConfigs(cfg.OpenAICompatibleServers)
+	if err != nil {
+		logger.Error("Failed to load OpenAI-compatible servers", "error", err)
+		return
+	}
+
+	for _, server := range servers {
+		if _, err := registry.GetRegistry().GetCapabilities(server.ID); err == nil {
+			logger.Error("OpenAI-compatible server ID is already taken", "id", server.ID)
+			continue
+		}
+		adapter, err := adapters.NewOpenAICompatibleAdapter(server)
+		if err != nil {
+			logger.Error("Skipping OpenAI-compatible server", "id", server.ID, "error", err)
+			continue
+		}
+		registry.RegisterTranscriptionAdapter(server.ID, adapter)
+		logger.Info("Registered OpenAI-compatible server", "id", server.ID, "base_url", server.BaseURL)
+	}
+}
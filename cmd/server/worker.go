@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"scriberr/internal/config"
+	"scriberr/internal/database"
+	"scriberr/internal/repository"
+	"scriberr/internal/transcription"
+	"scriberr/internal/worker"
+	"scriberr/pkg/logger"
+)
+
+// runWorker runs Scriberr as a remote worker. It leases jobs from the server at
+// cfg.WorkerServerURL and processes them with a local database and scratch
+// directories under cfg.WorkerDataDir, so it can share a machine with a server.
+// The speaker library lives in the server's database, so a worker does not
+// name speakers after enrolled voices. Webhooks are sent by the server.
+func runWorker(cfg *config.Config) {
+	if cfg.WorkerToken == "" {
+		logger.Error("WORKER_TOKEN is required in worker mode")
+		os.Exit(1)
+	}
+
+	logger.Startup("database", "Opening worker database")
+	if err := database.Initialize(filepath.Join(cfg.WorkerDataDir, "worker.db")); err != nil {
+		logger.Error("Failed to open worker database", "error", err)
+		os.Exit(1)
+	}
+	defer database.Close()
+
+	jobRepo := repository.NewJobRepository(database.DB)
+	transcriptsDir := filepath.Join(cfg.WorkerDataDir, "transcripts")
+
+	logger.Startup("transcription", "Initializing transcription service")
+	processor := transcription.NewUnifiedJobProcessor(jobRepo, filepath.Join(cfg.WorkerDataDir, "temp"), transcriptsDir)
+	if cfg.EnableAIPostProcessing {
+		processor.SetAIPostprocessor(cfg.OpenAIAPIKey, cfg.PostProcessingModel, cfg.EnableAIPostProcessing)
+	}
+	// The server calls job webhooks once it has stored the uploaded result
+	processor.DisableWebhooks()
+
+	logger.Startup("python", "Preparing Python environment")
+	if err := processor.InitEmbeddedPythonEnv(); err != nil {
+		logger.Error("Failed to prepare Python environment", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	client := worker.NewClient(cfg.WorkerServerURL, cfg.WorkerToken)
+	runner := worker.NewRunner(client, processor, jobRepo, filepath.Join(cfg.WorkerDataDir, "audio"), transcriptsDir)
+	runner.ApplyCustomModels = transcription.ApplyCustomModels
+	if err := runner.Run(ctx); err != nil {
+		logger.Error("Worker failed", "error", err)
+		os.Exit(1)
+	}
+}
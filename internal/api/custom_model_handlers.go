@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription"
+	"scriberr/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CustomModelRequest describes a fine-tuned checkpoint to register as a model variant
+type CustomModelRequest struct {
+	Name        string   `json:"name" binding:"required"`         // name profiles select, e.g. "whisper-medical"
+	DisplayName string   `json:"display_name,omitempty"`          // name shown in the UI, defaults to name
+	BaseAdapter string   `json:"base_adapter" binding:"required"` // whisperx, whisper_cpp, parakeet or canary
+	Source      string   `json:"source" binding:"required"`       // absolute local path or Hugging Face repo ID
+	Languages   []string `json:"languages,omitempty"`             // language codes the model supports, empty for all
+	Description *string  `json:"description,omitempty"`
+}
+
+// apply copies the request onto a custom model
+func (r CustomModelRequest) apply(custom *models.CustomModel) {
+	custom.Name = strings.TrimSpace(r.Name)
+	custom.DisplayName = strings.TrimSpace(r.DisplayName)
+	if custom.DisplayName == "" {
+		custom.DisplayName = custom.Name
+	}
+	custom.BaseAdapter = r.BaseAdapter
+	custom.Source = strings.TrimSpace(r.Source)
+	custom.Description = r.Description
+
+	var languages []string
+	for _, language := range r.Languages {
+		if language = strings.TrimSpace(language); language != "" {
+			languages = append(languages, language)
+		}
+	}
+	custom.Languages = nil
+	if len(languages) > 0 {
+		joined := strings.Join(languages, ",")
+		custom.Languages = &joined
+	}
+}
+
+// reloadCustomModels hands the stored custom models to their adapters
+func (h *Handler) reloadCustomModels(ctx context.Context) {
+	customModels, err := h.customModelRepo.ListAll(ctx)
+	if err != nil {
+		logger.Error("Failed to reload custom models", "error", err)
+		return
+	}
+	transcription.ApplyCustomModels(customModels)
+}
+
+// profilesUsingModel returns the names of the profiles that select a model
+func (h *Handler) profilesUsingModel(ctx context.Context, model string) ([]string, error) {
+	profiles, _, err := h.profileRepo.List(ctx, 0, 1000)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, profile := range profiles {
+		if profile.Parameters.Model == model {
+			names = append(names, profile.Name)
+		}
+	}
+	return names, nil
+}
+
+// @Summary List custom models
+// @Description Get the fine-tuned checkpoints registered as model variants of local adapters
+// @Tags admin
+// @Produce json
+// @Success 200 {array} models.CustomModel
+// @Router /api/v1/admin/custom-models [get]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) ListCustomModels(c *gin.Context) {
+	customModels, err := h.customModelRepo.ListAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch custom models"})
+		return
+	}
+	c.JSON(http.StatusOK, customModels)
+}
+
+// @Summary Register a custom model
+// @Description Register a fine-tuned checkpoint, given as a local path or Hugging Face repo ID, as a model variant of a local adapter. Profiles can then select it by name.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body CustomModelRequest true "Custom model"
+// @Success 201 {object} models.CustomModel
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/admin/custom-models [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) CreateCustomModel(c *gin.Context) {
+	var req CustomModelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	var custom models.CustomModel
+	req.apply(&custom)
+	if err := transcription.ValidateCustomModel(custom); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := h.customModelRepo.FindByName(ctx, custom.Name); err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "A custom model with this name already exists"})
+		return
+	}
+	if err := h.customModelRepo.Create(ctx, &custom); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create custom model"})
+		return
+	}
+	h.reloadCustomModels(ctx)
+
+	c.JSON(http.StatusCreated, custom)
+}
+
+// @Summary Update a custom model
+// @Description Change a custom model's source, languages or names. A model that profiles use cannot be renamed or moved to another adapter.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Custom model ID"
+// @Param request body CustomModelRequest true "Custom model"
+// @Success 200 {object} models.CustomModel
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/admin/custom-models/{id} [put]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) UpdateCustomModel(c *gin.Context) {
+	ctx := c.Request.Context()
+	custom, err := h.customModelRepo.FindByID(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Custom model not found"})
+		return
+	}
+
+	var req CustomModelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	oldName, oldAdapter := custom.Name, custom.BaseAdapter
+	req.apply(custom)
+	if err := transcription.ValidateCustomModel(*custom); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if custom.Name != oldName {
+		if existing, err := h.customModelRepo.FindByName(ctx, custom.Name); err == nil && existing.ID != custom.ID {
+			c.JSON(http.StatusConflict, gin.H{"error": "A custom model with this name already exists"})
+			return
+		}
+	}
+	if (custom.Name != oldName || custom.BaseAdapter != oldAdapter) && !h.ensureModelUnused(c, oldName) {
+		return
+	}
+
+	if err := h.customModelRepo.Update(ctx, custom); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update custom model"})
+		return
+	}
+	h.reloadCustomModels(ctx)
+
+	c.JSON(http.StatusOK, custom)
+}
+
+// @Summary Delete a custom model
+// @Description Unregister a custom model. The checkpoint itself is left on disk. A model that profiles use cannot be deleted.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Custom model ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/admin/custom-models/{id} [delete]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) DeleteCustomModel(c *gin.Context) {
+	ctx := c.Request.Context()
+	custom, err := h.customModelRepo.FindByID(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Custom model not found"})
+		return
+	}
+	if !h.ensureModelUnused(c, custom.Name) {
+		return
+	}
+
+	if err := h.customModelRepo.Delete(ctx, custom.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete custom model"})
+		return
+	}
+	h.reloadCustomModels(ctx)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Custom model deleted successfully"})
+}
+
+// ensureModelUnused responds with 409 and returns false if profiles select the model
+func (h *Handler) ensureModelUnused(c *gin.Context, model string) bool {
+	profiles, err := h.profilesUsingModel(c.Request.Context(), model)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check profiles"})
+		return false
+	}
+	if len(profiles) > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Custom model is used by profiles: " + strings.Join(profiles, ", ")})
+		return false
+	}
+	return true
+}
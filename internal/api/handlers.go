@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"scriberr/internal/auth"
@@ -24,6 +25,7 @@ import (
 	"scriberr/internal/service"
 	"scriberr/internal/sse"
 	"scriberr/internal/transcription"
+	"scriberr/internal/transcription/environments"
 	"scriberr/pkg/logger"
 
 	"github.com/gin-gonic/gin"
@@ -47,11 +49,21 @@ type Handler struct {
 	noteRepo            repository.NoteRepository
 	speakerMappingRepo  repository.SpeakerMappingRepository
 	refreshTokenRepo    repository.RefreshTokenRepository
+	workerRepo          repository.WorkerRepository
+	customModelRepo     repository.CustomModelRepository
+	voiceRepo           repository.VoiceRepository
 	taskQueue           *queue.TaskQueue
 	unifiedProcessor    *transcription.UnifiedJobProcessor
 	quickTranscription  *transcription.QuickTranscriptionService
 	multiTrackProcessor *processing.MultiTrackProcessor
 	broadcaster         *sse.Broadcaster
+	environments        *environments.Manager
+
+	// Jobs with a model run on their finished transcript queued or in
+	// progress, and the latest such task of each job
+	transcriptJobsMu sync.Mutex
+	transcriptJobs   map[string]bool
+	transcriptTasks  map[string]*TranscriptTask
 }
 
 // NewHandler creates a new handler
@@ -70,12 +82,22 @@ func NewHandler(
 	noteRepo repository.NoteRepository,
 	speakerMappingRepo repository.SpeakerMappingRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
+	workerRepo repository.WorkerRepository,
+	customModelRepo repository.CustomModelRepository,
+	voiceRepo repository.VoiceRepository,
 	taskQueue *queue.TaskQueue,
 	unifiedProcessor *transcription.UnifiedJobProcessor,
 	quickTranscription *transcription.QuickTranscriptionService,
 	multiTrackProcessor *processing.MultiTrackProcessor,
 	broadcaster *sse.Broadcaster,
 ) *Handler {
+	environmentManager := environments.NewManager(broadcaster)
+	if unifiedProcessor != nil {
+		environmentManager.SetUsageChecker(unifiedProcessor.JobsUsingModels)
+	}
+	if taskQueue != nil {
+		environmentManager.SetDispatchHold(taskQueue.HoldDispatch)
+	}
 	return &Handler{
 		config:              cfg,
 		authService:         authService,
@@ -91,11 +113,15 @@ func NewHandler(
 		noteRepo:            noteRepo,
 		speakerMappingRepo:  speakerMappingRepo,
 		refreshTokenRepo:    refreshTokenRepo,
+		workerRepo:          workerRepo,
+		customModelRepo:     customModelRepo,
+		voiceRepo:           voiceRepo,
 		taskQueue:           taskQueue,
 		unifiedProcessor:    unifiedProcessor,
 		quickTranscription:  quickTranscription,
 		multiTrackProcessor: multiTrackProcessor,
 		broadcaster:         broadcaster,
+		environments:        environmentManager,
 	}
 }
 
@@ -1075,6 +1101,7 @@ func (h *Handler) getValidatedTranscriptionParams(c *gin.Context, job *models.Tr
 		ChunkSize:                      30,
 		Diarize:                        false,
 		DiarizeModel:                   "pyannote/speaker-diarization-3.1",
+		SpeakerAssignment:              "word",
 		SpeakerEmbeddings:              false,
 		Temperature:                    0,
 		BestOf:                         5,
@@ -1304,6 +1331,16 @@ func (h *Handler) DeleteTranscriptionJob(c *gin.Context) {
 		fmt.Printf("Failed to delete speaker mappings for job %s: %v\n", jobID, err)
 	}
 
+	// Delete speaker embeddings that never joined the speaker library
+	if err := h.voiceRepo.DeleteUnassignedByJobID(ctx, jobID); err != nil {
+		fmt.Printf("Failed to delete speaker embeddings for job %s: %v\n", jobID, err)
+	}
+
+	// Delete Transcript Revisions
+	if err := h.jobRepo.DeleteTranscriptRevisionsByJobID(ctx, jobID); err != nil {
+		fmt.Printf("Failed to delete transcript revisions for job %s: %v\n", jobID, err)
+	}
+
 	// Delete Job Executions
 	if err := h.jobRepo.DeleteExecutionsByJobID(ctx, jobID); err != nil {
 		fmt.Printf("Failed to delete job executions for job %s: %v\n", jobID, err)
@@ -1417,6 +1454,25 @@ func (h *Handler) GetJobExecutionData(c *gin.Context) {
 		"is_multi_track":       job.IsMultiTrack,
 	}
 
+	// Add the preprocessing steps that ran on the audio
+	if execution.Preprocessing != nil {
+		response["preprocessing"] = json.RawMessage(*execution.Preprocessing)
+	}
+
+	// Add the models that produced the result and the ones tried before them
+	if execution.TranscriptionModel != nil {
+		response["transcription_model"] = *execution.TranscriptionModel
+	}
+	if execution.DiarizationModel != nil {
+		response["diarization_model"] = *execution.DiarizationModel
+	}
+	if execution.ModelAttempts != nil {
+		response["model_attempts"] = json.RawMessage(*execution.ModelAttempts)
+	}
+	if execution.ModelRouting != nil {
+		response["model_routing"] = json.RawMessage(*execution.ModelRouting)
+	}
+
 	// Add multi-track specific data if available
 	if job.IsMultiTrack && execution.MultiTrackTimings != nil {
 		// Deserialize track timings
@@ -2296,6 +2352,11 @@ func (h *Handler) CreateProfile(c *gin.Context) {
 		return
 	}
 
+	if err := h.unifiedProcessor.ValidateProfileParameters(profile.Parameters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Check if profile name already exists
 	// TODO: Add FindByName to ProfileRepository if needed, or rely on unique constraint error
 	// For now, we'll skip explicit check or implement it in repository.
@@ -2366,6 +2427,11 @@ func (h *Handler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
+	if err := h.unifiedProcessor.ValidateProfileParameters(updatedProfile.Parameters); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Check if profile name already exists (excluding current profile)
 	// TODO: Add check to repository
 
@@ -2533,6 +2599,7 @@ func (h *Handler) SubmitQuickTranscription(c *gin.Context) {
 			// Diarization settings
 			Diarize:           false,
 			DiarizeModel:      "pyannote/speaker-diarization-3.1",
+			SpeakerAssignment: "word",
 			SpeakerEmbeddings: false,
 
 			// Transcription quality settings
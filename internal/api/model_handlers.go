@@ -0,0 +1,121 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"scriberr/internal/transcription/environments"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InstallModelRequest selects what to install for a model
+type InstallModelRequest struct {
+	Model     string `json:"model,omitempty"`     // model variant to download, e.g. "large-v3"
+	Reinstall bool   `json:"reinstall,omitempty"` // delete and rebuild the environment
+}
+
+// environmentErrorStatus maps environment manager errors to HTTP status codes
+func environmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, environments.ErrNotFound), errors.Is(err, environments.ErrNotInstalled):
+		return http.StatusNotFound
+	case errors.Is(err, environments.ErrBusy), errors.Is(err, environments.ErrShared), errors.Is(err, environments.ErrInUse):
+		return http.StatusConflict
+	case errors.Is(err, environments.ErrNoVariants), errors.Is(err, environments.ErrNothingOnDisk):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// @Summary List model environments
+// @Description Get each adapter's environment path, readiness, installed model variants, disk usage and latest install task
+// @Tags admin
+// @Produce json
+// @Success 200 {array} environments.Environment
+// @Router /api/v1/admin/models [get]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) ListModelEnvironments(c *gin.Context) {
+	c.JSON(http.StatusOK, h.environments.List(c.Request.Context()))
+}
+
+// @Summary Get a model environment
+// @Description Get one adapter's environment state, installed model variants and disk usage
+// @Tags admin
+// @Produce json
+// @Param id path string true "Model ID"
+// @Success 200 {object} environments.Environment
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/admin/models/{id} [get]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) GetModelEnvironment(c *gin.Context) {
+	env, err := h.environments.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(environmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, env)
+}
+
+// @Summary Install a model environment
+// @Description Prepare an adapter's environment, or download one of its model variants, in the background. Progress is sent as model_install_* events on the "models" SSE channel. A reinstall is refused while queued or running jobs may use the environment.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Model ID"
+// @Param request body InstallModelRequest false "What to install"
+// @Success 202 {object} environments.Task
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/admin/models/{id}/install [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) InstallModelEnvironment(c *gin.Context) {
+	var req InstallModelRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+	}
+
+	task, err := h.environments.Install(c.Request.Context(), c.Param("id"), req.Model, req.Reinstall)
+	if err != nil {
+		c.JSON(environmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, task)
+}
+
+// @Summary Remove a model environment
+// @Description Delete an adapter's environment, or one of its model variants. The model is unavailable until installed again. Environments shared with other models need force. Models that queued or running jobs may use are not removed.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Model ID"
+// @Param model query string false "Model variant to remove instead of the environment"
+// @Param force query bool false "Remove an environment shared with other models"
+// @Success 200 {object} environments.Environment
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/admin/models/{id} [delete]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) RemoveModelEnvironment(c *gin.Context) {
+	force, _ := strconv.ParseBool(c.Query("force"))
+	if err := h.environments.Remove(c.Request.Context(), c.Param("id"), c.Query("model"), force); err != nil {
+		c.JSON(environmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	env, err := h.environments.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(environmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, env)
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"scriberr/internal/queue"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueueJobsRequest lists pending jobs targeted by a queue operation
+type QueueJobsRequest struct {
+	JobIDs []string `json:"job_ids"`
+}
+
+// QueueStateResponse reports the queue dispatch state and pending order
+type QueueStateResponse struct {
+	State   queue.State `json:"state"`
+	Pending []string    `json:"pending"`
+}
+
+// queueStateResponse builds the response returned by queue control endpoints
+func (h *Handler) queueStateResponse() QueueStateResponse {
+	return QueueStateResponse{
+		State:   h.taskQueue.State(),
+		Pending: h.taskQueue.PendingJobs(),
+	}
+}
+
+// @Summary List pending jobs
+// @Description Get the queue state and the IDs of pending jobs in dispatch order
+// @Tags admin
+// @Produce json
+// @Success 200 {object} QueueStateResponse
+// @Router /api/v1/admin/queue/pending [get]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) GetPendingJobs(c *gin.Context) {
+	c.JSON(http.StatusOK, h.queueStateResponse())
+}
+
+// @Summary Pause the queue
+// @Description Stop starting pending jobs. Running jobs continue.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} QueueStateResponse
+// @Router /api/v1/admin/queue/pause [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) PauseQueue(c *gin.Context) {
+	h.taskQueue.Pause()
+	c.JSON(http.StatusOK, h.queueStateResponse())
+}
+
+// @Summary Resume the queue
+// @Description Resume starting pending jobs after a pause or drain
+// @Tags admin
+// @Produce json
+// @Success 200 {object} QueueStateResponse
+// @Router /api/v1/admin/queue/resume [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) ResumeQueue(c *gin.Context) {
+	h.taskQueue.Resume()
+	c.JSON(http.StatusOK, h.queueStateResponse())
+}
+
+// @Summary Drain the queue
+// @Description Let running jobs finish without starting new ones. A queue_drained event is sent when no job is running.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} QueueStateResponse
+// @Router /api/v1/admin/queue/drain [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) DrainQueue(c *gin.Context) {
+	h.taskQueue.Drain()
+	c.JSON(http.StatusOK, h.queueStateResponse())
+}
+
+// @Summary Cancel pending jobs
+// @Description Cancel jobs that have not started yet. An empty job_ids list cancels every pending job.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body QueueJobsRequest false "Jobs to cancel"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/admin/queue/cancel [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) CancelPendingJobs(c *gin.Context) {
+	var req QueueJobsRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	cancelled, err := h.taskQueue.CancelPending(req.JobIDs)
+	if err != nil {
+		if errors.Is(err, queue.ErrJobNotPending) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cancelled": cancelled,
+		"state":     h.taskQueue.State(),
+		"pending":   h.taskQueue.PendingJobs(),
+	})
+}
+
+// @Summary Reorder pending jobs
+// @Description Move the listed pending jobs to the front of the queue in the given order
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body QueueJobsRequest true "Jobs in their new order"
+// @Success 200 {object} QueueStateResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/admin/queue/reorder [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) ReorderQueue(c *gin.Context) {
+	var req QueueJobsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.JobIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "job_ids is required"})
+		return
+	}
+
+	if err := h.taskQueue.Reorder(req.JobIDs); err != nil {
+		if errors.Is(err, queue.ErrJobNotPending) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.queueStateResponse())
+}
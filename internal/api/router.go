@@ -52,7 +52,7 @@ func SetupRoutes(handler *Handler, authService *auth.AuthService) *gin.Engine {
 			c.Header("Access-Control-Allow-Credentials", "true")
 		}
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key, X-Worker-Token")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -144,6 +144,11 @@ func SetupRoutes(handler *Handler, authService *auth.AuthService) *gin.Engine {
 			transcription.GET("/:id", handler.GetTranscriptionJob)
 			transcription.DELETE("/:id", handler.DeleteTranscriptionJob)
 			transcription.POST("/:id/reprocess", handler.ReprocessTranscript)
+			transcription.POST("/:id/diarize", handler.DiarizeTranscript)
+			transcription.POST("/:id/align", handler.AlignTranscript)
+			transcription.POST("/:id/retranscribe", handler.RetranscribeRange)
+			transcription.GET("/:id/transcript-task", handler.GetTranscriptTask)
+			transcription.GET("/:id/revisions", handler.ListTranscriptRevisions)
 			transcription.GET("/list", handler.ListTranscriptionJobs)
 			transcription.GET("/models", handler.GetSupportedModels)
 			// Notes for a transcription
@@ -153,6 +158,12 @@ func SetupRoutes(handler *Handler, authService *auth.AuthService) *gin.Engine {
 			// Speaker mappings for a transcription
 			transcription.GET("/:id/speakers", handler.GetSpeakerMappings)
 			transcription.POST("/:id/speakers", handler.UpdateSpeakerMappings)
+			transcription.POST("/:id/speakers/merge", handler.MergeSpeakers)
+			transcription.POST("/:id/speakers/reassign", handler.ReassignSpeaker)
+			transcription.POST("/:id/speakers/split", handler.SplitSpeaker)
+			transcription.POST("/:id/speakers/suggestions", handler.SuggestSpeakerNames)
+			transcription.PUT("/:id/speakers/:speaker/voice", handler.ConfirmSpeakerVoice)
+			transcription.DELETE("/:id/speakers/:speaker/voice", handler.RejectSpeakerVoice)
 
 			// Quick transcription endpoints
 			transcription.POST("/quick", handler.SubmitQuickTranscription)
@@ -171,6 +182,17 @@ func SetupRoutes(handler *Handler, authService *auth.AuthService) *gin.Engine {
 			profiles.POST("/:id/set-default", handler.SetDefaultProfile)
 		}
 
+		// Speaker library routes (require authentication)
+		voices := v1.Group("/voices")
+		voices.Use(middleware.AuthMiddleware(authService))
+		{
+			voices.GET("/", handler.ListVoices)
+			voices.POST("/", handler.CreateVoice)
+			voices.PUT("/:id", handler.UpdateVoice)
+			voices.DELETE("/:id", handler.DeleteVoice)
+			voices.POST("/:id/samples", handler.AddVoiceSample)
+		}
+
 		// User routes (require authentication)
 		user := v1.Group("/user")
 		user.Use(middleware.JWTOnlyMiddleware(authService))
@@ -188,6 +210,51 @@ func SetupRoutes(handler *Handler, authService *auth.AuthService) *gin.Engine {
 			queue := admin.Group("/queue")
 			{
 				queue.GET("/stats", handler.GetQueueStats)
+				queue.GET("/pending", handler.GetPendingJobs)
+				queue.POST("/pause", handler.PauseQueue)
+				queue.POST("/resume", handler.ResumeQueue)
+				queue.POST("/drain", handler.DrainQueue)
+				queue.POST("/cancel", handler.CancelPendingJobs)
+				queue.POST("/reorder", handler.ReorderQueue)
+			}
+
+			workers := admin.Group("/workers")
+			{
+				workers.GET("/", handler.ListWorkers)
+				workers.POST("/", handler.CreateWorker)
+				workers.DELETE("/:id", handler.DeleteWorker)
+			}
+
+			modelEnvs := admin.Group("/models")
+			{
+				modelEnvs.GET("/", handler.ListModelEnvironments)
+				modelEnvs.GET("/:id", handler.GetModelEnvironment)
+				modelEnvs.POST("/:id/install", handler.InstallModelEnvironment)
+				modelEnvs.DELETE("/:id", handler.RemoveModelEnvironment)
+			}
+
+			customModels := admin.Group("/custom-models")
+			{
+				customModels.GET("/", handler.ListCustomModels)
+				customModels.POST("/", handler.CreateCustomModel)
+				customModels.PUT("/:id", handler.UpdateCustomModel)
+				customModels.DELETE("/:id", handler.DeleteCustomModel)
+			}
+		}
+
+		// Remote worker routes (require a worker token)
+		workerRoutes := v1.Group("/worker")
+		workerRoutes.Use(middleware.WorkerAuthMiddleware())
+		{
+			workerRoutes.POST("/lease", handler.LeaseWorkerJob)
+			workerRoutes.POST("/jobs/:id/heartbeat", handler.WorkerHeartbeat)
+			workerRoutes.POST("/jobs/:id/complete", handler.CompleteWorkerJob)
+			workerRoutes.POST("/jobs/:id/release", handler.ReleaseWorkerJob)
+
+			workerDownloads := workerRoutes.Group("")
+			workerDownloads.Use(middleware.NoCompressionMiddleware())
+			{
+				workerDownloads.GET("/jobs/:id/audio", handler.GetWorkerJobAudio)
 			}
 		}
 
@@ -25,6 +25,23 @@ type SpeakerMappingResponse struct {
 	ID              uint   `json:"id"`
 	OriginalSpeaker string `json:"original_speaker"`
 	CustomName      string `json:"custom_name"`
+
+	// Set when the speaker was recognised from the speaker library
+	VoiceID    *string  `json:"voice_id,omitempty"`
+	MatchScore *float64 `json:"match_score,omitempty"`
+	Confirmed  bool     `json:"confirmed"`
+}
+
+// newSpeakerMappingResponse converts a speaker mapping to its response format
+func newSpeakerMappingResponse(mapping models.SpeakerMapping) SpeakerMappingResponse {
+	return SpeakerMappingResponse{
+		ID:              mapping.ID,
+		OriginalSpeaker: mapping.OriginalSpeaker,
+		CustomName:      mapping.CustomName,
+		VoiceID:         mapping.VoiceID,
+		MatchScore:      mapping.MatchScore,
+		Confirmed:       mapping.Confirmed,
+	}
 }
 
 // GetSpeakerMappings retrieves all speaker mappings for a transcription
@@ -71,11 +88,7 @@ func (h *Handler) GetSpeakerMappings(c *gin.Context) {
 	// Convert to response format
 	response := make([]SpeakerMappingResponse, len(mappings))
 	for i, mapping := range mappings {
-		response[i] = SpeakerMappingResponse{
-			ID:              mapping.ID,
-			OriginalSpeaker: mapping.OriginalSpeaker,
-			CustomName:      mapping.CustomName,
-		}
+		response[i] = newSpeakerMappingResponse(mapping)
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -122,14 +135,31 @@ func (h *Handler) UpdateSpeakerMappings(c *gin.Context) {
 		return
 	}
 
+	existing, err := h.speakerMappingRepo.ListByJob(c.Request.Context(), jobID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get speaker mappings"})
+		return
+	}
+	voices := make(map[string]models.SpeakerMapping, len(existing))
+	for _, mapping := range existing {
+		voices[mapping.OriginalSpeaker] = mapping
+	}
+
 	// Convert request to model
 	var mappings []models.SpeakerMapping
 	for _, mapping := range req.Mappings {
-		mappings = append(mappings, models.SpeakerMapping{
+		updated := models.SpeakerMapping{
 			TranscriptionJobID: jobID,
 			OriginalSpeaker:    mapping.OriginalSpeaker,
 			CustomName:         mapping.CustomName,
-		})
+		}
+		// A speaker keeps its recognised voice unless it is renamed
+		if previous, ok := voices[mapping.OriginalSpeaker]; ok && previous.CustomName == mapping.CustomName {
+			updated.VoiceID = previous.VoiceID
+			updated.MatchScore = previous.MatchScore
+			updated.Confirmed = previous.Confirmed
+		}
+		mappings = append(mappings, updated)
 	}
 
 	// Update mappings using repository
@@ -148,11 +178,7 @@ func (h *Handler) UpdateSpeakerMappings(c *gin.Context) {
 	// Convert to response format
 	response := make([]SpeakerMappingResponse, len(updatedMappings))
 	for i, mapping := range updatedMappings {
-		response[i] = SpeakerMappingResponse{
-			ID:              mapping.ID,
-			OriginalSpeaker: mapping.OriginalSpeaker,
-			CustomName:      mapping.CustomName,
-		}
+		response[i] = newSpeakerMappingResponse(mapping)
 	}
 
 	c.JSON(http.StatusOK, response)
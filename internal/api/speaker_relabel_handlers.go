@@ -0,0 +1,224 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MergeSpeakersRequest merges one speaker of a transcript into another
+type MergeSpeakersRequest struct {
+	Source string `json:"source" binding:"required"` // Speaker label that disappears
+	Target string `json:"target" binding:"required"` // Speaker label that takes over its speech
+}
+
+// SpeakerSelectionRequest selects part of a transcript: segments by index, or
+// the words between start and end seconds
+type SpeakerSelectionRequest struct {
+	Segments []int    `json:"segments,omitempty"`
+	Start    *float64 `json:"start,omitempty"`
+	End      *float64 `json:"end,omitempty"`
+}
+
+// ReassignSpeakerRequest gives part of a transcript to another of its speakers
+type ReassignSpeakerRequest struct {
+	SpeakerSelectionRequest
+	Speaker string `json:"speaker" binding:"required"`
+}
+
+// SplitSpeakerRequest gives part of a transcript to a new speaker
+type SplitSpeakerRequest struct {
+	SpeakerSelectionRequest
+	Name string `json:"name,omitempty"` // Custom name of the new speaker
+}
+
+// selection converts the request to a transcript selection
+func (r SpeakerSelectionRequest) selection() transcription.SpeakerSelection {
+	return transcription.SpeakerSelection{Segments: r.Segments, Start: r.Start, End: r.End}
+}
+
+// relabelSpeakers applies a speaker edit to a completed job's transcript,
+// saves the result as a new revision and drops the mappings of speakers the
+// transcript no longer has. The edit returns the names of new speakers. It
+// is refused while a model run on the transcript would overwrite it.
+func (h *Handler) relabelSpeakers(c *gin.Context, description string, edit func(*interfaces.TranscriptResult) (map[string]string, error)) {
+	ctx := c.Request.Context()
+	unlock, ok := h.lockTranscriptJob(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusConflict, gin.H{"error": "A diarization, alignment or range transcription of this job is still queued or running"})
+		return
+	}
+	defer unlock()
+
+	job, err := h.jobRepo.FindByID(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	if job.Status != models.StatusCompleted {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only completed transcriptions can be relabelled"})
+		return
+	}
+	if job.Transcript == nil || *job.Transcript == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No transcript available to relabel"})
+		return
+	}
+	var result interfaces.TranscriptResult
+	if err := json.Unmarshal([]byte(*job.Transcript), &result); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to parse transcript"})
+		return
+	}
+
+	names, err := edit(&result)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, transcription.ErrUnknownSpeaker) || errors.Is(err, transcription.ErrInvalidSelection) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	revision, err := newTranscriptRevision(job.ID, models.RevisionSourceSpeakerEdit, description, &result)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	existing, err := h.speakerMappingRepo.ListByJob(ctx, job.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get speaker mappings"})
+		return
+	}
+	present := make(map[string]bool)
+	for _, speaker := range transcriptSpeakers(&result) {
+		present[speaker] = true
+	}
+	var mappings []models.SpeakerMapping
+	for _, mapping := range existing {
+		if present[mapping.OriginalSpeaker] {
+			mapping.ID = 0
+			mappings = append(mappings, mapping)
+		}
+	}
+	for speaker, name := range names {
+		mappings = append(mappings, models.SpeakerMapping{
+			TranscriptionJobID: job.ID,
+			OriginalSpeaker:    speaker,
+			CustomName:         name,
+		})
+	}
+	// Speakers split off a transcript without diarization make it a diarized one
+	markDiarized := !job.Diarization && len(present) > 0
+	if err := h.jobRepo.SaveRelabelledTranscript(ctx, revision, markDiarized, mappings); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save transcript revision"})
+		return
+	}
+	// Voice embeddings of the old speakers no longer describe them
+	if err := h.voiceRepo.DeleteUnassignedByJobID(ctx, job.ID); err != nil {
+		logger.Warn("Failed to delete stale speaker embeddings", "job_id", job.ID, "error", err)
+	}
+
+	saved, err := h.speakerMappingRepo.ListByJob(ctx, job.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch speaker mappings"})
+		return
+	}
+	response := TranscriptRevisionResponse{Revision: *revision, Speakers: make([]SpeakerMappingResponse, len(saved))}
+	for i, mapping := range saved {
+		response.Speakers[i] = newSpeakerMappingResponse(mapping)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Merge two speakers
+// @Description Relabel everything one speaker said as another speaker, for diarizations that split one person in two. The result is saved as a new transcript revision and the merged speaker's name mapping is removed.
+// @Tags transcription
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param request body MergeSpeakersRequest true "Speakers to merge"
+// @Success 200 {object} TranscriptRevisionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/transcription/{id}/speakers/merge [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) MergeSpeakers(c *gin.Context) {
+	var req MergeSpeakersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	description := fmt.Sprintf("Merged %s into %s", req.Source, req.Target)
+	h.relabelSpeakers(c, description, func(result *interfaces.TranscriptResult) (map[string]string, error) {
+		return nil, transcription.MergeSpeakers(result, req.Source, req.Target)
+	})
+}
+
+// @Summary Reassign part of a transcript
+// @Description Give selected segments, or the words of a time range, to another speaker of the transcript. Segments are split where only some of their words change speaker. The result is saved as a new transcript revision.
+// @Tags transcription
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param request body ReassignSpeakerRequest true "Selection and its speaker"
+// @Success 200 {object} TranscriptRevisionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/transcription/{id}/speakers/reassign [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) ReassignSpeaker(c *gin.Context) {
+	var req ReassignSpeakerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	description := "Reassigned speech to " + req.Speaker
+	h.relabelSpeakers(c, description, func(result *interfaces.TranscriptResult) (map[string]string, error) {
+		return nil, transcription.ReassignSpeaker(result, req.selection(), req.Speaker)
+	})
+}
+
+// @Summary Split off a new speaker
+// @Description Give selected segments, or the words of a time range, to a new speaker, for diarizations that merged two people. The new speaker gets the next free SPEAKER_NN label and, if given, a custom name. The result is saved as a new transcript revision.
+// @Tags transcription
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param request body SplitSpeakerRequest true "Selection of the new speaker"
+// @Success 200 {object} TranscriptRevisionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/transcription/{id}/speakers/split [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) SplitSpeaker(c *gin.Context) {
+	var req SplitSpeakerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	h.relabelSpeakers(c, "Split off a new speaker", func(result *interfaces.TranscriptResult) (map[string]string, error) {
+		speaker, err := transcription.SplitSpeaker(result, req.selection())
+		if err != nil || req.Name == "" {
+			return nil, err
+		}
+		return map[string]string{speaker: req.Name}, nil
+	})
+}
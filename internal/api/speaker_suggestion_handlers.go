@@ -0,0 +1,294 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"scriberr/internal/llm"
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// speakerSuggestionPrompt asks the LLM for the names of a transcript's speakers
+const speakerSuggestionPrompt = `You identify the speakers of a transcript that was split into speakers automatically. Each line starts with the speaker's label and the time it was said.
+
+Suggest a name, and a role if one is mentioned, for each speaker the conversation identifies, for example when someone introduces themselves ("Hi, this is Maria from finance") or is addressed by name ("Thanks, Tom"). Use only what the transcript says; leave out speakers you cannot identify. Keep in mind that the person a name is said to is usually not the one saying it.
+
+For every suggestion give:
+- "speaker": the speaker's label exactly as in the transcript
+- "name": the person's name
+- "role": their role, team or organisation, or "" if not mentioned
+- "confidence": how sure you are, from 0 to 1
+- "quotes": the exact transcript passages that support the suggestion, copied word for word
+
+Return JSON only, no markdown or explanations, in this form:
+{"suggestions": [{"speaker": "SPEAKER_00", "name": "Maria", "role": "Finance", "confidence": 0.9, "quotes": ["Hi, this is Maria from finance"]}]}`
+
+// speakerSuggestionResponseTokens is kept free of the context window for the reply
+const speakerSuggestionResponseTokens = 1000
+
+// SpeakerSuggestionRequest selects the LLM that suggests speaker names
+type SpeakerSuggestionRequest struct {
+	Model         string  `json:"model" binding:"required"`
+	MinConfidence float64 `json:"min_confidence,omitempty"` // Leave out less certain suggestions
+}
+
+// SpeakerSuggestion is a name the LLM suggests for a speaker
+type SpeakerSuggestion struct {
+	OriginalSpeaker string   `json:"original_speaker"`
+	CurrentName     string   `json:"current_name"`
+	Name            string   `json:"name"`
+	Role            string   `json:"role,omitempty"`
+	Confidence      float64  `json:"confidence"`
+	Quotes          []string `json:"quotes"` // Transcript passages supporting the name
+}
+
+// SpeakerSuggestionsResponse is the suggested speaker names of a transcript
+type SpeakerSuggestionsResponse struct {
+	Model       string              `json:"model"`
+	Suggestions []SpeakerSuggestion `json:"suggestions"`
+	// The job's speaker mappings with the suggestions applied. Posting them to
+	// /api/v1/transcription/{id}/speakers accepts all suggestions at once.
+	Mappings  []SpeakerMappingRequest `json:"mappings"`
+	Truncated bool                    `json:"truncated"` // Only the start of a long transcript was sent
+}
+
+// speakerSuggestionReply is the JSON the LLM is asked to reply with
+type speakerSuggestionReply struct {
+	Suggestions []struct {
+		Speaker    string   `json:"speaker"`
+		Name       string   `json:"name"`
+		Role       string   `json:"role"`
+		Confidence float64  `json:"confidence"`
+		Quotes     []string `json:"quotes"`
+	} `json:"suggestions"`
+}
+
+// speakerTranscriptLines formats a transcript as one labelled line per
+// segment, as far as it fits in maxChars
+func speakerTranscriptLines(result *interfaces.TranscriptResult, maxChars int) (string, bool) {
+	var sb strings.Builder
+	for _, segment := range result.Segments {
+		speaker := "UNKNOWN"
+		if segment.Speaker != nil {
+			speaker = *segment.Speaker
+		}
+		line := fmt.Sprintf("[%s] [%s - %s] %s\n", speaker, formatTime(segment.Start), formatTime(segment.End), strings.TrimSpace(segment.Text))
+		if sb.Len()+len(line) > maxChars {
+			return sb.String(), true
+		}
+		sb.WriteString(line)
+	}
+	return sb.String(), false
+}
+
+// normalizeQuote reduces text to lower case letters and digits, so quotes
+// match the transcript regardless of punctuation and spacing
+func normalizeQuote(text string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// parseSpeakerSuggestions reads the LLM's reply. Suggestions for unknown
+// speakers, without a name or without a quote found in the transcript are
+// dropped, so names the LLM made up are not proposed.
+func parseSpeakerSuggestions(content, transcript string, names map[string]string) ([]SpeakerSuggestion, error) {
+	content = strings.TrimSpace(content)
+	if start, end := strings.Index(content, "{"), strings.LastIndex(content, "}"); start >= 0 && end > start {
+		content = content[start : end+1]
+	}
+	var reply speakerSuggestionReply
+	if err := json.Unmarshal([]byte(content), &reply); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	normalizedTranscript := normalizeQuote(transcript)
+	best := make(map[string]SpeakerSuggestion)
+	for _, s := range reply.Suggestions {
+		currentName, known := names[s.Speaker]
+		name := strings.TrimSpace(s.Name)
+		if !known || name == "" {
+			continue
+		}
+		var quotes []string
+		for _, quote := range s.Quotes {
+			if normalized := normalizeQuote(quote); normalized != "" && strings.Contains(normalizedTranscript, normalized) {
+				quotes = append(quotes, strings.TrimSpace(quote))
+			}
+		}
+		if len(quotes) == 0 {
+			continue
+		}
+		if utf8.RuneCountInString(name) > 100 {
+			name = string([]rune(name)[:100])
+		}
+		suggestion := SpeakerSuggestion{
+			OriginalSpeaker: s.Speaker,
+			CurrentName:     currentName,
+			Name:            name,
+			Role:            strings.TrimSpace(s.Role),
+			Confidence:      min(max(s.Confidence, 0), 1),
+			Quotes:          quotes,
+		}
+		if previous, ok := best[s.Speaker]; !ok || suggestion.Confidence > previous.Confidence {
+			best[s.Speaker] = suggestion
+		}
+	}
+
+	suggestions := make([]SpeakerSuggestion, 0, len(best))
+	for _, suggestion := range best {
+		suggestions = append(suggestions, suggestion)
+	}
+	sort.Slice(suggestions, func(i, j int) bool { return suggestions[i].OriginalSpeaker < suggestions[j].OriginalSpeaker })
+	return suggestions, nil
+}
+
+// @Summary Suggest speaker names
+// @Description Send the diarized transcript to the configured LLM and get suggested names and roles for its speakers, with supporting quotes and a confidence. Nothing is saved; the returned mappings can be posted to the speaker mappings endpoint to accept the suggestions. Speakers confirmed from the speaker library keep their names.
+// @Tags transcription
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param request body SpeakerSuggestionRequest true "LLM model"
+// @Success 200 {object} SpeakerSuggestionsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/transcription/{id}/speakers/suggestions [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) SuggestSpeakerNames(c *gin.Context) {
+	var req SpeakerSuggestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	job, err := h.jobRepo.FindByID(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	if job.Status != models.StatusCompleted {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only completed transcriptions have speakers to name"})
+		return
+	}
+	if job.Transcript == nil || *job.Transcript == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No transcript available"})
+		return
+	}
+	var result interfaces.TranscriptResult
+	if err := json.Unmarshal([]byte(*job.Transcript), &result); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to parse transcript"})
+		return
+	}
+	speakers := transcriptSpeakers(&result)
+	if len(speakers) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The transcript has no speakers"})
+		return
+	}
+
+	mappings, err := h.speakerMappingRepo.ListByJob(ctx, job.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get speaker mappings"})
+		return
+	}
+	names := make(map[string]string, len(speakers))
+	for _, speaker := range speakers {
+		names[speaker] = speaker
+	}
+	confirmed := make(map[string]bool)
+	for _, mapping := range mappings {
+		names[mapping.OriginalSpeaker] = mapping.CustomName
+		confirmed[mapping.OriginalSpeaker] = mapping.Confirmed
+	}
+
+	svc, _, err := h.getLLMService(ctx)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	contextWindow, err := svc.GetContextWindow(ctx, req.Model)
+	if err != nil {
+		contextWindow = 4096
+	}
+
+	// Estimate 1 token ~= 4 chars. People usually introduce themselves early,
+	// so a transcript too long for the model is cut at the end.
+	var header strings.Builder
+	header.WriteString("Speakers:\n")
+	for _, speaker := range speakers {
+		if names[speaker] != speaker {
+			fmt.Fprintf(&header, "- %s (currently named %q)\n", speaker, names[speaker])
+		} else {
+			fmt.Fprintf(&header, "- %s\n", speaker)
+		}
+	}
+	header.WriteString("\nTranscript:\n")
+	budget := (contextWindow-speakerSuggestionResponseTokens)*4 - len(speakerSuggestionPrompt) - header.Len()
+	if budget <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The model's context window is too small"})
+		return
+	}
+	transcript, truncated := speakerTranscriptLines(&result, budget)
+	messages := []llm.ChatMessage{
+		{Role: "system", Content: speakerSuggestionPrompt},
+		{Role: "user", Content: header.String() + transcript},
+	}
+
+	llmCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	resp, err := svc.ChatCompletion(llmCtx, req.Model, messages, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "LLM request failed: " + err.Error()})
+		return
+	}
+	if resp == nil || len(resp.Choices) == 0 {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Empty response from LLM"})
+		return
+	}
+	suggestions, err := parseSpeakerSuggestions(resp.Choices[0].Message.Content, transcript, names)
+	if err != nil {
+		logger.Warn("Unusable speaker name suggestions", "job_id", job.ID, "model", req.Model, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to parse LLM response: " + err.Error()})
+		return
+	}
+
+	response := SpeakerSuggestionsResponse{Model: req.Model, Suggestions: []SpeakerSuggestion{}, Truncated: truncated}
+	proposed := make(map[string]string)
+	for _, suggestion := range suggestions {
+		if suggestion.Confidence < req.MinConfidence {
+			continue
+		}
+		response.Suggestions = append(response.Suggestions, suggestion)
+		if !confirmed[suggestion.OriginalSpeaker] {
+			proposed[suggestion.OriginalSpeaker] = suggestion.Name
+		}
+	}
+	for _, speaker := range speakers {
+		name, ok := proposed[speaker]
+		if !ok {
+			name = names[speaker]
+		}
+		response.Mappings = append(response.Mappings, SpeakerMappingRequest{OriginalSpeaker: speaker, CustomName: name})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
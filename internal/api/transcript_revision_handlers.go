@@ -0,0 +1,560 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"scriberr/internal/models"
+	"scriberr/internal/queue"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DiarizeTranscriptRequest selects the diarization run on a finished transcript
+type DiarizeTranscriptRequest struct {
+	DiarizeModel      string  `json:"diarize_model" binding:"required"` // pyannote, nvidia_sortformer or a diarization plugin
+	MinSpeakers       *int    `json:"min_speakers,omitempty"`
+	MaxSpeakers       *int    `json:"max_speakers,omitempty"`
+	HfToken           *string `json:"hf_token,omitempty"`           // defaults to the job's token
+	SpeakerAssignment string  `json:"speaker_assignment,omitempty"` // 'word' (default) or 'segment'
+}
+
+// AlignTranscriptRequest overrides the alignment settings of the job
+type AlignTranscriptRequest struct {
+	Language   *string `json:"language,omitempty"`    // defaults to the transcript's language
+	AlignModel *string `json:"align_model,omitempty"` // e.g. KBLab/wav2vec2-large-voxrex-swedish
+}
+
+// RetranscribeRangeRequest selects a time range and how to transcribe it again
+type RetranscribeRangeRequest struct {
+	Start       float64 `json:"start" binding:"gte=0"`       // seconds
+	End         float64 `json:"end" binding:"required,gt=0"` // seconds
+	ModelFamily string  `json:"model_family,omitempty"`      // defaults to the job's
+	Model       string  `json:"model,omitempty"`             // required when the model family changes
+	Language    *string `json:"language,omitempty"`          // defaults to the job's
+	HfToken     *string `json:"hf_token,omitempty"`          // defaults to the job's token
+}
+
+// TranscriptRevisionResponse is a new transcript revision with the job's speakers
+type TranscriptRevisionResponse struct {
+	Revision models.TranscriptRevision `json:"revision"`
+	Speakers []SpeakerMappingResponse  `json:"speakers"`
+}
+
+// Transcript task states
+const (
+	TranscriptTaskQueued    = "queued"
+	TranscriptTaskRunning   = "running"
+	TranscriptTaskCompleted = "completed"
+	TranscriptTaskFailed    = "failed"
+)
+
+// TranscriptTask is a diarization, alignment or range transcription of a
+// finished job, run on a worker of the task queue
+type TranscriptTask struct {
+	JobID      string                     `json:"job_id"`
+	Kind       string                     `json:"kind"`   // diarize, align or retranscribe
+	Status     string                     `json:"status"` // queued, running, completed or failed
+	Error      string                     `json:"error,omitempty"`
+	Revision   *models.TranscriptRevision `json:"revision,omitempty"` // The saved transcript revision once completed
+	Start      *float64                   `json:"start,omitempty"`    // The range transcribed again, widened to the segments it overlaps
+	End        *float64                   `json:"end,omitempty"`
+	QueuedAt   time.Time                  `json:"queued_at"`
+	StartedAt  *time.Time                 `json:"started_at,omitempty"`
+	FinishedAt *time.Time                 `json:"finished_at,omitempty"`
+}
+
+// transcriptSpeakers returns the speakers of a transcript's segments and words
+// in order of appearance
+func transcriptSpeakers(result *interfaces.TranscriptResult) []string {
+	seen := make(map[string]bool)
+	var speakers []string
+	add := func(speaker *string) {
+		if speaker != nil && !seen[*speaker] {
+			seen[*speaker] = true
+			speakers = append(speakers, *speaker)
+		}
+	}
+	for _, segment := range result.Segments {
+		add(segment.Speaker)
+	}
+	for _, word := range result.WordSegments {
+		add(word.Speaker)
+	}
+	return speakers
+}
+
+// submitTranscriptTask reserves a job and queues a model run on its finished
+// transcript, answering with the queued task. run records its results in the
+// task it is given; the job stays reserved until run returns.
+func (h *Handler) submitTranscriptTask(c *gin.Context, jobID, kind string, run func(ctx context.Context, result *TranscriptTask) error) {
+	unlock, ok := h.lockTranscriptJob(jobID)
+	if !ok {
+		c.JSON(http.StatusConflict, gin.H{"error": "Another diarization, alignment or range transcription of this job is still queued or running"})
+		return
+	}
+
+	task := &TranscriptTask{JobID: jobID, Kind: kind, Status: TranscriptTaskQueued, QueuedAt: time.Now()}
+	queued := h.updateTranscriptTask(task, func(*TranscriptTask) {})
+	err := h.taskQueue.SubmitTask(func(ctx context.Context) {
+		defer unlock()
+		h.updateTranscriptTask(task, func(t *TranscriptTask) {
+			now := time.Now()
+			t.Status = TranscriptTaskRunning
+			t.StartedAt = &now
+		})
+
+		var result TranscriptTask
+		err := run(ctx, &result)
+		if err != nil {
+			logger.Warn("Transcript task failed", "job_id", jobID, "kind", kind, "error", err)
+		}
+		h.updateTranscriptTask(task, func(t *TranscriptTask) {
+			now := time.Now()
+			t.FinishedAt = &now
+			if err != nil {
+				t.Status = TranscriptTaskFailed
+				t.Error = err.Error()
+				return
+			}
+			t.Status = TranscriptTaskCompleted
+			t.Revision, t.Start, t.End = result.Revision, result.Start, result.End
+		})
+	})
+	if err != nil {
+		unlock()
+		h.updateTranscriptTask(task, func(t *TranscriptTask) {
+			now := time.Now()
+			t.Status = TranscriptTaskFailed
+			t.Error = err.Error()
+			t.FinishedAt = &now
+		})
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Failed to queue the task: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, queued)
+}
+
+// updateTranscriptTask changes a task as the job's latest transcript task,
+// sends the result as a transcript_task event on the job's channel and
+// returns it
+func (h *Handler) updateTranscriptTask(task *TranscriptTask, change func(*TranscriptTask)) TranscriptTask {
+	h.transcriptJobsMu.Lock()
+	change(task)
+	if h.transcriptTasks == nil {
+		h.transcriptTasks = make(map[string]*TranscriptTask)
+	}
+	h.transcriptTasks[task.JobID] = task
+	snapshot := *task
+	h.transcriptJobsMu.Unlock()
+
+	if h.broadcaster != nil {
+		h.broadcaster.Broadcast(task.JobID, "transcript_task", snapshot)
+	}
+	return snapshot
+}
+
+// completedTranscriptJob loads the job a queued transcript task works on. The
+// job is loaded when the task starts, so edits made while it waited are kept.
+func (h *Handler) completedTranscriptJob(ctx context.Context, jobID string) (*models.TranscriptionJob, error) {
+	job, err := h.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load job: %w", err)
+	}
+	if job.Status != models.StatusCompleted || job.Transcript == nil || *job.Transcript == "" {
+		return nil, fmt.Errorf("job %s no longer has a finished transcript", jobID)
+	}
+	return job, nil
+}
+
+// lockTranscriptJob reserves a job for a model run or speaker edit on its
+// finished transcript. It returns false if one is already running for the
+// job; otherwise the returned func releases the job.
+func (h *Handler) lockTranscriptJob(jobID string) (func(), bool) {
+	h.transcriptJobsMu.Lock()
+	defer h.transcriptJobsMu.Unlock()
+	if h.transcriptJobs[jobID] {
+		return nil, false
+	}
+	if h.transcriptJobs == nil {
+		h.transcriptJobs = make(map[string]bool)
+	}
+	h.transcriptJobs[jobID] = true
+	return func() {
+		h.transcriptJobsMu.Lock()
+		delete(h.transcriptJobs, jobID)
+		h.transcriptJobsMu.Unlock()
+	}, true
+}
+
+// saveTranscriptRevision stores a changed transcript as the job's newest revision
+func (h *Handler) saveTranscriptRevision(ctx context.Context, jobID, source, description string, result *interfaces.TranscriptResult) (*models.TranscriptRevision, error) {
+	revision, err := newTranscriptRevision(jobID, source, description, result)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.jobRepo.SaveTranscriptRevision(ctx, revision); err != nil {
+		return nil, fmt.Errorf("failed to save transcript revision: %w", err)
+	}
+	return revision, nil
+}
+
+// newTranscriptRevision builds an unsaved revision of a changed transcript
+func newTranscriptRevision(jobID, source, description string, result *interfaces.TranscriptResult) (*models.TranscriptRevision, error) {
+	transcriptJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transcript: %w", err)
+	}
+	revision := &models.TranscriptRevision{
+		TranscriptionJobID: jobID,
+		Source:             source,
+		Transcript:         string(transcriptJSON),
+	}
+	if description != "" {
+		revision.Description = &description
+	}
+	return revision, nil
+}
+
+// reanchorNotes points a job's notes at the words of its changed transcript
+// that fall within the notes' time spans. Notes over no words are kept as
+// they are.
+func (h *Handler) reanchorNotes(ctx context.Context, jobID string, words []interfaces.TranscriptWord) error {
+	notes, err := h.noteRepo.ListByJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to list notes: %w", err)
+	}
+	for _, note := range notes {
+		start, end := -1, -1
+		for i, word := range words {
+			if word.End > note.StartTime && word.Start < note.EndTime {
+				if start < 0 {
+					start = i
+				}
+				end = i
+			}
+		}
+		if start < 0 || (start == note.StartWordIndex && end == note.EndWordIndex) {
+			continue
+		}
+		note.StartWordIndex, note.EndWordIndex = start, end
+		if err := h.noteRepo.Update(ctx, &note); err != nil {
+			return fmt.Errorf("failed to update note %s: %w", note.ID, err)
+		}
+	}
+	return nil
+}
+
+// @Summary Diarize a finished transcript
+// @Description Queue a diarization of a completed job's audio. Its speakers are merged into the current transcript without changing the text, the result is saved as a new transcript revision and the new speakers replace the job's speaker mappings. The task in the response holds the revision once the diarization is done.
+// @Tags transcription
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param request body DiarizeTranscriptRequest true "Diarization settings"
+// @Success 202 {object} TranscriptTask
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/v1/transcription/{id}/diarize [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) DiarizeTranscript(c *gin.Context) {
+	var req DiarizeTranscriptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	if h.taskQueue.State() != queue.StateRunning {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "The task queue is " + string(h.taskQueue.State())})
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	if job.Status != models.StatusCompleted {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only completed transcriptions can be diarized"})
+		return
+	}
+	if job.Transcript == nil || *job.Transcript == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No transcript available to diarize"})
+		return
+	}
+	if err := h.unifiedProcessor.CheckDiarizationModel(req.DiarizeModel); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	jobID := job.ID
+	h.submitTranscriptTask(c, jobID, "diarize", func(ctx context.Context, result *TranscriptTask) error {
+		job, err := h.completedTranscriptJob(ctx, jobID)
+		if err != nil {
+			return err
+		}
+		params := job.Parameters
+		params.DiarizeModel = req.DiarizeModel
+		params.MinSpeakers = req.MinSpeakers
+		params.MaxSpeakers = req.MaxSpeakers
+		if req.HfToken != nil {
+			params.HfToken = req.HfToken
+		}
+		if req.SpeakerAssignment != "" {
+			params.SpeakerAssignment = req.SpeakerAssignment
+		}
+
+		merged, _, err := h.unifiedProcessor.DiarizeTranscript(ctx, job, params)
+		if err != nil {
+			return err
+		}
+		revision, err := newTranscriptRevision(job.ID, models.RevisionSourceDiarization, "Diarized with "+req.DiarizeModel, merged)
+		if err != nil {
+			return err
+		}
+
+		// Speaker labels of an earlier diarization no longer apply
+		var mappings []models.SpeakerMapping
+		for _, speaker := range transcriptSpeakers(merged) {
+			mappings = append(mappings, models.SpeakerMapping{
+				TranscriptionJobID: job.ID,
+				OriginalSpeaker:    speaker,
+				CustomName:         speaker,
+			})
+		}
+		if err := h.jobRepo.SaveDiarizedTranscript(ctx, revision, req.DiarizeModel, mappings); err != nil {
+			return fmt.Errorf("failed to save diarized transcript: %w", err)
+		}
+		if _, err := h.unifiedProcessor.IdentifySpeakers(ctx, job, merged); err != nil {
+			logger.Warn("Failed to identify speakers from the speaker library", "job_id", job.ID, "error", err)
+		}
+		result.Revision = revision
+		return nil
+	})
+}
+
+// @Summary Align a transcript's words
+// @Description Queue a word alignment of a completed job's current transcript with the WhisperX alignment model, for models without word timings or after the text was edited. Segments, their text and speakers are kept, the words are replaced and notes move to the words in their time span. The aligned transcript is saved as a new revision, which the task in the response holds once done.
+// @Tags transcription
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param request body AlignTranscriptRequest false "Alignment settings"
+// @Success 202 {object} TranscriptTask
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/v1/transcription/{id}/align [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) AlignTranscript(c *gin.Context) {
+	var req AlignTranscriptRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+	}
+
+	if h.taskQueue.State() != queue.StateRunning {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "The task queue is " + string(h.taskQueue.State())})
+		return
+	}
+
+	ctx := c.Request.Context()
+	job, err := h.jobRepo.FindByID(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	if job.Status != models.StatusCompleted {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only completed transcriptions can be aligned"})
+		return
+	}
+	if job.Transcript == nil || *job.Transcript == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No transcript available to align"})
+		return
+	}
+	if err := h.unifiedProcessor.CheckWordAligner(ctx); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+
+	jobID := job.ID
+	h.submitTranscriptTask(c, jobID, "align", func(ctx context.Context, result *TranscriptTask) error {
+		job, err := h.completedTranscriptJob(ctx, jobID)
+		if err != nil {
+			return err
+		}
+		params := job.Parameters
+		if req.AlignModel != nil {
+			params.AlignModel = req.AlignModel
+		}
+		var language string
+		if req.Language != nil {
+			language = *req.Language
+		}
+
+		aligned, err := h.unifiedProcessor.AlignTranscript(ctx, job, params, language)
+		if err != nil {
+			return err
+		}
+		revision, err := h.saveTranscriptRevision(ctx, job.ID, models.RevisionSourceAlignment, fmt.Sprintf("Aligned %d words", len(aligned.WordSegments)), aligned)
+		if err != nil {
+			return err
+		}
+		if err := h.reanchorNotes(ctx, job.ID, aligned.WordSegments); err != nil {
+			logger.Warn("Failed to re-anchor notes", "job_id", job.ID, "error", err)
+		}
+		result.Revision = revision
+		return nil
+	})
+}
+
+// @Summary Transcribe a time range again
+// @Description Queue a new transcription of part of a completed job's audio, e.g. with another model or language. The range is widened to the segments it overlaps and only those segments of the current transcript are replaced; the new speech gets the speakers of the speech it replaces and notes move to its words. Once done, the task in the response holds the widened range and the new transcript revision.
+// @Tags transcription
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param request body RetranscribeRangeRequest true "Time range and transcription settings"
+// @Success 202 {object} TranscriptTask
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/v1/transcription/{id}/retranscribe [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) RetranscribeRange(c *gin.Context) {
+	var req RetranscribeRangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	if req.End <= req.Start {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end must be after start"})
+		return
+	}
+
+	if h.taskQueue.State() != queue.StateRunning {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "The task queue is " + string(h.taskQueue.State())})
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	if job.Status != models.StatusCompleted {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only completed transcriptions can be transcribed again"})
+		return
+	}
+	if job.Transcript == nil || *job.Transcript == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No transcript available to splice into"})
+		return
+	}
+	if req.ModelFamily != "" && req.ModelFamily != job.Parameters.ModelFamily && req.Model == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "model is required when changing the model family"})
+		return
+	}
+
+	jobID := job.ID
+	h.submitTranscriptTask(c, jobID, "retranscribe", func(ctx context.Context, result *TranscriptTask) error {
+		job, err := h.completedTranscriptJob(ctx, jobID)
+		if err != nil {
+			return err
+		}
+		params := job.Parameters
+		if req.ModelFamily != "" {
+			params.ModelFamily = req.ModelFamily
+		}
+		if req.Model != "" {
+			params.Model = req.Model
+		}
+		if req.Language != nil {
+			params.Language = req.Language
+		}
+		if req.HfToken != nil {
+			params.HfToken = req.HfToken
+		}
+
+		retranscribed, err := h.unifiedProcessor.RetranscribeRange(ctx, job, params, req.Start, req.End)
+		if err != nil {
+			return err
+		}
+		description := fmt.Sprintf("Transcribed %s-%s again with %s", formatTime(retranscribed.Start), formatTime(retranscribed.End), retranscribed.ModelID)
+		revision, err := h.saveTranscriptRevision(ctx, job.ID, models.RevisionSourceRange, description, retranscribed.Result)
+		if err != nil {
+			return err
+		}
+		if err := h.reanchorNotes(ctx, job.ID, retranscribed.Result.WordSegments); err != nil {
+			logger.Warn("Failed to re-anchor notes", "job_id", job.ID, "error", err)
+		}
+		result.Revision = revision
+		result.Start, result.End = &retranscribed.Start, &retranscribed.End
+		return nil
+	})
+}
+
+// @Summary Get a job's transcript task
+// @Description Get the state of the latest diarization, alignment or range transcription queued for a job since the server started. Changes are also sent as transcript_task events on the job's SSE channel.
+// @Tags transcription
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} TranscriptTask
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/transcription/{id}/transcript-task [get]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) GetTranscriptTask(c *gin.Context) {
+	h.transcriptJobsMu.Lock()
+	task, ok := h.transcriptTasks[c.Param("id")]
+	var snapshot TranscriptTask
+	if ok {
+		snapshot = *task
+	}
+	h.transcriptJobsMu.Unlock()
+
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No transcript task found for this job"})
+		return
+	}
+	c.JSON(http.StatusOK, snapshot)
+}
+
+// @Summary List transcript revisions
+// @Description Get the saved versions of a job's transcript, oldest first. Revisions are stored once a finished transcript is changed.
+// @Tags transcription
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {array} models.TranscriptRevision
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/transcription/{id}/revisions [get]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) ListTranscriptRevisions(c *gin.Context) {
+	ctx := c.Request.Context()
+	jobID := c.Param("id")
+	if _, err := h.jobRepo.FindByID(ctx, jobID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+
+	revisions, err := h.jobRepo.ListTranscriptRevisions(ctx, jobID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch transcript revisions"})
+		return
+	}
+	c.JSON(http.StatusOK, revisions)
+}
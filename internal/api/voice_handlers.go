@@ -0,0 +1,428 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription"
+	"scriberr/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// VoiceResponse is a voice of the speaker library
+type VoiceResponse struct {
+	models.Voice
+	EmbeddingCount int64 `json:"embedding_count"` // Enrolled samples and confirmed speakers
+}
+
+// VoiceRequest renames a voice of the speaker library
+type VoiceRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// SpeakerVoiceRequest confirms or corrects the voice of a job's speaker
+type SpeakerVoiceRequest struct {
+	VoiceID *string `json:"voice_id,omitempty"` // An existing voice
+	Name    *string `json:"name,omitempty"`     // Or the name of a voice, enrolled if new
+}
+
+// SpeakerVoiceResponse is a job's speaker after confirming its voice
+type SpeakerVoiceResponse struct {
+	Mapping        models.SpeakerMapping `json:"mapping"`
+	LibraryUpdated bool                  `json:"library_updated"` // The speaker's embedding was added to the voice
+}
+
+// embeddingStatus maps a failure to compute a voice embedding to a response status
+func embeddingStatus(err error) int {
+	if errors.Is(err, transcription.ErrNoSpeakerEmbedder) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// embedUploadedVoice computes the embedding of the uploaded voice sample
+func (h *Handler) embedUploadedVoice(c *gin.Context) (*models.VoiceEmbedding, bool) {
+	header, err := c.FormFile(paramAudio)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Audio file is required"})
+		return nil, false
+	}
+	filePath, err := h.fileService.SaveUpload(header, h.config.UploadDir)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+		return nil, false
+	}
+	defer func() { _ = h.fileService.RemoveFile(filePath) }()
+
+	hfToken := c.PostForm("hf_token")
+	if hfToken == "" {
+		hfToken = h.config.HFToken
+	}
+	embedding, err := h.unifiedProcessor.EmbedVoice(c.Request.Context(), filePath, hfToken)
+	if err != nil {
+		c.JSON(embeddingStatus(err), gin.H{"error": "Failed to compute voice embedding: " + err.Error()})
+		return nil, false
+	}
+	return embedding, true
+}
+
+// @Summary List the speaker library
+// @Description Get the enrolled voices that diarized speakers are matched against
+// @Tags voices
+// @Produce json
+// @Success 200 {array} VoiceResponse
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/voices [get]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) ListVoices(c *gin.Context) {
+	ctx := c.Request.Context()
+	voices, err := h.voiceRepo.ListAll(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list voices"})
+		return
+	}
+	counts, err := h.voiceRepo.CountEmbeddings(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count voice embeddings"})
+		return
+	}
+
+	response := make([]VoiceResponse, len(voices))
+	for i, voice := range voices {
+		response[i] = VoiceResponse{Voice: voice, EmbeddingCount: counts[voice.ID]}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Enroll a voice
+// @Description Add a named person to the speaker library from a recording of them speaking alone. The voice embedding is computed with the diarization environment.
+// @Tags voices
+// @Accept multipart/form-data
+// @Produce json
+// @Param name formData string true "Name of the person"
+// @Param audio formData file true "Recording of the person speaking"
+// @Param hf_token formData string false "HuggingFace token for the embedding model"
+// @Success 201 {object} VoiceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/v1/voices [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) CreateVoice(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := strings.TrimSpace(c.PostForm("name"))
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Name is required"})
+		return
+	}
+	if _, err := h.voiceRepo.FindByName(ctx, name); err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "A voice with this name already exists"})
+		return
+	}
+
+	embedding, ok := h.embedUploadedVoice(c)
+	if !ok {
+		return
+	}
+
+	voice := models.Voice{Name: name}
+	if err := h.voiceRepo.Create(ctx, &voice); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create voice"})
+		return
+	}
+	embedding.VoiceID = &voice.ID
+	if err := h.voiceRepo.AddEmbedding(ctx, embedding); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save voice embedding"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, VoiceResponse{Voice: voice, EmbeddingCount: 1})
+}
+
+// @Summary Add a voice sample
+// @Description Add another recording of an enrolled person to improve matching
+// @Tags voices
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Voice ID"
+// @Param audio formData file true "Recording of the person speaking"
+// @Param hf_token formData string false "HuggingFace token for the embedding model"
+// @Success 200 {object} VoiceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/v1/voices/{id}/samples [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) AddVoiceSample(c *gin.Context) {
+	ctx := c.Request.Context()
+	voice, err := h.voiceRepo.FindByID(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Voice not found"})
+		return
+	}
+
+	embedding, ok := h.embedUploadedVoice(c)
+	if !ok {
+		return
+	}
+	embedding.VoiceID = &voice.ID
+	if err := h.voiceRepo.AddEmbedding(ctx, embedding); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save voice embedding"})
+		return
+	}
+
+	counts, err := h.voiceRepo.CountEmbeddings(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count voice embeddings"})
+		return
+	}
+	c.JSON(http.StatusOK, VoiceResponse{Voice: *voice, EmbeddingCount: counts[voice.ID]})
+}
+
+// @Summary Rename a voice
+// @Description Rename a person of the speaker library, along with the speakers matched to them
+// @Tags voices
+// @Accept json
+// @Produce json
+// @Param id path string true "Voice ID"
+// @Param request body VoiceRequest true "New name"
+// @Success 200 {object} models.Voice
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/voices/{id} [put]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) UpdateVoice(c *gin.Context) {
+	var req VoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Name is required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	voice, err := h.voiceRepo.FindByID(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Voice not found"})
+		return
+	}
+	if existing, err := h.voiceRepo.FindByName(ctx, name); err == nil && existing.ID != voice.ID {
+		c.JSON(http.StatusConflict, gin.H{"error": "A voice with this name already exists"})
+		return
+	}
+
+	voice.Name = name
+	if err := h.voiceRepo.Rename(ctx, voice); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rename voice"})
+		return
+	}
+	c.JSON(http.StatusOK, voice)
+}
+
+// @Summary Delete a voice
+// @Description Remove a person and their enrolled samples from the speaker library. Speakers matched to them keep their names.
+// @Tags voices
+// @Produce json
+// @Param id path string true "Voice ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/voices/{id} [delete]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) DeleteVoice(c *gin.Context) {
+	ctx := c.Request.Context()
+	voice, err := h.voiceRepo.FindByID(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Voice not found"})
+		return
+	}
+	if err := h.voiceRepo.DeleteVoice(ctx, voice.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete voice"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Voice deleted"})
+}
+
+// resolveVoice finds the voice a speaker is assigned to, enrolling a new one by name
+func (h *Handler) resolveVoice(ctx context.Context, req SpeakerVoiceRequest) (*models.Voice, int, error) {
+	if req.VoiceID != nil && *req.VoiceID != "" {
+		voice, err := h.voiceRepo.FindByID(ctx, *req.VoiceID)
+		if err != nil {
+			return nil, http.StatusNotFound, errors.New("voice not found")
+		}
+		return voice, http.StatusOK, nil
+	}
+
+	name := ""
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+	}
+	if name == "" {
+		return nil, http.StatusBadRequest, errors.New("voice_id or name is required")
+	}
+	voice, err := h.voiceRepo.FindByName(ctx, name)
+	if err == nil {
+		return voice, http.StatusOK, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, http.StatusInternalServerError, errors.New("failed to find voice")
+	}
+	voice = &models.Voice{Name: name}
+	if err := h.voiceRepo.Create(ctx, voice); err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to create voice")
+	}
+	return voice, http.StatusOK, nil
+}
+
+// @Summary Confirm a speaker's voice
+// @Description Confirm the voice a job's speaker was matched to, or correct it by choosing another voice or naming a new one. The speaker's embedding is added to the voice, so later recordings are matched better.
+// @Tags transcription
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param speaker path string true "Diarized speaker label"
+// @Param request body SpeakerVoiceRequest true "Voice of the speaker"
+// @Success 200 {object} SpeakerVoiceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/transcription/{id}/speakers/{speaker}/voice [put]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) ConfirmSpeakerVoice(c *gin.Context) {
+	var req SpeakerVoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	job, err := h.jobRepo.FindByID(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	speaker := c.Param("speaker")
+	mappings, err := h.speakerMappingRepo.ListByJob(ctx, job.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get speaker mappings"})
+		return
+	}
+
+	voice, status, err := h.resolveVoice(ctx, req)
+	if err != nil {
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The speaker's embedding joins the voice's library, unless none can be
+	// computed; the speaker is named either way
+	libraryUpdated := false
+	embedding, err := h.unifiedProcessor.EmbedJobSpeaker(ctx, job, speaker)
+	if err != nil {
+		logger.Warn("Failed to embed speaker for the speaker library", "job_id", job.ID, "speaker", speaker, "error", err)
+	} else if err := h.voiceRepo.AssignEmbedding(ctx, embedding.ID, &voice.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update the speaker library"})
+		return
+	} else {
+		libraryUpdated = true
+	}
+
+	confirmed := models.SpeakerMapping{
+		TranscriptionJobID: job.ID,
+		OriginalSpeaker:    speaker,
+		CustomName:         voice.Name,
+		VoiceID:            &voice.ID,
+		Confirmed:          true,
+	}
+	updated := []models.SpeakerMapping{}
+	for _, mapping := range mappings {
+		if mapping.OriginalSpeaker != speaker {
+			mapping.ID = 0
+			updated = append(updated, mapping)
+			continue
+		}
+		// Keep the score of a match the user confirmed
+		if mapping.VoiceID != nil && *mapping.VoiceID == voice.ID {
+			confirmed.MatchScore = mapping.MatchScore
+		}
+	}
+	updated = append(updated, confirmed)
+	if err := h.speakerMappingRepo.UpdateMappings(ctx, job.ID, updated); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update speaker mappings"})
+		return
+	}
+	for _, mapping := range updated {
+		if mapping.OriginalSpeaker == speaker {
+			confirmed = mapping
+		}
+	}
+
+	c.JSON(http.StatusOK, SpeakerVoiceResponse{Mapping: confirmed, LibraryUpdated: libraryUpdated})
+}
+
+// @Summary Reject a speaker's voice
+// @Description Remove the voice a job's speaker was matched to. The speaker goes back to its diarized label and its embedding is taken out of the voice's library.
+// @Tags transcription
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param speaker path string true "Diarized speaker label"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/transcription/{id}/speakers/{speaker}/voice [delete]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) RejectSpeakerVoice(c *gin.Context) {
+	ctx := c.Request.Context()
+	job, err := h.jobRepo.FindByID(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	speaker := c.Param("speaker")
+	mappings, err := h.speakerMappingRepo.ListByJob(ctx, job.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get speaker mappings"})
+		return
+	}
+
+	found := false
+	for i := range mappings {
+		mappings[i].ID = 0
+		if mappings[i].OriginalSpeaker != speaker || mappings[i].VoiceID == nil {
+			continue
+		}
+		found = true
+		mappings[i].CustomName = speaker
+		mappings[i].VoiceID, mappings[i].MatchScore = nil, nil
+		mappings[i].Confirmed = false
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Speaker has no voice"})
+		return
+	}
+
+	if embedding, err := h.voiceRepo.FindJobEmbedding(ctx, job.ID, speaker); err == nil && embedding.VoiceID != nil {
+		if err := h.voiceRepo.AssignEmbedding(ctx, embedding.ID, nil); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update the speaker library"})
+			return
+		}
+	}
+	if err := h.speakerMappingRepo.UpdateMappings(ctx, job.ID, mappings); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update speaker mappings"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Speaker voice removed"})
+}
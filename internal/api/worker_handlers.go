@@ -0,0 +1,385 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"scriberr/internal/models"
+	"scriberr/internal/queue"
+	"scriberr/internal/worker"
+	"scriberr/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CreateWorkerRequest represents the request to register a remote worker
+type CreateWorkerRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+// CreateWorkerResponse contains the new worker and its token. The token is only returned once.
+type CreateWorkerResponse struct {
+	Worker models.Worker `json:"worker"`
+	Token  string        `json:"token"`
+}
+
+// @Summary Register a remote worker
+// @Description Create a worker token for a remote transcription worker. The token is only shown once.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body CreateWorkerRequest true "Worker details"
+// @Success 201 {object} CreateWorkerResponse
+// @Failure 400 {object} map[string]string
+// @Router /api/v1/admin/workers [post]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) CreateWorker(c *gin.Context) {
+	var req CreateWorkerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	token := generateSecureAPIKey(40)
+	newWorker := models.Worker{
+		Name:      req.Name,
+		TokenHash: sha256Hex(token),
+		IsActive:  true,
+	}
+	if err := h.workerRepo.Create(c.Request.Context(), &newWorker); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create worker"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreateWorkerResponse{Worker: newWorker, Token: token})
+}
+
+// @Summary List remote workers
+// @Description Get all active remote workers with their last heartbeat and current job
+// @Tags admin
+// @Produce json
+// @Success 200 {array} models.Worker
+// @Router /api/v1/admin/workers [get]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) ListWorkers(c *gin.Context) {
+	workers, err := h.workerRepo.ListActive(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch workers"})
+		return
+	}
+	c.JSON(http.StatusOK, workers)
+}
+
+// @Summary Revoke a remote worker
+// @Description Revoke a worker token. Jobs it holds are requeued once their lease expires.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Worker ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/admin/workers/{id} [delete]
+// @Security ApiKeyAuth
+// @Security BearerAuth
+func (h *Handler) DeleteWorker(c *gin.Context) {
+	workerID := c.Param("id")
+	if _, err := h.workerRepo.FindByID(c.Request.Context(), workerID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Worker not found"})
+		return
+	}
+
+	if err := h.workerRepo.Revoke(c.Request.Context(), workerID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke worker"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Worker revoked successfully"})
+}
+
+// @Summary Lease a job
+// @Description Hand the next pending job to the calling remote worker, along with the custom models registered on the server. Returns 204 when there is nothing to do.
+// @Tags worker
+// @Produce json
+// @Success 200 {object} worker.LeaseResponse
+// @Success 204 "No job available"
+// @Failure 503 {object} map[string]string
+// @Router /api/v1/worker/lease [post]
+func (h *Handler) LeaseWorkerJob(c *gin.Context) {
+	workerID := c.GetString("worker_id")
+
+	jobID, err := h.taskQueue.LeaseJob(workerID)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+	h.touchWorker(c, workerID, jobID)
+	if jobID == "" {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(c.Request.Context(), jobID)
+	if err != nil {
+		_ = h.taskQueue.ReleaseLease(jobID, workerID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get job"})
+		return
+	}
+	customModels, err := h.customModelRepo.ListAll(c.Request.Context())
+	if err != nil {
+		_ = h.taskQueue.ReleaseLease(jobID, workerID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list custom models"})
+		return
+	}
+
+	if h.broadcaster != nil {
+		h.broadcaster.Broadcast(jobID, "job_update", map[string]interface{}{
+			"job_id":    jobID,
+			"status":    models.StatusProcessing,
+			"worker_id": workerID,
+		})
+	}
+
+	c.JSON(http.StatusOK, worker.LeaseResponse{
+		Job:             *job,
+		LeaseTTLSeconds: int(queue.LeaseTTL.Seconds()),
+		CustomModels:    customModels,
+	})
+}
+
+// @Summary Download job audio
+// @Description Download the audio file of a job leased to the calling worker
+// @Tags worker
+// @Produce application/octet-stream
+// @Param id path string true "Job ID"
+// @Success 200 {file} binary
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/worker/jobs/{id}/audio [get]
+func (h *Handler) GetWorkerJobAudio(c *gin.Context) {
+	jobID := c.Param("id")
+	if _, err := h.taskQueue.RenewLease(jobID, c.GetString("worker_id")); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, err := h.jobRepo.FindByID(c.Request.Context(), jobID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	if _, err := os.Stat(job.AudioPath); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Audio file not found"})
+		return
+	}
+
+	c.FileAttachment(job.AudioPath, filepath.Base(job.AudioPath))
+}
+
+// @Summary Send a job heartbeat
+// @Description Keep the lease on a job alive and report progress. The response tells the worker to stop if the job was killed.
+// @Tags worker
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param request body worker.HeartbeatRequest false "Progress"
+// @Success 200 {object} worker.HeartbeatResponse
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/worker/jobs/{id}/heartbeat [post]
+func (h *Handler) WorkerHeartbeat(c *gin.Context) {
+	jobID := c.Param("id")
+	workerID := c.GetString("worker_id")
+
+	var req worker.HeartbeatRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	cancel, err := h.taskQueue.RenewLease(jobID, workerID)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	h.touchWorker(c, workerID, jobID)
+
+	if req.Message != "" && !cancel && h.broadcaster != nil {
+		h.broadcaster.Broadcast(jobID, "job_progress", map[string]interface{}{
+			"job_id":    jobID,
+			"worker_id": workerID,
+			"message":   req.Message,
+		})
+	}
+
+	c.JSON(http.StatusOK, worker.HeartbeatResponse{Cancel: cancel})
+}
+
+// @Summary Complete a leased job
+// @Description Upload the outcome of a leased job: transcript, execution data and logs. The job stays leased until the result is stored.
+// @Tags worker
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param request body worker.CompleteRequest true "Job outcome"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/worker/jobs/{id}/complete [post]
+func (h *Handler) CompleteWorkerJob(c *gin.Context) {
+	jobID := c.Param("id")
+	workerID := c.GetString("worker_id")
+
+	var req worker.CompleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Status != models.StatusCompleted && req.Status != models.StatusFailed {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be completed or failed"})
+		return
+	}
+
+	// The lease is only released once the result is stored. Until then a
+	// failed write leaves the job leased, and the reaper requeues it once the
+	// worker stops sending heartbeats.
+	killed, err := h.taskQueue.CheckLease(jobID, workerID)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	h.touchWorker(c, workerID, "")
+
+	if req.Logs != "" {
+		h.saveWorkerLogs(jobID, req.Logs)
+	}
+	if killed {
+		if _, err := h.taskQueue.CompleteLease(jobID, workerID); err != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Job was killed, result discarded"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if req.Transcript != nil {
+		if err := h.jobRepo.UpdateTranscript(ctx, jobID, *req.Transcript); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save transcript"})
+			return
+		}
+		// Workers do not match speakers against the speaker library, which
+		// lives on the server, so matches of an earlier transcript are stale
+		if err := h.unifiedProcessor.ForgetVoiceMatches(ctx, jobID); err != nil {
+			logger.Warn("Failed to reset speaker library matches", "job_id", jobID, "error", err)
+		}
+	}
+	if req.OriginalTranscript != nil {
+		if err := h.jobRepo.UpdateOriginalTranscript(ctx, jobID, *req.OriginalTranscript); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save original transcript"})
+			return
+		}
+	}
+	var execution *models.TranscriptionJobExecution
+	if req.Execution != nil {
+		execution = req.Execution
+		execution.ID = ""
+		execution.TranscriptionJobID = jobID
+		execution.TranscriptionJob = models.TranscriptionJob{}
+		if err := h.jobRepo.CreateExecution(ctx, execution); err != nil {
+			logger.Error("Failed to save remote execution", "job_id", jobID, "error", err)
+		}
+	}
+
+	if err := h.jobRepo.UpdateStatus(ctx, jobID, req.Status); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update job status"})
+		return
+	}
+	if req.Status == models.StatusFailed {
+		if err := h.jobRepo.UpdateError(ctx, jobID, req.ErrorMessage); err != nil {
+			logger.Error("Failed to save remote job error", "job_id", jobID, "error", err)
+		}
+	}
+
+	killed, err = h.taskQueue.CompleteLease(jobID, workerID)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if killed {
+		// The job was killed while the result was being stored
+		_ = h.jobRepo.UpdateStatus(ctx, jobID, models.StatusFailed)
+		_ = h.jobRepo.UpdateError(ctx, jobID, "Job was forcefully terminated by user")
+		c.JSON(http.StatusOK, gin.H{"message": "Job was killed, result discarded"})
+		return
+	}
+
+	if h.broadcaster != nil {
+		h.broadcaster.Broadcast(jobID, "job_update", map[string]interface{}{
+			"job_id": jobID,
+			"status": req.Status,
+			"error":  req.ErrorMessage,
+		})
+	}
+	// Workers do not send webhooks, so the server reports the stored result
+	if job, err := h.jobRepo.FindByID(ctx, jobID); err != nil {
+		logger.Warn("Failed to load job for its webhook", "job_id", jobID, "error", err)
+	} else {
+		h.unifiedProcessor.SendWebhook(job, execution, req.Status)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job result saved"})
+}
+
+// @Summary Release a leased job
+// @Description Give a leased job back to the queue without finishing it, e.g. when the worker shuts down
+// @Tags worker
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/worker/jobs/{id}/release [post]
+func (h *Handler) ReleaseWorkerJob(c *gin.Context) {
+	jobID := c.Param("id")
+	workerID := c.GetString("worker_id")
+
+	if err := h.taskQueue.ReleaseLease(jobID, workerID); err != nil {
+		if errors.Is(err, queue.ErrLeaseNotFound) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.touchWorker(c, workerID, "")
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job returned to the queue"})
+}
+
+// touchWorker records a worker's last contact and the job it is working on
+func (h *Handler) touchWorker(c *gin.Context, workerID, jobID string) {
+	var currentJob *string
+	if jobID != "" {
+		currentJob = &jobID
+	}
+	if err := h.workerRepo.UpdateLastSeen(c.Request.Context(), workerID, currentJob); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Warn("Failed to update worker last seen", "worker_id", workerID, "error", err)
+	}
+}
+
+// saveWorkerLogs stores a remote job's log where GetJobLogs expects it
+func (h *Handler) saveWorkerLogs(jobID, logs string) {
+	logDir := filepath.Join(h.config.TranscriptsDir, jobID)
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		logger.Error("Failed to create log directory", "job_id", jobID, "error", err)
+		return
+	}
+	if err := os.WriteFile(filepath.Join(logDir, "transcription.log"), []byte(logs), 0644); err != nil {
+		logger.Error("Failed to save remote job logs", "job_id", jobID, "error", err)
+	}
+}
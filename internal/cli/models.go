@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"scriberr/pkg/downloader"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	modelsCmd = &cobra.Command{
+		Use:   "models",
+		Short: "Inspect, install and remove the server's model environments",
+	}
+
+	modelsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List model environments with their state and disk usage",
+		Args:  cobra.NoArgs,
+		Run:   runModelsList,
+	}
+
+	modelsInstallCmd = &cobra.Command{
+		Use:   "install model-id",
+		Short: "Install or update a model environment, or download a model variant",
+		Args:  cobra.ExactArgs(1),
+		Run:   runModelsInstall,
+	}
+
+	modelsRemoveCmd = &cobra.Command{
+		Use:   "remove model-id",
+		Short: "Delete a model environment or a downloaded model variant",
+		Args:  cobra.ExactArgs(1),
+		Run:   runModelsRemove,
+	}
+)
+
+var (
+	modelVariant     string
+	modelReinstall   bool
+	modelWait        bool
+	modelForceRemove bool
+)
+
+func init() {
+	rootCmd.AddCommand(modelsCmd)
+	modelsCmd.AddCommand(modelsListCmd)
+	modelsCmd.AddCommand(modelsInstallCmd)
+	modelsCmd.AddCommand(modelsRemoveCmd)
+
+	modelsInstallCmd.Flags().StringVar(&modelVariant, "model", "", "Model variant to download, e.g. large-v3")
+	modelsInstallCmd.Flags().BoolVar(&modelReinstall, "reinstall", false, "Delete and rebuild the environment to update it")
+	modelsInstallCmd.Flags().BoolVar(&modelWait, "wait", false, "Wait for the install to finish")
+	modelsRemoveCmd.Flags().StringVar(&modelVariant, "model", "", "Remove only this model variant")
+	modelsRemoveCmd.Flags().BoolVar(&modelForceRemove, "force", false, "Remove an environment shared with other models")
+}
+
+// modelTask mirrors a model environment install task
+type modelTask struct {
+	ModelID     string `json:"model_id"`
+	Model       string `json:"model"`
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+	BytesOnDisk int64  `json:"bytes_on_disk"`
+}
+
+// modelEnvironment mirrors the model environment endpoints' response
+type modelEnvironment struct {
+	ModelID         string   `json:"model_id"`
+	Type            string   `json:"type"`
+	Path            string   `json:"path"`
+	SharedWith      []string `json:"shared_with"`
+	Exists          bool     `json:"exists"`
+	Ready           bool     `json:"ready"`
+	DiskUsageBytes  int64    `json:"disk_usage_bytes"`
+	InstalledModels []struct {
+		Name           string `json:"name"`
+		DiskUsageBytes int64  `json:"disk_usage_bytes"`
+	} `json:"installed_models"`
+	Task *modelTask `json:"task"`
+}
+
+func printModelEnvironment(env modelEnvironment) {
+	state := "not ready"
+	if env.Ready {
+		state = "ready"
+	}
+	if env.Task != nil && env.Task.Status == "running" {
+		state = "installing"
+	}
+
+	usage := "-"
+	if env.Exists {
+		usage = downloader.FormatBytes(env.DiskUsageBytes)
+	}
+	fmt.Printf("%-24s %-14s %-10s %10s  %s\n", env.ModelID, env.Type, state, usage, env.Path)
+
+	if len(env.SharedWith) > 0 {
+		fmt.Printf("  shared with: %s\n", strings.Join(env.SharedWith, ", "))
+	}
+	for _, model := range env.InstalledModels {
+		fmt.Printf("  model %-18s %10s\n", model.Name, downloader.FormatBytes(model.DiskUsageBytes))
+	}
+	if env.Task != nil && env.Task.Status == "failed" {
+		fmt.Printf("  last install failed: %s\n", env.Task.Error)
+	}
+}
+
+func runModelsList(cmd *cobra.Command, args []string) {
+	var envs []modelEnvironment
+	if err := doJSONRequest("GET", "/api/v1/admin/models/", nil, &envs); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(envs) == 0 {
+		fmt.Println("No models registered")
+		return
+	}
+	fmt.Printf("%-24s %-14s %-10s %10s  %s\n", "MODEL", "TYPE", "STATE", "DISK", "PATH")
+	for _, env := range envs {
+		printModelEnvironment(env)
+	}
+}
+
+func runModelsInstall(cmd *cobra.Command, args []string) {
+	modelID := args[0]
+	body := map[string]interface{}{"model": modelVariant, "reinstall": modelReinstall}
+
+	var task modelTask
+	if err := doJSONRequest("POST", "/api/v1/admin/models/"+url.PathEscape(modelID)+"/install", body, &task); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Installing %s in the background\n", describeModel(modelID, modelVariant))
+	if !modelWait {
+		fmt.Printf("Run 'scriberr models list' to follow its progress\n")
+		return
+	}
+
+	for {
+		time.Sleep(2 * time.Second)
+		var env modelEnvironment
+		if err := doJSONRequest("GET", "/api/v1/admin/models/"+url.PathEscape(modelID), nil, &env); err != nil {
+			fmt.Printf("\nError: %v\n", err)
+			os.Exit(1)
+		}
+		if env.Task == nil {
+			continue
+		}
+		switch env.Task.Status {
+		case "completed":
+			fmt.Printf("\r\033[KInstalled %s\n", describeModel(modelID, modelVariant))
+			return
+		case "failed":
+			fmt.Printf("\r\033[KError: install failed: %s\n", env.Task.Error)
+			os.Exit(1)
+		default:
+			fmt.Printf("\r\033[KInstalling %s: %s on disk", describeModel(modelID, modelVariant), downloader.FormatBytes(env.Task.BytesOnDisk))
+		}
+	}
+}
+
+func runModelsRemove(cmd *cobra.Command, args []string) {
+	modelID := args[0]
+	query := url.Values{}
+	if modelVariant != "" {
+		query.Set("model", modelVariant)
+	}
+	if modelForceRemove {
+		query.Set("force", "true")
+	}
+
+	path := "/api/v1/admin/models/" + url.PathEscape(modelID)
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	var env modelEnvironment
+	if err := doJSONRequest("DELETE", path, nil, &env); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Removed %s\n", describeModel(modelID, modelVariant))
+	printModelEnvironment(env)
+}
+
+// describeModel names a model environment or one of its variants
+func describeModel(modelID, variant string) string {
+	if variant != "" {
+		return fmt.Sprintf("%s model %s", modelID, variant)
+	}
+	return modelID + " environment"
+}
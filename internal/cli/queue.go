@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	queueCmd = &cobra.Command{
+		Use:   "queue",
+		Short: "Inspect and control the server's transcription queue",
+	}
+
+	queueStatsCmd = &cobra.Command{
+		Use:   "stats",
+		Short: "Show queue statistics",
+		Args:  cobra.NoArgs,
+		Run:   runQueueStats,
+	}
+
+	queueListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List pending jobs in dispatch order",
+		Args:  cobra.NoArgs,
+		Run:   runQueueAction("GET", "/pending"),
+	}
+
+	queuePauseCmd = &cobra.Command{
+		Use:   "pause",
+		Short: "Stop starting new jobs (running jobs continue)",
+		Args:  cobra.NoArgs,
+		Run:   runQueueAction("POST", "/pause"),
+	}
+
+	queueResumeCmd = &cobra.Command{
+		Use:   "resume",
+		Short: "Resume starting pending jobs",
+		Args:  cobra.NoArgs,
+		Run:   runQueueAction("POST", "/resume"),
+	}
+
+	queueDrainCmd = &cobra.Command{
+		Use:   "drain",
+		Short: "Let running jobs finish and start nothing new",
+		Args:  cobra.NoArgs,
+		Run:   runQueueAction("POST", "/drain"),
+	}
+
+	queueCancelCmd = &cobra.Command{
+		Use:   "cancel [job-id...]",
+		Short: "Cancel pending jobs that have not started",
+		Run:   runQueueCancel,
+	}
+
+	queueReorderCmd = &cobra.Command{
+		Use:   "reorder job-id [job-id...]",
+		Short: "Move pending jobs to the front of the queue in the given order",
+		Args:  cobra.MinimumNArgs(1),
+		Run:   runQueueReorder,
+	}
+)
+
+var cancelAllPending bool
+
+func init() {
+	rootCmd.AddCommand(queueCmd)
+	queueCmd.AddCommand(queueStatsCmd)
+	queueCmd.AddCommand(queueListCmd)
+	queueCmd.AddCommand(queuePauseCmd)
+	queueCmd.AddCommand(queueResumeCmd)
+	queueCmd.AddCommand(queueDrainCmd)
+	queueCmd.AddCommand(queueCancelCmd)
+	queueCmd.AddCommand(queueReorderCmd)
+
+	queueCancelCmd.Flags().BoolVar(&cancelAllPending, "all", false, "Cancel every pending job")
+}
+
+// queueState mirrors the response of the queue control endpoints
+type queueState struct {
+	State     string   `json:"state"`
+	Pending   []string `json:"pending"`
+	Cancelled []string `json:"cancelled,omitempty"`
+}
+
+func printQueueState(state queueState) {
+	fmt.Printf("Queue state: %s\n", state.State)
+	if len(state.Pending) == 0 {
+		fmt.Println("No pending jobs")
+		return
+	}
+	fmt.Printf("Pending jobs (%d):\n", len(state.Pending))
+	for i, jobID := range state.Pending {
+		fmt.Printf("  %3d. %s\n", i+1, jobID)
+	}
+}
+
+func runQueueStats(cmd *cobra.Command, args []string) {
+	var stats map[string]interface{}
+	if err := doJSONRequest("GET", "/api/v1/admin/queue/stats", nil, &stats); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	keys := make([]string, 0, len(stats))
+	for key := range stats {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("%-16s %v\n", key+":", stats[key])
+	}
+}
+
+// runQueueAction returns a command handler that calls a queue endpoint and prints the resulting state
+func runQueueAction(method, path string) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		var state queueState
+		if err := doJSONRequest(method, "/api/v1/admin/queue"+path, nil, &state); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		printQueueState(state)
+	}
+}
+
+func runQueueCancel(cmd *cobra.Command, args []string) {
+	if len(args) == 0 && !cancelAllPending {
+		fmt.Println("Error: specify job IDs to cancel or pass --all")
+		os.Exit(1)
+	}
+	if len(args) > 0 && cancelAllPending {
+		fmt.Println("Error: --all cannot be combined with job IDs")
+		os.Exit(1)
+	}
+
+	var state queueState
+	body := map[string][]string{"job_ids": args}
+	if err := doJSONRequest("POST", "/api/v1/admin/queue/cancel", body, &state); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(state.Cancelled) == 0 {
+		fmt.Println("No pending jobs were cancelled")
+	} else {
+		fmt.Printf("Cancelled %d job(s): %s\n", len(state.Cancelled), strings.Join(state.Cancelled, ", "))
+	}
+	printQueueState(state)
+}
+
+func runQueueReorder(cmd *cobra.Command, args []string) {
+	var state queueState
+	body := map[string][]string{"job_ids": args}
+	if err := doJSONRequest("POST", "/api/v1/admin/queue/reorder", body, &state); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	printQueueState(state)
+}
@@ -47,6 +47,33 @@ type Config struct {
 	EnableAIPostProcessing   bool
 	PostProcessingModel      string
 	PostProcessingBatchSize  int
+
+	// Remote worker configuration
+	DisableLocalWorkers bool   // Leave all transcription to remote workers
+	WorkerServerURL     string // Server a worker process leases jobs from
+	WorkerToken         string // Token a worker process authenticates with
+	WorkerDataDir       string // Local database and scratch files of a worker process
+
+	// Multi-track configuration
+	TrackConcurrency int // Tracks of a multi-track job transcribed at once
+	TrackLimit       int // Tracks of all multi-track jobs transcribed at once, 0 for the queue's maximum worker count
+
+	// whisper.cpp configuration
+	WhisperCppBinary    string // whisper-cli program, looked up in PATH when empty
+	WhisperCppModelsDir string // GGML model files
+
+	// Directory of declarative adapter plugins, one subdirectory per plugin
+	PluginsDir string
+
+	// OpenAI-compatible servers, as inline JSON or the path of a JSON file
+	OpenAICompatibleServers string
+
+	// Model server configuration
+	ModelServers           bool // Keep local models loaded in long-lived processes
+	ModelServerIdleMinutes int  // Unload a model after this many idle minutes, 0 keeps it loaded
+
+	// Cosine similarity a diarized speaker needs to be named after a voice of the speaker library
+	VoiceMatchThreshold float64
 }
 
 // Load loads configuration from environment variables and .env file
@@ -79,6 +106,19 @@ func Load() *Config {
 		EnableAIPostProcessing:   getEnv("ENABLE_AI_POST_PROCESSING", "false") == "true",
 		PostProcessingModel:      getEnv("POST_PROCESSING_MODEL", "gpt-4o"),
 		PostProcessingBatchSize:  getEnvInt("POST_PROCESSING_BATCH_SIZE", 50),
+		DisableLocalWorkers:      getEnv("DISABLE_LOCAL_WORKERS", "false") == "true",
+		WorkerServerURL:          getEnv("SCRIBERR_SERVER_URL", "http://localhost:8080"),
+		WorkerToken:              getEnv("WORKER_TOKEN", ""),
+		WorkerDataDir:            getEnv("WORKER_DATA_DIR", "data/worker"),
+		TrackConcurrency:         getEnvInt("MULTITRACK_CONCURRENCY", 2),
+		TrackLimit:               getEnvInt("MULTITRACK_TRACK_LIMIT", 0),
+		WhisperCppBinary:         getEnv("WHISPER_CPP_BINARY", ""),
+		WhisperCppModelsDir:      getEnv("WHISPER_CPP_MODELS_DIR", "data/whisper-cpp-models"),
+		PluginsDir:               getEnv("PLUGINS_DIR", "data/plugins"),
+		OpenAICompatibleServers:  getEnv("OPENAI_COMPATIBLE_SERVERS", ""),
+		ModelServers:             getEnv("MODEL_SERVERS", "false") == "true",
+		ModelServerIdleMinutes:   getEnvInt("MODEL_SERVER_IDLE_MINUTES", 10),
+		VoiceMatchThreshold:      getEnvFloat("VOICE_MATCH_THRESHOLD", 0.7),
 	}
 }
 
@@ -105,6 +145,16 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvFloat gets a float environment variable with a default value
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultValue
+}
+
 // getJWTSecret gets JWT secret from env or generates a secure random one
 func getJWTSecret() string {
 	if secret := os.Getenv("JWT_SECRET"); secret != "" {
@@ -35,7 +35,8 @@ func Initialize(dbPath string) error {
 		"_pragma=cache_size(-64000)&"+ // 64MB cache size
 		"_pragma=temp_store(MEMORY)&"+ // Store temp tables in memory
 		"_pragma=mmap_size(268435456)&"+ // 256MB mmap size
-		"_timeout=30000", // 30 second timeout
+		"_pragma=busy_timeout(30000)&"+ // Wait up to 30 seconds for other writers
+		"_txlock=immediate", // Take the write lock when a transaction begins, so it waits instead of failing
 		dbPath)
 
 	// Open database connection with optimized config
@@ -76,6 +77,11 @@ func Initialize(dbPath string) error {
 		&models.Summary{},
 		&models.Note{},
 		&models.RefreshToken{},
+		&models.Worker{},
+		&models.CustomModel{},
+		&models.Voice{},
+		&models.VoiceEmbedding{},
+		&models.TranscriptRevision{},
 	); err != nil {
 		return fmt.Errorf("failed to auto migrate: %v", err)
 	}
@@ -15,4 +15,20 @@ type TaskQueueInterface interface {
 
 	// GetQueueStats returns queue statistics
 	GetQueueStats() map[string]interface{}
+
+	// PendingJobs returns the IDs of jobs waiting for a worker, in dispatch order
+	PendingJobs() []string
+
+	// Pause stops starting pending jobs; Resume starts them again
+	Pause()
+	Resume()
+
+	// Drain stops starting pending jobs and waits for running jobs to finish
+	Drain()
+
+	// CancelPending removes jobs that have not started yet from the queue
+	CancelPending(jobIDs []string) ([]string, error)
+
+	// Reorder moves the given pending jobs to the front of the queue
+	Reorder(jobIDs []string) error
 }
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CustomModel is a fine-tuned checkpoint registered as a model variant of a
+// local adapter. Profiles select it by name like a built-in model.
+type CustomModel struct {
+	ID          string    `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	Name        string    `json:"name" gorm:"not null;uniqueIndex;type:varchar(50)"`
+	DisplayName string    `json:"display_name" gorm:"type:varchar(255)"`
+	BaseAdapter string    `json:"base_adapter" gorm:"not null;type:varchar(50)"` // whisperx, whisper_cpp, parakeet or canary
+	Source      string    `json:"source" gorm:"not null;type:text"`              // Local path or Hugging Face repo ID
+	Languages   *string   `json:"languages,omitempty" gorm:"type:text"`          // Comma-separated language codes
+	Description *string   `json:"description,omitempty" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate sets the ID if not already set
+func (m *CustomModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// LanguageList returns the model's language codes
+func (m *CustomModel) LanguageList() []string {
+	if m.Languages == nil {
+		return nil
+	}
+	var languages []string
+	for _, language := range strings.Split(*m.Languages, ",") {
+		if language = strings.TrimSpace(language); language != "" {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
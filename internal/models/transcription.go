@@ -49,8 +49,9 @@ const (
 
 // WhisperXParams contains parameters for WhisperX transcription
 type WhisperXParams struct {
-	// Model family (whisper or nvidia)
+	// Model family (whisper or nvidia), or 'auto' to route by the detected language
 	ModelFamily string `json:"model_family" gorm:"type:varchar(20);default:'whisper'"`
+	AutoQuality string `json:"auto_quality" gorm:"type:varchar(10);default:'good'"` // Quality tier for 'auto': 'fast', 'good', 'best'
 
 	// Model parameters
 	Model          string  `json:"model" gorm:"type:varchar(50);default:'small'"`
@@ -90,6 +91,11 @@ type WhisperXParams struct {
 	MaxSpeakers       *int   `json:"max_speakers,omitempty" gorm:"type:int"`
 	DiarizeModel      string `json:"diarize_model" gorm:"type:varchar(50);default:'pyannote'"` // Options: 'pyannote', 'nvidia_sortformer'
 	SpeakerEmbeddings bool   `json:"speaker_embeddings" gorm:"type:boolean;default:false"`
+	SpeakerAssignment string `json:"speaker_assignment" gorm:"type:varchar(20);default:'word'"` // Merging a separate diarization: 'word' splits segments at speaker changes, 'segment' labels whole segments
+
+	// Models tried in order when the selected one is not ready or fails
+	FallbackModels        *string `json:"fallback_models,omitempty" gorm:"type:text"`         // Comma-separated model families, each optionally family:model, e.g. 'openai:whisper-1,whisper_cpp:small'
+	FallbackDiarizeModels *string `json:"fallback_diarize_models,omitempty" gorm:"type:text"` // Comma-separated diarization models, e.g. 'nvidia_sortformer'
 
 	// Transcription quality settings
 	Temperature                    float64 `json:"temperature" gorm:"type:real;default:0"`
@@ -121,6 +127,21 @@ type WhisperXParams struct {
 	AttentionContextLeft  int `json:"attention_context_left" gorm:"type:int;default:256"`
 	AttentionContextRight int `json:"attention_context_right" gorm:"type:int;default:256"`
 
+	// Long audio splitting
+	SplitStrategy      string  `json:"split_strategy" gorm:"type:varchar(20);default:'fixed'"` // Options: 'fixed', 'silence'
+	SplitSilenceWindow float64 `json:"split_silence_window" gorm:"type:real;default:30"`       // Seconds around each cut searched for a pause
+	SplitOverlap       float64 `json:"split_overlap" gorm:"type:real;default:0"`               // Seconds each chunk shares with the previous one
+	SplitMaxFileSizeMB float64 `json:"split_max_file_size_mb" gorm:"type:real;default:0"`      // Split larger files, 0 uses the model's limit
+	SplitMaxDuration   float64 `json:"split_max_duration" gorm:"type:real;default:0"`          // Split longer audio (seconds), 0 uses the model's limit
+	SplitChunkDuration float64 `json:"split_chunk_duration" gorm:"type:real;default:0"`        // Chunk length in seconds, 0 uses the model's preference
+
+	// Audio enhancement applied before transcription
+	Preprocessors     *string `json:"preprocessors,omitempty" gorm:"type:text"`                // Comma-separated chain of 'loudnorm', 'denoise', 'highpass', 'trim_silence', each optionally :required, :optional or :fallback
+	LoudnessTarget    float64 `json:"loudness_target" gorm:"type:real;default:-16"`            // Integrated loudness in LUFS for 'loudnorm'
+	DenoiseMethod     string  `json:"denoise_method" gorm:"type:varchar(20);default:'afftdn'"` // Options: 'afftdn', 'arnndn'
+	DenoiseModel      *string `json:"denoise_model,omitempty" gorm:"type:text"`                // RNNoise model file, required by 'arnndn'
+	HighpassFrequency int     `json:"highpass_frequency" gorm:"type:int;default:80"`           // Cutoff in Hz for 'highpass'
+
 	// Multi-track transcription settings
 	IsMultiTrackEnabled bool `json:"is_multi_track_enabled" gorm:"type:boolean;default:false"`
 
@@ -309,7 +330,16 @@ type TranscriptionJobExecution struct {
 	MergeEndTime      *time.Time `json:"merge_end_time,omitempty" gorm:"type:datetime"`
 	MergeDuration     *int64     `json:"merge_duration,omitempty"` // Merge phase duration in milliseconds
 
-	// Parameters used for this execution (may differ from job parameters due to profiles)
+	// Preprocessors that ran on the audio
+	Preprocessing *string `json:"preprocessing,omitempty" gorm:"type:text"` // JSON-serialized []pipeline.AppliedStep
+
+	// Models that produced the result, and every model tried along the fallback chains
+	TranscriptionModel *string `json:"transcription_model,omitempty" gorm:"type:varchar(100)"`
+	DiarizationModel   *string `json:"diarization_model,omitempty" gorm:"type:varchar(100)"`
+	ModelAttempts      *string `json:"model_attempts,omitempty" gorm:"type:text"` // JSON-serialized []transcription.ModelAttempt
+	ModelRouting       *string `json:"model_routing,omitempty" gorm:"type:text"`  // JSON-serialized transcription.RoutingDecision for 'auto'
+
+	// Parameters used for this execution (may differ from job parameters due to profiles and model fallbacks)
 	ActualParameters WhisperXParams `json:"actual_parameters" gorm:"embedded;embeddedPrefix:actual_"`
 
 	// Execution results
@@ -347,6 +377,9 @@ type SpeakerMapping struct {
 	TranscriptionJobID string    `json:"transcription_job_id" gorm:"type:varchar(36);not null;index"`
 	OriginalSpeaker    string    `json:"original_speaker" gorm:"type:varchar(50);not null"` // e.g., "speaker_00"
 	CustomName         string    `json:"custom_name" gorm:"type:varchar(100);not null"`     // e.g., "John Doe"
+	VoiceID            *string   `json:"voice_id,omitempty" gorm:"type:varchar(36);index"`  // Voice of the speaker library the speaker was matched to
+	MatchScore         *float64  `json:"match_score,omitempty"`                             // Cosine similarity of an automatic match
+	Confirmed          bool      `json:"confirmed" gorm:"default:false"`                    // The user confirmed or chose the voice
 	CreatedAt          time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt          time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 
@@ -359,6 +392,31 @@ func (SpeakerMapping) TableName() string {
 	return "speaker_mappings"
 }
 
+// Transcript revision sources
+const (
+	RevisionSourceTranscription = "transcription" // the transcript as first produced
+	RevisionSourceDiarization   = "diarization"   // speakers from diarizing the finished transcript
+	RevisionSourceSpeakerEdit   = "speaker_edit"  // speakers merged, reassigned or split by the user
+	RevisionSourceAlignment     = "alignment"     // word timings from forced alignment
+	RevisionSourceRange         = "range"         // part of the audio transcribed again
+)
+
+// TranscriptRevision is a saved version of a job's transcript. Revisions are
+// stored whenever a finished transcript is changed, so earlier versions can be
+// compared or restored.
+type TranscriptRevision struct {
+	ID                 uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	TranscriptionJobID string    `json:"transcription_job_id" gorm:"type:varchar(36);not null;index"`
+	Revision           int       `json:"revision" gorm:"type:int;not null"`       // 1 for the first version, increasing per job
+	Source             string    `json:"source" gorm:"type:varchar(50);not null"` // What produced the revision, e.g. 'diarization'
+	Description        *string   `json:"description,omitempty" gorm:"type:text"`  // e.g. the model used
+	Transcript         string    `json:"transcript" gorm:"type:text;not null"`    // Transcript JSON
+	CreatedAt          time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	// Relationships
+	TranscriptionJob TranscriptionJob `json:"-" gorm:"foreignKey:TranscriptionJobID;constraint:OnDelete:CASCADE"`
+}
+
 // MultiTrackFile represents an individual audio track in a multi-track recording
 type MultiTrackFile struct {
 	ID                 uint      `json:"id" gorm:"primaryKey;autoIncrement"`
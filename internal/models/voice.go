@@ -0,0 +1,58 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Voice is a named person in the speaker library. Diarized speakers of new
+// jobs are matched against the embeddings stored for each voice.
+type Voice struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex;type:varchar(100)"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate sets the ID if not already set
+func (v *Voice) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == "" {
+		v.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// VoiceEmbedding is the voice embedding of one speaker, either enrolled from
+// a recording of the person or computed for a diarized speaker of a job. Job
+// embeddings join a voice's library once the match is confirmed.
+type VoiceEmbedding struct {
+	ID                 uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	VoiceID            *string   `json:"voice_id,omitempty" gorm:"type:varchar(36);index"`
+	TranscriptionJobID *string   `json:"transcription_job_id,omitempty" gorm:"type:varchar(36);index"`
+	Speaker            *string   `json:"speaker,omitempty" gorm:"type:varchar(50)"` // Diarized speaker label within the job
+	Model              string    `json:"model" gorm:"not null;type:varchar(255)"`   // Only embeddings of the same model are compared
+	Vector             string    `json:"-" gorm:"not null;type:text"`               // JSON array of floats
+	CreatedAt          time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// Values decodes the embedding vector
+func (e *VoiceEmbedding) Values() ([]float64, error) {
+	var values []float64
+	if err := json.Unmarshal([]byte(e.Vector), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// SetValues encodes the embedding vector
+func (e *VoiceEmbedding) SetValues(values []float64) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	e.Vector = string(data)
+	return nil
+}
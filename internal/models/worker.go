@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Worker represents a remote transcription worker allowed to lease jobs
+type Worker struct {
+	ID         string     `json:"id" gorm:"primaryKey;type:varchar(36)"`
+	Name       string     `json:"name" gorm:"not null;type:varchar(100)"`
+	TokenHash  string     `json:"-" gorm:"not null;uniqueIndex;type:varchar(128)"`
+	IsActive   bool       `json:"is_active" gorm:"type:boolean;not null"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+	CurrentJob *string    `json:"current_job,omitempty" gorm:"type:varchar(36)"`
+	Hostname   *string    `json:"hostname,omitempty" gorm:"type:varchar(255)"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate sets the ID if not already set
+func (w *Worker) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == "" {
+		w.ID = uuid.New().String()
+	}
+	return nil
+}
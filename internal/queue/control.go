@@ -0,0 +1,226 @@
+package queue
+
+import (
+	"fmt"
+	"sync"
+
+	"scriberr/internal/models"
+	"scriberr/pkg/logger"
+)
+
+// State returns the current dispatch state of the queue
+func (tq *TaskQueue) State() State {
+	tq.pendingMutex.Lock()
+	defer tq.pendingMutex.Unlock()
+	return tq.state
+}
+
+// dispatchingLocked reports whether pending jobs may be started. Callers must
+// hold pendingMutex.
+func (tq *TaskQueue) dispatchingLocked() bool {
+	return tq.state == StateRunning && tq.holds == 0
+}
+
+// HoldDispatch stops pending jobs from being started, locally or by remote
+// workers, until the returned function is called. Unlike Pause it leaves the
+// queue state alone, so it can be used around short operations such as
+// removing a model environment without undoing an admin's pause.
+func (tq *TaskQueue) HoldDispatch() func() {
+	tq.pendingMutex.Lock()
+	tq.holds++
+	tq.pendingMutex.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			tq.pendingMutex.Lock()
+			tq.holds--
+			tq.notifyLocked()
+			tq.pendingMutex.Unlock()
+		})
+	}
+}
+
+// PendingJobs returns the IDs of jobs waiting for a worker, in dispatch order
+func (tq *TaskQueue) PendingJobs() []string {
+	tq.pendingMutex.Lock()
+	defer tq.pendingMutex.Unlock()
+
+	jobs := make([]string, len(tq.pending))
+	copy(jobs, tq.pending)
+	return jobs
+}
+
+// pendingCount returns the number of jobs waiting for a worker
+func (tq *TaskQueue) pendingCount() int {
+	tq.pendingMutex.Lock()
+	defer tq.pendingMutex.Unlock()
+	return len(tq.pending)
+}
+
+// Pause stops dispatching pending jobs. Running jobs are not affected.
+func (tq *TaskQueue) Pause() {
+	tq.pendingMutex.Lock()
+	tq.state = StatePaused
+	tq.pendingMutex.Unlock()
+
+	logger.Info("Task queue paused")
+	tq.broadcast("queue_paused", nil)
+}
+
+// Resume restarts dispatching pending jobs after a pause or drain
+func (tq *TaskQueue) Resume() {
+	tq.pendingMutex.Lock()
+	tq.state = StateRunning
+	tq.notifyLocked()
+	tq.pendingMutex.Unlock()
+
+	logger.Info("Task queue resumed")
+	tq.broadcast("queue_resumed", nil)
+}
+
+// Drain stops dispatching pending jobs and waits for running jobs to finish.
+// A "queue_drained" event is broadcast once no job is running, after which the
+// queue stays paused until Resume is called.
+func (tq *TaskQueue) Drain() {
+	tq.pendingMutex.Lock()
+	tq.state = StateDraining
+	tq.pendingMutex.Unlock()
+
+	logger.Info("Task queue draining")
+	tq.broadcast("queue_draining", nil)
+
+	tq.checkDrained()
+}
+
+// finishJob marks a dispatched job as done and completes a pending drain
+func (tq *TaskQueue) finishJob() {
+	tq.pendingMutex.Lock()
+	tq.dispatched--
+	tq.pendingMutex.Unlock()
+
+	tq.checkDrained()
+}
+
+// checkDrained switches a draining queue to paused once no job is running
+func (tq *TaskQueue) checkDrained() {
+	tq.pendingMutex.Lock()
+	if tq.state != StateDraining || tq.dispatched > 0 {
+		tq.pendingMutex.Unlock()
+		return
+	}
+	tq.state = StatePaused
+	tq.pendingMutex.Unlock()
+
+	logger.Info("Task queue drained")
+	tq.broadcast("queue_drained", nil)
+}
+
+// CancelPending removes jobs that have not started yet from the queue and marks
+// them as failed. An empty jobIDs cancels every pending job. No job is cancelled
+// if any of the given IDs is not pending.
+func (tq *TaskQueue) CancelPending(jobIDs []string) ([]string, error) {
+	tq.pendingMutex.Lock()
+	if len(jobIDs) == 0 {
+		jobIDs = make([]string, len(tq.pending))
+		copy(jobIDs, tq.pending)
+	}
+
+	cancel := make(map[string]bool, len(jobIDs))
+	for _, jobID := range jobIDs {
+		cancel[jobID] = true
+	}
+	remaining := make([]string, 0, len(tq.pending))
+	for _, jobID := range tq.pending {
+		if cancel[jobID] {
+			delete(cancel, jobID)
+			continue
+		}
+		remaining = append(remaining, jobID)
+	}
+	for jobID := range cancel {
+		tq.pendingMutex.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrJobNotPending, jobID)
+	}
+	tq.pending = remaining
+	tq.pendingMutex.Unlock()
+
+	for _, jobID := range jobIDs {
+		if err := tq.updateJobStatus(jobID, models.StatusFailed); err != nil {
+			logger.Error("Failed to update cancelled job status", "job_id", jobID, "error", err)
+		}
+		if err := tq.updateJobError(jobID, "Job was cancelled before it started"); err != nil {
+			logger.Error("Failed to update cancelled job error", "job_id", jobID, "error", err)
+		}
+		if tq.broadcaster != nil {
+			tq.broadcaster.Broadcast(jobID, "job_update", map[string]interface{}{
+				"job_id": jobID,
+				"status": models.StatusFailed,
+				"error":  "Job was cancelled before it started",
+			})
+		}
+	}
+
+	logger.Info("Cancelled pending jobs", "count", len(jobIDs))
+	tq.broadcast("queue_jobs_cancelled", map[string]interface{}{
+		"job_ids": jobIDs,
+	})
+	return jobIDs, nil
+}
+
+// Reorder moves the given pending jobs to the front of the queue in the given
+// order. Jobs that are not listed keep their relative order behind them.
+func (tq *TaskQueue) Reorder(jobIDs []string) error {
+	tq.pendingMutex.Lock()
+
+	moved := make(map[string]bool, len(jobIDs))
+	for _, jobID := range jobIDs {
+		if moved[jobID] {
+			tq.pendingMutex.Unlock()
+			return fmt.Errorf("job %s is listed more than once", jobID)
+		}
+		moved[jobID] = true
+	}
+
+	pending := make(map[string]bool, len(tq.pending))
+	rest := make([]string, 0, len(tq.pending))
+	for _, jobID := range tq.pending {
+		pending[jobID] = true
+		if !moved[jobID] {
+			rest = append(rest, jobID)
+		}
+	}
+	for _, jobID := range jobIDs {
+		if !pending[jobID] {
+			tq.pendingMutex.Unlock()
+			return fmt.Errorf("%w: %s", ErrJobNotPending, jobID)
+		}
+	}
+
+	order := make([]string, 0, len(tq.pending))
+	order = append(order, jobIDs...)
+	order = append(order, rest...)
+	tq.pending = order
+	tq.notifyLocked()
+
+	tq.pendingMutex.Unlock()
+
+	logger.Info("Reordered pending jobs", "moved", len(jobIDs))
+	tq.broadcast("queue_reordered", map[string]interface{}{
+		"pending": tq.PendingJobs(),
+	})
+	return nil
+}
+
+// broadcast sends a queue control event with the current queue state
+func (tq *TaskQueue) broadcast(eventType string, payload map[string]interface{}) {
+	if tq.broadcaster == nil {
+		return
+	}
+	if payload == nil {
+		payload = make(map[string]interface{})
+	}
+	payload["state"] = tq.State()
+	payload["queue_size"] = tq.pendingCount()
+	tq.broadcaster.Broadcast(EventsChannel, eventType, payload)
+}
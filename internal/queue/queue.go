@@ -14,9 +14,29 @@ import (
 
 	"scriberr/internal/models"
 	"scriberr/internal/repository"
+	"scriberr/internal/sse"
 	"scriberr/pkg/logger"
 )
 
+// queueCapacity is the maximum number of jobs waiting for a worker
+const queueCapacity = 200
+
+// EventsChannel is the SSE channel queue control events are broadcast on.
+// Clients subscribe with /api/v1/events?job_id=queue.
+const EventsChannel = "queue"
+
+// State describes whether the queue is dispatching pending jobs
+type State string
+
+const (
+	StateRunning  State = "running"  // Pending jobs are dispatched to workers
+	StatePaused   State = "paused"   // No new jobs are started; running jobs continue
+	StateDraining State = "draining" // Paused, waiting for running jobs to finish
+)
+
+// ErrJobNotPending is returned when a queue operation targets a job that is not waiting in the queue
+var ErrJobNotPending = fmt.Errorf("job is not pending in the queue")
+
 // RunningJob tracks both context cancellation and OS process
 type RunningJob struct {
 	Cancel  context.CancelFunc
@@ -27,8 +47,14 @@ type RunningJob struct {
 type TaskQueue struct {
 	minWorkers     int
 	maxWorkers     int
-	currentWorkers int64 // Use atomic for thread-safe access
-	jobChannel     chan string
+	currentWorkers int64         // Use atomic for thread-safe access
+	pending        []string      // Job IDs waiting for a worker, in dispatch order
+	tasks          []Task        // Tasks waiting for a worker, started ahead of pending jobs
+	wake           chan struct{} // Closed and replaced whenever pending or state changes
+	state          State
+	dispatched     int // Jobs taken from pending that have not finished yet
+	holds          int // Outstanding HoldDispatch calls; no job is started while positive
+	pendingMutex   sync.Mutex
 	ctx            context.Context
 	cancel         context.CancelFunc
 	wg             sync.WaitGroup
@@ -38,6 +64,9 @@ type TaskQueue struct {
 	autoScale      bool
 	lastScaleTime  time.Time
 	jobRepo        repository.JobRepository
+	broadcaster    *sse.Broadcaster
+	leases         map[string]*lease // Jobs held by remote workers, guarded by pendingMutex
+	multiTrackOnly bool              // Local workers only run multi-track jobs, which are never leased
 }
 
 // JobProcessor defines the interface for processing jobs
@@ -53,6 +82,13 @@ type MultiTrackJobProcessor interface {
 	IsMultiTrackJob(jobID string) bool
 }
 
+// ResumableJobProcessor extends JobProcessor for processors that save progress
+// and can continue an interrupted job instead of starting it over
+type ResumableJobProcessor interface {
+	JobProcessor
+	HasCheckpoint(jobID string) bool
+}
+
 // getOptimalWorkerCount calculates optimal worker count based on system resources
 func getOptimalWorkerCount() (min, max int) {
 	numCPU := runtime.NumCPU()
@@ -99,7 +135,9 @@ func NewTaskQueue(legacyWorkers int, processor JobProcessor, jobRepo repository.
 		minWorkers:     min,
 		maxWorkers:     max,
 		currentWorkers: int64(min),
-		jobChannel:     make(chan string, 200), // Increased buffer for better throughput
+		pending:        make([]string, 0, queueCapacity),
+		wake:           make(chan struct{}),
+		state:          StateRunning,
 		ctx:            ctx,
 		cancel:         cancel,
 		processor:      processor,
@@ -107,9 +145,31 @@ func NewTaskQueue(legacyWorkers int, processor JobProcessor, jobRepo repository.
 		autoScale:      autoScale,
 		lastScaleTime:  time.Now(),
 		jobRepo:        jobRepo,
+		leases:         make(map[string]*lease),
 	}
 }
 
+// SetBroadcaster sets the SSE broadcaster used for queue control events
+func (tq *TaskQueue) SetBroadcaster(b *sse.Broadcaster) {
+	tq.broadcaster = b
+}
+
+// DisableLocalWorkers leaves jobs to remote workers. A single local worker is
+// kept for multi-track jobs, since remote workers never lease them. Must be
+// called before Start.
+func (tq *TaskQueue) DisableLocalWorkers() {
+	tq.minWorkers = 1
+	tq.maxWorkers = 1
+	tq.autoScale = false
+	tq.multiTrackOnly = true
+	atomic.StoreInt64(&tq.currentWorkers, 1)
+}
+
+// MaxWorkers returns the most jobs the queue runs locally at once
+func (tq *TaskQueue) MaxWorkers() int {
+	return tq.maxWorkers
+}
+
 // Start starts the task queue workers
 func (tq *TaskQueue) Start() {
 	workers := int(atomic.LoadInt64(&tq.currentWorkers))
@@ -132,6 +192,10 @@ func (tq *TaskQueue) Start() {
 		go tq.worker(i)
 	}
 
+	// Return jobs held by unresponsive remote workers to the queue
+	tq.wg.Add(1)
+	go tq.leaseReaper()
+
 	// Start auto-scaling monitor if enabled
 	if tq.autoScale {
 		tq.wg.Add(1)
@@ -141,11 +205,8 @@ func (tq *TaskQueue) Start() {
 
 // Stop stops the task queue
 func (tq *TaskQueue) Stop() {
-	logger.Debug("Stopping task queue")
 	logger.Debug("Stopping task queue")
 	tq.cancel()
-	// Do not close jobChannel here as it causes panics in EnqueueJob
-	// The channel will be garbage collected when the queue is no longer referenced
 	tq.wg.Wait()
 	logger.Debug("Task queue stopped")
 }
@@ -159,17 +220,101 @@ func (tq *TaskQueue) EnqueueJob(jobID string) error {
 	default:
 	}
 
-	select {
-	case tq.jobChannel <- jobID:
-		return nil
-	case <-tq.ctx.Done():
-		return fmt.Errorf("queue is shutting down")
-	default:
+	tq.pendingMutex.Lock()
+	defer tq.pendingMutex.Unlock()
+
+	for _, pendingID := range tq.pending {
+		if pendingID == jobID {
+			return nil // Already waiting for a worker
+		}
+	}
+	if len(tq.pending) >= queueCapacity {
 		return fmt.Errorf("queue is full")
 	}
+	tq.pending = append(tq.pending, jobID)
+	tq.notifyLocked()
+	return nil
+}
+
+// notifyLocked wakes all idle workers. Callers must hold pendingMutex.
+func (tq *TaskQueue) notifyLocked() {
+	close(tq.wake)
+	tq.wake = make(chan struct{})
+}
+
+// nextJob pops the next queued task or, if there is none, the next pending
+// job if the queue is dispatching. When neither can be started it returns a
+// channel that is closed on the next state change.
+func (tq *TaskQueue) nextJob() (string, Task, <-chan struct{}) {
+	if tq.multiTrackOnly {
+		return tq.nextMultiTrackJob()
+	}
+
+	tq.pendingMutex.Lock()
+	defer tq.pendingMutex.Unlock()
+
+	if task := tq.nextTaskLocked(); task != nil {
+		return "", task, nil
+	}
+	if !tq.dispatchingLocked() || len(tq.pending) == 0 {
+		return "", nil, tq.wake
+	}
+
+	jobID := tq.pending[0]
+	tq.pending = tq.pending[1:]
+	tq.dispatched++
+	return jobID, nil, nil
+}
+
+// nextMultiTrackJob pops the next queued task or the first pending multi-track
+// job, leaving the other jobs to remote workers. The wake channel is taken before the pending list is
+// scanned so that a job enqueued during the scan is not missed.
+func (tq *TaskQueue) nextMultiTrackJob() (string, Task, <-chan struct{}) {
+	tq.pendingMutex.Lock()
+	wake := tq.wake
+	task := tq.nextTaskLocked()
+	tq.pendingMutex.Unlock()
+	if task != nil {
+		return "", task, nil
+	}
+
+	for _, jobID := range tq.PendingJobs() {
+		job, err := tq.jobRepo.FindByID(context.Background(), jobID)
+		if err != nil || !job.IsMultiTrack {
+			continue
+		}
+		if tq.takePending(jobID) {
+			return jobID, nil, nil
+		}
+	}
+	return "", nil, wake
+}
+
+// takePending removes a job from pending and counts it as dispatched, provided
+// the queue is still dispatching and the job was not taken by someone else
+func (tq *TaskQueue) takePending(jobID string) bool {
+	tq.pendingMutex.Lock()
+	defer tq.pendingMutex.Unlock()
+	return tq.takePendingLocked(jobID)
+}
+
+// takePendingLocked is takePending for callers that hold pendingMutex
+func (tq *TaskQueue) takePendingLocked(jobID string) bool {
+	if !tq.dispatchingLocked() {
+		return false
+	}
+	for i, pendingID := range tq.pending {
+		if pendingID != jobID {
+			continue
+		}
+		tq.pending = append(tq.pending[:i:i], tq.pending[i+1:]...)
+		tq.dispatched++
+		return true
+	}
+	return false
 }
 
-// worker processes jobs from the channel
+// worker processes jobs from the pending list
 func (tq *TaskQueue) worker(id int) {
 	defer tq.wg.Done()
 
@@ -177,83 +322,105 @@ func (tq *TaskQueue) worker(id int) {
 
 	for {
 		select {
-		case jobID, ok := <-tq.jobChannel:
-			if !ok {
-				logger.Debug("Worker stopped", "worker_id", id)
+		case <-tq.ctx.Done():
+			logger.Debug("Worker stopped", "worker_id", id, "reason", "context_cancelled")
+			return
+		default:
+		}
+
+		jobID, task, wake := tq.nextJob()
+		if task != nil {
+			tq.runTask(id, task)
+			continue
+		}
+		if jobID == "" {
+			select {
+			case <-wake:
+			case <-tq.ctx.Done():
+				logger.Debug("Worker stopped", "worker_id", id, "reason", "context_cancelled")
 				return
 			}
+			continue
+		}
 
-			logger.WorkerOperation(id, jobID, "start")
+		tq.processJob(id, jobID)
+	}
+}
 
-			// Update job status to processing
-			if err := tq.updateJobStatus(jobID, models.StatusProcessing); err != nil {
-				logger.Error("Failed to update job status", "worker_id", id, "job_id", jobID, "error", err)
-				continue
-			}
+// processJob runs a single job on behalf of a worker and records its outcome
+func (tq *TaskQueue) processJob(id int, jobID string) {
+	defer tq.finishJob()
 
-			// Create context for this job and track it
-			jobCtx, jobCancel := context.WithCancel(tq.ctx)
-			runningJob := &RunningJob{
-				Cancel:  jobCancel,
-				Process: nil, // Will be set by registerProcess callback
-			}
+	logger.WorkerOperation(id, jobID, "start")
 
-			tq.jobsMutex.Lock()
-			tq.runningJobs[jobID] = runningJob
-			tq.jobsMutex.Unlock()
-
-			// Register process callback
-			registerProcess := func(cmd *exec.Cmd) {
-				tq.jobsMutex.Lock()
-				if job, exists := tq.runningJobs[jobID]; exists {
-					job.Process = cmd
-				}
-				tq.jobsMutex.Unlock()
-			}
+	// Update job status to processing
+	if err := tq.updateJobStatus(jobID, models.StatusProcessing); err != nil {
+		logger.Error("Failed to update job status", "worker_id", id, "job_id", jobID, "error", err)
+		return
+	}
 
-			// Process the job with process registration
-			err := tq.processor.ProcessJobWithProcess(jobCtx, jobID, registerProcess)
-
-			// Remove job from running jobs
-			tq.jobsMutex.Lock()
-			delete(tq.runningJobs, jobID)
-			tq.jobsMutex.Unlock()
-
-			// Handle result
-			if err != nil {
-				if jobCtx.Err() == context.Canceled {
-					logger.Info("Job cancelled", "worker_id", id, "job_id", jobID)
-					if err := tq.updateJobStatus(jobID, models.StatusFailed); err != nil {
-						logger.Error("Failed to update job status", "job_id", jobID, "error", err)
-					}
-					if err := tq.updateJobError(jobID, "Job was cancelled by user"); err != nil {
-						logger.Error("Failed to update job error", "job_id", jobID, "error", err)
-					}
-				} else {
-					logger.Error("Job processing failed", "worker_id", id, "job_id", jobID, "error", err)
-					if err := tq.updateJobStatus(jobID, models.StatusFailed); err != nil {
-						logger.Error("Failed to update job status", "job_id", jobID, "error", err)
-					}
-					if err := tq.updateJobError(jobID, err.Error()); err != nil {
-						logger.Error("Failed to update job error", "job_id", jobID, "error", err)
-					}
-				}
-			} else {
-				logger.Debug("Job processed successfully", "worker_id", id, "job_id", jobID)
-				if err := tq.updateJobStatus(jobID, models.StatusCompleted); err != nil {
-					logger.Error("Failed to update job status", "job_id", jobID, "error", err)
-				}
-			}
+	// Create context for this job and track it
+	jobCtx, jobCancel := context.WithCancel(tq.ctx)
+	runningJob := &RunningJob{
+		Cancel:  jobCancel,
+		Process: nil, // Will be set by registerProcess callback
+	}
 
-		case <-tq.ctx.Done():
-			logger.Debug("Worker stopped", "worker_id", id, "reason", "context_cancelled")
-			return
+	tq.jobsMutex.Lock()
+	tq.runningJobs[jobID] = runningJob
+	tq.jobsMutex.Unlock()
+
+	// Register process callback
+	registerProcess := func(cmd *exec.Cmd) {
+		tq.jobsMutex.Lock()
+		if job, exists := tq.runningJobs[jobID]; exists {
+			job.Process = cmd
 		}
+		tq.jobsMutex.Unlock()
 	}
+
+	// Process the job with process registration
+	err := tq.processor.ProcessJobWithProcess(jobCtx, jobID, registerProcess)
+
+	// Remove job from running jobs
+	tq.jobsMutex.Lock()
+	delete(tq.runningJobs, jobID)
+	tq.jobsMutex.Unlock()
+
+	// Handle result
+	if err != nil {
+		if jobCtx.Err() == context.Canceled {
+			logger.Info("Job cancelled", "worker_id", id, "job_id", jobID)
+			if err := tq.updateJobStatus(jobID, models.StatusFailed); err != nil {
+				logger.Error("Failed to update job status", "job_id", jobID, "error", err)
+			}
+			if err := tq.updateJobError(jobID, "Job was cancelled by user"); err != nil {
+				logger.Error("Failed to update job error", "job_id", jobID, "error", err)
+			}
+		} else {
+			logger.Error("Job processing failed", "worker_id", id, "job_id", jobID, "error", err)
+			if err := tq.updateJobStatus(jobID, models.StatusFailed); err != nil {
+				logger.Error("Failed to update job status", "job_id", jobID, "error", err)
+			}
+			if err := tq.updateJobError(jobID, err.Error()); err != nil {
+				logger.Error("Failed to update job error", "job_id", jobID, "error", err)
+			}
+		}
+	} else {
+		logger.Debug("Job processed successfully", "worker_id", id, "job_id", jobID)
+		if err := tq.updateJobStatus(jobID, models.StatusCompleted); err != nil {
+			logger.Error("Failed to update job status", "job_id", jobID, "error", err)
+		}
+	}
+
 }
 
 // KillJob aggressively terminates a running job
 func (tq *TaskQueue) KillJob(jobID string) error {
+	if tq.killLeasedJob(jobID) {
+		return nil
+	}
+
 	tq.jobsMutex.Lock()
 	defer tq.jobsMutex.Unlock()
 
@@ -321,7 +488,7 @@ func (tq *TaskQueue) IsJobRunning(jobID string) bool {
 	defer tq.jobsMutex.RUnlock()
 
 	_, exists := tq.runningJobs[jobID]
-	return exists
+	return exists || tq.isLeased(jobID)
 }
 
 // updateJobStatus updates the status of a job
@@ -366,7 +533,7 @@ func (tq *TaskQueue) checkAndScale() {
 		return
 	}
 
-	queueSize := len(tq.jobChannel)
+	queueSize := tq.pendingCount()
 	currentWorkers := int(atomic.LoadInt64(&tq.currentWorkers))
 
 	tq.jobsMutex.RLock()
@@ -410,13 +577,15 @@ func (tq *TaskQueue) GetQueueStats() map[string]interface{} {
 	tq.jobsMutex.RUnlock()
 
 	return map[string]interface{}{
-		"queue_size":      len(tq.jobChannel),
-		"queue_capacity":  cap(tq.jobChannel),
+		"queue_size":      tq.pendingCount(),
+		"queue_capacity":  queueCapacity,
+		"state":           tq.State(),
 		"current_workers": int(atomic.LoadInt64(&tq.currentWorkers)),
 		"min_workers":     tq.minWorkers,
 		"max_workers":     tq.maxWorkers,
 		"auto_scale":      tq.autoScale,
 		"running_jobs":    runningJobsCount,
+		"remote_jobs":     tq.leaseCount(),
 		"pending_jobs":    pendingCount,
 		"processing_jobs": processingCount,
 		"completed_jobs":  completedCount,
@@ -424,7 +593,8 @@ func (tq *TaskQueue) GetQueueStats() map[string]interface{} {
 	}
 }
 
-// ResetZombieJobs finds jobs stuck in processing state from previous runs and marks them as failed
+// ResetZombieJobs finds jobs stuck in processing state from previous runs and marks them as failed.
+// Jobs that saved chunk checkpoints are requeued instead so they resume where they stopped.
 func (tq *TaskQueue) ResetZombieJobs() {
 	// Find all jobs with status "processing"
 	zombieJobs, err := tq.jobRepo.FindByStatus(context.Background(), models.StatusProcessing)
@@ -439,7 +609,18 @@ func (tq *TaskQueue) ResetZombieJobs() {
 
 	logger.Info("Found zombie jobs from previous run", "count", len(zombieJobs))
 
+	resumable, _ := tq.processor.(ResumableJobProcessor)
+
 	for _, job := range zombieJobs {
+		// Jobs with saved progress go back to pending and are picked up by recoverPendingJobs
+		if resumable != nil && resumable.HasCheckpoint(job.ID) {
+			logger.Info("Requeueing interrupted job to resume from checkpoint", "job_id", job.ID)
+			if err := tq.updateJobStatus(job.ID, models.StatusPending); err != nil {
+				logger.Error("Failed to requeue zombie job", "job_id", job.ID, "error", err)
+			}
+			continue
+		}
+
 		logger.Info("Resetting zombie job", "job_id", job.ID)
 
 		// Mark as failed
@@ -471,11 +652,10 @@ func (tq *TaskQueue) recoverPendingJobs() {
 	logger.Info("Recovering pending jobs from previous server run", "count", len(pendingJobs))
 
 	for _, job := range pendingJobs {
-		select {
-		case tq.jobChannel <- job.ID:
-			logger.Debug("Recovered pending job", "job_id", job.ID)
-		default:
+		if err := tq.EnqueueJob(job.ID); err != nil {
 			logger.Warn("Queue full during startup recovery, job will remain pending", "job_id", job.ID)
+			continue
 		}
+		logger.Debug("Recovered pending job", "job_id", job.ID)
 	}
 }
@@ -0,0 +1,254 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"scriberr/internal/models"
+	"scriberr/pkg/logger"
+)
+
+// LeaseTTL is how long a remote worker may hold a job without sending a heartbeat
+const LeaseTTL = 2 * time.Minute
+
+// leaseReapInterval is how often expired leases are returned to the queue
+const leaseReapInterval = 15 * time.Second
+
+// ErrLeaseNotFound is returned when a worker reports on a job it does not hold
+var ErrLeaseNotFound = fmt.Errorf("job is not leased to this worker")
+
+// lease tracks a job handed to a remote worker
+type lease struct {
+	workerID  string
+	expiresAt time.Time
+	cancelled bool // Set when the job is killed; the worker is told on its next heartbeat
+}
+
+// LeaseJob hands the next pending job to a remote worker. It returns an empty
+// job ID when the queue is not dispatching or no pending job can run remotely.
+// Multi-track jobs always stay with the local workers.
+func (tq *TaskQueue) LeaseJob(workerID string) (string, error) {
+	select {
+	case <-tq.ctx.Done():
+		return "", fmt.Errorf("queue is shutting down")
+	default:
+	}
+
+	if tq.State() != StateRunning {
+		return "", nil
+	}
+
+	for _, jobID := range tq.PendingJobs() {
+		job, err := tq.jobRepo.FindByID(context.Background(), jobID)
+		if err != nil || job.IsMultiTrack {
+			continue
+		}
+		if !tq.takeForLease(jobID, workerID) {
+			continue
+		}
+
+		if err := tq.updateJobStatus(jobID, models.StatusProcessing); err != nil {
+			tq.dropLease(jobID)
+			tq.requeueFront(jobID)
+			return "", fmt.Errorf("failed to update job status: %w", err)
+		}
+
+		logger.Info("Leased job to remote worker", "job_id", jobID, "worker_id", workerID)
+		return jobID, nil
+	}
+
+	return "", nil
+}
+
+// takeForLease removes a job from pending and records the lease, provided the
+// queue is still dispatching and the job was not taken by someone else
+func (tq *TaskQueue) takeForLease(jobID, workerID string) bool {
+	tq.pendingMutex.Lock()
+	defer tq.pendingMutex.Unlock()
+
+	if !tq.takePendingLocked(jobID) {
+		return false
+	}
+	tq.leases[jobID] = &lease{
+		workerID:  workerID,
+		expiresAt: time.Now().Add(LeaseTTL),
+	}
+	return true
+}
+
+// RenewLease extends a worker's lease on a job. It reports whether the job has
+// been killed and the worker should abandon it.
+func (tq *TaskQueue) RenewLease(jobID, workerID string) (bool, error) {
+	tq.pendingMutex.Lock()
+	defer tq.pendingMutex.Unlock()
+
+	l, exists := tq.leases[jobID]
+	if !exists || l.workerID != workerID {
+		return false, ErrLeaseNotFound
+	}
+	if l.cancelled {
+		return true, nil
+	}
+	l.expiresAt = time.Now().Add(LeaseTTL)
+	return false, nil
+}
+
+// CheckLease reports whether a worker holds the lease on a job and whether
+// the job was killed while it did, without releasing the lease
+func (tq *TaskQueue) CheckLease(jobID, workerID string) (bool, error) {
+	tq.pendingMutex.Lock()
+	defer tq.pendingMutex.Unlock()
+
+	l, exists := tq.leases[jobID]
+	if !exists || l.workerID != workerID {
+		return false, ErrLeaseNotFound
+	}
+	return l.cancelled, nil
+}
+
+// CompleteLease releases a worker's lease once it has reported the job's
+// outcome. It reports whether the job was killed while the worker held it, in
+// which case the reported outcome must be discarded.
+func (tq *TaskQueue) CompleteLease(jobID, workerID string) (bool, error) {
+	tq.pendingMutex.Lock()
+	l, exists := tq.leases[jobID]
+	if !exists || l.workerID != workerID {
+		tq.pendingMutex.Unlock()
+		return false, ErrLeaseNotFound
+	}
+	cancelled := l.cancelled
+	delete(tq.leases, jobID)
+	tq.pendingMutex.Unlock()
+
+	tq.finishJob()
+	return cancelled, nil
+}
+
+// ReleaseLease hands a job back to the queue when a worker gives it up without
+// finishing, for example because it is shutting down. The job goes back to the
+// front of the pending list.
+func (tq *TaskQueue) ReleaseLease(jobID, workerID string) error {
+	cancelled, err := tq.CompleteLease(jobID, workerID)
+	if err != nil || cancelled {
+		return err
+	}
+	return tq.requeueLeased(jobID)
+}
+
+// requeueLeased resets a job taken back from a remote worker to pending
+func (tq *TaskQueue) requeueLeased(jobID string) error {
+	if err := tq.updateJobStatus(jobID, models.StatusPending); err != nil {
+		return fmt.Errorf("failed to reset job status: %w", err)
+	}
+	tq.requeueFront(jobID)
+	return nil
+}
+
+// dropLease forgets a lease and counts its job as finished
+func (tq *TaskQueue) dropLease(jobID string) {
+	tq.pendingMutex.Lock()
+	if _, exists := tq.leases[jobID]; !exists {
+		tq.pendingMutex.Unlock()
+		return
+	}
+	delete(tq.leases, jobID)
+	tq.pendingMutex.Unlock()
+
+	tq.finishJob()
+}
+
+// requeueFront puts a job back at the head of the pending list
+func (tq *TaskQueue) requeueFront(jobID string) {
+	tq.pendingMutex.Lock()
+	defer tq.pendingMutex.Unlock()
+
+	tq.pending = append([]string{jobID}, tq.pending...)
+	tq.notifyLocked()
+}
+
+// isLeased reports whether a job is currently held by a remote worker
+func (tq *TaskQueue) isLeased(jobID string) bool {
+	tq.pendingMutex.Lock()
+	defer tq.pendingMutex.Unlock()
+
+	_, exists := tq.leases[jobID]
+	return exists
+}
+
+// leaseCount returns the number of jobs held by remote workers
+func (tq *TaskQueue) leaseCount() int {
+	tq.pendingMutex.Lock()
+	defer tq.pendingMutex.Unlock()
+	return len(tq.leases)
+}
+
+// killLeasedJob marks a remotely held job as failed. The worker learns about
+// it on its next heartbeat. Returns false if the job is not leased.
+func (tq *TaskQueue) killLeasedJob(jobID string) bool {
+	tq.pendingMutex.Lock()
+	l, exists := tq.leases[jobID]
+	var workerID string
+	if exists {
+		l.cancelled = true
+		workerID = l.workerID
+	}
+	tq.pendingMutex.Unlock()
+
+	if !exists {
+		return false
+	}
+
+	logger.Info("Killing remote job", "job_id", jobID, "worker_id", workerID)
+	if err := tq.updateJobStatus(jobID, models.StatusFailed); err != nil {
+		logger.Error("Failed to update job status", "job_id", jobID, "error", err)
+	}
+	if err := tq.updateJobError(jobID, "Job was forcefully terminated by user"); err != nil {
+		logger.Error("Failed to update job error", "job_id", jobID, "error", err)
+	}
+	return true
+}
+
+// leaseReaper returns jobs whose worker stopped sending heartbeats to the queue
+func (tq *TaskQueue) leaseReaper() {
+	defer tq.wg.Done()
+
+	ticker := time.NewTicker(leaseReapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			tq.reapExpiredLeases(time.Now())
+		case <-tq.ctx.Done():
+			return
+		}
+	}
+}
+
+// reapExpiredLeases requeues jobs whose lease expired before now. Killed jobs
+// are dropped since their status is already final.
+func (tq *TaskQueue) reapExpiredLeases(now time.Time) {
+	// Copies are taken under the lock, since killLeasedJob may set cancelled
+	tq.pendingMutex.Lock()
+	expired := make(map[string]lease)
+	for jobID, l := range tq.leases {
+		if now.After(l.expiresAt) {
+			expired[jobID] = *l
+			delete(tq.leases, jobID)
+		}
+	}
+	tq.pendingMutex.Unlock()
+
+	for jobID, l := range expired {
+		tq.finishJob()
+		if l.cancelled {
+			continue
+		}
+
+		logger.Warn("Remote worker lease expired, requeueing job", "job_id", jobID, "worker_id", l.workerID)
+		if err := tq.requeueLeased(jobID); err != nil {
+			logger.Error("Failed to requeue expired job", "job_id", jobID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+
+	"scriberr/pkg/logger"
+)
+
+// Task is model work on a job that is not a transcription of its own, such as
+// diarizing its finished transcript again. Tasks run on the queue's local
+// workers, so they count against the worker limit like jobs do.
+type Task func(ctx context.Context)
+
+// SubmitTask queues a task for the next free local worker. Tasks start ahead
+// of pending jobs and, like them, wait while the queue is paused or held.
+func (tq *TaskQueue) SubmitTask(task Task) error {
+	select {
+	case <-tq.ctx.Done():
+		return fmt.Errorf("queue is shutting down")
+	default:
+	}
+
+	tq.pendingMutex.Lock()
+	defer tq.pendingMutex.Unlock()
+
+	if len(tq.tasks) >= queueCapacity {
+		return fmt.Errorf("queue is full")
+	}
+	tq.tasks = append(tq.tasks, task)
+	tq.notifyLocked()
+	return nil
+}
+
+// nextTaskLocked pops the next queued task if the queue is dispatching and
+// counts it as dispatched. Callers must hold pendingMutex.
+func (tq *TaskQueue) nextTaskLocked() Task {
+	if !tq.dispatchingLocked() || len(tq.tasks) == 0 {
+		return nil
+	}
+	task := tq.tasks[0]
+	tq.tasks = tq.tasks[1:]
+	tq.dispatched++
+	return task
+}
+
+// runTask runs a task on behalf of a worker
+func (tq *TaskQueue) runTask(id int, task Task) {
+	defer tq.finishJob()
+
+	logger.Debug("Worker running task", "worker_id", id)
+	task(tq.ctx)
+}
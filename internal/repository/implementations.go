@@ -53,10 +53,16 @@ type JobRepository interface {
 	FindWithAssociations(ctx context.Context, id string) (*models.TranscriptionJob, error)
 	FindActiveTrackJobs(ctx context.Context, parentJobID string) ([]models.TranscriptionJob, error)
 	FindLatestCompletedExecution(ctx context.Context, jobID string) (*models.TranscriptionJobExecution, error)
+	FindLatestExecution(ctx context.Context, jobID string) (*models.TranscriptionJobExecution, error)
 	ListWithParams(ctx context.Context, offset, limit int, sortBy, sortOrder, searchQuery string, updatedAfter *time.Time) ([]models.TranscriptionJob, int64, error)
 	ListByUser(ctx context.Context, userID uint, offset, limit int) ([]models.TranscriptionJob, int64, error)
 	UpdateTranscript(ctx context.Context, jobID string, transcript string) error
 	UpdateOriginalTranscript(ctx context.Context, jobID string, transcript string) error
+	SaveTranscriptRevision(ctx context.Context, revision *models.TranscriptRevision) error
+	SaveDiarizedTranscript(ctx context.Context, revision *models.TranscriptRevision, diarizeModel string, mappings []models.SpeakerMapping) error
+	SaveRelabelledTranscript(ctx context.Context, revision *models.TranscriptRevision, markDiarized bool, mappings []models.SpeakerMapping) error
+	ListTranscriptRevisions(ctx context.Context, jobID string) ([]models.TranscriptRevision, error)
+	DeleteTranscriptRevisionsByJobID(ctx context.Context, jobID string) error
 	CreateExecution(ctx context.Context, execution *models.TranscriptionJobExecution) error
 	UpdateExecution(ctx context.Context, execution *models.TranscriptionJobExecution) error
 	DeleteExecutionsByJobID(ctx context.Context, jobID string) error
@@ -153,6 +159,110 @@ func (r *jobRepository) UpdateOriginalTranscript(ctx context.Context, jobID stri
 		Update("original_transcript", transcript).Error
 }
 
+// SaveTranscriptRevision makes a revision the job's transcript. The first time
+// a transcript is revised its previous version is kept as revision 1.
+func (r *jobRepository) SaveTranscriptRevision(ctx context.Context, revision *models.TranscriptRevision) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return saveTranscriptRevision(tx, revision)
+	})
+}
+
+// SaveDiarizedTranscript saves a diarized transcript like SaveTranscriptRevision,
+// marks the job as diarized with diarizeModel and replaces its speaker
+// mappings, all in one transaction
+func (r *jobRepository) SaveDiarizedTranscript(ctx context.Context, revision *models.TranscriptRevision, diarizeModel string, mappings []models.SpeakerMapping) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := saveTranscriptRevision(tx, revision); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.TranscriptionJob{}).
+			Where("id = ?", revision.TranscriptionJobID).
+			Updates(map[string]interface{}{
+				"diarization":   true,
+				"diarize":       true,
+				"diarize_model": diarizeModel,
+			}).Error; err != nil {
+			return err
+		}
+		return replaceSpeakerMappings(tx, revision.TranscriptionJobID, mappings)
+	})
+}
+
+// SaveRelabelledTranscript saves a transcript with edited speakers like
+// SaveTranscriptRevision and replaces the job's speaker mappings, all in one
+// transaction. With markDiarized the job is also marked as diarized.
+func (r *jobRepository) SaveRelabelledTranscript(ctx context.Context, revision *models.TranscriptRevision, markDiarized bool, mappings []models.SpeakerMapping) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := saveTranscriptRevision(tx, revision); err != nil {
+			return err
+		}
+		if markDiarized {
+			if err := tx.Model(&models.TranscriptionJob{}).
+				Where("id = ?", revision.TranscriptionJobID).
+				Update("diarization", true).Error; err != nil {
+				return err
+			}
+		}
+		return replaceSpeakerMappings(tx, revision.TranscriptionJobID, mappings)
+	})
+}
+
+// replaceSpeakerMappings swaps a job's speaker mappings for mappings within tx
+func replaceSpeakerMappings(tx *gorm.DB, jobID string, mappings []models.SpeakerMapping) error {
+	if err := tx.Where("transcription_job_id = ?", jobID).Delete(&models.SpeakerMapping{}).Error; err != nil {
+		return err
+	}
+	if len(mappings) > 0 {
+		return tx.Create(&mappings).Error
+	}
+	return nil
+}
+
+// saveTranscriptRevision stores a revision and makes it the job's transcript within tx
+func saveTranscriptRevision(tx *gorm.DB, revision *models.TranscriptRevision) error {
+	var job models.TranscriptionJob
+	if err := tx.Select("id", "transcript").Where("id = ?", revision.TranscriptionJobID).First(&job).Error; err != nil {
+		return err
+	}
+
+	var latest int
+	if err := tx.Model(&models.TranscriptRevision{}).
+		Where("transcription_job_id = ?", revision.TranscriptionJobID).
+		Select("COALESCE(MAX(revision), 0)").Scan(&latest).Error; err != nil {
+		return err
+	}
+	if latest == 0 && job.Transcript != nil && *job.Transcript != "" {
+		original := models.TranscriptRevision{
+			TranscriptionJobID: revision.TranscriptionJobID,
+			Revision:           1,
+			Source:             models.RevisionSourceTranscription,
+			Transcript:         *job.Transcript,
+		}
+		if err := tx.Create(&original).Error; err != nil {
+			return err
+		}
+		latest = 1
+	}
+
+	revision.Revision = latest + 1
+	if err := tx.Create(revision).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.TranscriptionJob{}).
+		Where("id = ?", revision.TranscriptionJobID).
+		Update("transcript", revision.Transcript).Error
+}
+
+func (r *jobRepository) ListTranscriptRevisions(ctx context.Context, jobID string) ([]models.TranscriptRevision, error) {
+	var revisions []models.TranscriptRevision
+	err := r.db.WithContext(ctx).Where("transcription_job_id = ?", jobID).Order("revision ASC").Find(&revisions).Error
+	return revisions, err
+}
+
+func (r *jobRepository) DeleteTranscriptRevisionsByJobID(ctx context.Context, jobID string) error {
+	return r.db.WithContext(ctx).Where("transcription_job_id = ?", jobID).Delete(&models.TranscriptRevision{}).Error
+}
+
 func (r *jobRepository) CreateExecution(ctx context.Context, execution *models.TranscriptionJobExecution) error {
 	return r.db.WithContext(ctx).Create(execution).Error
 }
@@ -189,6 +299,18 @@ func (r *jobRepository) FindLatestCompletedExecution(ctx context.Context, jobID
 	return &execution, nil
 }
 
+func (r *jobRepository) FindLatestExecution(ctx context.Context, jobID string) (*models.TranscriptionJobExecution, error) {
+	var execution models.TranscriptionJobExecution
+	err := r.db.WithContext(ctx).
+		Where("transcription_job_id = ?", jobID).
+		Order("created_at DESC").
+		First(&execution).Error
+	if err != nil {
+		return nil, err
+	}
+	return &execution, nil
+}
+
 func (r *jobRepository) UpdateStatus(ctx context.Context, jobID string, status models.JobStatus) error {
 	return r.db.WithContext(ctx).Model(&models.TranscriptionJob{}).Where("id = ?", jobID).Update("status", status).Error
 }
@@ -630,3 +752,222 @@ func (r *refreshTokenRepository) Revoke(ctx context.Context, id uint) error {
 func (r *refreshTokenRepository) RevokeByHash(ctx context.Context, hash string) error {
 	return r.db.WithContext(ctx).Model(&models.RefreshToken{}).Where("hashed = ?", hash).Update("revoked", true).Error
 }
+
+// WorkerRepository handles remote worker operations
+type WorkerRepository interface {
+	Repository[models.Worker]
+	FindByTokenHash(ctx context.Context, tokenHash string) (*models.Worker, error)
+	ListActive(ctx context.Context) ([]models.Worker, error)
+	Revoke(ctx context.Context, id string) error
+	UpdateLastSeen(ctx context.Context, id string, currentJob *string) error
+}
+
+type workerRepository struct {
+	*BaseRepository[models.Worker]
+}
+
+func NewWorkerRepository(db *gorm.DB) WorkerRepository {
+	return &workerRepository{
+		BaseRepository: NewBaseRepository[models.Worker](db),
+	}
+}
+
+func (r *workerRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*models.Worker, error) {
+	var worker models.Worker
+	err := r.db.WithContext(ctx).Where("token_hash = ? AND is_active = ?", tokenHash, true).First(&worker).Error
+	if err != nil {
+		return nil, err
+	}
+	return &worker, nil
+}
+
+func (r *workerRepository) ListActive(ctx context.Context) ([]models.Worker, error) {
+	var workers []models.Worker
+	err := r.db.WithContext(ctx).Where("is_active = ?", true).Order("created_at ASC").Find(&workers).Error
+	return workers, err
+}
+
+func (r *workerRepository) Revoke(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Model(&models.Worker{}).Where("id = ?", id).Update("is_active", false).Error
+}
+
+func (r *workerRepository) UpdateLastSeen(ctx context.Context, id string, currentJob *string) error {
+	return r.db.WithContext(ctx).Model(&models.Worker{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_seen_at": time.Now(),
+		"current_job":  currentJob,
+	}).Error
+}
+
+// CustomModelRepository handles custom model variant operations
+type CustomModelRepository interface {
+	Repository[models.CustomModel]
+	FindByName(ctx context.Context, name string) (*models.CustomModel, error)
+	ListAll(ctx context.Context) ([]models.CustomModel, error)
+}
+
+type customModelRepository struct {
+	*BaseRepository[models.CustomModel]
+}
+
+func NewCustomModelRepository(db *gorm.DB) CustomModelRepository {
+	return &customModelRepository{
+		BaseRepository: NewBaseRepository[models.CustomModel](db),
+	}
+}
+
+func (r *customModelRepository) FindByName(ctx context.Context, name string) (*models.CustomModel, error) {
+	var model models.CustomModel
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&model).Error
+	if err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+func (r *customModelRepository) ListAll(ctx context.Context) ([]models.CustomModel, error) {
+	var customModels []models.CustomModel
+	err := r.db.WithContext(ctx).Order("name ASC").Find(&customModels).Error
+	return customModels, err
+}
+
+// VoiceRepository handles the speaker library
+type VoiceRepository interface {
+	Repository[models.Voice]
+	FindByName(ctx context.Context, name string) (*models.Voice, error)
+	ListAll(ctx context.Context) ([]models.Voice, error)
+	CountEmbeddings(ctx context.Context) (map[string]int64, error)
+	Rename(ctx context.Context, voice *models.Voice) error
+	DeleteVoice(ctx context.Context, id string) error
+	AddEmbedding(ctx context.Context, embedding *models.VoiceEmbedding) error
+	ListLibraryEmbeddings(ctx context.Context, model string) ([]models.VoiceEmbedding, error)
+	ReplaceJobEmbeddings(ctx context.Context, jobID string, embeddings []models.VoiceEmbedding) error
+	FindJobEmbedding(ctx context.Context, jobID, speaker string) (*models.VoiceEmbedding, error)
+	AssignEmbedding(ctx context.Context, id uint, voiceID *string) error
+	DeleteUnassignedByJobID(ctx context.Context, jobID string) error
+}
+
+type voiceRepository struct {
+	*BaseRepository[models.Voice]
+}
+
+func NewVoiceRepository(db *gorm.DB) VoiceRepository {
+	return &voiceRepository{
+		BaseRepository: NewBaseRepository[models.Voice](db),
+	}
+}
+
+func (r *voiceRepository) FindByName(ctx context.Context, name string) (*models.Voice, error) {
+	var voice models.Voice
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&voice).Error
+	if err != nil {
+		return nil, err
+	}
+	return &voice, nil
+}
+
+func (r *voiceRepository) ListAll(ctx context.Context) ([]models.Voice, error) {
+	var voices []models.Voice
+	err := r.db.WithContext(ctx).Order("name ASC").Find(&voices).Error
+	return voices, err
+}
+
+// CountEmbeddings returns how many embeddings each voice has
+func (r *voiceRepository) CountEmbeddings(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		VoiceID string
+		Count   int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.VoiceEmbedding{}).
+		Select("voice_id, COUNT(*) AS count").
+		Where("voice_id IS NOT NULL").
+		Group("voice_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.VoiceID] = row.Count
+	}
+	return counts, nil
+}
+
+// Rename saves a voice's new name and shows it for the speakers matched to it
+func (r *voiceRepository) Rename(ctx context.Context, voice *models.Voice) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(voice).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.SpeakerMapping{}).Where("voice_id = ?", voice.ID).Update("custom_name", voice.Name).Error
+	})
+}
+
+// DeleteVoice removes a voice with its enrolled embeddings. Embeddings of job
+// speakers and speaker mappings are unlinked but kept.
+func (r *voiceRepository) DeleteVoice(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("voice_id = ? AND transcription_job_id IS NULL", id).Delete(&models.VoiceEmbedding{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.VoiceEmbedding{}).Where("voice_id = ?", id).Update("voice_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.SpeakerMapping{}).Where("voice_id = ?", id).Updates(map[string]interface{}{
+			"voice_id":    nil,
+			"match_score": nil,
+			"confirmed":   false,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Voice{}, "id = ?", id).Error
+	})
+}
+
+func (r *voiceRepository) AddEmbedding(ctx context.Context, embedding *models.VoiceEmbedding) error {
+	return r.db.WithContext(ctx).Create(embedding).Error
+}
+
+// ListLibraryEmbeddings returns the embeddings of all voices computed with a model
+func (r *voiceRepository) ListLibraryEmbeddings(ctx context.Context, model string) ([]models.VoiceEmbedding, error) {
+	var embeddings []models.VoiceEmbedding
+	err := r.db.WithContext(ctx).Where("voice_id IS NOT NULL AND model = ?", model).Find(&embeddings).Error
+	return embeddings, err
+}
+
+// ReplaceJobEmbeddings stores new embeddings for a job's speakers, replacing
+// the ones that were not confirmed as a voice
+func (r *voiceRepository) ReplaceJobEmbeddings(ctx context.Context, jobID string, embeddings []models.VoiceEmbedding) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("transcription_job_id = ? AND voice_id IS NULL", jobID).Delete(&models.VoiceEmbedding{}).Error; err != nil {
+			return err
+		}
+		if len(embeddings) == 0 {
+			return nil
+		}
+		return tx.Create(&embeddings).Error
+	})
+}
+
+// FindJobEmbedding returns the latest embedding of a job's speaker
+func (r *voiceRepository) FindJobEmbedding(ctx context.Context, jobID, speaker string) (*models.VoiceEmbedding, error) {
+	var embedding models.VoiceEmbedding
+	err := r.db.WithContext(ctx).
+		Where("transcription_job_id = ? AND speaker = ?", jobID, speaker).
+		Order("id DESC").
+		First(&embedding).Error
+	if err != nil {
+		return nil, err
+	}
+	return &embedding, nil
+}
+
+// AssignEmbedding adds an embedding to a voice's library, or removes it with a nil voice
+func (r *voiceRepository) AssignEmbedding(ctx context.Context, id uint, voiceID *string) error {
+	return r.db.WithContext(ctx).Model(&models.VoiceEmbedding{}).Where("id = ?", id).Update("voice_id", voiceID).Error
+}
+
+// DeleteUnassignedByJobID removes a job's speaker embeddings that are not part
+// of a voice's library
+func (r *voiceRepository) DeleteUnassignedByJobID(ctx context.Context, jobID string) error {
+	return r.db.WithContext(ctx).Where("transcription_job_id = ? AND voice_id IS NULL", jobID).Delete(&models.VoiceEmbedding{}).Error
+}
@@ -80,6 +80,17 @@ func CheckEnvironmentReady(envPath, importStatement string) bool {
 	return result.(bool)
 }
 
+// ResetEnvironmentCache forgets the cached readiness of environments under a path
+func ResetEnvironmentCache(envPath string) {
+	envCacheMutex.Lock()
+	defer envCacheMutex.Unlock()
+	for key := range envCache {
+		if strings.HasPrefix(key, envPath+":") || strings.HasPrefix(key, envPath+string(filepath.Separator)) {
+			delete(envCache, key)
+		}
+	}
+}
+
 // BaseAdapter provides common functionality for all model adapters
 type BaseAdapter struct {
 	modelID      string
@@ -276,6 +287,16 @@ func (b *BaseAdapter) PrepareEnvironment(ctx context.Context) error {
 	return nil
 }
 
+// ResetEnvironment marks the environment as not prepared, e.g. after it was
+// deleted, and stops the adapter's model server
+func (b *BaseAdapter) ResetEnvironment() {
+	b.initialized = false
+	StopModelServer(b.modelID)
+	if b.modelPath != "" {
+		ResetEnvironmentCache(b.modelPath)
+	}
+}
+
 // IsReady checks if the adapter is ready to process jobs
 func (b *BaseAdapter) IsReady(ctx context.Context) bool {
 	if !b.initialized {
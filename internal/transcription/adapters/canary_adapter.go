@@ -22,6 +22,7 @@ var nvidiaScripts embed.FS
 // CanaryAdapter implements the TranscriptionAdapter interface for NVIDIA Canary
 type CanaryAdapter struct {
 	*BaseAdapter
+	customModelSet
 	envPath string
 }
 
@@ -57,9 +58,21 @@ func NewCanaryAdapter(envPath string) *CanaryAdapter {
 			"format":         "16khz_mono_wav",
 			"memory_warning": "requires_8gb_plus",
 		},
+		// The Canary script has no long-form mode, so long audio is split beforehand
+		Chunking: interfaces.ChunkLimits{
+			MaxDurationSeconds:   300,
+			ChunkDurationSeconds: 300,
+		},
 	}
 
 	schema := []interfaces.ParameterSchema{
+		{
+			Name:        "model",
+			Type:        "string",
+			Required:    false,
+			Description: "Custom model registered for Canary, the bundled canary-1b-v2 when empty",
+			Group:       "basic",
+		},
 		// Language settings
 		{
 			Name:        "source_lang",
@@ -161,7 +174,7 @@ func NewCanaryAdapter(envPath string) *CanaryAdapter {
 
 // GetSupportedModels returns the specific Canary model available
 func (c *CanaryAdapter) GetSupportedModels() []string {
-	return []string{"canary-1b-v2"}
+	return c.withCustomModels([]string{"canary-1b-v2"})
 }
 
 // PrepareEnvironment sets up the Canary environment (shared with Parakeet)
@@ -339,25 +352,22 @@ func (c *CanaryAdapter) Transcribe(ctx context.Context, input interfaces.AudioIn
 		return nil, fmt.Errorf("failed to build command: %w", err)
 	}
 
-	// Execute Canary
-	cmd := exec.CommandContext(ctx, "uv", args...)
-	cmd.Env = append(os.Environ(),
-		"PYTHONUNBUFFERED=1",
-		"PYTORCH_CUDA_ALLOC_CONF=expandable_segments:True")
-
 	// Setup log file
 	logFile, err := os.OpenFile(filepath.Join(procCtx.OutputDirectory, "transcription.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		logger.Warn("Failed to create log file", "error", err)
 	} else {
 		defer logFile.Close()
-		cmd.Stdout = logFile
-		cmd.Stderr = logFile
 	}
 
 	logger.Info("Executing Canary command", "args", strings.Join(args, " "))
 
-	if err := cmd.Run(); err != nil {
+	// Execute Canary
+	env := []string{
+		"PYTHONUNBUFFERED=1",
+		"PYTORCH_CUDA_ALLOC_CONF=expandable_segments:True",
+	}
+	if err := runUV(ctx, "canary", args, env, logFile); err != nil {
 		if ctx.Err() == context.Canceled {
 			return nil, fmt.Errorf("transcription was cancelled")
 		}
@@ -380,7 +390,7 @@ func (c *CanaryAdapter) Transcribe(ctx context.Context, input interfaces.AudioIn
 	}
 
 	result.ProcessingTime = time.Since(startTime)
-	result.ModelUsed = "canary-1b-v2"
+	result.ModelUsed = c.modelUsed(params, "canary-1b-v2")
 	result.Metadata = c.CreateDefaultMetadata(params)
 
 	logger.Info("Canary transcription completed",
@@ -403,6 +413,8 @@ func (c *CanaryAdapter) buildCanaryArgs(input interfaces.AudioInput, params map[
 		"--output", outputFile,
 	}
 
+	args = append(args, c.customModelArgs(params)...)
+
 	// Add language settings
 	args = append(args, "--source-lang", c.GetStringParameter(params, "source_lang"))
 	args = append(args, "--target-lang", c.GetStringParameter(params, "target_lang"))
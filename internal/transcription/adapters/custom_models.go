@@ -0,0 +1,83 @@
+package adapters
+
+import (
+	"sync"
+
+	"scriberr/internal/transcription/interfaces"
+)
+
+// customModelSet holds the custom checkpoints registered for an adapter
+type customModelSet struct {
+	customMu     sync.RWMutex
+	customModels []interfaces.CustomModel
+}
+
+// SetCustomModels replaces the custom models the adapter accepts
+func (s *customModelSet) SetCustomModels(models []interfaces.CustomModel) {
+	s.customMu.Lock()
+	defer s.customMu.Unlock()
+	s.customModels = append([]interfaces.CustomModel(nil), models...)
+}
+
+// GetCustomModels returns the custom models the adapter accepts
+func (s *customModelSet) GetCustomModels() []interfaces.CustomModel {
+	s.customMu.RLock()
+	defer s.customMu.RUnlock()
+	return append([]interfaces.CustomModel(nil), s.customModels...)
+}
+
+// customModel looks up a custom model by name
+func (s *customModelSet) customModel(name string) (interfaces.CustomModel, bool) {
+	s.customMu.RLock()
+	defer s.customMu.RUnlock()
+	for _, model := range s.customModels {
+		if model.Name == name {
+			return model, true
+		}
+	}
+	return interfaces.CustomModel{}, false
+}
+
+// withCustomModels appends the custom model names to a list of models
+func (s *customModelSet) withCustomModels(models []string) []string {
+	for _, model := range s.GetCustomModels() {
+		models = append(models, model.Name)
+	}
+	return models
+}
+
+// withoutCustomModel drops the model parameter when it names a custom model,
+// which the schema's options do not list
+func (s *customModelSet) withoutCustomModel(params map[string]interface{}) map[string]interface{} {
+	name, _ := params["model"].(string)
+	if _, ok := s.customModel(name); !ok {
+		return params
+	}
+	filtered := make(map[string]interface{}, len(params))
+	for key, value := range params {
+		if key != "model" {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+// customModelArgs returns the --model flag of the NVIDIA scripts when the
+// model parameter names a custom model
+func (s *customModelSet) customModelArgs(params map[string]interface{}) []string {
+	name, _ := params["model"].(string)
+	if model, ok := s.customModel(name); ok {
+		return []string{"--model", model.Source}
+	}
+	return nil
+}
+
+// modelUsed names the custom model selected by the parameters, or the
+// adapter's bundled model
+func (s *customModelSet) modelUsed(params map[string]interface{}, bundled string) string {
+	name, _ := params["model"].(string)
+	if _, ok := s.customModel(name); ok {
+		return name
+	}
+	return bundled
+}
@@ -0,0 +1,179 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"scriberr/internal/transcription/modelserver"
+	"scriberr/pkg/logger"
+)
+
+//go:embed py/model_server.py
+var modelServerScript []byte
+
+var (
+	modelServersMu sync.RWMutex
+	modelServers   *modelserver.Manager
+)
+
+// EnableModelServers makes local adapters run their scripts in long-lived
+// model server processes, one per adapter, which keep loaded models in memory
+// between jobs
+func EnableModelServers(opts modelserver.Options) {
+	modelServersMu.Lock()
+	defer modelServersMu.Unlock()
+	if modelServers == nil {
+		modelServers = modelserver.NewManager(opts)
+	}
+}
+
+// StopModelServers shuts down all model server processes
+func StopModelServers() {
+	modelServersMu.Lock()
+	manager := modelServers
+	modelServers = nil
+	modelServersMu.Unlock()
+
+	if manager != nil {
+		manager.StopAll()
+	}
+}
+
+// StopModelServer shuts down the model server of one adapter, e.g. after its
+// environment changed
+func StopModelServer(adapterName string) {
+	modelServersMu.RLock()
+	manager := modelServers
+	modelServersMu.RUnlock()
+
+	if manager != nil {
+		manager.Stop(adapterName)
+	}
+}
+
+// runScriptRequest is the params of the model server's run method
+type runScriptRequest struct {
+	Script string            `json:"script,omitempty"`
+	Module string            `json:"module,omitempty"`
+	Argv   []string          `json:"argv"`
+	Env    map[string]string `json:"env,omitempty"` // Set for the duration of the run
+}
+
+// runUV runs `uv args...` for an adapter with its output going to logFile, which
+// may be nil. With model servers enabled, a `uv run ... python <script|-m module>`
+// command is sent to the adapter's model server instead of starting a new process.
+func runUV(ctx context.Context, adapterName string, args []string, env []string, logFile *os.File) error {
+	modelServersMu.RLock()
+	manager := modelServers
+	modelServersMu.RUnlock()
+
+	if manager != nil {
+		if command, req, ok := splitPythonCommand(args); ok {
+			return runInModelServer(ctx, manager, adapterName, command, req, env, logFile)
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, "uv", args...)
+	cmd.Env = append(os.Environ(), env...)
+	if logFile != nil {
+		cmd.Stdout = logFile
+		cmd.Stderr = logFile
+	}
+	return cmd.Run()
+}
+
+func runInModelServer(ctx context.Context, manager *modelserver.Manager, adapterName string, command []string, req runScriptRequest, env []string, logFile *os.File) error {
+	project := projectPath(command)
+	if project == "" {
+		return fmt.Errorf("model server for %s needs a --project path", adapterName)
+	}
+	serverPath := filepath.Join(project, "model_server.py")
+	if err := writeIfChanged(serverPath, modelServerScript); err != nil {
+		return fmt.Errorf("failed to write model server script: %w", err)
+	}
+
+	server := manager.Server(adapterName, append(command, serverPath), env)
+	// The server keeps the environment it was started with, so the adapter's
+	// current environment is sent along with every run
+	req.Env = envMap(env)
+	logger.Debug("Running script in model server", "server", adapterName, "script", req.Script, "module", req.Module)
+
+	var result struct {
+		ExitCode int `json:"exit_code"`
+	}
+	// A nil *os.File must not become a non-nil writer
+	var log io.Writer
+	if logFile != nil {
+		log = logFile
+	}
+	if err := server.Call(ctx, "run", req, &result, log); err != nil {
+		return err
+	}
+	if result.ExitCode != 0 {
+		return fmt.Errorf("exit status %d", result.ExitCode)
+	}
+	return nil
+}
+
+// splitPythonCommand separates `run [flags] python <script|-m module> args...`
+// into the command starting the interpreter and the script to run in it
+func splitPythonCommand(args []string) ([]string, runScriptRequest, bool) {
+	if len(args) == 0 || args[0] != "run" {
+		return nil, runScriptRequest{}, false
+	}
+	for i, arg := range args {
+		if arg != "python" {
+			continue
+		}
+		rest := args[i+1:]
+		command := append([]string{"uv"}, args[:i+1]...)
+		switch {
+		case len(rest) >= 2 && rest[0] == "-m":
+			return command, runScriptRequest{Module: rest[1], Argv: append([]string{}, rest[2:]...)}, true
+		case len(rest) >= 1 && rest[0] != "-c":
+			return command, runScriptRequest{Script: rest[0], Argv: append([]string{}, rest[1:]...)}, true
+		default:
+			return nil, runScriptRequest{}, false
+		}
+	}
+	return nil, runScriptRequest{}, false
+}
+
+// envMap turns KEY=VALUE pairs into a map, skipping malformed entries
+func envMap(env []string) map[string]string {
+	if len(env) == 0 {
+		return nil
+	}
+	vars := make(map[string]string, len(env))
+	for _, kv := range env {
+		if key, value, ok := strings.Cut(kv, "="); ok && key != "" {
+			vars[key] = value
+		}
+	}
+	return vars
+}
+
+// projectPath returns the value of --project in a uv command
+func projectPath(command []string) string {
+	for i := 0; i+1 < len(command); i++ {
+		if command[i] == "--project" {
+			return command[i+1]
+		}
+	}
+	return ""
+}
+
+func writeIfChanged(path string, content []byte) error {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, content) {
+		return nil
+	}
+	return os.WriteFile(path, content, 0755)
+}
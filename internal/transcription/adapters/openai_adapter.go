@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"scriberr/internal/transcription/interfaces"
@@ -19,10 +20,24 @@ import (
 	"scriberr/pkg/logger"
 )
 
+// OpenAIDefaultBaseURL is the API root of the hosted OpenAI service
+const OpenAIDefaultBaseURL = "https://api.openai.com/v1"
+
 // OpenAIAdapter implements the TranscriptionAdapter interface for OpenAI API
 type OpenAIAdapter struct {
 	*BaseAdapter
 	apiKey string
+
+	// Endpoint settings, which OpenAI-compatible servers override
+	baseURL        string
+	authHeader     string
+	authScheme     string
+	headers        map[string]string
+	requireAPIKey  bool
+	modelMu        sync.RWMutex // Guards defaultModel, which model discovery may set later
+	defaultModel   string
+	wordTimestamps bool // Request word timestamps from every model, not only whisper-1
+	client         *http.Client
 }
 
 // NewOpenAIAdapter creates a new OpenAI adapter
@@ -51,6 +66,11 @@ func NewOpenAIAdapter(apiKey string) *OpenAIAdapter {
 			"provider": "openai",
 			"api_url":  "https://api.openai.com/v1/audio/transcriptions",
 		},
+		Chunking: interfaces.ChunkLimits{
+			MaxFileSizeBytes:     25 * 1024 * 1024, // API upload limit
+			MaxDurationSeconds:   300,
+			ChunkDurationSeconds: 300,
+		},
 	}
 
 	schema := []interfaces.ParameterSchema{
@@ -99,8 +119,77 @@ func NewOpenAIAdapter(apiKey string) *OpenAIAdapter {
 	baseAdapter := NewBaseAdapter("openai_whisper", "", capabilities, schema)
 
 	return &OpenAIAdapter{
-		BaseAdapter: baseAdapter,
-		apiKey:      apiKey,
+		BaseAdapter:   baseAdapter,
+		apiKey:        apiKey,
+		baseURL:       OpenAIDefaultBaseURL,
+		authHeader:    "Authorization",
+		authScheme:    "Bearer",
+		requireAPIKey: true,
+		defaultModel:  "whisper-1",
+		client:        newOpenAIHTTPClient(nil, 10*time.Minute),
+	}
+}
+
+// newOpenAIHTTPClient creates the client for transcription requests.
+// HTTP/1.1 is forced to avoid HTTP/2 framing layer issues with OpenAI's API
+// during long-running transcription requests.
+func newOpenAIHTTPClient(tlsConfig *tls.Config, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:             http.ProxyFromEnvironment,
+			TLSClientConfig:   tlsConfig,
+			ForceAttemptHTTP2: false,
+			TLSNextProto:      make(map[string]func(authority string, c *tls.Conn) http.RoundTripper),
+		},
+	}
+}
+
+// getDefaultModel returns the model used when a job names none
+func (a *OpenAIAdapter) getDefaultModel() string {
+	a.modelMu.RLock()
+	defer a.modelMu.RUnlock()
+	return a.defaultModel
+}
+
+// transcriptionsURL returns the transcription endpoint of the configured server
+func (a *OpenAIAdapter) transcriptionsURL() string {
+	return strings.TrimRight(a.baseURL, "/") + "/audio/transcriptions"
+}
+
+// setRequestHeaders adds authentication and any extra headers to req
+func (a *OpenAIAdapter) setRequestHeaders(req *http.Request, apiKey string) {
+	for name, value := range a.headers {
+		req.Header.Set(name, value)
+	}
+	if apiKey != "" && a.authHeader != "" {
+		value := apiKey
+		if a.authScheme != "" {
+			value = a.authScheme + " " + apiKey
+		}
+		req.Header.Set(a.authHeader, value)
+	}
+}
+
+// writeFormatFields asks for the richest response format the model supports
+func (a *OpenAIAdapter) writeFormatFields(writer *multipart.Writer, model string) {
+	if strings.HasPrefix(model, "gpt-4o") {
+		if strings.Contains(model, "diarize") {
+			_ = writer.WriteField("response_format", "diarized_json")
+			// chunking_strategy is required for diarization models
+			_ = writer.WriteField("chunking_strategy", "auto")
+		} else {
+			_ = writer.WriteField("response_format", "json")
+		}
+		// gpt-4o models don't support timestamp_granularities with these formats
+		return
+	}
+
+	_ = writer.WriteField("response_format", "verbose_json")
+	// timestamp_granularities is only supported for whisper-1 on OpenAI itself
+	if model == "whisper-1" || a.wordTimestamps {
+		_ = writer.WriteField("timestamp_granularities[]", "word")    // Request word timestamps
+		_ = writer.WriteField("timestamp_granularities[]", "segment") // Request segment timestamps
 	}
 }
 
@@ -155,7 +244,7 @@ func (a *OpenAIAdapter) Transcribe(ctx context.Context, input interfaces.AudioIn
 		apiKey = key
 	}
 
-	if apiKey == "" {
+	if apiKey == "" && a.requireAPIKey {
 		writeLog("Error: OpenAI API key is required but not provided")
 		return nil, fmt.Errorf("OpenAI API key is required but not provided")
 	}
@@ -185,28 +274,11 @@ func (a *OpenAIAdapter) Transcribe(ctx context.Context, input interfaces.AudioIn
 	// Add parameters
 	model := a.GetStringParameter(params, "model")
 	if model == "" {
-		model = "whisper-1"
+		model = a.getDefaultModel()
 	}
 	writeLog("Model: %s", model)
 	_ = writer.WriteField("model", model)
-
-	if strings.HasPrefix(model, "gpt-4o") {
-		if strings.Contains(model, "diarize") {
-			_ = writer.WriteField("response_format", "diarized_json")
-			// chunking_strategy is required for diarization models
-			_ = writer.WriteField("chunking_strategy", "auto")
-		} else {
-			_ = writer.WriteField("response_format", "json")
-		}
-		// gpt-4o models don't support timestamp_granularities with these formats
-	} else {
-		_ = writer.WriteField("response_format", "verbose_json")
-		// timestamp_granularities is only supported for whisper-1
-		if model == "whisper-1" {
-			_ = writer.WriteField("timestamp_granularities[]", "word")    // Request word timestamps
-			_ = writer.WriteField("timestamp_granularities[]", "segment") // Request segment timestamps
-		}
-	}
+	a.writeFormatFields(writer, model)
 
 	if lang := a.GetStringParameter(params, "language"); lang != "" {
 		writeLog("Language: %s", lang)
@@ -253,26 +325,18 @@ func (a *OpenAIAdapter) Transcribe(ctx context.Context, input interfaces.AudioIn
 	}
 
 	// Create request
-	writeLog("Sending request to OpenAI API...")
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/audio/transcriptions", body)
+	writeLog("Sending request to %s...", a.transcriptionsURL())
+	req, err := http.NewRequestWithContext(ctx, "POST", a.transcriptionsURL(), body)
 	if err != nil {
 		writeLog("Error: Failed to create request: %v", err)
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("Authorization", "Bearer "+apiKey)
+	a.setRequestHeaders(req, apiKey)
 
 	// Execute request with retry logic for transient network errors
-	// Force HTTP/1.1 to avoid HTTP/2 framing layer issues with OpenAI's API
-	// during long-running transcription requests
-	client := &http.Client{
-		Timeout: 10 * time.Minute, // Generous timeout for large files
-		Transport: &http.Transport{
-			ForceAttemptHTTP2: false,
-			TLSNextProto:      make(map[string]func(authority string, c *tls.Conn) http.RoundTripper),
-		},
-	}
+	client := a.client
 
 	var resp *http.Response
 	maxRetries := 3
@@ -328,20 +392,7 @@ func (a *OpenAIAdapter) Transcribe(ctx context.Context, input interfaces.AudioIn
 		}
 
 		_ = writer.WriteField("model", model)
-		if strings.HasPrefix(model, "gpt-4o") {
-			if strings.Contains(model, "diarize") {
-				_ = writer.WriteField("response_format", "diarized_json")
-				_ = writer.WriteField("chunking_strategy", "auto")
-			} else {
-				_ = writer.WriteField("response_format", "json")
-			}
-		} else {
-			_ = writer.WriteField("response_format", "verbose_json")
-			if model == "whisper-1" {
-				_ = writer.WriteField("timestamp_granularities[]", "word")
-				_ = writer.WriteField("timestamp_granularities[]", "segment")
-			}
-		}
+		a.writeFormatFields(writer, model)
 		if lang := a.GetStringParameter(params, "language"); lang != "" {
 			_ = writer.WriteField("language", lang)
 		}
@@ -372,13 +423,13 @@ func (a *OpenAIAdapter) Transcribe(ctx context.Context, input interfaces.AudioIn
 		}
 		writer.Close()
 
-		req, err = http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/audio/transcriptions", body)
+		req, err = http.NewRequestWithContext(ctx, "POST", a.transcriptionsURL(), body)
 		if err != nil {
 			writeLog("Error: Failed to create request on retry: %v", err)
 			return nil, fmt.Errorf("failed to create request on retry: %w", err)
 		}
 		req.Header.Set("Content-Type", writer.FormDataContentType())
-		req.Header.Set("Authorization", "Bearer "+apiKey)
+		a.setRequestHeaders(req, apiKey)
 	}
 	defer resp.Body.Close()
 
@@ -0,0 +1,377 @@
+package adapters
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/pkg/logger"
+)
+
+// FamilyOpenAICompatible is the model family of self-hosted servers that speak
+// the OpenAI transcription API
+const FamilyOpenAICompatible = "openai_compatible"
+
+// OpenAICompatibleConfig describes one self-hosted server, e.g. speaches,
+// LocalAI, the whisper.cpp server or vLLM
+type OpenAICompatibleConfig struct {
+	ID          string `json:"id"`           // Model ID profiles select it by, e.g. "speaches"
+	DisplayName string `json:"display_name"` // Defaults to the ID
+	Description string `json:"description"`
+	BaseURL     string `json:"base_url"` // API root, e.g. http://localhost:8000/v1
+
+	// Authentication. The key is sent as "<auth_scheme> <key>" in auth_header.
+	APIKey     string            `json:"api_key"`
+	APIKeyEnv  string            `json:"api_key_env"` // Read the key from this environment variable instead
+	AuthHeader string            `json:"auth_header"` // Defaults to Authorization
+	AuthScheme *string           `json:"auth_scheme"` // Defaults to Bearer for the Authorization header
+	Headers    map[string]string `json:"headers"`
+
+	// Models the server offers. Without a list they are discovered from GET /models.
+	Models         []string `json:"models"`
+	DefaultModel   string   `json:"default_model"`
+	WordTimestamps bool     `json:"word_timestamps"` // Server returns word timestamps for every model
+
+	TLS            OpenAICompatibleTLS `json:"tls"`
+	TimeoutSeconds int                 `json:"timeout_seconds"` // Defaults to 600
+
+	// Capabilities of the server
+	Languages        []string        `json:"languages"`
+	Formats          []string        `json:"formats"`
+	Features         map[string]bool `json:"features"`
+	MaxFileSizeMB    int             `json:"max_file_size_mb"` // Larger files are split, 0 for no limit
+	MaxDurationSecs  float64         `json:"max_duration_seconds"`
+	ChunkDurationSec float64         `json:"chunk_duration_seconds"`
+}
+
+// OpenAICompatibleTLS holds TLS settings for servers with private certificates
+type OpenAICompatibleTLS struct {
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
+	CAFile             string `json:"ca_file"`
+	CertFile           string `json:"cert_file"` // Client certificate for mutual TLS
+	KeyFile            string `json:"key_file"`
+	ServerName         string `json:"server_name"`
+}
+
+// LoadOpenAICompatibleConfigs parses the server list from inline JSON or from
+// the JSON file value points to
+func LoadOpenAICompatibleConfigs(value string) ([]OpenAICompatibleConfig, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	data := []byte(value)
+	if !strings.HasPrefix(value, "[") {
+		var err error
+		if data, err = os.ReadFile(value); err != nil {
+			return nil, fmt.Errorf("failed to read OpenAI-compatible server config: %w", err)
+		}
+	}
+
+	var configs []OpenAICompatibleConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("invalid OpenAI-compatible server config: %w", err)
+	}
+	return configs, nil
+}
+
+// OpenAICompatibleAdapter transcribes with a self-hosted server that implements
+// the OpenAI transcription API
+type OpenAICompatibleAdapter struct {
+	*OpenAIAdapter
+	config OpenAICompatibleConfig
+
+	mu     sync.RWMutex
+	models []string
+}
+
+// NewOpenAICompatibleAdapter creates an adapter for one configured server
+func NewOpenAICompatibleAdapter(cfg OpenAICompatibleConfig) (*OpenAICompatibleAdapter, error) {
+	if cfg.ID == "" {
+		return nil, fmt.Errorf("OpenAI-compatible server needs an id")
+	}
+	if !strings.HasPrefix(cfg.BaseURL, "http://") && !strings.HasPrefix(cfg.BaseURL, "https://") {
+		return nil, fmt.Errorf("OpenAI-compatible server %s needs an http(s) base_url", cfg.ID)
+	}
+
+	tlsConfig, err := cfg.TLS.build()
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI-compatible server %s: %w", cfg.ID, err)
+	}
+
+	timeout := 10 * time.Minute
+	if cfg.TimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.TimeoutSeconds) * time.Second
+	}
+
+	apiKey := cfg.APIKey
+	if cfg.APIKeyEnv != "" {
+		apiKey = os.Getenv(cfg.APIKeyEnv)
+	}
+
+	authHeader := cfg.AuthHeader
+	if authHeader == "" {
+		authHeader = "Authorization"
+	}
+	authScheme := ""
+	if cfg.AuthScheme != nil {
+		authScheme = *cfg.AuthScheme
+	} else if strings.EqualFold(authHeader, "Authorization") {
+		authScheme = "Bearer"
+	}
+
+	defaultModel := cfg.DefaultModel
+	if defaultModel == "" && len(cfg.Models) > 0 {
+		defaultModel = cfg.Models[0]
+	}
+
+	adapter := &OpenAICompatibleAdapter{
+		config: cfg,
+		models: append([]string(nil), cfg.Models...),
+	}
+	adapter.OpenAIAdapter = &OpenAIAdapter{
+		BaseAdapter:    NewBaseAdapter(cfg.ID, "", cfg.capabilities(), compatibleSchema(cfg.Models, defaultModel)),
+		apiKey:         apiKey,
+		baseURL:        strings.TrimRight(cfg.BaseURL, "/"),
+		authHeader:     authHeader,
+		authScheme:     authScheme,
+		headers:        cfg.Headers,
+		defaultModel:   defaultModel,
+		wordTimestamps: cfg.WordTimestamps,
+		client:         newOpenAIHTTPClient(tlsConfig, timeout),
+	}
+	return adapter, nil
+}
+
+// build turns the TLS settings into a client configuration, or nil for the defaults
+func (t OpenAICompatibleTLS) build() (*tls.Config, error) {
+	if t == (OpenAICompatibleTLS{}) {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		InsecureSkipVerify: t.InsecureSkipVerify, //nolint:gosec // Explicitly requested for self-signed servers
+		ServerName:         t.ServerName,
+	}
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", t.CAFile)
+		}
+		config.RootCAs = pool
+	}
+	if t.CertFile != "" || t.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// capabilities describes the server; unset fields fall back to what Whisper-based
+// servers usually offer
+func (c OpenAICompatibleConfig) capabilities() interfaces.ModelCapabilities {
+	displayName := c.DisplayName
+	if displayName == "" {
+		displayName = c.ID
+	}
+	description := c.Description
+	if description == "" {
+		description = "Self-hosted server speaking the OpenAI transcription API at " + c.BaseURL
+	}
+
+	formats := c.Formats
+	if len(formats) == 0 {
+		formats = []string{"flac", "mp3", "mp4", "mpeg", "mpga", "m4a", "ogg", "wav", "webm"}
+	}
+
+	features := map[string]bool{
+		"timestamps":         true,
+		"word_level":         c.WordTimestamps,
+		"diarization":        false,
+		"translation":        false,
+		"language_detection": true,
+	}
+	for name, enabled := range c.Features {
+		features[name] = enabled
+	}
+
+	return interfaces.ModelCapabilities{
+		ModelID:            c.ID,
+		ModelFamily:        FamilyOpenAICompatible,
+		DisplayName:        displayName,
+		Description:        description,
+		Version:            "v1",
+		SupportedLanguages: c.Languages,
+		SupportedFormats:   formats,
+		RequiresGPU:        false,
+		MemoryRequirement:  0, // Runs on its own server
+		Features:           features,
+		Metadata: map[string]string{
+			"provider": FamilyOpenAICompatible,
+			"api_url":  strings.TrimRight(c.BaseURL, "/") + "/audio/transcriptions",
+		},
+		Chunking: interfaces.ChunkLimits{
+			MaxFileSizeBytes:     int64(c.MaxFileSizeMB) * 1024 * 1024,
+			MaxDurationSeconds:   c.MaxDurationSecs,
+			ChunkDurationSeconds: c.ChunkDurationSec,
+		},
+	}
+}
+
+// compatibleSchema lists the parameters the OpenAI transcription API accepts
+func compatibleSchema(models []string, defaultModel string) []interfaces.ParameterSchema {
+	return []interfaces.ParameterSchema{
+		{
+			Name:        "api_key",
+			Type:        "string",
+			Required:    false,
+			Description: "API key (overrides the configured key)",
+			Group:       "authentication",
+		},
+		{
+			Name:        "model",
+			Type:        "string",
+			Required:    false,
+			Default:     defaultModel,
+			Options:     models,
+			Description: "Model served by the server",
+			Group:       "basic",
+		},
+		{
+			Name:        "language",
+			Type:        "string",
+			Required:    false,
+			Description: "Language of the input audio (ISO-639-1)",
+			Group:       "basic",
+		},
+		{
+			Name:        "prompt",
+			Type:        "string",
+			Required:    false,
+			Description: "Optional text to guide the model's style",
+			Group:       "advanced",
+		},
+		{
+			Name:        "temperature",
+			Type:        "float",
+			Required:    false,
+			Default:     0.0,
+			Min:         &[]float64{0.0}[0],
+			Max:         &[]float64{1.0}[0],
+			Description: "Sampling temperature",
+			Group:       "quality",
+		},
+	}
+}
+
+// GetCapabilities returns the server capabilities with the models it offers
+func (a *OpenAICompatibleAdapter) GetCapabilities() interfaces.ModelCapabilities {
+	capabilities := a.BaseAdapter.GetCapabilities()
+
+	metadata := make(map[string]string, len(capabilities.Metadata)+1)
+	for k, v := range capabilities.Metadata {
+		metadata[k] = v
+	}
+	metadata["models"] = strings.Join(a.GetSupportedModels(), ",")
+	if defaultModel := a.getDefaultModel(); defaultModel != "" {
+		metadata["default_model"] = defaultModel
+	}
+	capabilities.Metadata = metadata
+	return capabilities
+}
+
+// GetSupportedModels returns the configured or discovered models
+func (a *OpenAICompatibleAdapter) GetSupportedModels() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return append([]string(nil), a.models...)
+}
+
+// PrepareEnvironment discovers the models of servers configured without a list.
+// An unreachable server is not fatal, it may come up later.
+func (a *OpenAICompatibleAdapter) PrepareEnvironment(ctx context.Context) error {
+	a.initialized = true
+	if len(a.config.Models) > 0 {
+		return nil
+	}
+
+	models, err := a.DiscoverModels(ctx)
+	if err != nil {
+		logger.Warn("Could not discover models of OpenAI-compatible server", "id", a.config.ID, "error", err)
+		return nil
+	}
+
+	a.mu.Lock()
+	a.models = models
+	a.mu.Unlock()
+
+	a.modelMu.Lock()
+	if a.defaultModel == "" && len(models) > 0 {
+		a.defaultModel = models[0]
+	}
+	a.modelMu.Unlock()
+	logger.Info("Discovered models of OpenAI-compatible server", "id", a.config.ID, "models", models)
+	return nil
+}
+
+// DiscoverModels asks the server for its models via GET /models
+func (a *OpenAICompatibleAdapter) DiscoverModels(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/models", nil)
+	if err != nil {
+		return nil, err
+	}
+	a.setRequestHeaders(req, a.apiKey)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("model list request failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var list struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode model list: %w", err)
+	}
+
+	models := make([]string, 0, len(list.Data))
+	for _, m := range list.Data {
+		if m.ID != "" {
+			models = append(models, m.ID)
+		}
+	}
+	sort.Strings(models)
+	return models, nil
+}
@@ -19,6 +19,7 @@ import (
 // ParakeetAdapter implements the TranscriptionAdapter interface for NVIDIA Parakeet
 type ParakeetAdapter struct {
 	*BaseAdapter
+	customModelSet
 	envPath string
 }
 
@@ -49,9 +50,20 @@ func NewParakeetAdapter(envPath string) *ParakeetAdapter {
 			"sample_rate": "16000",
 			"format":      "16khz_mono_wav",
 		},
+		// Long audio is chunked by the buffered inference script, not the splitter
+		Chunking: interfaces.ChunkLimits{
+			ChunkDurationSeconds: float64(parakeetChunkSeconds()),
+		},
 	}
 
 	schema := []interfaces.ParameterSchema{
+		{
+			Name:        "model",
+			Type:        "string",
+			Required:    false,
+			Description: "Custom model registered for Parakeet, the bundled parakeet-tdt-0.6b-v3 when empty",
+			Group:       "basic",
+		},
 		// Core settings
 		{
 			Name:        "timestamps",
@@ -93,6 +105,17 @@ func NewParakeetAdapter(envPath string) *ParakeetAdapter {
 			Group:       "advanced",
 		},
 
+		{
+			Name:        "chunk_length",
+			Type:        "int",
+			Required:    false,
+			Default:     parakeetChunkSeconds(),
+			Min:         &[]float64{30}[0],
+			Max:         &[]float64{3600}[0],
+			Description: "Audio longer than this many seconds is transcribed in buffered chunks of this length",
+			Group:       "advanced",
+		},
+
 		// Audio preprocessing
 		{
 			Name:        "auto_convert_audio",
@@ -118,7 +141,7 @@ func NewParakeetAdapter(envPath string) *ParakeetAdapter {
 
 // GetSupportedModels returns the specific Parakeet model available
 func (p *ParakeetAdapter) GetSupportedModels() []string {
-	return []string{"parakeet-tdt-0.6b-v3"}
+	return p.withCustomModels([]string{"parakeet-tdt-0.6b-v3"})
 }
 
 // PrepareEnvironment sets up the Parakeet environment
@@ -314,12 +337,10 @@ func (p *ParakeetAdapter) Transcribe(ctx context.Context, input interfaces.Audio
 		}
 	}
 
-	// Get chunk threshold from environment (default: 300 seconds = 5 minutes)
-	chunkThreshold := 300
-	if thresholdStr := os.Getenv("PARAKEET_CHUNK_THRESHOLD_SECS"); thresholdStr != "" {
-		if parsed, err := strconv.Atoi(thresholdStr); err == nil && parsed > 0 {
-			chunkThreshold = parsed
-		}
+	// Chunk threshold from the profile, or the adapter default
+	chunkThreshold := p.GetIntParameter(params, "chunk_length")
+	if chunkThreshold <= 0 {
+		chunkThreshold = parakeetChunkSeconds()
 	}
 
 	// Choose processing path based on audio duration
@@ -342,7 +363,7 @@ func (p *ParakeetAdapter) Transcribe(ctx context.Context, input interfaces.Audio
 	}
 
 	result.ProcessingTime = time.Since(startTime)
-	result.ModelUsed = "parakeet-tdt-0.6b-v3"
+	result.ModelUsed = p.modelUsed(params, "parakeet-tdt-0.6b-v3")
 	result.Metadata = p.CreateDefaultMetadata(params)
 
 	logger.Info("Parakeet transcription completed",
@@ -383,23 +404,18 @@ func (p *ParakeetAdapter) transcribeStandard(ctx context.Context, input interfac
 		return nil, fmt.Errorf("failed to build command: %w", err)
 	}
 
-	// Execute Parakeet
-	cmd := exec.CommandContext(ctx, "uv", args...)
-	cmd.Env = append(os.Environ(), "PYTHONUNBUFFERED=1")
-
 	// Setup log file
 	logFile, err := os.OpenFile(filepath.Join(outputDir, "transcription.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		logger.Warn("Failed to create log file", "error", err)
 	} else {
 		defer logFile.Close()
-		cmd.Stdout = logFile
-		cmd.Stderr = logFile
 	}
 
 	logger.Info("Executing Parakeet command", "args", strings.Join(args, " "))
 
-	if err := cmd.Run(); err != nil {
+	// Execute Parakeet
+	if err := runUV(ctx, "parakeet", args, []string{"PYTHONUNBUFFERED=1"}, logFile); err != nil {
 		if ctx.Err() == context.Canceled {
 			return nil, fmt.Errorf("transcription was cancelled")
 		}
@@ -432,23 +448,18 @@ func (p *ParakeetAdapter) transcribeBuffered(ctx context.Context, input interfac
 		return nil, fmt.Errorf("failed to build buffered command: %w", err)
 	}
 
-	// Execute buffered inference
-	cmd := exec.CommandContext(ctx, "uv", args...)
-	cmd.Env = append(os.Environ(), "PYTHONUNBUFFERED=1")
-
 	// Setup log file
 	logFile, err := os.OpenFile(filepath.Join(outputDir, "transcription.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		logger.Warn("Failed to create log file", "error", err)
 	} else {
 		defer logFile.Close()
-		cmd.Stdout = logFile
-		cmd.Stderr = logFile
 	}
 
 	logger.Info("Executing Parakeet buffered inference", "args", strings.Join(args, " "))
 
-	if err := cmd.Run(); err != nil {
+	// Execute buffered inference
+	if err := runUV(ctx, "parakeet", args, []string{"PYTHONUNBUFFERED=1"}, logFile); err != nil {
 		if ctx.Err() == context.Canceled {
 			return nil, fmt.Errorf("transcription was cancelled")
 		}
@@ -484,6 +495,8 @@ func (p *ParakeetAdapter) buildParakeetArgs(input interfaces.AudioInput, params
 		"--output", outputFile,
 	}
 
+	args = append(args, p.customModelArgs(params)...)
+
 	// Add timestamps flag (Parakeet script supports --timestamps)
 	if p.GetBoolParameter(params, "timestamps") {
 		args = append(args, "--timestamps")
@@ -587,11 +600,7 @@ func (p *ParakeetAdapter) copyBufferedScript() error {
 func (p *ParakeetAdapter) buildBufferedArgs(input interfaces.AudioInput, params map[string]interface{}, tempDir string) ([]string, error) {
 	outputFile := filepath.Join(tempDir, "result.json")
 
-	// Get chunk threshold from environment (default: 300 seconds = 5 minutes)
-	chunkDuration := "300"
-	if thresholdStr := os.Getenv("PARAKEET_CHUNK_THRESHOLD_SECS"); thresholdStr != "" {
-		chunkDuration = thresholdStr
-	}
+	chunkDuration := strconv.Itoa(p.GetIntParameter(params, "chunk_length"))
 
 	scriptPath := filepath.Join(p.envPath, "parakeet_transcribe_buffered.py")
 	args := []string{
@@ -600,6 +609,7 @@ func (p *ParakeetAdapter) buildBufferedArgs(input interfaces.AudioInput, params
 		"--output", outputFile,
 		"--chunk-len", chunkDuration,
 	}
+	args = append(args, p.customModelArgs(params)...)
 
 	return args, nil
 }
@@ -618,3 +628,14 @@ func (p *ParakeetAdapter) GetEstimatedProcessingTime(input interfaces.AudioInput
 	// Parakeet typically processes at about 30% of audio duration
 	return time.Duration(float64(baseTime) * 1.5)
 }
+
+// parakeetChunkSeconds returns the default buffered chunk length. It can be set
+// with PARAKEET_CHUNK_THRESHOLD_SECS (default: 300 seconds = 5 minutes).
+func parakeetChunkSeconds() int {
+	if thresholdStr := os.Getenv("PARAKEET_CHUNK_THRESHOLD_SECS"); thresholdStr != "" {
+		if parsed, err := strconv.Atoi(thresholdStr); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return 300
+}
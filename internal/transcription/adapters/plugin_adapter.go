@@ -0,0 +1,530 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/pkg/logger"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PluginProtocolVersion is the version of the request and result documents
+// exchanged with plugins. See PLUGINS.md.
+const PluginProtocolVersion = 1
+
+// Plugin types
+const (
+	PluginTypeTranscription = "transcription"
+	PluginTypeDiarization   = "diarization"
+)
+
+// pluginManifestNames are the file names a plugin directory is recognised by
+var pluginManifestNames = []string{"plugin.json", "plugin.yaml", "plugin.yml"}
+
+// PluginManifest describes an external adapter
+type PluginManifest struct {
+	ID             string                       `json:"id"`
+	Type           string                       `json:"type"` // transcription or diarization
+	Capabilities   interfaces.ModelCapabilities `json:"capabilities"`
+	Parameters     []interfaces.ParameterSchema `json:"parameters"`
+	Models         []string                     `json:"models"`
+	Command        []string                     `json:"command"` // Argument templates, see PluginTemplateData
+	Env            map[string]string            `json:"env"`
+	Setup          PluginSetup                  `json:"setup"`
+	TimeoutSeconds int                          `json:"timeout_seconds"` // 0 for no limit besides job cancellation
+	MinSpeakers    int                          `json:"min_speakers"`    // Diarization plugins only
+	MaxSpeakers    int                          `json:"max_speakers"`
+
+	// Dir is the plugin directory, commands run there
+	Dir string `json:"-"`
+}
+
+// PluginSetup prepares the environment of a plugin at startup
+type PluginSetup struct {
+	Check          []string `json:"check"`   // Exits with 0 when the environment is ready
+	Command        []string `json:"command"` // Installs the environment, run when the check fails
+	TimeoutSeconds int      `json:"timeout_seconds"`
+}
+
+// PluginTemplateData is available to command templates, e.g. {{.Request}}.
+// Arguments that render to an empty string are left out.
+type PluginTemplateData struct {
+	PluginDir string                 // Directory of the manifest
+	WorkDir   string                 // Scratch directory of this call
+	Request   string                 // Path of the request document
+	Output    string                 // Path the result document must be written to
+	Input     string                 // Path of the audio file
+	Params    map[string]interface{} // Declared parameters with defaults applied
+}
+
+// PluginRequest is written to the request document before the command runs
+type PluginRequest struct {
+	Version    int                    `json:"version"`
+	Type       string                 `json:"type"`
+	JobID      string                 `json:"job_id"`
+	Input      PluginInput            `json:"input"`
+	Params     map[string]interface{} `json:"params"`
+	OutputPath string                 `json:"output_path"`
+}
+
+// PluginInput describes the audio handed to a plugin
+type PluginInput struct {
+	Path            string  `json:"path"`
+	Format          string  `json:"format"`
+	SampleRate      int     `json:"sample_rate"`
+	Channels        int     `json:"channels"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// pluginTranscriptionResult is the result document of a transcription plugin
+type pluginTranscriptionResult struct {
+	Text         string                         `json:"text"`
+	Language     string                         `json:"language"`
+	Segments     []interfaces.TranscriptSegment `json:"segments"`
+	WordSegments []interfaces.TranscriptWord    `json:"word_segments"`
+	Error        string                         `json:"error"`
+}
+
+// pluginDiarizationResult is the result document of a diarization plugin
+type pluginDiarizationResult struct {
+	Segments []interfaces.DiarizationSegment `json:"segments"`
+	Error    string                          `json:"error"`
+}
+
+// LoadPluginManifests reads the manifest of every plugin directory directly
+// below dir. A missing directory holds no plugins. Broken manifests are
+// reported in errs and skipped.
+func LoadPluginManifests(dir string) (manifests []PluginManifest, errs []error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, []error{fmt.Errorf("failed to read plugins directory: %w", err)}
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		pluginDir := filepath.Join(dir, entry.Name())
+		for _, name := range pluginManifestNames {
+			path := filepath.Join(pluginDir, name)
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+			manifest, err := ReadPluginManifest(path)
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				manifests = append(manifests, manifest)
+			}
+			break
+		}
+	}
+	return manifests, errs
+}
+
+// ReadPluginManifest parses and validates a JSON or YAML manifest
+func ReadPluginManifest(path string) (PluginManifest, error) {
+	var manifest PluginManifest
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return manifest, fmt.Errorf("failed to read plugin manifest: %w", err)
+	}
+
+	// YAML is converted to JSON so both formats share the JSON field names
+	if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+		var doc interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return manifest, fmt.Errorf("invalid plugin manifest %s: %w", path, err)
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return manifest, fmt.Errorf("invalid plugin manifest %s: %w", path, err)
+		}
+	}
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("invalid plugin manifest %s: %w", path, err)
+	}
+	if manifest.Dir, err = filepath.Abs(filepath.Dir(path)); err != nil {
+		return manifest, err
+	}
+
+	if err := manifest.validate(); err != nil {
+		return manifest, fmt.Errorf("invalid plugin manifest %s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+func (m *PluginManifest) validate() error {
+	if m.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	if m.Type != PluginTypeTranscription && m.Type != PluginTypeDiarization {
+		return fmt.Errorf("type must be %q or %q", PluginTypeTranscription, PluginTypeDiarization)
+	}
+	if len(m.Command) == 0 {
+		return fmt.Errorf("command is required")
+	}
+	for _, arg := range append(append([]string{}, m.Command...), m.Setup.Check...) {
+		if _, err := template.New("arg").Option("missingkey=zero").Parse(arg); err != nil {
+			return fmt.Errorf("bad template %q: %w", arg, err)
+		}
+	}
+	return nil
+}
+
+// PluginAdapter runs an external program described by a manifest. It
+// implements both TranscriptionAdapter and DiarizationAdapter; the manifest
+// type decides which one it is registered as.
+type PluginAdapter struct {
+	*BaseAdapter
+	manifest PluginManifest
+}
+
+// NewPluginAdapter creates an adapter from a validated manifest
+func NewPluginAdapter(manifest PluginManifest) *PluginAdapter {
+	capabilities := manifest.Capabilities
+	capabilities.ModelID = manifest.ID
+	if capabilities.ModelFamily == "" {
+		capabilities.ModelFamily = manifest.ID
+	}
+	if capabilities.DisplayName == "" {
+		capabilities.DisplayName = manifest.ID
+	}
+
+	metadata := map[string]string{}
+	for k, v := range capabilities.Metadata {
+		metadata[k] = v
+	}
+	metadata["plugin"] = "true"
+	metadata["plugin_type"] = manifest.Type
+	metadata["plugin_dir"] = manifest.Dir
+	if len(manifest.Models) > 0 {
+		metadata["models"] = strings.Join(manifest.Models, ",")
+	}
+	for _, param := range manifest.Parameters {
+		if name, ok := param.Default.(string); ok && param.Name == "model" {
+			metadata["default_model"] = name
+		}
+	}
+	capabilities.Metadata = metadata
+
+	return &PluginAdapter{
+		BaseAdapter: NewBaseAdapter(manifest.ID, "", capabilities, manifest.Parameters),
+		manifest:    manifest,
+	}
+}
+
+// Manifest returns the manifest the adapter was created from
+func (p *PluginAdapter) Manifest() PluginManifest {
+	return p.manifest
+}
+
+// GetSupportedModels returns the models listed in the manifest
+func (p *PluginAdapter) GetSupportedModels() []string {
+	return p.manifest.Models
+}
+
+// GetMaxSpeakers returns the speaker limit declared by a diarization plugin
+func (p *PluginAdapter) GetMaxSpeakers() int {
+	return p.manifest.MaxSpeakers
+}
+
+// GetMinSpeakers returns the speaker minimum declared by a diarization plugin
+func (p *PluginAdapter) GetMinSpeakers() int {
+	if p.manifest.MinSpeakers < 1 {
+		return 1
+	}
+	return p.manifest.MinSpeakers
+}
+
+// PrepareEnvironment runs the setup command unless the check says the
+// environment is ready
+func (p *PluginAdapter) PrepareEnvironment(ctx context.Context) error {
+	setup := p.manifest.Setup
+	if setup.TimeoutSeconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(setup.TimeoutSeconds)*time.Second)
+		defer cancel()
+	}
+	data := PluginTemplateData{PluginDir: p.manifest.Dir, Params: map[string]interface{}{}}
+
+	if len(setup.Check) > 0 {
+		args, err := renderPluginArgs(setup.Check, data)
+		if err != nil {
+			return err
+		}
+		if p.command(ctx, args).Run() == nil {
+			p.initialized = true
+			return nil
+		}
+	}
+
+	if len(setup.Command) > 0 {
+		args, err := renderPluginArgs(setup.Command, data)
+		if err != nil {
+			return err
+		}
+		logger.Info("Setting up plugin environment", "plugin", p.manifest.ID, "command", strings.Join(args, " "))
+		if out, err := p.command(ctx, args).CombinedOutput(); err != nil {
+			return fmt.Errorf("plugin %s setup failed: %w: %s", p.manifest.ID, err, strings.TrimSpace(string(out)))
+		}
+	}
+
+	p.initialized = true
+	return nil
+}
+
+// Transcribe runs a transcription plugin
+func (p *PluginAdapter) Transcribe(ctx context.Context, input interfaces.AudioInput, params map[string]interface{}, procCtx interfaces.ProcessingContext) (*interfaces.TranscriptResult, error) {
+	startTime := time.Now()
+	p.LogProcessingStart(input, procCtx)
+	defer func() {
+		p.LogProcessingEnd(procCtx, time.Since(startTime), nil)
+	}()
+
+	var output pluginTranscriptionResult
+	params, err := p.run(ctx, PluginTypeTranscription, input, params, procCtx, &output)
+	if err != nil {
+		return nil, err
+	}
+
+	text := output.Text
+	if text == "" {
+		parts := make([]string, 0, len(output.Segments))
+		for _, seg := range output.Segments {
+			parts = append(parts, strings.TrimSpace(seg.Text))
+		}
+		text = strings.Join(parts, " ")
+	}
+
+	model := p.manifest.ID
+	if name, ok := params["model"].(string); ok && name != "" {
+		model = name
+	}
+
+	return &interfaces.TranscriptResult{
+		Text:           text,
+		Language:       output.Language,
+		Segments:       output.Segments,
+		WordSegments:   output.WordSegments,
+		ProcessingTime: time.Since(startTime),
+		ModelUsed:      model,
+		Metadata:       p.CreateDefaultMetadata(params),
+	}, nil
+}
+
+// Diarize runs a diarization plugin
+func (p *PluginAdapter) Diarize(ctx context.Context, input interfaces.AudioInput, params map[string]interface{}, procCtx interfaces.ProcessingContext) (*interfaces.DiarizationResult, error) {
+	startTime := time.Now()
+	p.LogProcessingStart(input, procCtx)
+	defer func() {
+		p.LogProcessingEnd(procCtx, time.Since(startTime), nil)
+	}()
+
+	var output pluginDiarizationResult
+	params, err := p.run(ctx, PluginTypeDiarization, input, params, procCtx, &output)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var speakers []string
+	for _, seg := range output.Segments {
+		if !seen[seg.Speaker] {
+			seen[seg.Speaker] = true
+			speakers = append(speakers, seg.Speaker)
+		}
+	}
+	sort.Strings(speakers)
+
+	return &interfaces.DiarizationResult{
+		Segments:       output.Segments,
+		SpeakerCount:   len(speakers),
+		Speakers:       speakers,
+		ProcessingTime: time.Since(startTime),
+		ModelUsed:      p.manifest.ID,
+		Metadata:       p.CreateDefaultMetadata(params),
+	}, nil
+}
+
+// run writes the request, runs the command and decodes the result into out.
+// It returns the parameters that were sent.
+func (p *PluginAdapter) run(ctx context.Context, kind string, input interfaces.AudioInput, params map[string]interface{}, procCtx interfaces.ProcessingContext, out interface{}) (map[string]interface{}, error) {
+	if p.manifest.Type != kind {
+		return nil, fmt.Errorf("plugin %s is a %s plugin", p.manifest.ID, p.manifest.Type)
+	}
+	if err := p.ValidateAudioInput(input); err != nil {
+		return nil, fmt.Errorf("invalid audio input: %w", err)
+	}
+
+	params = p.declaredParams(params)
+	if err := p.ValidateParameters(params); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	workDir, err := p.CreateTempDirectory(procCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer p.CleanupTempDirectory(workDir)
+
+	// The command runs in the plugin directory, so relative paths would break
+	if workDir, err = filepath.Abs(workDir); err != nil {
+		return nil, err
+	}
+	inputPath, err := filepath.Abs(input.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	data := PluginTemplateData{
+		PluginDir: p.manifest.Dir,
+		WorkDir:   workDir,
+		Request:   filepath.Join(workDir, "request.json"),
+		Output:    filepath.Join(workDir, "result.json"),
+		Input:     inputPath,
+		Params:    params,
+	}
+
+	request, err := json.MarshalIndent(PluginRequest{
+		Version: PluginProtocolVersion,
+		Type:    kind,
+		JobID:   procCtx.JobID,
+		Input: PluginInput{
+			Path:            inputPath,
+			Format:          input.Format,
+			SampleRate:      input.SampleRate,
+			Channels:        input.Channels,
+			DurationSeconds: input.Duration.Seconds(),
+		},
+		Params:     params,
+		OutputPath: data.Output,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+	if err := os.WriteFile(data.Request, request, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write plugin request: %w", err)
+	}
+
+	args, err := renderPluginArgs(p.manifest.Command, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.manifest.TimeoutSeconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(p.manifest.TimeoutSeconds)*time.Second)
+		defer cancel()
+	}
+
+	logPath := filepath.Join(procCtx.OutputDirectory, "transcription.log")
+	cmd := p.command(ctx, args)
+	if logFile, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+		logger.Warn("Failed to create log file", "error", err)
+	} else {
+		defer logFile.Close()
+		cmd.Stdout = logFile
+		cmd.Stderr = logFile
+	}
+
+	logger.Info("Executing plugin", "plugin", p.manifest.ID, "args", strings.Join(args, " "))
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.Canceled {
+			return nil, fmt.Errorf("transcription was cancelled")
+		}
+		logTail, _ := p.ReadLogTail(logPath, 2048)
+		if message := readPluginError(data.Output); message != "" {
+			return nil, fmt.Errorf("plugin %s failed: %s", p.manifest.ID, message)
+		}
+		return nil, fmt.Errorf("plugin %s failed: %w\nLogs:\n%s", p.manifest.ID, err, logTail)
+	}
+
+	result, err := os.ReadFile(data.Output)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s wrote no result: %w", p.manifest.ID, err)
+	}
+	if err := json.Unmarshal(result, out); err != nil {
+		return nil, fmt.Errorf("plugin %s wrote an invalid result: %w", p.manifest.ID, err)
+	}
+	if message := readPluginError(data.Output); message != "" {
+		return nil, fmt.Errorf("plugin %s failed: %s", p.manifest.ID, message)
+	}
+
+	return params, nil
+}
+
+// declaredParams keeps the parameters the manifest declares and fills in
+// defaults for the ones that are missing or empty
+func (p *PluginAdapter) declaredParams(params map[string]interface{}) map[string]interface{} {
+	declared := make(map[string]interface{}, len(p.manifest.Parameters))
+	for _, schema := range p.manifest.Parameters {
+		if value, ok := params[schema.Name]; ok && value != nil && value != "" {
+			declared[schema.Name] = value
+		} else if schema.Default != nil {
+			declared[schema.Name] = schema.Default
+		}
+	}
+	return declared
+}
+
+// command prepares a plugin program to run in the plugin directory
+func (p *PluginAdapter) command(ctx context.Context, args []string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = p.manifest.Dir
+	cmd.Env = append(os.Environ(), "SCRIBERR_PLUGIN_DIR="+p.manifest.Dir, "PYTHONUNBUFFERED=1")
+	for k, v := range p.manifest.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	return cmd
+}
+
+// renderPluginArgs expands the argument templates, dropping empty arguments
+func renderPluginArgs(templates []string, data PluginTemplateData) ([]string, error) {
+	args := make([]string, 0, len(templates))
+	for _, text := range templates {
+		tmpl, err := template.New("arg").Option("missingkey=zero").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("bad template %q: %w", text, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to expand %q: %w", text, err)
+		}
+		if arg := buf.String(); arg != "" && arg != "<no value>" {
+			args = append(args, arg)
+		}
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("plugin command is empty")
+	}
+	return args, nil
+}
+
+// readPluginError returns the error a plugin reported in its result document
+func readPluginError(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var result struct {
+		Error string `json:"error"`
+	}
+	_ = json.Unmarshal(data, &result)
+	return result.Error
+}
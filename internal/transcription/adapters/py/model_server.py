@@ -0,0 +1,160 @@
+#!/usr/bin/env python3
+"""
+Long-lived model server for Scriberr adapters.
+
+Runs the adapter scripts in-process so the models they load stay in memory
+between jobs. Requests arrive as JSON-RPC 2.0 messages, one per line on stdin,
+and responses are written one per line to the original stdout. Everything the
+scripts print goes to stderr, which Scriberr copies into the job log.
+
+Methods:
+  ping                          -> {"status": "ok", "cached_models": n}
+  run {script|module, argv, env} -> {"exit_code": 0}
+  shutdown                      -> {"status": "bye"}, then exits
+"""
+
+import contextlib
+import functools
+import importlib
+import json
+import os
+import runpy
+import sys
+import traceback
+
+# Loaders whose results are kept between requests: (module, class or None, function)
+# Subclasses come before their base classes, so each is wrapped with its own binding
+MODEL_LOADERS = [
+    ("nemo.collections.asr.models", "SortformerEncLabelModel", "restore_from"),
+    ("nemo.collections.asr.models", "SortformerEncLabelModel", "from_pretrained"),
+    ("nemo.collections.asr.models", "ASRModel", "restore_from"),
+    ("nemo.collections.asr.models", "ASRModel", "from_pretrained"),
+    ("pyannote.audio", "Pipeline", "from_pretrained"),
+    ("whisperx.asr", None, "load_model"),
+    ("whisperx.alignment", None, "load_align_model"),
+    # The WhisperX CLI imports the loaders by name
+    ("whisperx.transcribe", None, "load_model"),
+    ("whisperx.transcribe", None, "load_align_model"),
+    ("transformers", "AutoProcessor", "from_pretrained"),
+    ("transformers", "VoxtralForConditionalGeneration", "from_pretrained"),
+]
+
+_cache = {}
+
+
+def _cache_key(name, args, kwargs):
+    return repr((name, args, sorted(kwargs.items())))
+
+
+def _memoize(name, loader):
+    @functools.wraps(loader)
+    def cached(*args, **kwargs):
+        key = _cache_key(name, args, kwargs)
+        if key not in _cache:
+            print(f"[model_server] loading {name}", file=sys.stderr)
+            _cache[key] = loader(*args, **kwargs)
+        else:
+            print(f"[model_server] reusing loaded {name}", file=sys.stderr)
+        return _cache[key]
+
+    cached.__scriberr_memoized__ = True
+    return cached
+
+
+def install_model_cache():
+    """Wrap every loader available in this environment. Missing packages are skipped."""
+    for module_name, class_name, function_name in MODEL_LOADERS:
+        try:
+            module = importlib.import_module(module_name)
+        except Exception:
+            continue
+
+        owner = getattr(module, class_name, None) if class_name else module
+        if owner is None:
+            continue
+        loader = getattr(owner, function_name, None)
+        if loader is None or getattr(loader, "__scriberr_memoized__", False):
+            continue
+
+        name = ".".join(filter(None, [module_name, class_name, function_name]))
+        wrapped = _memoize(name, loader)
+        setattr(owner, function_name, staticmethod(wrapped) if class_name else wrapped)
+
+
+def run_script(params):
+    argv = [str(a) for a in params.get("argv", [])]
+    env = params.get("env") or {}
+
+    saved_argv = sys.argv
+    saved_env = {k: os.environ.get(k) for k in env}
+    os.environ.update({k: str(v) for k, v in env.items()})
+
+    exit_code = 0
+    try:
+        if params.get("module"):
+            sys.argv = [params["module"]] + argv
+            runpy.run_module(params["module"], run_name="__main__", alter_sys=True)
+        else:
+            sys.argv = [params["script"]] + argv
+            runpy.run_path(params["script"], run_name="__main__")
+    except SystemExit as e:
+        if isinstance(e.code, int):
+            exit_code = e.code
+        elif e.code is not None:
+            print(e.code, file=sys.stderr)
+            exit_code = 1
+    finally:
+        sys.argv = saved_argv
+        for k, v in saved_env.items():
+            if v is None:
+                os.environ.pop(k, None)
+            else:
+                os.environ[k] = v
+        sys.stderr.flush()
+
+    return {"exit_code": exit_code}
+
+
+def main():
+    # Keep the real stdout for responses and send fd 1 to stderr, so output of
+    # the scripts and of native libraries cannot corrupt the protocol
+    protocol = os.fdopen(os.dup(1), "w", buffering=1, encoding="utf-8")
+    os.dup2(2, 1)
+    sys.stdout = sys.stderr
+
+    install_model_cache()
+
+    for line in sys.stdin:
+        line = line.strip()
+        if not line:
+            continue
+
+        request_id = None
+        try:
+            request = json.loads(line)
+            request_id = request.get("id")
+            method = request.get("method")
+
+            if method == "ping":
+                result = {"status": "ok", "cached_models": len(_cache)}
+            elif method == "run":
+                with contextlib.redirect_stdout(sys.stderr):
+                    result = run_script(request.get("params") or {})
+                # Scripts may import loader modules only now
+                install_model_cache()
+            elif method == "shutdown":
+                protocol.write(json.dumps({"jsonrpc": "2.0", "id": request_id, "result": {"status": "bye"}}) + "\n")
+                return
+            else:
+                raise ValueError(f"unknown method {method}")
+
+            response = {"jsonrpc": "2.0", "id": request_id, "result": result}
+        except Exception as e:
+            traceback.print_exc(file=sys.stderr)
+            response = {"jsonrpc": "2.0", "id": request_id, "error": {"code": -32000, "message": str(e)}}
+
+        protocol.write(json.dumps(response) + "\n")
+
+
+if __name__ == "__main__":
+    main()
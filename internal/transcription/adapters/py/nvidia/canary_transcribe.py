@@ -11,22 +11,20 @@ from pathlib import Path
 import nemo.collections.asr as nemo_asr
 
 
-def transcribe_audio(
-    audio_path: str,
-    source_lang: str = "en",
-    target_lang: str = "en",
-    task: str = "transcribe",
-    timestamps: bool = True,
-    output_file: str = None,
-    include_confidence: bool = True,
-    preserve_formatting: bool = True,
-):
+def load_model(model: str, model_filename: str, name: str):
     """
-    Transcribe or translate audio using NVIDIA Canary model.
+    Load a custom checkpoint, given as a .nemo file or a pretrained model name,
+    or the bundled model from the project root.
     """
-    # Determine model path
-    model_filename = "canary-1b-v2.nemo"
-    model_path = None
+    if model:
+        if model.endswith(".nemo"):
+            if not os.path.exists(model):
+                print(f"Error during transcription: Can't find custom model: {model}")
+                sys.exit(1)
+            print(f"Loading custom {name} model from: {model}")
+            return nemo_asr.models.ASRModel.restore_from(model)
+        print(f"Loading pretrained {name} model: {model}")
+        return nemo_asr.models.ASRModel.from_pretrained(model)
 
     # Locate project root: derived from VIRTUAL_ENV, which is set by `uv run` to path/.venv
     virtual_env = os.environ.get("VIRTUAL_ENV")
@@ -41,8 +39,25 @@ def transcribe_audio(
         print(f"Error during transcription: Can't find {model_filename} in project root: {project_root}")
         sys.exit(1)
 
-    print(f"Loading NVIDIA Canary model from: {model_path}")
-    asr_model = nemo_asr.models.ASRModel.restore_from(model_path)
+    print(f"Loading {name} model from: {model_path}")
+    return nemo_asr.models.ASRModel.restore_from(model_path)
+
+
+def transcribe_audio(
+    audio_path: str,
+    source_lang: str = "en",
+    target_lang: str = "en",
+    task: str = "transcribe",
+    timestamps: bool = True,
+    output_file: str = None,
+    include_confidence: bool = True,
+    preserve_formatting: bool = True,
+    model: str = None,
+):
+    """
+    Transcribe or translate audio using NVIDIA Canary model.
+    """
+    asr_model = load_model(model, "canary-1b-v2.nemo", "NVIDIA Canary")
 
     print(f"Processing: {audio_path}")
     print(f"Task: {task}")
@@ -176,6 +191,10 @@ def main():
         "--preserve-formatting", action="store_true", default=True,
         help="Preserve punctuation and capitalization"
     )
+    parser.add_argument(
+        "--model",
+        help="Custom checkpoint: a .nemo file or a pretrained model name (default: bundled model)",
+    )
 
     args = parser.parse_args()
 
@@ -194,6 +213,7 @@ def main():
             output_file=args.output,
             include_confidence=args.include_confidence,
             preserve_formatting=args.preserve_formatting,
+            model=args.model,
         )
     except Exception as e:
         print(f"Error during transcription: {e}")
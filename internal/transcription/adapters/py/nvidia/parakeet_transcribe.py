@@ -11,20 +11,20 @@ from pathlib import Path
 import nemo.collections.asr as nemo_asr
 
 
-def transcribe_audio(
-    audio_path: str,
-    timestamps: bool = True,
-    output_file: str = None,
-    context_left: int = 256,
-    context_right: int = 256,
-    include_confidence: bool = True,
-):
+def load_model(model: str, model_filename: str, name: str):
     """
-    Transcribe audio using NVIDIA Parakeet model.
+    Load a custom checkpoint, given as a .nemo file or a pretrained model name,
+    or the bundled model from the project root.
     """
-    # Determine model path
-    model_filename = "parakeet-tdt-0.6b-v3.nemo"
-    model_path = None
+    if model:
+        if model.endswith(".nemo"):
+            if not os.path.exists(model):
+                print(f"Error during transcription: Can't find custom model: {model}")
+                sys.exit(1)
+            print(f"Loading custom {name} model from: {model}")
+            return nemo_asr.models.ASRModel.restore_from(model)
+        print(f"Loading pretrained {name} model: {model}")
+        return nemo_asr.models.ASRModel.from_pretrained(model)
 
     # Locate project root: derived from VIRTUAL_ENV, which is set by `uv run` to path/.venv
     virtual_env = os.environ.get("VIRTUAL_ENV")
@@ -39,8 +39,23 @@ def transcribe_audio(
         print(f"Error during transcription: Can't find {model_filename} in project root: {project_root}")
         sys.exit(1)
 
-    print(f"Loading NVIDIA Parakeet model from: {model_path}")
-    asr_model = nemo_asr.models.ASRModel.restore_from(model_path)
+    print(f"Loading {name} model from: {model_path}")
+    return nemo_asr.models.ASRModel.restore_from(model_path)
+
+
+def transcribe_audio(
+    audio_path: str,
+    timestamps: bool = True,
+    output_file: str = None,
+    context_left: int = 256,
+    context_right: int = 256,
+    include_confidence: bool = True,
+    model: str = None,
+):
+    """
+    Transcribe audio using NVIDIA Parakeet model.
+    """
+    asr_model = load_model(model, "parakeet-tdt-0.6b-v3.nemo", "NVIDIA Parakeet")
 
     # Disable CUDA graphs to fix Error 35 on RTX 2000e Ada GPU
     # Uses change_decoding_strategy() to properly reconfigure the TDT decoder
@@ -162,6 +177,10 @@ def main():
         "--no-confidence", dest="include_confidence", action="store_false",
         help="Exclude confidence scores"
     )
+    parser.add_argument(
+        "--model",
+        help="Custom checkpoint: a .nemo file or a pretrained model name (default: bundled model)",
+    )
 
     args = parser.parse_args()
 
@@ -178,6 +197,7 @@ def main():
             context_left=args.context_left,
             context_right=args.context_right,
             include_confidence=args.include_confidence,
+            model=args.model,
         )
     except Exception as e:
         print(f"Error during transcription: {e}")
@@ -35,17 +35,20 @@ def split_audio_file(audio_path, chunk_duration_secs=300):
     return chunks, sr
 
 
-def transcribe_buffered(
-    audio_path: str,
-    output_file: str = None,
-    chunk_duration_secs: float = 300,  # 5 minutes default
-):
+def load_model(model: str, model_filename: str, name: str):
     """
-    Transcribe long audio by splitting into chunks and merging results.
+    Load a custom checkpoint, given as a .nemo file or a pretrained model name,
+    or the bundled model from the project root.
     """
-    # Determine model path
-    model_filename = "parakeet-tdt-0.6b-v3.nemo"
-    model_path = None
+    if model:
+        if model.endswith(".nemo"):
+            if not os.path.exists(model):
+                print(f"Error during transcription: Can't find custom model: {model}")
+                sys.exit(1)
+            print(f"Loading custom {name} model from: {model}")
+            return nemo_asr.models.ASRModel.restore_from(model)
+        print(f"Loading pretrained {name} model: {model}")
+        return nemo_asr.models.ASRModel.from_pretrained(model)
 
     # Locate project root: derived from VIRTUAL_ENV, which is set by `uv run` to path/.venv
     virtual_env = os.environ.get("VIRTUAL_ENV")
@@ -60,9 +63,20 @@ def transcribe_buffered(
         print(f"Error during transcription: Can't find {model_filename} in project root: {project_root}")
         sys.exit(1)
 
-    print(f"Loading NVIDIA Parakeet model from: {model_path}")
+    print(f"Loading {name} model from: {model_path}")
+    return nemo_asr.models.ASRModel.restore_from(model_path)
 
-    asr_model = nemo_asr.models.ASRModel.restore_from(model_path)
+
+def transcribe_buffered(
+    audio_path: str,
+    output_file: str = None,
+    chunk_duration_secs: float = 300,  # 5 minutes default
+    model: str = None,
+):
+    """
+    Transcribe long audio by splitting into chunks and merging results.
+    """
+    asr_model = load_model(model, "parakeet-tdt-0.6b-v3.nemo", "NVIDIA Parakeet")
 
     # Disable CUDA graphs to fix Error 35 on RTX 2000e Ada GPU
     # Uses change_decoding_strategy() to properly reconfigure the TDT decoder
@@ -164,6 +178,10 @@ def main():
         "--chunk-len", type=float, default=300,
         help="Chunk duration in seconds (default: 300 = 5 minutes)"
     )
+    parser.add_argument(
+        "--model",
+        help="Custom checkpoint: a .nemo file or a pretrained model name (default: bundled model)",
+    )
 
     args = parser.parse_args()
 
@@ -175,6 +193,7 @@ def main():
         audio_path=args.audio_file,
         output_file=args.output,
         chunk_duration_secs=args.chunk_len,
+        model=args.model,
     )
 
 
@@ -0,0 +1,90 @@
+#!/usr/bin/env python3
+"""
+PyAnnote speaker embedding script.
+Computes one voice embedding per audio clip for the speaker library.
+"""
+
+import argparse
+import json
+import sys
+
+import torch
+from pyannote.audio import Inference, Model
+
+# Fix for PyTorch 2.6+ which defaults weights_only=True
+# We need to allowlist PyAnnote's custom classes
+try:
+    from pyannote.audio.core.task import Specifications, Problem, Resolution
+    if hasattr(torch.serialization, "add_safe_globals"):
+        torch.serialization.add_safe_globals([Specifications, Problem, Resolution])
+except ImportError:
+    pass
+except Exception as e:
+    print(f"Warning: Could not add safe globals: {e}")
+
+
+def embed_clips(clips, output_file: str, hf_token: str, model: str):
+    """
+    Compute an embedding of the whole of each clip.
+    """
+    print(f"Loading PyAnnote embedding model: {model}")
+
+    try:
+        embedding_model = Model.from_pretrained(model, token=hf_token)
+        inference = Inference(embedding_model, window="whole")
+        if torch.cuda.is_available():
+            inference.to(torch.device("cuda"))
+            print("Using CUDA for embeddings")
+        print("Model loaded successfully")
+    except Exception as e:
+        print(f"Error loading model: {e}")
+        print("Make sure you have a valid Hugging Face token and have accepted the model's license")
+        sys.exit(1)
+
+    embeddings = []
+    try:
+        for clip in clips:
+            print(f"Embedding clip: {clip}")
+            embedding = inference(clip)
+            embeddings.append([float(value) for value in embedding.reshape(-1)])
+    except Exception as e:
+        print(f"Error computing embeddings: {e}")
+        sys.exit(1)
+
+    with open(output_file, "w") as f:
+        json.dump({"model": model, "embeddings": embeddings}, f)
+
+    print(f"Saved {len(embeddings)} embeddings to: {output_file}")
+
+
+def main():
+    parser = argparse.ArgumentParser(
+        description="Compute speaker embeddings using PyAnnote.audio"
+    )
+    parser.add_argument(
+        "clips",
+        nargs="+",
+        help="Paths to audio clips of a single speaker each"
+    )
+    parser.add_argument(
+        "--output", "-o",
+        required=True,
+        help="Output JSON file path"
+    )
+    parser.add_argument(
+        "--hf-token",
+        required=True,
+        help="Hugging Face access token"
+    )
+    parser.add_argument(
+        "--model",
+        default="pyannote/wespeaker-voxceleb-resnet34-LM",
+        help="PyAnnote embedding model to use"
+    )
+
+    args = parser.parse_args()
+    embed_clips(args.clips, args.output, args.hf_token, args.model)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,119 @@
+#!/usr/bin/env python3
+"""
+WhisperX forced alignment script.
+Times the words of an existing transcript against its audio.
+"""
+
+import argparse
+import json
+import sys
+
+import whisperx
+
+
+def align_transcript(
+    audio_path: str,
+    segments_file: str,
+    output_file: str,
+    language: str,
+    device: str = "cpu",
+    align_model: str = None,
+    interpolate_method: str = "nearest",
+):
+    """
+    Align each segment separately, so the words can be told apart per segment
+    even where WhisperX splits a segment into sentences.
+    """
+    with open(segments_file) as f:
+        segments = json.load(f)
+
+    print(f"Loading alignment model for language: {language}")
+    try:
+        model, metadata = whisperx.load_align_model(
+            language_code=language, device=device, model_name=align_model
+        )
+    except Exception as e:
+        print(f"Error loading alignment model: {e}")
+        sys.exit(1)
+
+    print(f"Processing: {audio_path}")
+    audio = whisperx.load_audio(audio_path)
+
+    aligned = []
+    for segment in segments:
+        words = []
+        if segment["text"].strip():
+            try:
+                result = whisperx.align(
+                    [segment],
+                    model,
+                    metadata,
+                    audio,
+                    device,
+                    interpolate_method=interpolate_method,
+                    return_char_alignments=False,
+                )
+                for sentence in result["segments"]:
+                    words.extend(sentence.get("words", []))
+            except Exception as e:
+                print(f"Could not align segment at {segment['start']:.2f}s: {e}")
+        aligned.append(words)
+
+    with open(output_file, "w") as f:
+        json.dump({"language": language, "segments": aligned}, f)
+
+    print(f"Aligned {len(aligned)} segments, saved to: {output_file}")
+
+
+def main():
+    parser = argparse.ArgumentParser(
+        description="Align transcript words with audio using WhisperX"
+    )
+    parser.add_argument("audio", help="Path to the audio file")
+    parser.add_argument(
+        "--segments",
+        required=True,
+        help="JSON file with the segments to align: a list of {start, end, text}"
+    )
+    parser.add_argument(
+        "--output", "-o",
+        required=True,
+        help="Output JSON file path"
+    )
+    parser.add_argument(
+        "--language",
+        required=True,
+        help="Language code of the transcript"
+    )
+    parser.add_argument(
+        "--device",
+        default="cpu",
+        choices=["cpu", "cuda"],
+        help="Device to use for computation"
+    )
+    parser.add_argument(
+        "--align-model",
+        default=None,
+        help="Custom alignment model, defaults to WhisperX's model for the language"
+    )
+    parser.add_argument(
+        "--interpolate-method",
+        default="nearest",
+        choices=["nearest", "linear", "ignore"],
+        help="How to time words that cannot be aligned"
+    )
+
+    args = parser.parse_args()
+    align_transcript(
+        args.audio,
+        args.segments,
+        args.output,
+        args.language,
+        device=args.device,
+        align_model=args.align_model,
+        interpolate_method=args.interpolate_method,
+    )
+
+
+if __name__ == "__main__":
+    main()
@@ -21,6 +21,9 @@ var pyannoteScripts embed.FS
 
 const OutputFormatJSON = "json"
 
+// pyannoteEmbeddingModel computes the voice embeddings of the speaker library
+const pyannoteEmbeddingModel = "pyannote/wespeaker-voxceleb-resnet34-LM"
+
 // PyAnnoteAdapter implements the DiarizationAdapter interface for PyAnnote
 type PyAnnoteAdapter struct {
 	*BaseAdapter
@@ -252,21 +255,24 @@ func (p *PyAnnoteAdapter) setupPyAnnoteEnvironment() error {
 	return nil
 }
 
-// copyDiarizationScript creates the Python script for PyAnnote diarization
+// copyDiarizationScript creates the Python scripts for PyAnnote diarization
+// and speaker embeddings
 func (p *PyAnnoteAdapter) copyDiarizationScript() error {
 	// Ensure the directory exists first
 	if err := os.MkdirAll(p.envPath, 0755); err != nil {
 		return fmt.Errorf("failed to create pyannote directory: %w", err)
 	}
 
-	scriptContent, err := pyannoteScripts.ReadFile("py/pyannote/pyannote_diarize.py")
-	if err != nil {
-		return fmt.Errorf("failed to read embedded pyannote_diarize.py: %w", err)
-	}
+	for _, script := range []string{"pyannote_diarize.py", "speaker_embed.py"} {
+		scriptContent, err := pyannoteScripts.ReadFile("py/pyannote/" + script)
+		if err != nil {
+			return fmt.Errorf("failed to read embedded %s: %w", script, err)
+		}
 
-	scriptPath := filepath.Join(p.envPath, "pyannote_diarize.py")
-	if err := os.WriteFile(scriptPath, scriptContent, 0755); err != nil {
-		return fmt.Errorf("failed to write diarization script: %w", err)
+		scriptPath := filepath.Join(p.envPath, script)
+		if err := os.WriteFile(scriptPath, scriptContent, 0755); err != nil {
+			return fmt.Errorf("failed to write %s: %w", script, err)
+		}
 	}
 
 	return nil
@@ -314,23 +320,18 @@ func (p *PyAnnoteAdapter) Diarize(ctx context.Context, input interfaces.AudioInp
 		return nil, fmt.Errorf("failed to build command: %w", err)
 	}
 
-	// Execute PyAnnote
-	cmd := exec.CommandContext(ctx, "uv", args...)
-	cmd.Env = append(os.Environ(), "PYTHONUNBUFFERED=1")
-
 	// Setup log file
 	logFile, err := os.OpenFile(filepath.Join(procCtx.OutputDirectory, "transcription.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		logger.Warn("Failed to create log file", "error", err)
 	} else {
 		defer logFile.Close()
-		cmd.Stdout = logFile
-		cmd.Stderr = logFile
 	}
 
 	logger.Info("Executing PyAnnote command", "args", strings.Join(args, " "))
 
-	if err := cmd.Run(); err != nil {
+	// Execute PyAnnote
+	if err := runUV(ctx, "pyannote", args, []string{"PYTHONUNBUFFERED=1"}, logFile); err != nil {
 		if ctx.Err() == context.Canceled {
 			return nil, fmt.Errorf("diarization was cancelled")
 		}
@@ -364,6 +365,74 @@ func (p *PyAnnoteAdapter) Diarize(ctx context.Context, input interfaces.AudioInp
 	return result, nil
 }
 
+// EmbeddingModel returns the model speaker embeddings are computed with
+func (p *PyAnnoteAdapter) EmbeddingModel() string {
+	return pyannoteEmbeddingModel
+}
+
+// EmbedSpeakers computes a voice embedding of each clip with the PyAnnote
+// embedding model
+func (p *PyAnnoteAdapter) EmbedSpeakers(ctx context.Context, clips []string, params map[string]interface{}, procCtx interfaces.ProcessingContext) ([][]float64, error) {
+	if len(clips) == 0 {
+		return nil, nil
+	}
+
+	hfToken := p.GetStringParameter(params, "hf_token")
+	if hfToken == "" {
+		hfToken = os.Getenv("HF_TOKEN")
+	}
+	if hfToken == "" {
+		return nil, fmt.Errorf("HuggingFace token is required for PyAnnote speaker embeddings. Set HF_TOKEN environment variable or provide it in the UI")
+	}
+
+	tempDir, err := p.CreateTempDirectory(procCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer p.CleanupTempDirectory(tempDir)
+
+	outputFile := filepath.Join(tempDir, "embeddings.json")
+	args := []string{
+		"run", "--native-tls", "--project", p.envPath, "python", filepath.Join(p.envPath, "speaker_embed.py"),
+		"--output", outputFile,
+		"--hf-token", hfToken,
+		"--model", pyannoteEmbeddingModel,
+	}
+	args = append(args, clips...)
+
+	var logFile *os.File
+	if procCtx.OutputDirectory != "" {
+		if logFile, err = os.OpenFile(filepath.Join(procCtx.OutputDirectory, "transcription.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+			logger.Warn("Failed to create log file", "error", err)
+		} else {
+			defer logFile.Close()
+		}
+	}
+
+	logger.Info("Computing speaker embeddings", "clips", len(clips), "model", pyannoteEmbeddingModel)
+	if err := runUV(ctx, "pyannote", args, []string{"PYTHONUNBUFFERED=1"}, logFile); err != nil {
+		if ctx.Err() == context.Canceled {
+			return nil, fmt.Errorf("speaker embedding was cancelled")
+		}
+		return nil, fmt.Errorf("PyAnnote speaker embedding failed: %w", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embeddings: %w", err)
+	}
+	var result struct {
+		Embeddings [][]float64 `json:"embeddings"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse embeddings: %w", err)
+	}
+	if len(result.Embeddings) != len(clips) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(clips), len(result.Embeddings))
+	}
+	return result.Embeddings, nil
+}
+
 // buildPyAnnoteArgs builds the command arguments for PyAnnote
 func (p *PyAnnoteAdapter) buildPyAnnoteArgs(input interfaces.AudioInput, params map[string]interface{}, tempDir string) ([]string, error) {
 	outputFormat := p.GetStringParameter(params, "output_format")
@@ -223,23 +223,18 @@ func (v *VoxtralAdapter) Transcribe(ctx context.Context, input interfaces.AudioI
 		return nil, fmt.Errorf("failed to build command: %w", err)
 	}
 
-	// Execute Voxtral
-	cmd := exec.CommandContext(ctx, "uv", args...)
-	cmd.Env = append(os.Environ(), "PYTHONUNBUFFERED=1")
-
 	// Setup log file
 	logFile, err := os.OpenFile(filepath.Join(procCtx.OutputDirectory, "transcription.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		logger.Warn("Failed to create log file", "error", err)
 	} else {
 		defer logFile.Close()
-		cmd.Stdout = logFile
-		cmd.Stderr = logFile
 	}
 
 	logger.Info("Executing Voxtral command", "args", strings.Join(args, " "))
 
-	if err := cmd.Run(); err != nil {
+	// Execute Voxtral
+	if err := runUV(ctx, "voxtral", args, []string{"PYTHONUNBUFFERED=1"}, logFile); err != nil {
 		if ctx.Err() == context.Canceled {
 			return nil, fmt.Errorf("transcription was cancelled")
 		}
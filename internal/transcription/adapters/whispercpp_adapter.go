@@ -0,0 +1,553 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/pkg/downloader"
+	"scriberr/pkg/logger"
+)
+
+// whisperCppModelURL is where GGML models missing from the models directory are downloaded from
+const whisperCppModelURL = "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-%s.bin?download=true"
+
+// whisperCppModelName guards the model name used in file names and download URLs
+var whisperCppModelName = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// WhisperCppAdapter implements the TranscriptionAdapter interface for the
+// whisper.cpp command-line program, which needs no Python environment
+type WhisperCppAdapter struct {
+	*BaseAdapter
+	customModelSet
+	binary    string // Configured binary name or path
+	modelsDir string
+
+	mu         sync.Mutex
+	binaryPath string                 // Resolved by PrepareEnvironment
+	modelLocks map[string]*sync.Mutex // One per model file, held while it is downloaded or removed
+}
+
+// NewWhisperCppAdapter creates a new whisper.cpp adapter. binary is the
+// whisper-cli (or older main) program; when empty it is looked up in PATH.
+// GGML model files are kept in modelsDir.
+func NewWhisperCppAdapter(binary, modelsDir string) *WhisperCppAdapter {
+	capabilities := interfaces.ModelCapabilities{
+		ModelID:     "whisper_cpp",
+		ModelFamily: "whisper_cpp",
+		DisplayName: "whisper.cpp",
+		Description: "Whisper on the CPU via the whisper.cpp command-line program and GGML models",
+		Version:     "1.0.0",
+		SupportedLanguages: []string{
+			"en", "zh", "de", "es", "ru", "ko", "fr", "ja", "pt", "tr", "pl", "ca", "nl",
+			"ar", "sv", "it", "id", "hi", "fi", "vi", "he", "uk", "el", "ms", "cs", "ro",
+			"da", "hu", "ta", "no", "th", "ur", "hr", "bg", "lt", "la", "mi", "ml", "cy",
+			"sk", "te", "fa", "lv", "bn", "sr", "az", "sl", "kn", "et", "mk", "br", "eu",
+			"is", "hy", "ne", "mn", "bs", "kk", "sq", "sw", "gl", "mr", "pa", "si", "km",
+		},
+		SupportedFormats:  []string{"wav"}, // 16 kHz WAV, produced by the format preprocessor
+		RequiresGPU:       false,
+		MemoryRequirement: 1024,
+		Features: map[string]bool{
+			"timestamps":         true,
+			"word_level":         true, // Token timestamps, coarser than forced alignment
+			"diarization":        false,
+			"translation":        true,
+			"language_detection": true,
+			"cpu_only":           true,
+		},
+		Metadata: map[string]string{
+			"engine":     "whisper.cpp",
+			"framework":  "ggml",
+			"license":    "MIT",
+			"models_dir": modelsDir,
+		},
+	}
+
+	schema := []interfaces.ParameterSchema{
+		{
+			Name:        "model",
+			Type:        "string",
+			Required:    false,
+			Default:     "small",
+			Options:     []string{"tiny", "tiny.en", "base", "base.en", "small", "small.en", "medium", "medium.en", "large-v2", "large-v3", "large-v3-turbo", "large-v3-turbo-q5_0"},
+			Description: "GGML model name, or the path of a model file",
+			Group:       "basic",
+		},
+		{
+			Name:        "language",
+			Type:        "string",
+			Required:    false,
+			Description: "Language of the audio, detected when empty",
+			Group:       "basic",
+		},
+		{
+			Name:        "task",
+			Type:        "string",
+			Required:    false,
+			Default:     "transcribe",
+			Options:     []string{"transcribe", "translate"},
+			Description: "Transcribe, or translate to English",
+			Group:       "basic",
+		},
+		{
+			Name:        "threads",
+			Type:        "int",
+			Required:    false,
+			Default:     0,
+			Min:         &[]float64{0}[0],
+			Max:         &[]float64{64}[0],
+			Description: "CPU threads, 0 for the whisper.cpp default",
+			Group:       "advanced",
+		},
+		{
+			Name:        "beam_size",
+			Type:        "int",
+			Required:    false,
+			Default:     5,
+			Min:         &[]float64{0}[0],
+			Max:         &[]float64{16}[0],
+			Description: "Beam size for beam search, 0 for the whisper.cpp default",
+			Group:       "quality",
+		},
+		{
+			Name:        "best_of",
+			Type:        "int",
+			Required:    false,
+			Default:     5,
+			Min:         &[]float64{0}[0],
+			Max:         &[]float64{16}[0],
+			Description: "Candidates when sampling with non-zero temperature, 0 for the whisper.cpp default",
+			Group:       "quality",
+		},
+		{
+			Name:        "temperature",
+			Type:        "float",
+			Required:    false,
+			Default:     0.0,
+			Min:         &[]float64{0.0}[0],
+			Max:         &[]float64{1.0}[0],
+			Description: "Sampling temperature",
+			Group:       "quality",
+		},
+		{
+			Name:        "temperature_increment_on_fallback",
+			Type:        "float",
+			Required:    false,
+			Default:     0.2,
+			Min:         &[]float64{0.0}[0],
+			Max:         &[]float64{1.0}[0],
+			Description: "Temperature increase when decoding fails, 0 disables fallback",
+			Group:       "quality",
+		},
+		{
+			Name:        "initial_prompt",
+			Type:        "string",
+			Required:    false,
+			Description: "Text to guide the style and vocabulary of the transcript",
+			Group:       "advanced",
+		},
+	}
+
+	return &WhisperCppAdapter{
+		BaseAdapter: NewBaseAdapter("whisper_cpp", modelsDir, capabilities, schema),
+		binary:      binary,
+		modelsDir:   modelsDir,
+	}
+}
+
+// GetSupportedModels returns the GGML models present in the models directory
+// and the custom models
+func (w *WhisperCppAdapter) GetSupportedModels() []string {
+	return w.withCustomModels(w.downloadedModels())
+}
+
+// ValidateParameters validates the parameters, accepting custom models
+func (w *WhisperCppAdapter) ValidateParameters(params map[string]interface{}) error {
+	return w.BaseAdapter.ValidateParameters(w.withoutCustomModel(params))
+}
+
+// downloadedModels returns the GGML models present in the models directory
+func (w *WhisperCppAdapter) downloadedModels() []string {
+	files, _ := filepath.Glob(filepath.Join(w.modelsDir, "ggml-*.bin"))
+	models := make([]string, 0, len(files))
+	for _, file := range files {
+		models = append(models, strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), "ggml-"), ".bin"))
+	}
+	sort.Strings(models)
+	return models
+}
+
+// InstalledModels maps the downloaded GGML models to their files
+func (w *WhisperCppAdapter) InstalledModels() map[string]string {
+	installed := make(map[string]string)
+	for _, model := range w.downloadedModels() {
+		installed[model] = filepath.Join(w.modelsDir, "ggml-"+model+".bin")
+	}
+	return installed
+}
+
+// InstallModel downloads a named model ahead of the first job that needs it
+func (w *WhisperCppAdapter) InstallModel(ctx context.Context, model string) error {
+	if strings.HasSuffix(model, ".bin") || filepath.IsAbs(model) {
+		return fmt.Errorf("invalid whisper.cpp model name %q", model)
+	}
+	if err := os.MkdirAll(w.modelsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create whisper.cpp models directory: %w", err)
+	}
+	_, err := w.ensureModel(ctx, model)
+	return err
+}
+
+// RemoveModel deletes a downloaded model
+func (w *WhisperCppAdapter) RemoveModel(model string) error {
+	if !whisperCppModelName.MatchString(model) {
+		return fmt.Errorf("invalid whisper.cpp model name %q", model)
+	}
+
+	lock := w.modelLock(model)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := os.Remove(filepath.Join(w.modelsDir, "ggml-"+model+".bin")); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("whisper.cpp model %s is not installed", model)
+		}
+		return fmt.Errorf("failed to remove whisper.cpp model %s: %w", model, err)
+	}
+	return nil
+}
+
+// PrepareEnvironment finds the whisper.cpp binary and creates the models directory.
+// Models are downloaded when a job first needs them.
+func (w *WhisperCppAdapter) PrepareEnvironment(ctx context.Context) error {
+	binaryPath, err := w.resolveBinary()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(w.modelsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create whisper.cpp models directory: %w", err)
+	}
+
+	w.mu.Lock()
+	w.binaryPath = binaryPath
+	w.initialized = true
+	w.mu.Unlock()
+	logger.Info("whisper.cpp ready", "binary", binaryPath, "models_dir", w.modelsDir, "models", w.downloadedModels())
+	return nil
+}
+
+// resolveBinary returns the path of the configured binary, or of whisper-cli in PATH
+func (w *WhisperCppAdapter) resolveBinary() (string, error) {
+	candidates := []string{w.binary}
+	if w.binary == "" {
+		candidates = []string{"whisper-cli", "whisper-cpp"}
+	}
+	for _, candidate := range candidates {
+		if path, err := exec.LookPath(candidate); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("whisper.cpp binary not found (tried %s), set WHISPER_CPP_BINARY", strings.Join(candidates, ", "))
+}
+
+// resolvedBinary returns the binary found by PrepareEnvironment, if any
+func (w *WhisperCppAdapter) resolvedBinary() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.binaryPath
+}
+
+// modelLock returns the lock of a model file
+func (w *WhisperCppAdapter) modelLock(model string) *sync.Mutex {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.modelLocks == nil {
+		w.modelLocks = make(map[string]*sync.Mutex)
+	}
+	lock, exists := w.modelLocks[model]
+	if !exists {
+		lock = &sync.Mutex{}
+		w.modelLocks[model] = lock
+	}
+	return lock
+}
+
+// IsReady reports whether the binary was found
+func (w *WhisperCppAdapter) IsReady(ctx context.Context) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.BaseAdapter.IsReady(ctx) && w.binaryPath != ""
+}
+
+// Transcribe processes audio with whisper.cpp
+func (w *WhisperCppAdapter) Transcribe(ctx context.Context, input interfaces.AudioInput, params map[string]interface{}, procCtx interfaces.ProcessingContext) (*interfaces.TranscriptResult, error) {
+	startTime := time.Now()
+	w.LogProcessingStart(input, procCtx)
+	defer func() {
+		w.LogProcessingEnd(procCtx, time.Since(startTime), nil)
+	}()
+
+	if !w.IsReady(ctx) {
+		if err := w.PrepareEnvironment(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.ValidateAudioInput(input); err != nil {
+		return nil, fmt.Errorf("invalid audio input: %w", err)
+	}
+	if err := w.ValidateParameters(params); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	model := w.GetStringParameter(params, "model")
+	if model == "" {
+		model = "small"
+	}
+	if custom, ok := w.customModel(model); ok {
+		model = custom.Source
+	}
+	modelPath, err := w.ensureModel(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	tempDir, err := w.CreateTempDirectory(procCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer w.CleanupTempDirectory(tempDir)
+
+	outputPrefix := filepath.Join(tempDir, "result")
+	args := w.buildArgs(input, params, modelPath, outputPrefix)
+
+	logPath := filepath.Join(procCtx.OutputDirectory, "transcription.log")
+	logFile, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		logger.Warn("Failed to create log file", "error", err)
+	} else {
+		defer logFile.Close()
+	}
+
+	binaryPath := w.resolvedBinary()
+	logger.Info("Executing whisper.cpp command", "binary", binaryPath, "args", strings.Join(args, " "))
+
+	cmd := exec.CommandContext(ctx, binaryPath, args...)
+	if logFile != nil {
+		cmd.Stdout = logFile
+		cmd.Stderr = logFile
+	}
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.Canceled {
+			return nil, fmt.Errorf("transcription was cancelled")
+		}
+		logTail, readErr := w.ReadLogTail(logPath, 2048)
+		if readErr != nil {
+			logger.Warn("Failed to read log tail", "error", readErr)
+		}
+		logger.Error("whisper.cpp execution failed", "error", err)
+		return nil, fmt.Errorf("whisper.cpp execution failed: %w\nLogs:\n%s", err, logTail)
+	}
+
+	result, err := w.parseResult(outputPrefix + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse result: %w", err)
+	}
+
+	result.ProcessingTime = time.Since(startTime)
+	result.ModelUsed = model
+	result.Metadata = w.CreateDefaultMetadata(params)
+
+	logger.Info("whisper.cpp transcription completed",
+		"segments", len(result.Segments),
+		"words", len(result.WordSegments),
+		"processing_time", result.ProcessingTime)
+
+	return result, nil
+}
+
+// ensureModel returns the model file, downloading a named model on first use
+func (w *WhisperCppAdapter) ensureModel(ctx context.Context, model string) (string, error) {
+	if strings.HasSuffix(model, ".bin") || filepath.IsAbs(model) {
+		if _, err := os.Stat(model); err != nil {
+			return "", fmt.Errorf("whisper.cpp model file not found: %w", err)
+		}
+		return model, nil
+	}
+	if !whisperCppModelName.MatchString(model) {
+		return "", fmt.Errorf("invalid whisper.cpp model name %q", model)
+	}
+
+	modelPath := filepath.Join(w.modelsDir, "ggml-"+model+".bin")
+
+	// One download per model, even when several jobs start at once. Other
+	// models are not held up by it.
+	lock := w.modelLock(model)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if _, err := os.Stat(modelPath); err == nil {
+		return modelPath, nil
+	}
+
+	logger.Info("Downloading whisper.cpp model", "model", model, "path", modelPath)
+	if err := downloader.DownloadFile(ctx, fmt.Sprintf(whisperCppModelURL, model), modelPath); err != nil {
+		_ = os.Remove(modelPath + ".tmp")
+		return "", fmt.Errorf("failed to download whisper.cpp model %s: %w", model, err)
+	}
+	return modelPath, nil
+}
+
+// buildArgs maps the parameters to whisper-cli flags
+func (w *WhisperCppAdapter) buildArgs(input interfaces.AudioInput, params map[string]interface{}, modelPath, outputPrefix string) []string {
+	args := []string{
+		"--model", modelPath,
+		"--file", input.FilePath,
+		"--output-json-full",
+		"--output-file", outputPrefix,
+		"--print-progress",
+	}
+
+	language := w.GetStringParameter(params, "language")
+	if language == "" {
+		language = "auto"
+	}
+	args = append(args, "--language", language)
+
+	if w.GetStringParameter(params, "task") == "translate" {
+		args = append(args, "--translate")
+	}
+	if threads := w.GetIntParameter(params, "threads"); threads > 0 {
+		args = append(args, "--threads", strconv.Itoa(threads))
+	}
+	if beamSize := w.GetIntParameter(params, "beam_size"); beamSize > 0 {
+		args = append(args, "--beam-size", strconv.Itoa(beamSize))
+	}
+	if bestOf := w.GetIntParameter(params, "best_of"); bestOf > 0 {
+		args = append(args, "--best-of", strconv.Itoa(bestOf))
+	}
+
+	args = append(args, "--temperature", strconv.FormatFloat(w.GetFloatParameter(params, "temperature"), 'f', 2, 64))
+	if _, ok := params["temperature_increment_on_fallback"]; ok {
+		if increment := w.GetFloatParameter(params, "temperature_increment_on_fallback"); increment > 0 {
+			args = append(args, "--temperature-inc", strconv.FormatFloat(increment, 'f', 2, 64))
+		} else {
+			args = append(args, "--no-fallback")
+		}
+	}
+
+	if prompt := w.GetStringParameter(params, "initial_prompt"); prompt != "" {
+		args = append(args, "--prompt", prompt)
+	}
+
+	return args
+}
+
+// whisperCppOutput is the document written by --output-json-full
+type whisperCppOutput struct {
+	Params struct {
+		Language string `json:"language"`
+	} `json:"params"`
+	Result struct {
+		Language string `json:"language"`
+	} `json:"result"`
+	Transcription []struct {
+		Offsets whisperCppOffsets `json:"offsets"`
+		Text    string            `json:"text"`
+		Tokens  []struct {
+			Text    string            `json:"text"`
+			Offsets whisperCppOffsets `json:"offsets"`
+			P       float64           `json:"p"`
+		} `json:"tokens"`
+	} `json:"transcription"`
+}
+
+// whisperCppOffsets are milliseconds from the start of the audio
+type whisperCppOffsets struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// parseResult converts the whisper.cpp JSON into a transcript. Tokens are
+// sub-word pieces; a token starting with a space begins a new word.
+func (w *WhisperCppAdapter) parseResult(resultFile string) (*interfaces.TranscriptResult, error) {
+	data, err := os.ReadFile(resultFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read result file: %w", err)
+	}
+
+	var output whisperCppOutput
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON result: %w", err)
+	}
+
+	result := &interfaces.TranscriptResult{
+		Language: output.Result.Language,
+		Segments: make([]interfaces.TranscriptSegment, 0, len(output.Transcription)),
+	}
+	if result.Language == "" {
+		result.Language = output.Params.Language
+	}
+
+	var texts []string
+	for _, seg := range output.Transcription {
+		text := strings.TrimSpace(seg.Text)
+		if text == "" {
+			continue
+		}
+		texts = append(texts, text)
+		result.Segments = append(result.Segments, interfaces.TranscriptSegment{
+			Start: float64(seg.Offsets.From) / 1000,
+			End:   float64(seg.Offsets.To) / 1000,
+			Text:  text,
+		})
+
+		firstWord := len(result.WordSegments)
+		for _, token := range seg.Tokens {
+			// Special tokens such as [_BEG_] and [_TT_150] carry no text
+			if token.Text == "" || strings.HasPrefix(token.Text, "[_") || strings.HasPrefix(token.Text, "<|") {
+				continue
+			}
+			start := float64(token.Offsets.From) / 1000
+			end := float64(token.Offsets.To) / 1000
+
+			n := len(result.WordSegments)
+			if n > firstWord && !strings.HasPrefix(token.Text, " ") {
+				// Continuation of the previous word, which is only as certain as its weakest piece
+				word := &result.WordSegments[n-1]
+				word.Word += token.Text
+				word.End = end
+				word.Score = math.Min(word.Score, token.P)
+				continue
+			}
+			result.WordSegments = append(result.WordSegments, interfaces.TranscriptWord{
+				Word:  strings.TrimSpace(token.Text),
+				Start: start,
+				End:   end,
+				Score: token.P,
+			})
+		}
+	}
+	result.Text = strings.Join(texts, " ")
+
+	return result, nil
+}
+
+// GetEstimatedProcessingTime provides whisper.cpp-specific time estimation
+func (w *WhisperCppAdapter) GetEstimatedProcessingTime(input interfaces.AudioInput) time.Duration {
+	// CPU inference of the small model runs at roughly half of real time
+	return time.Duration(float64(input.Duration) * 0.5)
+}
@@ -2,6 +2,7 @@ package adapters
 
 import (
 	"context"
+	"embed"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -15,9 +16,13 @@ import (
 	"scriberr/pkg/logger"
 )
 
+//go:embed py/whisperx/*
+var whisperxScripts embed.FS
+
 // WhisperXAdapter implements the TranscriptionAdapter interface for WhisperX
 type WhisperXAdapter struct {
 	*BaseAdapter
+	customModelSet
 	envPath string
 }
 
@@ -282,13 +287,18 @@ func NewWhisperXAdapter(envPath string) *WhisperXAdapter {
 
 // GetSupportedModels returns the list of Whisper models supported
 func (w *WhisperXAdapter) GetSupportedModels() []string {
-	return []string{
+	return w.withCustomModels([]string{
 		"tiny", "tiny.en",
 		"base", "base.en",
 		"small", "small.en",
 		"medium", "medium.en",
 		"large", "large-v1", "large-v2", "large-v3",
-	}
+	})
+}
+
+// ValidateParameters validates the parameters, accepting custom models
+func (w *WhisperXAdapter) ValidateParameters(params map[string]interface{}) error {
+	return w.BaseAdapter.ValidateParameters(w.withoutCustomModel(params))
 }
 
 // PrepareEnvironment sets up the WhisperX environment
@@ -299,6 +309,9 @@ func (w *WhisperXAdapter) PrepareEnvironment(ctx context.Context) error {
 
 	// Check if WhisperX is already set up and working (using cache to speed up repeated checks)
 	if CheckEnvironmentReady(whisperxPath, "import whisperx") {
+		if err := w.copyAlignScript(whisperxPath); err != nil {
+			return fmt.Errorf("failed to create alignment script: %w", err)
+		}
 		logger.Info("WhisperX environment already ready")
 		w.initialized = true
 		return nil
@@ -324,6 +337,10 @@ func (w *WhisperXAdapter) PrepareEnvironment(ctx context.Context) error {
 		return fmt.Errorf("failed to sync WhisperX: %w", err)
 	}
 
+	if err := w.copyAlignScript(whisperxPath); err != nil {
+		return fmt.Errorf("failed to create alignment script: %w", err)
+	}
+
 	w.initialized = true
 	logger.Info("WhisperX environment prepared successfully")
 	return nil
@@ -382,6 +399,87 @@ func (w *WhisperXAdapter) uvSyncWhisperX(whisperxPath string) error {
 	return nil
 }
 
+// copyAlignScript writes the forced alignment script into the WhisperX project
+func (w *WhisperXAdapter) copyAlignScript(whisperxPath string) error {
+	scriptContent, err := whisperxScripts.ReadFile("py/whisperx/whisperx_align.py")
+	if err != nil {
+		return fmt.Errorf("failed to read embedded whisperx_align.py: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(whisperxPath, "whisperx_align.py"), scriptContent, 0755); err != nil {
+		return fmt.Errorf("failed to write whisperx_align.py: %w", err)
+	}
+	return nil
+}
+
+// huggingFaceHubCache returns the directory Hugging Face downloads models to
+func huggingFaceHubCache() string {
+	if dir := os.Getenv("HF_HUB_CACHE"); dir != "" {
+		return dir
+	}
+	if home := os.Getenv("HF_HOME"); home != "" {
+		return filepath.Join(home, "hub")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".cache", "huggingface", "hub")
+}
+
+// whisperXModelDirs returns the Hugging Face cache entries of the downloaded
+// faster-whisper models, keyed by model name
+func whisperXModelDirs() map[string][]string {
+	dirs, _ := filepath.Glob(filepath.Join(huggingFaceHubCache(), "models--*--faster-whisper-*"))
+	models := make(map[string][]string)
+	for _, dir := range dirs {
+		_, name, _ := strings.Cut(filepath.Base(dir), "faster-whisper-")
+		name = strings.TrimSuffix(name, "-ct2")
+		models[name] = append(models[name], dir)
+	}
+	return models
+}
+
+// InstalledModels maps the downloaded Whisper models to their cache entries
+func (w *WhisperXAdapter) InstalledModels() map[string]string {
+	installed := make(map[string]string)
+	for name, dirs := range whisperXModelDirs() {
+		installed[name] = dirs[0]
+	}
+	return installed
+}
+
+// InstallModel downloads a Whisper model ahead of the first job that needs it
+func (w *WhisperXAdapter) InstallModel(ctx context.Context, model string) error {
+	if !w.stringInSlice(model, w.GetSupportedModels()) {
+		return fmt.Errorf("unsupported WhisperX model %q", model)
+	}
+	// Custom models are downloaded from their Hugging Face repo, local ones are already there
+	if custom, ok := w.customModel(model); ok {
+		if filepath.IsAbs(custom.Source) {
+			return nil
+		}
+		model = custom.Source
+	}
+	whisperxPath := filepath.Join(w.envPath, "WhisperX")
+	script := fmt.Sprintf("import faster_whisper; faster_whisper.download_model(%q)", model)
+	cmd := exec.CommandContext(ctx, "uv", "run", "--native-tls", "--project", whisperxPath, "python", "-c", script)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to download WhisperX model %s: %w: %s", model, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// RemoveModel deletes a downloaded Whisper model from the Hugging Face cache
+func (w *WhisperXAdapter) RemoveModel(model string) error {
+	dirs, ok := whisperXModelDirs()[model]
+	if !ok {
+		return fmt.Errorf("WhisperX model %s is not installed", model)
+	}
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to remove WhisperX model %s: %w", model, err)
+		}
+	}
+	return nil
+}
+
 // Transcribe processes audio using WhisperX
 func (w *WhisperXAdapter) Transcribe(ctx context.Context, input interfaces.AudioInput, params map[string]interface{}, procCtx interfaces.ProcessingContext) (*interfaces.TranscriptResult, error) {
 	startTime := time.Now()
@@ -413,34 +511,7 @@ func (w *WhisperXAdapter) Transcribe(ctx context.Context, input interfaces.Audio
 		return nil, fmt.Errorf("failed to build command: %w", err)
 	}
 
-	// Execute WhisperX
-	cmd := exec.CommandContext(ctx, "uv", args...)
-
-	// Add nvidia libraries to LD_LIBRARY_PATH
-	env := os.Environ()
-	if nvidiaPaths, err := w.findNvidiaLibPaths(); err == nil && len(nvidiaPaths) > 0 {
-		ldLibraryPath := os.Getenv("LD_LIBRARY_PATH")
-		newPath := strings.Join(nvidiaPaths, string(os.PathListSeparator))
-		if ldLibraryPath != "" {
-			newPath = newPath + string(os.PathListSeparator) + ldLibraryPath
-		}
-
-		// Update LD_LIBRARY_PATH in env
-		found := false
-		for i, e := range env {
-			if strings.HasPrefix(e, "LD_LIBRARY_PATH=") {
-				env[i] = "LD_LIBRARY_PATH=" + newPath
-				found = true
-				break
-			}
-		}
-		if !found {
-			env = append(env, "LD_LIBRARY_PATH="+newPath)
-		}
-		logger.Debug("Updated LD_LIBRARY_PATH for WhisperX", "path", newPath)
-	}
-
-	cmd.Env = append(env, "PYTHONUNBUFFERED=1")
+	env := w.commandEnv()
 
 	// Setup log file
 	logFile, err := os.OpenFile(filepath.Join(procCtx.OutputDirectory, "transcription.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
@@ -448,13 +519,12 @@ func (w *WhisperXAdapter) Transcribe(ctx context.Context, input interfaces.Audio
 		logger.Warn("Failed to create log file", "error", err)
 	} else {
 		defer logFile.Close()
-		cmd.Stdout = logFile
-		cmd.Stderr = logFile
 	}
 
 	logger.Info("Executing WhisperX command", "args", strings.Join(args, " "))
 
-	if err := cmd.Run(); err != nil {
+	// Execute WhisperX
+	if err := runUV(ctx, "whisperx", args, env, logFile); err != nil {
 		if ctx.Err() == context.Canceled {
 			return nil, fmt.Errorf("transcription was cancelled")
 		}
@@ -488,6 +558,135 @@ func (w *WhisperXAdapter) Transcribe(ctx context.Context, input interfaces.Audio
 	return result, nil
 }
 
+// commandEnv returns the environment WhisperX runs with, the nvidia libraries
+// of its virtual environment added to LD_LIBRARY_PATH
+func (w *WhisperXAdapter) commandEnv() []string {
+	env := []string{"PYTHONUNBUFFERED=1"}
+	if nvidiaPaths, err := w.findNvidiaLibPaths(); err == nil && len(nvidiaPaths) > 0 {
+		newPath := strings.Join(nvidiaPaths, string(os.PathListSeparator))
+		if ldLibraryPath := os.Getenv("LD_LIBRARY_PATH"); ldLibraryPath != "" {
+			newPath = newPath + string(os.PathListSeparator) + ldLibraryPath
+		}
+		env = append(env, "LD_LIBRARY_PATH="+newPath)
+		logger.Debug("Updated LD_LIBRARY_PATH for WhisperX", "path", newPath)
+	}
+	return env
+}
+
+// AlignWords times the words of each segment with the WhisperX alignment
+// model. The transcript's language selects the model unless an align_model
+// is given.
+func (w *WhisperXAdapter) AlignWords(ctx context.Context, input interfaces.AudioInput, segments []interfaces.TranscriptSegment, params map[string]interface{}, procCtx interfaces.ProcessingContext) ([][]interfaces.TranscriptWord, error) {
+	if len(segments) == 0 {
+		return nil, nil
+	}
+	language := w.GetStringParameter(params, "language")
+	if language == "" || language == "auto" {
+		return nil, fmt.Errorf("the transcript's language is required for alignment")
+	}
+
+	tempDir, err := w.CreateTempDirectory(procCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer w.CleanupTempDirectory(tempDir)
+
+	type alignSegment struct {
+		Start float64 `json:"start"`
+		End   float64 `json:"end"`
+		Text  string  `json:"text"`
+	}
+	request := make([]alignSegment, len(segments))
+	for i, segment := range segments {
+		request[i] = alignSegment{Start: segment.Start, End: segment.End, Text: segment.Text}
+	}
+	segmentsData, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode segments: %w", err)
+	}
+	segmentsFile := filepath.Join(tempDir, "segments.json")
+	if err := os.WriteFile(segmentsFile, segmentsData, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write segments: %w", err)
+	}
+
+	whisperxPath := filepath.Join(w.envPath, "WhisperX")
+	outputFile := filepath.Join(tempDir, "aligned.json")
+	args := []string{
+		"run", "--native-tls", "--project", whisperxPath, "python", filepath.Join(whisperxPath, "whisperx_align.py"),
+		input.FilePath,
+		"--segments", segmentsFile,
+		"--output", outputFile,
+		"--language", language,
+		"--device", w.GetStringParameter(params, "device"),
+	}
+	if alignModel := w.GetStringParameter(params, "align_model"); alignModel != "" {
+		args = append(args, "--align-model", alignModel)
+	}
+	if method := w.GetStringParameter(params, "interpolate_method"); method != "" {
+		args = append(args, "--interpolate-method", method)
+	}
+
+	var logFile *os.File
+	if procCtx.OutputDirectory != "" {
+		if logFile, err = os.OpenFile(filepath.Join(procCtx.OutputDirectory, "transcription.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+			logger.Warn("Failed to create log file", "error", err)
+		} else {
+			defer logFile.Close()
+		}
+	}
+
+	logger.Info("Aligning transcript words", "segments", len(segments), "language", language)
+	if err := runUV(ctx, "whisperx", args, w.commandEnv(), logFile); err != nil {
+		if ctx.Err() == context.Canceled {
+			return nil, fmt.Errorf("alignment was cancelled")
+		}
+		return nil, fmt.Errorf("WhisperX alignment failed: %w", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alignment: %w", err)
+	}
+	var result struct {
+		Segments [][]struct {
+			Start *float64 `json:"start"`
+			End   *float64 `json:"end"`
+			Word  string   `json:"word"`
+			Score float64  `json:"score"`
+		} `json:"segments"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse alignment: %w", err)
+	}
+	if len(result.Segments) != len(segments) {
+		return nil, fmt.Errorf("expected words of %d segments, got %d", len(segments), len(result.Segments))
+	}
+
+	// Words the model could not time, such as numbers, run from the end of the
+	// word before them to the start of the next timed word
+	aligned := make([][]interfaces.TranscriptWord, len(segments))
+	for i, words := range result.Segments {
+		aligned[i] = make([]interfaces.TranscriptWord, len(words))
+		previousEnd := segments[i].Start
+		for j, word := range words {
+			start, end := previousEnd, segments[i].End
+			if word.Start != nil && word.End != nil {
+				start, end = *word.Start, *word.End
+			} else {
+				for _, next := range words[j+1:] {
+					if next.Start != nil {
+						end = *next.Start
+						break
+					}
+				}
+			}
+			aligned[i][j] = interfaces.TranscriptWord{Start: start, End: end, Word: word.Word, Score: word.Score}
+			previousEnd = end
+		}
+	}
+	return aligned, nil
+}
+
 // buildWhisperXArgs builds the command arguments for WhisperX
 func (w *WhisperXAdapter) buildWhisperXArgs(input interfaces.AudioInput, params map[string]interface{}, outputDir string) ([]string, error) {
 	whisperxPath := filepath.Join(w.envPath, "WhisperX")
@@ -499,7 +698,12 @@ func (w *WhisperXAdapter) buildWhisperXArgs(input interfaces.AudioInput, params
 	}
 
 	// Core parameters
-	args = append(args, "--model", w.GetStringParameter(params, "model"))
+	// Custom models are passed as their local path or Hugging Face repo ID
+	model := w.GetStringParameter(params, "model")
+	if custom, ok := w.customModel(model); ok {
+		model = custom.Source
+	}
+	args = append(args, "--model", model)
 	args = append(args, "--device", w.GetStringParameter(params, "device"))
 	args = append(args, "--device_index", strconv.Itoa(w.GetIntParameter(params, "device_index")))
 	args = append(args, "--batch_size", strconv.Itoa(w.GetIntParameter(params, "batch_size")))
@@ -105,6 +105,44 @@ func (m *MockJobRepository) FindLatestCompletedExecution(ctx context.Context, jo
 	return args.Get(0).(*models.TranscriptionJobExecution), args.Error(1)
 }
 
+func (m *MockJobRepository) UpdateOriginalTranscript(ctx context.Context, jobID string, transcript string) error {
+	args := m.Called(ctx, jobID, transcript)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) SaveTranscriptRevision(ctx context.Context, revision *models.TranscriptRevision) error {
+	args := m.Called(ctx, revision)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) SaveDiarizedTranscript(ctx context.Context, revision *models.TranscriptRevision, diarizeModel string, mappings []models.SpeakerMapping) error {
+	args := m.Called(ctx, revision, diarizeModel, mappings)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) SaveRelabelledTranscript(ctx context.Context, revision *models.TranscriptRevision, markDiarized bool, mappings []models.SpeakerMapping) error {
+	args := m.Called(ctx, revision, markDiarized, mappings)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) ListTranscriptRevisions(ctx context.Context, jobID string) ([]models.TranscriptRevision, error) {
+	args := m.Called(ctx, jobID)
+	return args.Get(0).([]models.TranscriptRevision), args.Error(1)
+}
+
+func (m *MockJobRepository) DeleteTranscriptRevisionsByJobID(ctx context.Context, jobID string) error {
+	args := m.Called(ctx, jobID)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) FindLatestExecution(ctx context.Context, jobID string) (*models.TranscriptionJobExecution, error) {
+	args := m.Called(ctx, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TranscriptionJobExecution), args.Error(1)
+}
+
 func (m *MockJobRepository) UpdateStatus(ctx context.Context, jobID string, status models.JobStatus) error {
 	args := m.Called(ctx, jobID, status)
 	return args.Error(0)
@@ -531,6 +569,53 @@ func TestParameterConversion(t *testing.T) {
 	}
 }
 
+func TestSplitOptions(t *testing.T) {
+	limits := interfaces.ChunkLimits{
+		MaxFileSizeBytes:     25 * 1024 * 1024,
+		MaxDurationSeconds:   300,
+		ChunkDurationSeconds: 240,
+	}
+
+	// Without profile settings the model's limits apply
+	opts := splitOptions(models.WhisperXParams{SplitStrategy: "silence"}, limits)
+	if opts.MaxFileSizeBytes != limits.MaxFileSizeBytes || opts.MaxDurationSeconds != 300 || opts.ChunkDurationSeconds != 240 {
+		t.Errorf("Expected model limits, got %+v", opts)
+	}
+	if opts.Strategy != "silence" {
+		t.Errorf("Expected strategy from profile, got %q", opts.Strategy)
+	}
+
+	// Profile settings override them
+	opts = splitOptions(models.WhisperXParams{
+		SplitMaxFileSizeMB: 10,
+		SplitMaxDuration:   600,
+		SplitChunkDuration: 120,
+	}, limits)
+	if opts.MaxFileSizeBytes != 10*1024*1024 || opts.MaxDurationSeconds != 600 || opts.ChunkDurationSeconds != 120 {
+		t.Errorf("Expected profile overrides, got %+v", opts)
+	}
+
+	// Local models without limits are never split unless the profile asks for it
+	opts = splitOptions(models.WhisperXParams{}, interfaces.ChunkLimits{})
+	if opts.MaxFileSizeBytes != 0 || opts.MaxDurationSeconds != 0 {
+		t.Errorf("Expected no limits, got %+v", opts)
+	}
+}
+
+func TestParakeetChunkLengthFromProfile(t *testing.T) {
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), "data/temp", "data/transcripts")
+
+	params := service.convertToParakeetParams(models.WhisperXParams{})
+	if _, ok := params["chunk_length"]; ok {
+		t.Error("Expected the adapter default chunk length when the profile sets none")
+	}
+
+	params = service.convertToParakeetParams(models.WhisperXParams{SplitChunkDuration: 600})
+	if params["chunk_length"] != 600 {
+		t.Errorf("Expected chunk_length 600, got %v", params["chunk_length"])
+	}
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,131 @@
+package transcription
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultChunkConcurrency is how many chunks of one job each adapter transcribes
+// at once. Local models load onto the same GPU per call, so they stay sequential.
+var defaultChunkConcurrency = map[string]int{
+	ModelOpenAI: 4,
+}
+
+// defaultChunkRateLimits caps requests per minute for API adapters
+var defaultChunkRateLimits = map[string]int{
+	ModelOpenAI: 50,
+}
+
+// chunkEnvKey turns a model ID into an environment variable name, e.g.
+// CHUNK_CONCURRENCY_OPENAI_WHISPER for openai_whisper
+func chunkEnvKey(prefix, modelID string) string {
+	replacer := strings.NewReplacer("-", "_", "/", "_", ".", "_")
+	return prefix + "_" + strings.ToUpper(replacer.Replace(modelID))
+}
+
+// envInt returns the first positive or zero integer set in the given variables
+func envInt(keys ...string) (int, bool) {
+	for _, key := range keys {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// chunkConcurrency returns how many chunks of a job the adapter may transcribe in
+// parallel. CHUNK_CONCURRENCY_<MODEL> overrides the adapter default, and
+// CHUNK_CONCURRENCY applies to adapters without a specific setting.
+func chunkConcurrency(modelID string) int {
+	n, ok := envInt(chunkEnvKey("CHUNK_CONCURRENCY", modelID))
+	if !ok {
+		if n, ok = defaultChunkConcurrency[modelID]; !ok {
+			n, _ = envInt("CHUNK_CONCURRENCY")
+		}
+	}
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// chunkRateLimit returns the requests per minute allowed for an adapter, or 0 for
+// no limit. CHUNK_RATE_LIMIT_<MODEL> overrides the adapter default.
+func chunkRateLimit(modelID string) int {
+	if n, ok := envInt(chunkEnvKey("CHUNK_RATE_LIMIT", modelID)); ok {
+		return n
+	}
+	return defaultChunkRateLimits[modelID]
+}
+
+// rateLimiter spaces out calls evenly so no more than a fixed number start per minute
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newRateLimiter creates a limiter for perMinute calls, or nil for no limit
+func newRateLimiter(perMinute int) *rateLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Minute / time.Duration(perMinute)}
+}
+
+// Wait blocks until the caller may start its call or ctx is done. A nil limiter never waits.
+func (r *rateLimiter) Wait(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	now := time.Now()
+	slot := r.next
+	if slot.Before(now) {
+		slot = now
+	}
+	r.next = slot.Add(r.interval)
+	r.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// chunkLimiters share one rate limiter per adapter across all jobs of the process
+var chunkLimiters = struct {
+	sync.Mutex
+	byModel map[string]*rateLimiter
+}{byModel: make(map[string]*rateLimiter)}
+
+// chunkLimiter returns the shared rate limiter of an adapter, or nil if it is unlimited
+func chunkLimiter(modelID string) *rateLimiter {
+	chunkLimiters.Lock()
+	defer chunkLimiters.Unlock()
+
+	if limiter, ok := chunkLimiters.byModel[modelID]; ok {
+		return limiter
+	}
+	limiter := newRateLimiter(chunkRateLimit(modelID))
+	chunkLimiters.byModel[modelID] = limiter
+	return limiter
+}
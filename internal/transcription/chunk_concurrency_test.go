@@ -0,0 +1,68 @@
+package transcription
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestChunkConcurrency(t *testing.T) {
+	if got := chunkConcurrency(ModelWhisperX); got != 1 {
+		t.Errorf("local adapters should be sequential by default, got %d", got)
+	}
+	if got := chunkConcurrency(ModelOpenAI); got != defaultChunkConcurrency[ModelOpenAI] {
+		t.Errorf("expected openai default %d, got %d", defaultChunkConcurrency[ModelOpenAI], got)
+	}
+
+	t.Setenv("CHUNK_CONCURRENCY", "3")
+	if got := chunkConcurrency(ModelWhisperX); got != 3 {
+		t.Errorf("expected global override 3, got %d", got)
+	}
+
+	t.Setenv("CHUNK_CONCURRENCY_OPENAI_WHISPER", "8")
+	if got := chunkConcurrency(ModelOpenAI); got != 8 {
+		t.Errorf("expected per-model override 8, got %d", got)
+	}
+
+	t.Setenv("CHUNK_CONCURRENCY_WHISPERX", "0")
+	if got := chunkConcurrency(ModelWhisperX); got != 1 {
+		t.Errorf("concurrency should never drop below 1, got %d", got)
+	}
+}
+
+func TestChunkRateLimit(t *testing.T) {
+	if got := chunkRateLimit(ModelWhisperX); got != 0 {
+		t.Errorf("local adapters should be unlimited, got %d", got)
+	}
+
+	t.Setenv("CHUNK_RATE_LIMIT_OPENAI_WHISPER", "0")
+	if got := chunkRateLimit(ModelOpenAI); got != 0 {
+		t.Errorf("expected rate limit override to disable limiting, got %d", got)
+	}
+}
+
+func TestRateLimiterSpacesCalls(t *testing.T) {
+	limiter := newRateLimiter(600) // one call every 100ms
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("three calls at 600/min should take at least 200ms, took %v", elapsed)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := limiter.Wait(cancelled); err == nil {
+		t.Error("expected Wait to return the context error")
+	}
+
+	var unlimited *rateLimiter
+	if err := unlimited.Wait(ctx); err != nil {
+		t.Errorf("nil limiter should never block, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package transcription
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/registry"
+	"scriberr/pkg/logger"
+)
+
+var (
+	// customModelName is what profiles may use as the name of a custom model
+	customModelName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,49}$`)
+
+	// huggingFaceRepoID matches an "owner/name" Hugging Face model repo
+	huggingFaceRepoID = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*/[A-Za-z0-9][A-Za-z0-9._-]*$`)
+)
+
+// ApplyCustomModels hands the registered custom models to their base adapters
+func ApplyCustomModels(customModels []models.CustomModel) {
+	byAdapter := make(map[string][]interfaces.CustomModel)
+	for _, custom := range customModels {
+		byAdapter[custom.BaseAdapter] = append(byAdapter[custom.BaseAdapter], interfaces.CustomModel{
+			Name:        custom.Name,
+			DisplayName: custom.DisplayName,
+			Source:      custom.Source,
+			Languages:   custom.LanguageList(),
+		})
+	}
+
+	r := registry.GetRegistry()
+	for _, modelID := range r.GetTranscriptionModels() {
+		adapter, err := r.GetTranscriptionAdapter(modelID)
+		if err != nil {
+			continue
+		}
+		if customizable, ok := adapter.(interfaces.CustomModelAdapter); ok {
+			customizable.SetCustomModels(byAdapter[modelID])
+			delete(byAdapter, modelID)
+		}
+	}
+	for modelID, orphans := range byAdapter {
+		logger.Warn("Custom models for an adapter that is not registered", "base_adapter", modelID, "count", len(orphans))
+	}
+}
+
+// CustomModelAdapters returns the registered adapters that accept custom models
+func CustomModelAdapters() []string {
+	r := registry.GetRegistry()
+	var ids []string
+	for _, modelID := range r.GetTranscriptionModels() {
+		if adapter, err := r.GetTranscriptionAdapter(modelID); err == nil {
+			if _, ok := adapter.(interfaces.CustomModelAdapter); ok {
+				ids = append(ids, modelID)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ValidateCustomModel checks that a custom model can be registered: its base
+// adapter accepts custom models, its name does not shadow a built-in model and
+// its source is a file or directory the adapter can load
+func ValidateCustomModel(custom models.CustomModel) error {
+	if !customModelName.MatchString(custom.Name) {
+		return fmt.Errorf("invalid name %q: use up to 50 letters, digits, '.', '_' or '-'", custom.Name)
+	}
+
+	adapter, err := registry.GetRegistry().GetTranscriptionAdapter(custom.BaseAdapter)
+	if err != nil {
+		return fmt.Errorf("unknown base adapter %q, expected one of: %s", custom.BaseAdapter, strings.Join(CustomModelAdapters(), ", "))
+	}
+	customizable, ok := adapter.(interfaces.CustomModelAdapter)
+	if !ok {
+		return fmt.Errorf("%s does not accept custom models, expected one of: %s", custom.BaseAdapter, strings.Join(CustomModelAdapters(), ", "))
+	}
+	if containsString(modelOptions(adapter), custom.Name) ||
+		(containsString(adapter.GetSupportedModels(), custom.Name) && !isCustomModel(customizable, custom.Name)) {
+		return fmt.Errorf("%s is a built-in %s model", custom.Name, custom.BaseAdapter)
+	}
+
+	source := strings.TrimSpace(custom.Source)
+	switch {
+	case source == "":
+		return fmt.Errorf("source is required")
+	case filepath.IsAbs(source):
+		info, err := os.Stat(source)
+		if err != nil {
+			return fmt.Errorf("source not found: %w", err)
+		}
+		if err := checkSourceKind(custom.BaseAdapter, source, info); err != nil {
+			return err
+		}
+	case custom.BaseAdapter == ModelWhisperCpp:
+		return fmt.Errorf("whisper.cpp models must be the absolute path of a GGML file")
+	case !huggingFaceRepoID.MatchString(source):
+		return fmt.Errorf("source must be an absolute path or a Hugging Face repo ID such as org/model")
+	}
+	return nil
+}
+
+// checkSourceKind checks that a local source has the layout its adapter loads
+func checkSourceKind(baseAdapter, source string, info os.FileInfo) error {
+	switch baseAdapter {
+	case ModelWhisperCpp:
+		if info.IsDir() {
+			return fmt.Errorf("whisper.cpp models must be a GGML file, not a directory")
+		}
+	case ModelParakeet, ModelCanary:
+		if info.IsDir() || !strings.HasSuffix(source, ".nemo") {
+			return fmt.Errorf("NeMo models must be a .nemo file")
+		}
+	case ModelWhisperX:
+		if !info.IsDir() {
+			return fmt.Errorf("WhisperX models must be a directory with a CTranslate2 model")
+		}
+	}
+	return nil
+}
+
+// isCustomModel reports whether an adapter accepts name as a custom model
+func isCustomModel(adapter interfaces.CustomModelAdapter, name string) bool {
+	for _, custom := range adapter.GetCustomModels() {
+		if custom.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateProfileParameters checks that a profile's model is one its model
+// family offers. Custom models must belong to the profile's adapter and
+// support its language.
+func (u *UnifiedTranscriptionService) ValidateProfileParameters(params models.WhisperXParams) error {
+	if params.ModelFamily == FamilyAuto || params.Model == "" {
+		return nil
+	}
+	modelID, ok := u.transcriptionModelForFamily(params.ModelFamily)
+	if !ok {
+		return nil
+	}
+	adapter, err := u.registry.GetTranscriptionAdapter(modelID)
+	if err != nil {
+		return nil
+	}
+
+	// A custom model of another adapter cannot be run by this one
+	for _, otherID := range CustomModelAdapters() {
+		other, err := u.registry.GetTranscriptionAdapter(otherID)
+		if err != nil || otherID == modelID {
+			continue
+		}
+		if isCustomModel(other.(interfaces.CustomModelAdapter), params.Model) {
+			return fmt.Errorf("custom model %s is a %s model, not %s", params.Model, otherID, modelID)
+		}
+	}
+
+	customizable, ok := adapter.(interfaces.CustomModelAdapter)
+	if !ok {
+		return nil
+	}
+	for _, custom := range customizable.GetCustomModels() {
+		if custom.Name != params.Model {
+			continue
+		}
+		language := ""
+		if params.Language != nil {
+			language = *params.Language
+		}
+		if language != "" && language != "auto" && len(custom.Languages) > 0 && !containsString(custom.Languages, language) {
+			return fmt.Errorf("custom model %s does not support language %s (supports %s)", custom.Name, language, strings.Join(custom.Languages, ", "))
+		}
+		return nil
+	}
+
+	// Built-in models are checked against the options of the adapter's model parameter
+	if options := modelOptions(adapter); len(options) > 0 && !containsString(options, params.Model) {
+		return fmt.Errorf("unknown %s model %s", modelID, params.Model)
+	}
+	return nil
+}
+
+// modelOptions returns the built-in models an adapter's model parameter allows
+func modelOptions(adapter interfaces.TranscriptionAdapter) []string {
+	for _, param := range adapter.GetParameterSchema() {
+		if param.Name == "model" {
+			return param.Options
+		}
+	}
+	return nil
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package transcription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/pkg/logger"
+)
+
+// ErrUnknownDiarizationModel is returned for a diarization model no adapter serves
+var ErrUnknownDiarizationModel = errors.New("unknown diarization model")
+
+// CheckDiarizationModel returns ErrUnknownDiarizationModel if no adapter serves
+// the named diarization model
+func (u *UnifiedTranscriptionService) CheckDiarizationModel(name string) error {
+	if modelID, ok := u.diarizationModelForName(name); ok {
+		if _, err := u.registry.GetDiarizationAdapter(modelID); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownDiarizationModel, name)
+}
+
+// DiarizeTranscript runs a diarization model on a finished job's audio and
+// merges the speakers into the job's current transcript. The text, including
+// edits made after transcription, is kept; segments are only split where the
+// speaker changes and their text still matches the words. The result is not
+// saved.
+func (u *UnifiedTranscriptionService) DiarizeTranscript(ctx context.Context, job *models.TranscriptionJob, params models.WhisperXParams) (*interfaces.TranscriptResult, *interfaces.DiarizationResult, error) {
+	if job.Transcript == nil || *job.Transcript == "" {
+		return nil, nil, fmt.Errorf("no transcript found for job %s", job.ID)
+	}
+	var transcript interfaces.TranscriptResult
+	if err := json.Unmarshal([]byte(*job.Transcript), &transcript); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse existing transcript: %w", err)
+	}
+
+	modelID, ok := u.diarizationModelForName(params.DiarizeModel)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnknownDiarizationModel, params.DiarizeModel)
+	}
+	adapter, err := u.registry.GetDiarizationAdapter(modelID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnknownDiarizationModel, params.DiarizeModel)
+	}
+
+	procCtx := interfaces.ProcessingContext{
+		JobID:           job.ID,
+		OutputDirectory: filepath.Join(u.outputDirectory, job.ID),
+		TempDirectory:   u.tempDirectory,
+		Metadata:        map[string]string{},
+	}
+	if err := os.MkdirAll(procCtx.OutputDirectory, 0755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	preprocessedInput, cleanup, err := u.prepareRecordingAudio(ctx, job.AudioPath, adapter.GetCapabilities())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cleanup()
+
+	started := time.Now()
+	params.Diarize = true
+	diarization, err := u.runDiarization(ctx, modelCandidate{ModelID: modelID, Params: params}, preprocessedInput, procCtx)
+	if err != nil {
+		appendJobLog(procCtx.OutputDirectory, fmt.Sprintf("Diarizing the transcript with %s failed: %v", modelID, err))
+		return nil, nil, fmt.Errorf("diarization failed: %w", err)
+	}
+	message := fmt.Sprintf("Diarized the transcript with %s: %d speakers in %dms", modelID, diarization.SpeakerCount, time.Since(started).Milliseconds())
+	logger.Info(message, "job_id", job.ID)
+	appendJobLog(procCtx.OutputDirectory, message)
+
+	return u.mergeDiarizationWithTranscription(&transcript, diarization, params.SpeakerAssignment), diarization, nil
+}
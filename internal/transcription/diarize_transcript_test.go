@@ -0,0 +1,95 @@
+package transcription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/adapters"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/registry"
+)
+
+const turnsPluginJSON = `{
+  "id": "turns_diarizer",
+  "type": "diarization",
+  "command": ["sh", "-c", "printf '{\"segments\": [{\"start\": 0, \"end\": 2, \"speaker\": \"B\"}, {\"start\": 2, \"end\": 4, \"speaker\": \"A\"}, {\"start\": 4, \"end\": 6, \"speaker\": \"B\"}]}' > {{.Output}}"]
+}`
+
+func TestDiarizeTranscript(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+
+	root := t.TempDir()
+	writePlugin(t, root, "diarizer", map[string]string{"plugin.json": turnsPluginJSON})
+	manifests, errs := adapters.LoadPluginManifests(root)
+	if len(errs) > 0 || len(manifests) != 1 {
+		t.Fatalf("unexpected plugins: %v %v", manifests, errs)
+	}
+
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+	registry.RegisterDiarizationAdapter(manifests[0].ID, adapters.NewPluginAdapter(manifests[0]))
+
+	audio := filepath.Join(root, "audio.wav")
+	if err := os.WriteFile(audio, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second segment was edited after transcription
+	transcript := interfaces.TranscriptResult{
+		Language: "en",
+		Segments: []interfaces.TranscriptSegment{
+			{Start: 0, End: 3, Text: "Hi there. Yes."},
+			{Start: 3, End: 6, Text: "Well, I think so!"},
+		},
+		WordSegments: []interfaces.TranscriptWord{
+			{Start: 0.0, End: 0.5, Word: "Hi"},
+			{Start: 0.6, End: 1.5, Word: "there."},
+			{Start: 2.1, End: 2.8, Word: "Yes."},
+			{Start: 3.1, End: 3.5, Word: "Well"},
+			{Start: 4.1, End: 4.3, Word: "I"},
+			{Start: 4.4, End: 5.0, Word: "think"},
+			{Start: 5.1, End: 5.8, Word: "so"},
+		},
+	}
+	transcriptJSON, _ := json.Marshal(transcript)
+	stored := string(transcriptJSON)
+	job := &models.TranscriptionJob{ID: "job", AudioPath: audio, Transcript: &stored}
+
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), root, root)
+	merged, diarization, err := service.DiarizeTranscript(context.Background(), job, models.WhisperXParams{DiarizeModel: "turns_diarizer"})
+	if err != nil {
+		t.Fatalf("diarization failed: %v", err)
+	}
+	if diarization.SpeakerCount != 2 {
+		t.Errorf("unexpected diarization: %+v", diarization)
+	}
+
+	var got []string
+	for _, segment := range merged.Segments {
+		got = append(got, speakerOf(segment.Speaker)+": "+segment.Text)
+	}
+	want := []string{"B: Hi there.", "A: Yes.", "B: Well, I think so!"}
+	if len(got) != len(want) {
+		t.Fatalf("segments = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("segment %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if speakerOf(merged.WordSegments[3].Speaker) != "A" {
+		t.Errorf("word speaker = %s", speakerOf(merged.WordSegments[3].Speaker))
+	}
+
+	if _, _, err := service.DiarizeTranscript(context.Background(), job, models.WhisperXParams{DiarizeModel: "missing"}); !errors.Is(err, ErrUnknownDiarizationModel) {
+		t.Errorf("expected an unknown model error, got %v", err)
+	}
+}
@@ -0,0 +1,517 @@
+// Package environments inspects, installs and removes the environments and
+// model caches adapters keep on disk
+package environments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"scriberr/internal/sse"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/registry"
+	"scriberr/pkg/logger"
+)
+
+// EventsChannel is the SSE channel install and removal events are broadcast on
+const EventsChannel = "models"
+
+// Task states
+const (
+	TaskRunning   = "running"
+	TaskCompleted = "completed"
+	TaskFailed    = "failed"
+)
+
+// progressInterval is how often a running install reports its disk usage
+var progressInterval = 5 * time.Second
+
+var (
+	ErrNotFound      = errors.New("model not found")
+	ErrBusy          = errors.New("an install is already running for this model")
+	ErrNoVariants    = errors.New("model does not manage variants")
+	ErrNotInstalled  = errors.New("model variant is not installed")
+	ErrNothingOnDisk = errors.New("model keeps nothing on disk")
+	ErrShared        = errors.New("environment is shared with other models")
+	ErrInUse         = errors.New("model is used by queued or running jobs")
+)
+
+// UsageFunc returns the IDs of queued or running jobs that may use any of the models
+type UsageFunc func(ctx context.Context, modelIDs []string) ([]string, error)
+
+// HoldFunc stops queued jobs from starting until the returned function is called
+type HoldFunc func() (release func())
+
+// InstalledModel is a model variant downloaded by an adapter
+type InstalledModel struct {
+	Name           string `json:"name"`
+	Path           string `json:"path"`
+	DiskUsageBytes int64  `json:"disk_usage_bytes"`
+}
+
+// Environment reports what an adapter keeps on disk
+type Environment struct {
+	ModelID         string           `json:"model_id"`
+	Type            string           `json:"type"` // transcription or diarization
+	Path            string           `json:"path,omitempty"`
+	SharedWith      []string         `json:"shared_with,omitempty"`
+	Exists          bool             `json:"exists"`
+	Ready           bool             `json:"ready"`
+	DiskUsageBytes  int64            `json:"disk_usage_bytes"`
+	SupportedModels []string         `json:"supported_models,omitempty"`
+	InstalledModels []InstalledModel `json:"installed_models,omitempty"`
+	Task            *Task            `json:"task,omitempty"`
+}
+
+// Task is a background install of an environment or model variant
+type Task struct {
+	ModelID     string     `json:"model_id"`
+	Model       string     `json:"model,omitempty"`
+	Reinstall   bool       `json:"reinstall"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	BytesOnDisk int64      `json:"bytes_on_disk"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// entry is a registered adapter and its kind
+type entry struct {
+	modelID string
+	kind    string
+	adapter interfaces.ModelAdapter
+}
+
+// Manager runs environment operations for the registered adapters
+type Manager struct {
+	registry    *registry.ModelRegistry
+	broadcaster *sse.Broadcaster
+
+	mu    sync.Mutex
+	tasks map[string]*Task // latest task per model ID
+	usage UsageFunc
+	hold  HoldFunc
+}
+
+// NewManager creates a manager for the adapters in the global registry.
+// The broadcaster may be nil.
+func NewManager(broadcaster *sse.Broadcaster) *Manager {
+	return &Manager{
+		registry:    registry.GetRegistry(),
+		broadcaster: broadcaster,
+		tasks:       make(map[string]*Task),
+	}
+}
+
+// SetUsageChecker makes the manager refuse to remove or reinstall environments
+// that queued or running jobs may use
+func (m *Manager) SetUsageChecker(usage UsageFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.usage = usage
+}
+
+// SetDispatchHold makes the manager keep queued jobs from starting while it
+// checks and removes an environment, so no job starts on one being deleted
+func (m *Manager) SetDispatchHold(hold HoldFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hold = hold
+}
+
+// holdDispatch keeps queued jobs from starting until the returned function is
+// called. Calling it more than once releases the hold once.
+func (m *Manager) holdDispatch() func() {
+	m.mu.Lock()
+	hold := m.hold
+	m.mu.Unlock()
+
+	if hold == nil {
+		return func() {}
+	}
+	var once sync.Once
+	release := hold()
+	return func() { once.Do(release) }
+}
+
+// checkNotInUse fails when an install task runs for any of the entries or a
+// job may use them
+func (m *Manager) checkNotInUse(ctx context.Context, entries []entry) error {
+	modelIDs := sharedIDs(entries)
+
+	m.mu.Lock()
+	usage := m.usage
+	for _, modelID := range modelIDs {
+		if current, ok := m.tasks[modelID]; ok && current.Status == TaskRunning {
+			m.mu.Unlock()
+			return ErrBusy
+		}
+	}
+	m.mu.Unlock()
+
+	if usage == nil {
+		return nil
+	}
+	jobIDs, err := usage(ctx, modelIDs)
+	if err != nil {
+		return fmt.Errorf("failed to check jobs using the model: %w", err)
+	}
+	if len(jobIDs) > 0 {
+		return fmt.Errorf("%w: %s", ErrInUse, strings.Join(jobIDs, ", "))
+	}
+	return nil
+}
+
+// entries returns the registered adapters sorted by model ID
+func (m *Manager) entries() []entry {
+	var entries []entry
+	for _, modelID := range m.registry.GetTranscriptionModels() {
+		if adapter, err := m.registry.GetTranscriptionAdapter(modelID); err == nil {
+			entries = append(entries, entry{modelID: modelID, kind: "transcription", adapter: adapter})
+		}
+	}
+	for _, modelID := range m.registry.GetDiarizationModels() {
+		if adapter, err := m.registry.GetDiarizationAdapter(modelID); err == nil {
+			entries = append(entries, entry{modelID: modelID, kind: "diarization", adapter: adapter})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].modelID < entries[j].modelID })
+	return entries
+}
+
+// find returns the adapter registered under modelID and the other adapters
+// sharing its environment
+func (m *Manager) find(modelID string) (entry, []entry, error) {
+	entries := m.entries()
+	for _, e := range entries {
+		if e.modelID == modelID {
+			return e, sharing(entries, e), nil
+		}
+	}
+	return entry{}, nil, ErrNotFound
+}
+
+// sharing returns the other entries whose environment is the same directory as e's
+func sharing(entries []entry, e entry) []entry {
+	var shared []entry
+	for _, other := range entries {
+		if other.modelID != e.modelID && e.adapter.GetModelPath() != "" && other.adapter.GetModelPath() == e.adapter.GetModelPath() {
+			shared = append(shared, other)
+		}
+	}
+	return shared
+}
+
+// List reports the environment of every registered adapter
+func (m *Manager) List(ctx context.Context) []Environment {
+	entries := m.entries()
+	environments := make([]Environment, 0, len(entries))
+	for _, e := range entries {
+		environments = append(environments, m.describe(ctx, e, sharing(entries, e)))
+	}
+	return environments
+}
+
+// Get reports the environment of one adapter
+func (m *Manager) Get(ctx context.Context, modelID string) (Environment, error) {
+	e, shared, err := m.find(modelID)
+	if err != nil {
+		return Environment{}, err
+	}
+	return m.describe(ctx, e, shared), nil
+}
+
+// describe builds the report for an adapter
+func (m *Manager) describe(ctx context.Context, e entry, shared []entry) Environment {
+	env := Environment{
+		ModelID: e.modelID,
+		Type:    e.kind,
+		Path:    e.adapter.GetModelPath(),
+		Ready:   e.adapter.IsReady(ctx),
+		Task:    m.task(e.modelID),
+	}
+	if len(shared) > 0 {
+		env.SharedWith = sharedIDs(shared)
+	}
+	if env.Path != "" {
+		if _, err := os.Stat(env.Path); err == nil {
+			env.Exists = true
+			env.DiskUsageBytes = diskUsage(env.Path)
+		}
+	}
+	if transcriber, ok := e.adapter.(interfaces.TranscriptionAdapter); ok {
+		env.SupportedModels = transcriber.GetSupportedModels()
+	}
+	if variants, ok := e.adapter.(interfaces.ModelVariantManager); ok {
+		installed := variants.InstalledModels()
+		names := make([]string, 0, len(installed))
+		for name := range installed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			env.InstalledModels = append(env.InstalledModels, InstalledModel{
+				Name:           name,
+				Path:           installed[name],
+				DiskUsageBytes: diskUsage(installed[name]),
+			})
+		}
+	}
+	return env
+}
+
+// task returns a copy of the latest task of a model
+func (m *Manager) task(modelID string) *Task {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if task, ok := m.tasks[modelID]; ok {
+		copied := *task
+		return &copied
+	}
+	return nil
+}
+
+// Install prepares an adapter's environment in the background, or downloads
+// one of its model variants when model is set. With reinstall the environment
+// is deleted first, which updates it to the latest dependencies; that is
+// refused while jobs may use it or a model sharing it.
+func (m *Manager) Install(ctx context.Context, modelID, model string, reinstall bool) (*Task, error) {
+	e, shared, err := m.find(modelID)
+	if err != nil {
+		return nil, err
+	}
+	variants, hasVariants := e.adapter.(interfaces.ModelVariantManager)
+	if model != "" && !hasVariants {
+		return nil, ErrNoVariants
+	}
+	// Jobs are held until the old environment is gone, so none starts on it
+	// between the usage check and the removal
+	release := func() {}
+	if reinstall {
+		release = m.holdDispatch()
+		if err := m.checkNotInUse(ctx, append([]entry{e}, shared...)); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	m.mu.Lock()
+	busy := []entry{e}
+	if reinstall {
+		busy = append(busy, shared...)
+	}
+	for _, other := range busy {
+		if current, ok := m.tasks[other.modelID]; ok && current.Status == TaskRunning {
+			m.mu.Unlock()
+			release()
+			return nil, ErrBusy
+		}
+	}
+	task := &Task{ModelID: modelID, Model: model, Reinstall: reinstall, Status: TaskRunning, StartedAt: time.Now()}
+	m.tasks[modelID] = task
+	copied := *task
+	m.mu.Unlock()
+
+	m.broadcast("model_install_started", copied)
+
+	go m.runInstall(task, e, shared, variants, model, reinstall, release)
+	return &copied, nil
+}
+
+// runInstall performs an install and reports its progress. release lets held
+// jobs start again; it is called once the old environment is removed.
+func (m *Manager) runInstall(task *Task, e entry, shared []entry, variants interfaces.ModelVariantManager, model string, reinstall bool, release func()) {
+	defer release()
+	ctx := context.Background()
+	path := e.adapter.GetModelPath()
+	if model != "" {
+		if installed, ok := variants.InstalledModels()[model]; ok {
+			path = installed
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				m.mu.Lock()
+				if path != "" {
+					task.BytesOnDisk = diskUsage(path)
+				}
+				copied := *task
+				m.mu.Unlock()
+				m.broadcast("model_install_progress", copied)
+			}
+		}
+	}()
+
+	logger.Info("Installing model environment", "model_id", e.modelID, "model", model, "reinstall", reinstall)
+	err := m.install(ctx, e, shared, variants, model, reinstall, release)
+	close(done)
+
+	finished := time.Now()
+	m.mu.Lock()
+	task.FinishedAt = &finished
+	task.Status = TaskCompleted
+	if err != nil {
+		task.Status = TaskFailed
+		task.Error = err.Error()
+	}
+	if path != "" {
+		task.BytesOnDisk = diskUsage(path)
+	}
+	copied := *task
+	m.mu.Unlock()
+
+	if err != nil {
+		logger.Error("Model environment install failed", "model_id", e.modelID, "model", model, "error", err)
+		m.broadcast("model_install_failed", copied)
+		return
+	}
+	logger.Info("Model environment installed", "model_id", e.modelID, "model", model)
+	m.broadcast("model_install_completed", copied)
+}
+
+// install prepares the environment, then downloads the variant if one was asked for
+func (m *Manager) install(ctx context.Context, e entry, shared []entry, variants interfaces.ModelVariantManager, model string, reinstall bool, release func()) error {
+	if reinstall {
+		err := removeEnvironment(e, shared)
+		release()
+		if err != nil {
+			return err
+		}
+		// Adapters sharing the environment lost their files too
+		for _, user := range append([]entry{e}, shared...) {
+			if err := user.adapter.PrepareEnvironment(ctx); err != nil {
+				return fmt.Errorf("%s: %w", user.modelID, err)
+			}
+		}
+	} else if !e.adapter.IsReady(ctx) {
+		if err := e.adapter.PrepareEnvironment(ctx); err != nil {
+			return err
+		}
+	}
+	if model != "" {
+		return variants.InstallModel(ctx, model)
+	}
+	return nil
+}
+
+// Remove deletes an adapter's environment, or one of its model variants when
+// model is set. An environment shared with other adapters is only removed
+// with force, and becomes unavailable to all of them until installed again.
+// Nothing is removed while jobs may use the model or one sharing it.
+func (m *Manager) Remove(ctx context.Context, modelID, model string, force bool) error {
+	e, shared, err := m.find(modelID)
+	if err != nil {
+		return err
+	}
+
+	// Jobs are held until the removal is done, so none starts on the
+	// environment between the usage check and the removal
+	release := m.holdDispatch()
+	defer release()
+	if err := m.checkNotInUse(ctx, append([]entry{e}, shared...)); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, other := range append([]entry{e}, shared...) {
+		if current, ok := m.tasks[other.modelID]; ok && current.Status == TaskRunning {
+			return ErrBusy
+		}
+	}
+
+	payload := map[string]interface{}{"model_id": modelID}
+	if model != "" {
+		variants, ok := e.adapter.(interfaces.ModelVariantManager)
+		if !ok {
+			return ErrNoVariants
+		}
+		if _, ok := variants.InstalledModels()[model]; !ok {
+			return fmt.Errorf("%w: %s", ErrNotInstalled, model)
+		}
+		if err := variants.RemoveModel(model); err != nil {
+			return err
+		}
+		payload["model"] = model
+	} else {
+		if len(shared) > 0 && !force {
+			return fmt.Errorf("%w: %s", ErrShared, strings.Join(sharedIDs(shared), ", "))
+		}
+		if err := removeEnvironment(e, shared); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Removed model environment", "model_id", modelID, "model", model)
+	m.broadcast("model_removed", payload)
+	return nil
+}
+
+// removeEnvironment deletes an adapter's environment directory and resets
+// every adapter using it
+func removeEnvironment(e entry, shared []entry) error {
+	path := e.adapter.GetModelPath()
+	if path == "" {
+		return ErrNothingOnDisk
+	}
+	if clean := filepath.Clean(path); clean == "." || clean == string(filepath.Separator) {
+		return fmt.Errorf("refusing to remove %q", path)
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	for _, user := range append([]entry{e}, shared...) {
+		if resetter, ok := user.adapter.(interfaces.EnvironmentResetter); ok {
+			resetter.ResetEnvironment()
+		}
+	}
+	return nil
+}
+
+// sharedIDs lists the model IDs of entries
+func sharedIDs(entries []entry) []string {
+	ids := make([]string, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.modelID)
+	}
+	return ids
+}
+
+// broadcast sends an event on the models channel
+func (m *Manager) broadcast(eventType string, payload interface{}) {
+	if m.broadcaster != nil {
+		m.broadcaster.Broadcast(EventsChannel, eventType, payload)
+	}
+}
+
+// diskUsage returns the total size of the files under path
+func diskUsage(path string) int64 {
+	var total int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	return total
+}
@@ -0,0 +1,293 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/pkg/logger"
+)
+
+// Outcomes of a model attempt
+const (
+	AttemptSucceeded = "succeeded"
+	AttemptFailed    = "failed"
+)
+
+// ModelAttempt records one model tried for a stage of a job
+type ModelAttempt struct {
+	Stage      string `json:"stage"` // transcription or diarization
+	ModelID    string `json:"model_id"`
+	Model      string `json:"model,omitempty"`
+	Ready      bool   `json:"ready"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// modelCandidate is one entry of a fallback chain: the adapter to run and the
+// profile parameters rewritten for it
+type modelCandidate struct {
+	ModelID string
+	Params  models.WhisperXParams
+	Ready   bool
+}
+
+// transcriptionModelForFamily maps a model family to the adapter that serves
+// it. Adapters configured at runtime, such as OpenAI-compatible servers and
+// plugins, are selected by their model ID.
+func (u *UnifiedTranscriptionService) transcriptionModelForFamily(family string) (string, bool) {
+	switch family {
+	case FamilyNvidiaParakeet:
+		return ModelParakeet, true
+	case FamilyNvidiaCanary:
+		return ModelCanary, true
+	case FamilyWhisper:
+		return ModelWhisperX, true
+	case FamilyOpenAI:
+		return ModelOpenAI, true
+	case FamilyMistralVoxtral:
+		return ModelVoxtral, true
+	case FamilyWhisperCpp:
+		return ModelWhisperCpp, true
+	}
+	if _, err := u.registry.GetTranscriptionAdapter(family); err == nil && family != "" {
+		return family, true
+	}
+	return "", false
+}
+
+// diarizationModelForName maps a profile's diarization model to its adapter.
+// Diarization plugins are selected by their model ID.
+func (u *UnifiedTranscriptionService) diarizationModelForName(name string) (string, bool) {
+	switch name {
+	case DiarizeSortformer:
+		return ModelSortformer, true
+	case ModelPyannote, ModelDiarization31:
+		return ModelPyannote, true
+	}
+	if _, err := u.registry.GetDiarizationAdapter(name); err == nil && name != "" {
+		return name, true
+	}
+	return "", false
+}
+
+// parseFallbackList splits a comma-separated fallback setting
+func parseFallbackList(value *string) []string {
+	if value == nil {
+		return nil
+	}
+	var entries []string
+	for _, entry := range strings.Split(*value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// transcriptionCandidates returns the profile's transcription model followed
+// by its fallbacks. A fallback is a model family, optionally followed by
+// ':model' to use a different model of that family.
+func (u *UnifiedTranscriptionService) transcriptionCandidates(ctx context.Context, params models.WhisperXParams) []modelCandidate {
+	primary, ok := u.transcriptionModelForFamily(params.ModelFamily)
+	if !ok {
+		primary = ModelWhisperX // Default fallback
+	}
+	candidates := []modelCandidate{{ModelID: primary, Params: params}}
+
+	for _, entry := range parseFallbackList(params.FallbackModels) {
+		family, model, _ := strings.Cut(entry, ":")
+		modelID, ok := u.transcriptionModelForFamily(family)
+		if !ok {
+			logger.Warn("Ignoring unknown fallback model family", "family", family)
+			continue
+		}
+		fallback := params
+		fallback.ModelFamily = family
+		if model != "" {
+			fallback.Model = model
+		}
+		candidates = append(candidates, modelCandidate{ModelID: modelID, Params: fallback})
+	}
+
+	return orderByReadiness(dedupeCandidates(candidates), func(modelID string) bool {
+		adapter, err := u.registry.GetTranscriptionAdapter(modelID)
+		return err == nil && adapter.IsReady(ctx)
+	})
+}
+
+// diarizationCandidates returns the profile's diarization model followed by its
+// fallbacks, or nothing when diarization is off
+func (u *UnifiedTranscriptionService) diarizationCandidates(ctx context.Context, params models.WhisperXParams) []modelCandidate {
+	if !params.Diarize {
+		return nil
+	}
+	primary, ok := u.diarizationModelForName(params.DiarizeModel)
+	if !ok {
+		primary = ModelPyannote // Default fallback
+	}
+	candidates := []modelCandidate{{ModelID: primary, Params: params}}
+
+	for _, name := range parseFallbackList(params.FallbackDiarizeModels) {
+		modelID, ok := u.diarizationModelForName(name)
+		if !ok {
+			logger.Warn("Ignoring unknown fallback diarization model", "model", name)
+			continue
+		}
+		fallback := params
+		fallback.DiarizeModel = name
+		candidates = append(candidates, modelCandidate{ModelID: modelID, Params: fallback})
+	}
+
+	return orderByReadiness(dedupeCandidates(candidates), func(modelID string) bool {
+		adapter, err := u.registry.GetDiarizationAdapter(modelID)
+		return err == nil && adapter.IsReady(ctx)
+	})
+}
+
+// dedupeCandidates drops entries that would run the same adapter and model again
+func dedupeCandidates(candidates []modelCandidate) []modelCandidate {
+	seen := map[string]bool{}
+	unique := candidates[:0]
+	for _, candidate := range candidates {
+		key := candidate.ModelID + "\x00" + candidate.Params.Model + "\x00" + candidate.Params.DiarizeModel
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, candidate)
+	}
+	return unique
+}
+
+// orderByReadiness moves models whose adapter is not ready behind the ready
+// ones. They stay in the chain, since some adapters only become usable with
+// the job's parameters, such as an API key set in the profile.
+func orderByReadiness(candidates []modelCandidate, isReady func(modelID string) bool) []modelCandidate {
+	var ready, notReady []modelCandidate
+	for _, candidate := range candidates {
+		candidate.Ready = isReady(candidate.ModelID)
+		if candidate.Ready {
+			ready = append(ready, candidate)
+		} else {
+			if len(candidates) > 1 {
+				logger.Warn("Model not ready, trying it after the ready ones", "model_id", candidate.ModelID)
+			}
+			notReady = append(notReady, candidate)
+		}
+	}
+	return append(ready, notReady...)
+}
+
+// transcribeWithFallbacks runs the transcription chain until a model succeeds.
+// The input is preprocessed for the first model; fallbacks get it converted
+// to the format they need. A cancelled job is not retried.
+func (u *UnifiedTranscriptionService) transcribeWithFallbacks(ctx context.Context, chain []modelCandidate, input interfaces.AudioInput, procCtx interfaces.ProcessingContext, attempts *[]ModelAttempt) (*interfaces.TranscriptResult, modelCandidate, error) {
+	for i, candidate := range chain {
+		logger.Info("Running transcription", "model_id", candidate.ModelID)
+		started := time.Now()
+		candidateInput, cleanup := input, func() {}
+		var err error
+		if i > 0 {
+			candidateInput, cleanup, err = u.fallbackInput(ctx, candidate, input)
+		}
+		var result *interfaces.TranscriptResult
+		if err == nil {
+			result, err = u.runTranscription(ctx, candidate, candidateInput, procCtx)
+			cleanup()
+		}
+		u.recordAttempt(attempts, procCtx, "transcription", candidate, started, err)
+		if err == nil {
+			return result, candidate, nil
+		}
+		if ctx.Err() != nil || i == len(chain)-1 {
+			return nil, candidate, err
+		}
+		u.reportFallback(procCtx, "transcription", candidate, chain[i+1])
+	}
+	return nil, modelCandidate{}, fmt.Errorf("no transcription model configured")
+}
+
+// fallbackInput converts the audio to the format a fallback model needs,
+// since the chain's first model may have taken it without conversion. The
+// returned function removes the converted file.
+func (u *UnifiedTranscriptionService) fallbackInput(ctx context.Context, candidate modelCandidate, input interfaces.AudioInput) (interfaces.AudioInput, func(), error) {
+	adapter, err := u.registry.GetTranscriptionAdapter(candidate.ModelID)
+	if err != nil {
+		return input, func() {}, fmt.Errorf("failed to get transcription adapter: %w", err)
+	}
+	converted, _, err := u.pipeline.ProcessAudio(ctx, input, adapter.GetCapabilities(), nil, nil)
+	cleanup := func() {}
+	if path := converted.TempFilePath; path != "" && path != input.FilePath && path != input.TempFilePath {
+		cleanup = func() { _ = os.Remove(path) }
+	}
+	if err != nil {
+		cleanup()
+		return input, func() {}, fmt.Errorf("audio preprocessing failed: %w", err)
+	}
+	return converted, cleanup, nil
+}
+
+// diarizeWithFallbacks runs the diarization chain until a model succeeds
+func (u *UnifiedTranscriptionService) diarizeWithFallbacks(ctx context.Context, chain []modelCandidate, input interfaces.AudioInput, procCtx interfaces.ProcessingContext, attempts *[]ModelAttempt) (*interfaces.DiarizationResult, modelCandidate, error) {
+	for i, candidate := range chain {
+		logger.Info("Running separate diarization", "model_id", candidate.ModelID)
+		started := time.Now()
+		result, err := u.runDiarization(ctx, candidate, input, procCtx)
+		u.recordAttempt(attempts, procCtx, "diarization", candidate, started, err)
+		if err == nil {
+			return result, candidate, nil
+		}
+		if ctx.Err() != nil || i == len(chain)-1 {
+			return nil, candidate, err
+		}
+		u.reportFallback(procCtx, "diarization", candidate, chain[i+1])
+	}
+	return nil, modelCandidate{}, fmt.Errorf("no diarization model configured")
+}
+
+// recordAttempt adds the outcome of running a model to the job's attempts and
+// writes it to the job log
+func (u *UnifiedTranscriptionService) recordAttempt(attempts *[]ModelAttempt, procCtx interfaces.ProcessingContext, stage string, candidate modelCandidate, started time.Time, err error) {
+	attempt := ModelAttempt{
+		Stage:      stage,
+		ModelID:    candidate.ModelID,
+		Ready:      candidate.Ready,
+		Status:     AttemptSucceeded,
+		DurationMs: time.Since(started).Milliseconds(),
+	}
+	if stage == "transcription" {
+		attempt.Model = candidate.Params.Model
+	}
+	if err != nil {
+		attempt.Status = AttemptFailed
+		attempt.Error = err.Error()
+	}
+	*attempts = append(*attempts, attempt)
+
+	if err != nil {
+		appendJobLog(procCtx.OutputDirectory, fmt.Sprintf("%s with %s failed: %v", stage, candidate.ModelID, err))
+	}
+}
+
+// reportFallback tells the job log and listeners that the next model of a
+// chain is being tried
+func (u *UnifiedTranscriptionService) reportFallback(procCtx interfaces.ProcessingContext, stage string, failed, next modelCandidate) {
+	message := fmt.Sprintf("Falling back from %s to %s for %s", failed.ModelID, next.ModelID, stage)
+	logger.Warn(message, "job_id", procCtx.JobID)
+	appendJobLog(procCtx.OutputDirectory, message)
+
+	if u.broadcaster != nil {
+		u.broadcaster.Broadcast(procCtx.JobID, "job_progress", map[string]interface{}{
+			"job_id":   procCtx.JobID,
+			"stage":    stage,
+			"fallback": next.ModelID,
+			"message":  message,
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package transcription
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/adapters"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/registry"
+)
+
+// registerFallbackPlugins registers plugins that fail, succeed, or are never
+// prepared and so not ready
+func registerFallbackPlugins(t *testing.T, root string) {
+	t.Helper()
+	succeed := `"command": ["sh", "-c", "printf '{\"segments\": [{\"start\": 0, \"end\": 1, \"text\": \"ok\", \"speaker\": \"A\"}]}' > {{.Output}}"]`
+	fail := `"command": ["sh", "-c", "echo '{\"error\": \"CUDA missing\"}' > {{.Output}}; exit 1"]`
+	manifests := map[string]string{
+		"broken_asr":  `{"id": "broken_asr", "type": "transcription", ` + fail + `}`,
+		"good_asr":    `{"id": "good_asr", "type": "transcription", "parameters": [{"name": "model", "type": "string"}], ` + succeed + `}`,
+		"idle_asr":    `{"id": "idle_asr", "type": "transcription", ` + succeed + `}`,
+		"broken_diar": `{"id": "broken_diar", "type": "diarization", ` + fail + `}`,
+		"good_diar":   `{"id": "good_diar", "type": "diarization", ` + succeed + `}`,
+	}
+	for name, manifest := range manifests {
+		writePlugin(t, root, name, map[string]string{"plugin.json": manifest})
+	}
+	loaded, errs := adapters.LoadPluginManifests(root)
+	if len(errs) > 0 || len(loaded) != len(manifests) {
+		t.Fatalf("failed to load plugins: %v", errs)
+	}
+
+	for _, manifest := range loaded {
+		adapter := adapters.NewPluginAdapter(manifest)
+		if manifest.ID != "idle_asr" {
+			if err := adapter.PrepareEnvironment(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if manifest.Type == adapters.PluginTypeDiarization {
+			registry.RegisterDiarizationAdapter(manifest.ID, adapter)
+		} else {
+			registry.RegisterTranscriptionAdapter(manifest.ID, adapter)
+		}
+	}
+}
+
+func TestFallbackChainOrder(t *testing.T) {
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+	registerFallbackPlugins(t, t.TempDir())
+
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), "data/temp", "data/transcripts")
+	params := models.WhisperXParams{
+		ModelFamily:           "idle_asr",
+		Model:                 "large",
+		FallbackModels:        stringPtr("unknown_family, broken_asr, good_asr:tiny, broken_asr"),
+		Diarize:               true,
+		DiarizeModel:          "broken_diar",
+		FallbackDiarizeModels: stringPtr("good_diar"),
+	}
+
+	chain := service.transcriptionCandidates(context.Background(), params)
+	var ids []string
+	for _, candidate := range chain {
+		ids = append(ids, candidate.ModelID)
+	}
+	// Unknown families and repeats are dropped, models that are not ready go last
+	if strings.Join(ids, ",") != "broken_asr,good_asr,idle_asr" {
+		t.Fatalf("unexpected chain %v", ids)
+	}
+	if chain[1].Params.Model != "tiny" || chain[1].Params.ModelFamily != "good_asr" || chain[2].Params.Model != "large" {
+		t.Errorf("expected each fallback to carry its own model, got %+v", chain)
+	}
+	if chain[2].Ready {
+		t.Error("expected the unprepared plugin to be reported as not ready")
+	}
+
+	transcriptionID := service.transcriptionCandidates(context.Background(), params)[0].ModelID
+	diarizationID := service.diarizationCandidates(context.Background(), params)[0].ModelID
+	if transcriptionID != "broken_asr" || diarizationID != "broken_diar" {
+		t.Errorf("expected the first ready models, got %s and %s", transcriptionID, diarizationID)
+	}
+
+	// Without fallbacks the selection is unchanged
+	params.FallbackModels = nil
+	if chain := service.transcriptionCandidates(context.Background(), params); len(chain) != 1 || chain[0].ModelID != "idle_asr" {
+		t.Errorf("expected only the selected model, got %+v", chain)
+	}
+	params.Diarize = false
+	if chain := service.diarizationCandidates(context.Background(), params); len(chain) != 0 {
+		t.Errorf("expected no diarization chain, got %+v", chain)
+	}
+}
+
+func TestFallbackOnAdapterFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+	root := t.TempDir()
+	registerFallbackPlugins(t, root)
+
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), "data/temp", "data/transcripts")
+	params := models.WhisperXParams{
+		ModelFamily:           "broken_asr",
+		FallbackModels:        stringPtr("good_asr:tiny"),
+		Diarize:               true,
+		DiarizeModel:          "broken_diar",
+		FallbackDiarizeModels: stringPtr("good_diar"),
+	}
+
+	audio := filepath.Join(root, "audio.wav")
+	if err := os.WriteFile(audio, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Already converted by the format preprocessor
+	input := interfaces.AudioInput{FilePath: audio, Format: "wav", SampleRate: 16000, Channels: 1, Size: 4}
+	procCtx := interfaces.ProcessingContext{JobID: "job", OutputDirectory: root, TempDirectory: root}
+
+	var attempts []ModelAttempt
+	result, used, err := service.transcribeWithFallbacks(context.Background(), service.transcriptionCandidates(context.Background(), params), input, procCtx, &attempts)
+	if err != nil || result.Text != "ok" || used.ModelID != "good_asr" {
+		t.Fatalf("expected the fallback to transcribe, got %+v from %s (%v)", result, used.ModelID, err)
+	}
+	_, usedDiarization, err := service.diarizeWithFallbacks(context.Background(), service.diarizationCandidates(context.Background(), params), input, procCtx, &attempts)
+	if err != nil || usedDiarization.ModelID != "good_diar" {
+		t.Fatalf("expected the fallback to diarize, got %s (%v)", usedDiarization.ModelID, err)
+	}
+
+	if len(attempts) != 4 || attempts[0].Status != AttemptFailed || !strings.Contains(attempts[0].Error, "CUDA missing") ||
+		attempts[1].Status != AttemptSucceeded || attempts[1].Model != "tiny" {
+		t.Errorf("unexpected attempts: %+v", attempts)
+	}
+
+	execution := &models.TranscriptionJobExecution{}
+	service.recordModels(execution, &attempts)
+	if execution.TranscriptionModel == nil || *execution.TranscriptionModel != "good_asr" ||
+		execution.DiarizationModel == nil || *execution.DiarizationModel != "good_diar" ||
+		execution.ModelAttempts == nil || !strings.Contains(*execution.ModelAttempts, `"model_id":"broken_asr"`) {
+		t.Errorf("expected the models used to be recorded, got %+v", execution)
+	}
+	if log, _ := os.ReadFile(filepath.Join(root, "transcription.log")); !strings.Contains(string(log), "Falling back from broken_asr to good_asr") {
+		t.Errorf("expected the fallback in the job log, got %s", log)
+	}
+
+	// The last model's error is returned once the chain is exhausted
+	params.FallbackModels = stringPtr("broken_asr")
+	if _, _, err := service.transcribeWithFallbacks(context.Background(), service.transcriptionCandidates(context.Background(), params), input, procCtx, &attempts); err == nil || !strings.Contains(err.Error(), "CUDA missing") {
+		t.Errorf("expected the chain to fail, got %v", err)
+	}
+}
+
+// formatAdapter fails or records the audio it is given
+type formatAdapter struct {
+	*adapters.BaseAdapter
+	fail  bool
+	input interfaces.AudioInput
+}
+
+func (f *formatAdapter) Transcribe(ctx context.Context, input interfaces.AudioInput, params map[string]interface{}, procCtx interfaces.ProcessingContext) (*interfaces.TranscriptResult, error) {
+	if err := f.ValidateAudioInput(input); err != nil {
+		return nil, err
+	}
+	if f.fail {
+		return nil, errors.New("rate limited")
+	}
+	f.input = input
+	return &interfaces.TranscriptResult{Text: "ok"}, nil
+}
+
+func (f *formatAdapter) GetSupportedModels() []string { return nil }
+
+func TestFallbackConvertsAudioForWavOnlyModel(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ffmpeg is a shell script")
+	}
+
+	// ffmpeg writes a WAV file to its last argument
+	root := t.TempDir()
+	bin := filepath.Join(root, "bin")
+	if err := os.MkdirAll(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	ffmpeg := "#!/bin/sh\nfor last; do :; done\nprintf RIFF > \"$last\"\n"
+	if err := os.WriteFile(filepath.Join(bin, "ffmpeg"), []byte(ffmpeg), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+	remote := &formatAdapter{fail: true, BaseAdapter: adapters.NewBaseAdapter("fake_openai", "",
+		interfaces.ModelCapabilities{ModelID: "fake_openai", ModelFamily: "openai", SupportedFormats: []string{"mp3", "wav"}}, nil)}
+	local := &formatAdapter{BaseAdapter: adapters.NewBaseAdapter("fake_wav", "",
+		interfaces.ModelCapabilities{ModelID: "fake_wav", ModelFamily: "fake_wav", SupportedFormats: []string{"wav"}}, nil)}
+	for _, adapter := range []*formatAdapter{remote, local} {
+		if err := adapter.PrepareEnvironment(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		registry.RegisterTranscriptionAdapter(adapter.GetCapabilities().ModelID, adapter)
+	}
+
+	// The OpenAI family skips the format conversion, so the chain starts with the upload
+	audio := filepath.Join(root, "upload.mp3")
+	if err := os.WriteFile(audio, []byte("ID3"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	input := interfaces.AudioInput{FilePath: audio, Format: "mp3", Size: 3}
+	procCtx := interfaces.ProcessingContext{JobID: "job", OutputDirectory: root, TempDirectory: root}
+	chain := []modelCandidate{{ModelID: "fake_openai"}, {ModelID: "fake_wav"}}
+
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), root, root)
+	var attempts []ModelAttempt
+	result, used, err := service.transcribeWithFallbacks(context.Background(), chain, input, procCtx, &attempts)
+	if err != nil || result.Text != "ok" || used.ModelID != "fake_wav" {
+		t.Fatalf("expected the WAV-only fallback to transcribe, got %+v from %s (%v)", result, used.ModelID, err)
+	}
+	if local.input.Format != "wav" || local.input.SampleRate != 16000 || local.input.FilePath == audio {
+		t.Errorf("expected converted audio, got %+v", local.input)
+	}
+	if _, err := os.Stat(local.input.FilePath); !os.IsNotExist(err) {
+		t.Errorf("expected the converted audio to be removed, got %v", err)
+	}
+	if _, err := os.Stat(audio); err != nil {
+		t.Errorf("expected the upload to be kept: %v", err)
+	}
+}
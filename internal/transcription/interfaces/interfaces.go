@@ -20,6 +20,15 @@ type ModelCapabilities struct {
 	MemoryRequirement  int               `json:"memory_requirement_mb"`
 	Features           map[string]bool   `json:"features"`
 	Metadata           map[string]string `json:"metadata"`
+	Chunking           ChunkLimits       `json:"chunking"`
+}
+
+// ChunkLimits describes how much audio a model accepts in one call. Longer or
+// larger input is split into chunks first. Zero values mean no limit.
+type ChunkLimits struct {
+	MaxFileSizeBytes     int64   `json:"max_file_size_bytes,omitempty"`
+	MaxDurationSeconds   float64 `json:"max_duration_seconds,omitempty"`
+	ChunkDurationSeconds float64 `json:"chunk_duration_seconds,omitempty"` // Preferred chunk length when splitting
 }
 
 // ParameterSchema defines a parameter that a model accepts
@@ -62,6 +71,9 @@ type TranscriptSegment struct {
 	Text     string  `json:"text"`
 	Speaker  *string `json:"speaker,omitempty"`
 	Language *string `json:"language,omitempty"`
+
+	// Other speakers talking during the segment, set when merging a separate diarization
+	OverlappingSpeakers []string `json:"overlapping_speakers,omitempty"`
 }
 
 // TranscriptWord represents word-level timing information
@@ -71,6 +83,9 @@ type TranscriptWord struct {
 	Word    string  `json:"word"`
 	Score   float64 `json:"score"`
 	Speaker *string `json:"speaker,omitempty"`
+
+	// Other speakers talking during the word, set when merging a separate diarization
+	OverlappingSpeakers []string `json:"overlapping_speakers,omitempty"`
 }
 
 // TranscriptResult represents the output of transcription
@@ -170,6 +185,62 @@ type CompositeAdapter interface {
 	ProcessCombined(ctx context.Context, input AudioInput, params map[string]interface{}, procCtx ProcessingContext) (*TranscriptResult, *DiarizationResult, error)
 }
 
+// CustomModel is a fine-tuned checkpoint registered as a model variant of an adapter
+type CustomModel struct {
+	Name        string   `json:"name"` // Name profiles select it by
+	DisplayName string   `json:"display_name"`
+	Source      string   `json:"source"` // Local path or Hugging Face repo ID
+	Languages   []string `json:"languages,omitempty"`
+}
+
+// CustomModelAdapter is implemented by adapters that run custom checkpoints
+type CustomModelAdapter interface {
+	// SetCustomModels replaces the custom models the adapter accepts
+	SetCustomModels(models []CustomModel)
+
+	// GetCustomModels returns the custom models the adapter accepts
+	GetCustomModels() []CustomModel
+}
+
+// ModelVariantManager is implemented by adapters that download model variants
+// separately from their environment
+type ModelVariantManager interface {
+	// InstalledModels maps each downloaded variant to its location on disk
+	InstalledModels() map[string]string
+
+	// InstallModel downloads a variant
+	InstallModel(ctx context.Context, model string) error
+
+	// RemoveModel deletes a downloaded variant
+	RemoveModel(model string) error
+}
+
+// EnvironmentResetter is implemented by adapters whose environment can be
+// deleted and prepared again
+type EnvironmentResetter interface {
+	// ResetEnvironment forgets that the environment was prepared
+	ResetEnvironment()
+}
+
+// SpeakerEmbedder is implemented by diarization adapters that compute voice
+// embeddings, used to recognise enrolled speakers across recordings
+type SpeakerEmbedder interface {
+	// EmbedSpeakers returns one voice embedding per audio clip, in order
+	EmbedSpeakers(ctx context.Context, clips []string, params map[string]interface{}, procCtx ProcessingContext) ([][]float64, error)
+
+	// EmbeddingModel names the embedding model. Embeddings of different
+	// models cannot be compared.
+	EmbeddingModel() string
+}
+
+// WordAligner is implemented by adapters that time the words of an existing
+// transcript against its audio (forced alignment)
+type WordAligner interface {
+	// AlignWords returns the timed words of each segment, in order. A segment
+	// whose words could not be aligned gets none.
+	AlignWords(ctx context.Context, input AudioInput, segments []TranscriptSegment, params map[string]interface{}, procCtx ProcessingContext) ([][]TranscriptWord, error)
+}
+
 // ModelRequirements specifies what capabilities are needed for a job
 type ModelRequirements struct {
 	Language         string            `json:"language"`
@@ -0,0 +1,51 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+
+	"scriberr/internal/models"
+)
+
+// JobsUsingModels returns the IDs of pending and processing jobs that may run
+// on any of the given models, including their fallbacks. Jobs in auto mode
+// may be routed to any transcription model, so they count for all of them.
+func (u *UnifiedTranscriptionService) JobsUsingModels(ctx context.Context, modelIDs []string) ([]string, error) {
+	wanted := make(map[string]bool, len(modelIDs))
+	for _, modelID := range modelIDs {
+		wanted[modelID] = true
+	}
+
+	var jobIDs []string
+	for _, status := range []models.JobStatus{models.StatusPending, models.StatusProcessing} {
+		jobs, err := u.jobRepo.FindByStatus(ctx, status)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s jobs: %w", status, err)
+		}
+		for _, job := range jobs {
+			for _, modelID := range u.jobModels(ctx, job.Parameters) {
+				if wanted[modelID] {
+					jobIDs = append(jobIDs, job.ID)
+					break
+				}
+			}
+		}
+	}
+	return jobIDs, nil
+}
+
+// jobModels lists the models a job with these parameters may run on
+func (u *UnifiedTranscriptionService) jobModels(ctx context.Context, params models.WhisperXParams) []string {
+	var modelIDs []string
+	if params.ModelFamily == FamilyAuto {
+		modelIDs = u.registry.GetTranscriptionModels()
+	} else {
+		for _, candidate := range u.transcriptionCandidates(ctx, params) {
+			modelIDs = append(modelIDs, candidate.ModelID)
+		}
+	}
+	for _, candidate := range u.diarizationCandidates(ctx, params) {
+		modelIDs = append(modelIDs, candidate.ModelID)
+	}
+	return modelIDs
+}
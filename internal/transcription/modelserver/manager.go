@@ -0,0 +1,66 @@
+package modelserver
+
+import "sync"
+
+// Manager owns the model servers of all adapters
+type Manager struct {
+	opts Options
+
+	mu      sync.Mutex
+	servers map[string]*Server
+}
+
+// NewManager creates a manager whose servers use opts
+func NewManager(opts Options) *Manager {
+	return &Manager{
+		opts:    opts,
+		servers: make(map[string]*Server),
+	}
+}
+
+// Server returns the server registered under name, creating it with command
+// and env the first time. Nothing is started until it receives a request.
+func (m *Manager) Server(name string, command []string, env []string) *Server {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if server, ok := m.servers[name]; ok {
+		return server
+	}
+	server := NewServer(name, command, env, m.opts)
+	m.servers[name] = server
+	return server
+}
+
+// Stop stops the server registered under name. The next request for it starts
+// a new one.
+func (m *Manager) Stop(name string) {
+	m.mu.Lock()
+	server, ok := m.servers[name]
+	delete(m.servers, name)
+	m.mu.Unlock()
+
+	if ok {
+		server.Stop()
+	}
+}
+
+// StopAll stops every server
+func (m *Manager) StopAll() {
+	m.mu.Lock()
+	servers := make([]*Server, 0, len(m.servers))
+	for _, server := range m.servers {
+		servers = append(servers, server)
+	}
+	m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, server := range servers {
+		wg.Add(1)
+		go func(s *Server) {
+			defer wg.Done()
+			s.Stop()
+		}(server)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,17 @@
+//go:build windows
+
+package modelserver
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on Windows
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the server process. Windows lacks a simple process
+// group kill, so children it started may outlive it.
+func killProcessGroup(p *os.Process) error {
+	return p.Kill()
+}
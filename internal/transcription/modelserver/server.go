@@ -0,0 +1,475 @@
+// Package modelserver keeps adapter Python processes alive between jobs so
+// models are loaded once instead of for every transcription.
+//
+// A server is a child process speaking newline-delimited JSON-RPC 2.0 over its
+// stdin and stdout. Its stderr carries log output, which is copied to the log
+// of the request being served. Each server handles one request at a time.
+package modelserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"scriberr/pkg/logger"
+)
+
+// Options configures model server processes
+type Options struct {
+	IdleTimeout    time.Duration // Stop a server after this long without requests, 0 keeps it running
+	StartTimeout   time.Duration // Time a new process has to answer its first ping
+	HealthInterval time.Duration // How often an idle server is pinged, 0 disables health checks
+	PingTimeout    time.Duration // Time a ping may take before the server counts as hung
+}
+
+// DefaultOptions returns the settings used when none are configured
+func DefaultOptions() Options {
+	return Options{
+		IdleTimeout:    10 * time.Minute,
+		StartTimeout:   5 * time.Minute,
+		HealthInterval: time.Minute,
+		PingTimeout:    10 * time.Second,
+	}
+}
+
+// ErrServerExited is returned when the process dies while serving a request
+var ErrServerExited = errors.New("model server exited")
+
+// RPCError is an error reported by the server for a request
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("model server error %d: %s", e.Code, e.Message)
+}
+
+type request struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int64       `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+type response struct {
+	ID     int64           `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// Server manages one long-lived process. It is started on the first request,
+// restarted after it crashes or is killed, and stopped when idle.
+type Server struct {
+	name    string
+	command []string
+	env     []string
+	opts    Options
+
+	slot chan struct{} // Held while a request or health check is in flight
+
+	mu       sync.Mutex
+	proc     *process
+	idle     *time.Timer
+	restarts int
+	stopped  bool
+
+	nextID atomic.Int64
+}
+
+// process is one run of the server command
+type process struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	responses chan response
+	done      chan struct{} // Closed once the process has exited
+	exitErr   error
+
+	logMu sync.Mutex
+	log   io.Writer // Receives stderr while a request is served
+}
+
+// NewServer creates a server that runs command when first needed
+func NewServer(name string, command []string, env []string, opts Options) *Server {
+	return &Server{
+		name:    name,
+		command: command,
+		env:     env,
+		opts:    opts,
+		slot:    make(chan struct{}, 1),
+	}
+}
+
+// Name returns the name the server was registered under
+func (s *Server) Name() string {
+	return s.name
+}
+
+// Call sends a request and decodes its result into result, which may be nil.
+// Output written by the server while handling the request goes to log. When ctx
+// is cancelled the process is killed, as a running model cannot be interrupted
+// otherwise; the next call starts a fresh one.
+func (s *Server) Call(ctx context.Context, method string, params interface{}, result interface{}, log io.Writer) error {
+	select {
+	case s.slot <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-s.slot }()
+
+	proc, err := s.ensureRunning(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.stopIdleTimer()
+	defer s.startIdleTimer()
+
+	proc.setLog(log)
+	defer proc.setLog(nil)
+
+	return s.roundTrip(ctx, proc, method, params, result)
+}
+
+// Ping checks that the server answers, starting it if needed
+func (s *Server) Ping(ctx context.Context) error {
+	return s.Call(ctx, "ping", nil, nil, nil)
+}
+
+// Running reports whether the server process is alive
+func (s *Server) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.proc != nil && !s.proc.exited()
+}
+
+// Restarts returns how often the process was started again after the first time
+func (s *Server) Restarts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.restarts
+}
+
+// Stop shuts the process down and prevents new requests from starting it again.
+// A request still running is cancelled by killing the process.
+func (s *Server) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	proc := s.proc
+	s.mu.Unlock()
+
+	select {
+	case s.slot <- struct{}{}:
+		s.shutdown()
+		<-s.slot
+	default:
+		if proc != nil {
+			s.kill(proc)
+		}
+	}
+}
+
+// roundTrip writes one request and waits for its response
+func (s *Server) roundTrip(ctx context.Context, proc *process, method string, params interface{}, result interface{}) error {
+	id := s.nextID.Add(1)
+	line, err := json.Marshal(request{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	if _, err := proc.stdin.Write(append(line, '\n')); err != nil {
+		s.kill(proc)
+		return fmt.Errorf("%w: %v", ErrServerExited, err)
+	}
+
+	for {
+		select {
+		case resp := <-proc.responses:
+			if resp.ID != id {
+				// A reply to an earlier request that was abandoned
+				continue
+			}
+			if resp.Error != nil {
+				return resp.Error
+			}
+			if result != nil && len(resp.Result) > 0 {
+				if err := json.Unmarshal(resp.Result, result); err != nil {
+					return fmt.Errorf("failed to decode %s result: %w", method, err)
+				}
+			}
+			return nil
+		case <-proc.done:
+			return fmt.Errorf("%w: %v", ErrServerExited, proc.exitErr)
+		case <-ctx.Done():
+			logger.Info("Killing model server to cancel request", "server", s.name, "method", method)
+			s.kill(proc)
+			return ctx.Err()
+		}
+	}
+}
+
+// ensureRunning returns the live process, starting one if there is none
+func (s *Server) ensureRunning(ctx context.Context) (*process, error) {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("model server %s is stopped", s.name)
+	}
+	if s.proc != nil && !s.proc.exited() {
+		proc := s.proc
+		s.mu.Unlock()
+		return proc, nil
+	}
+	if s.proc != nil {
+		s.restarts++
+		logger.Warn("Restarting model server", "server", s.name, "restarts", s.restarts, "exit", s.proc.exitErr)
+	}
+
+	proc, err := s.start()
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.proc = proc
+	s.mu.Unlock()
+
+	startCtx := ctx
+	if s.opts.StartTimeout > 0 {
+		var cancel context.CancelFunc
+		startCtx, cancel = context.WithTimeout(ctx, s.opts.StartTimeout)
+		defer cancel()
+	}
+	if err := s.roundTrip(startCtx, proc, "ping", nil, nil); err != nil {
+		s.kill(proc)
+		return nil, fmt.Errorf("model server %s did not start: %w", s.name, err)
+	}
+
+	logger.Info("Model server started", "server", s.name, "pid", proc.cmd.Process.Pid)
+	if s.opts.HealthInterval > 0 {
+		go s.watch(proc)
+	}
+	return proc, nil
+}
+
+// start launches the command; the caller holds s.mu
+func (s *Server) start() (*process, error) {
+	if len(s.command) == 0 {
+		return nil, fmt.Errorf("model server %s has no command", s.name)
+	}
+
+	cmd := exec.Command(s.command[0], s.command[1:]...)
+	cmd.Env = append(os.Environ(), s.env...)
+	setProcessGroup(cmd)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Starting model server", "server", s.name, "command", strings.Join(s.command, " "))
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start model server %s: %w", s.name, err)
+	}
+
+	proc := &process{
+		cmd:       cmd,
+		stdin:     stdin,
+		responses: make(chan response, 1),
+		done:      make(chan struct{}),
+	}
+
+	var readers sync.WaitGroup
+	readers.Add(2)
+	go func() {
+		defer readers.Done()
+		proc.readResponses(s.name, stdout)
+	}()
+	go func() {
+		defer readers.Done()
+		proc.copyLogs(s.name, stderr)
+	}()
+	go func() {
+		readers.Wait()
+		proc.exitErr = cmd.Wait()
+		close(proc.done)
+	}()
+
+	return proc, nil
+}
+
+// watch pings the process while it is idle and kills it when it stops answering
+func (s *Server) watch(proc *process) {
+	ticker := time.NewTicker(s.opts.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-proc.done:
+			return
+		case <-ticker.C:
+		}
+
+		// Skip the check while a request is running
+		select {
+		case s.slot <- struct{}{}:
+		default:
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opts.PingTimeout)
+		err := s.roundTrip(ctx, proc, "ping", nil, nil)
+		cancel()
+		<-s.slot
+
+		if err != nil {
+			logger.Warn("Model server failed health check", "server", s.name, "error", err)
+			s.kill(proc)
+			return
+		}
+	}
+}
+
+// shutdown asks the process to exit and kills it if it does not. The caller
+// holds the slot.
+func (s *Server) shutdown() {
+	s.stopIdleTimer()
+
+	s.mu.Lock()
+	proc := s.proc
+	// Not a crash, so the next start is not counted as a restart
+	s.proc = nil
+	s.mu.Unlock()
+	if proc == nil || proc.exited() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = s.roundTrip(ctx, proc, "shutdown", nil, nil)
+
+	select {
+	case <-proc.done:
+	case <-ctx.Done():
+		s.kill(proc)
+	}
+	logger.Info("Model server stopped", "server", s.name)
+}
+
+// kill terminates the process and everything it started
+func (s *Server) kill(proc *process) {
+	if proc.exited() || proc.cmd.Process == nil {
+		return
+	}
+	if err := killProcessGroup(proc.cmd.Process); err != nil {
+		_ = proc.cmd.Process.Kill()
+	}
+	<-proc.done
+}
+
+func (s *Server) startIdleTimer() {
+	if s.opts.IdleTimeout <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.idle != nil {
+		s.idle.Stop()
+	}
+	s.idle = time.AfterFunc(s.opts.IdleTimeout, func() {
+		// A request that started meanwhile keeps the model loaded
+		select {
+		case s.slot <- struct{}{}:
+		default:
+			return
+		}
+		defer func() { <-s.slot }()
+
+		logger.Info("Unloading idle model server", "server", s.name, "idle", s.opts.IdleTimeout)
+		s.shutdown()
+	})
+}
+
+func (s *Server) stopIdleTimer() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.idle != nil {
+		s.idle.Stop()
+		s.idle = nil
+	}
+}
+
+func (p *process) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *process) setLog(w io.Writer) {
+	p.logMu.Lock()
+	p.log = w
+	p.logMu.Unlock()
+}
+
+// readResponses decodes one response per stdout line
+func (p *process) readResponses(name string, stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var resp response
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			logger.Warn("Ignoring malformed model server output", "server", name, "line", scanner.Text())
+			continue
+		}
+		// Drop the oldest reply if nobody collected it, so the reader never blocks
+		select {
+		case p.responses <- resp:
+		default:
+			select {
+			case <-p.responses:
+			default:
+			}
+			p.responses <- resp
+		}
+	}
+}
+
+// copyLogs forwards stderr lines to the log of the current request. Lines
+// longer than the read buffer, such as progress bars redrawn without a
+// newline, are forwarded in pieces so the pipe is always drained.
+func (p *process) copyLogs(name string, stderr io.Reader) {
+	reader := bufio.NewReaderSize(stderr, 64*1024)
+	for {
+		line, _, err := reader.ReadLine()
+		if err != nil {
+			return
+		}
+		p.logMu.Lock()
+		w := p.log
+		if w != nil {
+			fmt.Fprintln(w, string(line))
+		}
+		p.logMu.Unlock()
+		if w == nil {
+			logger.Debug("Model server output", "server", name, "line", string(line))
+		}
+	}
+}
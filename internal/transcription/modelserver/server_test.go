@@ -0,0 +1,181 @@
+package modelserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestServer runs the model server script shipped with the adapters
+func newTestServer(t *testing.T, opts Options) *Server {
+	t.Helper()
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 not available")
+	}
+	script, err := filepath.Abs(filepath.Join("..", "adapters", "py", "model_server.py"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewServer("test", []string{python, script}, nil, opts)
+	t.Cleanup(s.Stop)
+	return s
+}
+
+func writeScript(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "job.py")
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+type runResult struct {
+	ExitCode int `json:"exit_code"`
+}
+
+func TestServerRunsScriptsInOneProcess(t *testing.T) {
+	s := newTestServer(t, Options{StartTimeout: 30 * time.Second})
+	script := writeScript(t, `
+import os, sys
+print("hello", sys.argv[1], os.environ["JOB_NAME"])
+sys.exit(int(sys.argv[2]))
+`)
+
+	var log bytes.Buffer
+	var result runResult
+	params := map[string]interface{}{"script": script, "argv": []string{"world", "0"}, "env": map[string]string{"JOB_NAME": "first"}}
+	if err := s.Call(context.Background(), "run", params, &result, &log); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if result.ExitCode != 0 {
+		t.Errorf("expected exit code 0, got %d", result.ExitCode)
+	}
+	if !strings.Contains(log.String(), "hello world first") {
+		t.Errorf("script output missing from log: %q", log.String())
+	}
+
+	params = map[string]interface{}{"script": script, "argv": []string{"again", "3"}, "env": map[string]string{"JOB_NAME": "second"}}
+	if err := s.Call(context.Background(), "run", params, &result, nil); err != nil {
+		t.Fatalf("second run failed: %v", err)
+	}
+	if result.ExitCode != 3 {
+		t.Errorf("expected exit code 3, got %d", result.ExitCode)
+	}
+	if s.Restarts() != 0 || !s.Running() {
+		t.Errorf("expected the same process to serve both runs, restarts %d", s.Restarts())
+	}
+}
+
+func TestServerRestartsAfterCrash(t *testing.T) {
+	s := newTestServer(t, Options{StartTimeout: 30 * time.Second})
+	crash := writeScript(t, "import os\nos._exit(9)\n")
+
+	err := s.Call(context.Background(), "run", map[string]interface{}{"script": crash}, nil, nil)
+	if !errors.Is(err, ErrServerExited) {
+		t.Fatalf("expected ErrServerExited, got %v", err)
+	}
+
+	if err := s.Ping(context.Background()); err != nil {
+		t.Fatalf("expected the server to come back, got %v", err)
+	}
+	if s.Restarts() != 1 {
+		t.Errorf("expected one restart, got %d", s.Restarts())
+	}
+}
+
+func TestServerCancelKillsRequest(t *testing.T) {
+	s := newTestServer(t, Options{StartTimeout: 30 * time.Second})
+	if err := s.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	slow := writeScript(t, "import time\ntime.sleep(60)\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	err := s.Call(ctx, "run", map[string]interface{}{"script": slow}, nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline error, got %v", err)
+	}
+	if time.Since(started) > 10*time.Second {
+		t.Errorf("cancellation took %v", time.Since(started))
+	}
+	if s.Running() {
+		t.Error("expected the process to be killed")
+	}
+}
+
+func TestServerUnloadsWhenIdle(t *testing.T) {
+	s := newTestServer(t, Options{StartTimeout: 30 * time.Second, IdleTimeout: 200 * time.Millisecond})
+	if err := s.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for s.Running() && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if s.Running() {
+		t.Fatal("expected the idle server to exit")
+	}
+
+	// Starting again after an idle unload is not a restart
+	if err := s.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if s.Restarts() != 0 {
+		t.Errorf("expected no restarts, got %d", s.Restarts())
+	}
+}
+
+func TestStoppedServerRejectsCalls(t *testing.T) {
+	s := newTestServer(t, Options{StartTimeout: 30 * time.Second})
+	if err := s.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	s.Stop()
+
+	if s.Running() {
+		t.Error("expected the process to exit")
+	}
+	if err := s.Ping(context.Background()); err == nil {
+		t.Error("expected an error after Stop")
+	}
+}
+
+func TestServerDrainsLongLogLines(t *testing.T) {
+	s := newTestServer(t, Options{StartTimeout: 30 * time.Second})
+	// A progress bar redrawn without newlines, larger than the pipe buffer
+	script := writeScript(t, `
+import sys
+for _ in range(40000):
+    sys.stderr.write("\r" + "#" * 64)
+sys.stderr.write("\ndone\n")
+sys.stderr.flush()
+`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	var log bytes.Buffer
+	var result runResult
+	if err := s.Call(ctx, "run", map[string]interface{}{"script": script}, &result, &log); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if !strings.Contains(log.String(), "done") {
+		t.Error("output after the long line missing from log")
+	}
+
+	// The server keeps serving requests afterwards
+	if err := s.Call(ctx, "run", map[string]interface{}{"script": script}, &result, nil); err != nil {
+		t.Fatalf("second run failed: %v", err)
+	}
+}
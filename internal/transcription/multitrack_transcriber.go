@@ -17,6 +17,7 @@ import (
 	"scriberr/internal/transcription/interfaces"
 	"scriberr/pkg/logger"
 
+	"golang.org/x/sync/errgroup"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"gorm.io/gorm"
@@ -31,6 +32,7 @@ type MultiTrackTranscriber struct {
 	// Track active temporary jobs for termination support
 	activeTrackJobs map[string][]string // main job ID -> list of track job IDs
 	trackJobsMutex  sync.RWMutex
+	concurrency     int // Tracks transcribed at once
 }
 
 // NewMultiTrackTranscriber creates a new multi-track transcriber
@@ -39,6 +41,7 @@ func NewMultiTrackTranscriber(unifiedProcessor *UnifiedJobProcessor) *MultiTrack
 		unifiedProcessor: unifiedProcessor,
 		db:               database.DB,
 		activeTrackJobs:  make(map[string][]string),
+		concurrency:      unifiedProcessor.unifiedService.trackConcurrency,
 	}
 }
 
@@ -89,73 +92,104 @@ func (mt *MultiTrackTranscriber) ProcessMultiTrackTranscription(ctx context.Cont
 		mt.trackJobsMutex.Unlock()
 	}()
 
-	// Process each track individually and track timing
-	trackTranscripts := make([]TrackTranscript, 0, len(job.MultiTrackFiles))
+	// Process tracks in parallel and track timing. Results keep the track order.
+	trackTranscripts := make([]TrackTranscript, len(job.MultiTrackFiles))
 	individualTranscripts := make(map[string]string)
-	trackTimings := make([]models.MultiTrackTiming, 0, len(job.MultiTrackFiles))
+	trackTimings := make([]models.MultiTrackTiming, len(job.MultiTrackFiles))
+	var progressMutex sync.Mutex
+
+	concurrency := mt.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	logger.Info("Transcribing tracks", "job_id", jobID, "concurrency", concurrency)
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(concurrency)
+	for i := range job.MultiTrackFiles {
+		trackFile := &job.MultiTrackFiles[i]
+		group.Go(func() error {
+			// Tracks of other multi-track jobs share the same limit
+			release, err := mt.unifiedProcessor.unifiedService.acquireTrackSlot(groupCtx)
+			if err != nil {
+				return err
+			}
+			defer release()
 
-	for i, trackFile := range job.MultiTrackFiles {
-		trackStartTime := time.Now()
+			trackStartTime := time.Now()
 
-		logger.Info("Processing track",
-			"job_id", jobID,
-			"track_index", i+1,
-			"track_name", trackFile.FileName,
-			"offset", trackFile.Offset)
+			logger.Info("Processing track",
+				"job_id", jobID,
+				"track_index", i+1,
+				"track_name", trackFile.FileName,
+				"offset", trackFile.Offset)
 
-		// Create a temporary job for this individual track
-		trackResult, err := mt.transcribeIndividualTrack(ctx, &job, &trackFile)
-		trackEndTime := time.Now()
-		trackDuration := trackEndTime.Sub(trackStartTime).Milliseconds()
+			// Create a temporary job for this individual track
+			trackResult, err := mt.transcribeIndividualTrack(groupCtx, &job, trackFile)
+			trackEndTime := time.Now()
+			trackDuration := trackEndTime.Sub(trackStartTime).Milliseconds()
 
-		if err != nil {
-			return fmt.Errorf("failed to transcribe track %s: %w", trackFile.FileName, err)
-		}
+			if err != nil {
+				return fmt.Errorf("failed to transcribe track %s: %w", trackFile.FileName, err)
+			}
 
-		// Store timing data for this track
-		trackTiming := models.MultiTrackTiming{
-			TrackName: trackFile.FileName,
-			StartTime: trackStartTime,
-			EndTime:   trackEndTime,
-			Duration:  trackDuration,
-		}
-		trackTimings = append(trackTimings, trackTiming)
+			// Store timing data for this track
+			trackTimings[i] = models.MultiTrackTiming{
+				TrackName: trackFile.FileName,
+				StartTime: trackStartTime,
+				EndTime:   trackEndTime,
+				Duration:  trackDuration,
+			}
 
-		logger.Info("Completed track transcription",
-			"job_id", jobID,
-			"track_name", trackFile.FileName,
-			"duration_ms", trackDuration)
+			logger.Info("Completed track transcription",
+				"job_id", jobID,
+				"track_name", trackFile.FileName,
+				"duration_ms", trackDuration)
 
-		// Store individual transcript
-		trackTranscriptJSON, err := json.Marshal(trackResult)
-		if err != nil {
-			return fmt.Errorf("failed to serialize track transcript: %w", err)
-		}
-		individualTranscripts[trackFile.FileName] = string(trackTranscriptJSON)
+			// Store individual transcript
+			trackTranscriptJSON, err := json.Marshal(trackResult)
+			if err != nil {
+				return fmt.Errorf("failed to serialize track transcript: %w", err)
+			}
 
-		// Save current progress to database (so API can show real-time progress)
-		individualTranscriptsJSON, err := json.Marshal(individualTranscripts)
-		if err != nil {
-			logger.Warn("Failed to serialize individual transcripts for progress update", "error", err)
-		} else {
-			individualTranscriptsStr := string(individualTranscriptsJSON)
-			if err := mt.db.Model(&models.TranscriptionJob{}).Where("id = ?", jobID).Update("individual_transcripts", &individualTranscriptsStr).Error; err != nil {
-				logger.Warn("Failed to update individual transcripts progress", "job_id", jobID, "error", err)
+			// Save current progress to database (so API can show real-time progress)
+			progressMutex.Lock()
+			individualTranscripts[trackFile.FileName] = string(trackTranscriptJSON)
+			individualTranscriptsJSON, err := json.Marshal(individualTranscripts)
+			if err != nil {
+				logger.Warn("Failed to serialize individual transcripts for progress update", "error", err)
+			} else {
+				individualTranscriptsStr := string(individualTranscriptsJSON)
+				if err := mt.db.Model(&models.TranscriptionJob{}).Where("id = ?", jobID).Update("individual_transcripts", &individualTranscriptsStr).Error; err != nil {
+					logger.Warn("Failed to update individual transcripts progress", "job_id", jobID, "error", err)
+				}
 			}
-		}
+			progressMutex.Unlock()
 
-		// Log individual transcript details for debugging
-		mt.logIndividualTranscript(trackFile.FileName, trackResult, trackFile.Offset)
+			// Log individual transcript details for debugging
+			mt.logIndividualTranscript(trackFile.FileName, trackResult, trackFile.Offset)
 
-		// Create track transcript with metadata
-		trackTranscript := TrackTranscript{
-			FileName: trackFile.FileName,
-			Speaker:  getBaseFileName(trackFile.FileName), // Use filename as speaker name
-			Offset:   trackFile.Offset,
-			Result:   trackResult,
-		}
+			// Create track transcript with metadata
+			trackTranscripts[i] = TrackTranscript{
+				FileName: trackFile.FileName,
+				Speaker:  getBaseFileName(trackFile.FileName), // Use filename as speaker name
+				Offset:   trackFile.Offset,
+				Result:   trackResult,
+			}
+			return nil
+		})
+	}
 
-		trackTranscripts = append(trackTranscripts, trackTranscript)
+	if err := group.Wait(); err != nil {
+		// A failed track cancelled the others; clean up whatever they left behind.
+		// A killed job has already been terminated by the queue.
+		if ctx.Err() == nil {
+			logger.Error("Track transcription failed, cancelling multi-track job", "job_id", jobID, "error", err)
+			if stopErr := mt.stopMultiTrackJob(jobID, err.Error()); stopErr != nil {
+				logger.Warn("Failed to stop multi-track job", "job_id", jobID, "error", stopErr)
+			}
+		}
+		return err
 	}
 
 	// Merge all track transcripts with timing
@@ -361,6 +395,16 @@ func (mt *MultiTrackTranscriber) cleanupTempJob(jobID string) {
 
 // TerminateMultiTrackJob terminates a multi-track job and all its active track jobs
 func (mt *MultiTrackTranscriber) TerminateMultiTrackJob(jobID string) error {
+	if err := mt.stopMultiTrackJob(jobID, "Job was terminated by user"); err != nil {
+		return err
+	}
+	logger.Info("Multi-track job terminated successfully", "job_id", jobID)
+	return nil
+}
+
+// stopMultiTrackJob cleans up a multi-track job's active track jobs and marks
+// it failed with the given error message
+func (mt *MultiTrackTranscriber) stopMultiTrackJob(jobID, errorMessage string) error {
 	mt.trackJobsMutex.RLock()
 	trackJobs, exists := mt.activeTrackJobs[jobID]
 	if !exists {
@@ -391,12 +435,10 @@ func (mt *MultiTrackTranscriber) TerminateMultiTrackJob(jobID string) error {
 		Where("id = ?", jobID).
 		Updates(map[string]interface{}{
 			"status":        models.StatusFailed,
-			"error_message": "Job was terminated by user",
+			"error_message": errorMessage,
 		}).Error; err != nil {
 		logger.Warn("Failed to update main job status after termination", "job_id", jobID, "error", err)
 	}
-
-	logger.Info("Multi-track job terminated successfully", "job_id", jobID)
 	return nil
 }
 
@@ -0,0 +1,90 @@
+package transcription
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"scriberr/internal/models"
+	"scriberr/internal/repository"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestMultiTrackFailedTrackTerminatesJob(t *testing.T) {
+	dir := t.TempDir()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(dir, "test.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.TranscriptionJob{},
+		&models.TranscriptionJobExecution{},
+		&models.MultiTrackFile{},
+		&models.SpeakerMapping{},
+	))
+
+	job := models.TranscriptionJob{
+		ID:           "multi-track-job",
+		AudioPath:    filepath.Join(dir, "merged.wav"),
+		Status:       models.StatusProcessing,
+		IsMultiTrack: true,
+		Parameters:   models.WhisperXParams{ModelFamily: FamilyWhisper},
+	}
+	require.NoError(t, db.Create(&job).Error)
+
+	// Neither track exists, so every track fails
+	for i, name := range []string{"alice.wav", "bob.wav", "carol.wav"} {
+		require.NoError(t, db.Create(&models.MultiTrackFile{
+			TranscriptionJobID: job.ID,
+			FileName:           name,
+			FilePath:           filepath.Join(dir, name),
+			TrackIndex:         i,
+		}).Error)
+	}
+
+	processor := NewUnifiedJobProcessor(repository.NewJobRepository(db), filepath.Join(dir, "temp"), filepath.Join(dir, "transcripts"))
+	processor.SetTrackConcurrency(2)
+	transcriber := NewMultiTrackTranscriber(processor)
+	transcriber.db = db
+	assert.Equal(t, 2, transcriber.concurrency)
+
+	err = transcriber.ProcessMultiTrackTranscription(context.Background(), job.ID)
+	assert.Error(t, err)
+
+	var updated models.TranscriptionJob
+	require.NoError(t, db.First(&updated, "id = ?", job.ID).Error)
+	assert.Equal(t, models.StatusFailed, updated.Status)
+
+	// The job carries the track's error, not a user termination
+	require.NotNil(t, updated.ErrorMessage)
+	assert.Equal(t, err.Error(), *updated.ErrorMessage)
+	assert.Contains(t, *updated.ErrorMessage, "failed to transcribe track")
+
+	// No temporary track jobs are left behind
+	var remaining int64
+	db.Model(&models.TranscriptionJob{}).Where("id <> ?", job.ID).Count(&remaining)
+	assert.Equal(t, int64(0), remaining)
+	assert.Nil(t, transcriber.GetActiveTrackJobs(job.ID))
+}
+
+func TestTrackSlotsAreSharedAcrossJobs(t *testing.T) {
+	service := NewUnifiedTranscriptionService(nil, t.TempDir(), t.TempDir())
+	service.SetTrackLimit(1)
+
+	release, err := service.acquireTrackSlot(context.Background())
+	require.NoError(t, err)
+
+	// A track of another job waits until the slot is freed
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = service.acquireTrackSlot(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	release, err = service.acquireTrackSlot(context.Background())
+	require.NoError(t, err)
+	release()
+}
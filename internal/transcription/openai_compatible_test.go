@@ -0,0 +1,161 @@
+package transcription
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/adapters"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/registry"
+)
+
+// newCompatibleServer fakes a self-hosted server speaking the OpenAI API
+func newCompatibleServer(t *testing.T, received map[string][]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/models":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]string{{"id": "whisper-large-v3"}, {"id": "distil-small.en"}},
+			})
+		case "/v1/audio/transcriptions":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			for k, v := range r.MultipartForm.Value {
+				received[k] = v
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"language": "en",
+				"duration": 2.0,
+				"text":     "hello world",
+				"segments": []map[string]interface{}{{"start": 0.0, "end": 2.0, "text": "hello world"}},
+				"words":    []map[string]interface{}{{"word": "hello", "start": 0.0, "end": 1.0}, {"word": "world", "start": 1.0, "end": 2.0}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOpenAICompatibleAdapter(t *testing.T) {
+	received := map[string][]string{}
+	server := newCompatibleServer(t, received)
+
+	noScheme := ""
+	adapter, err := adapters.NewOpenAICompatibleAdapter(adapters.OpenAICompatibleConfig{
+		ID:             "speaches",
+		BaseURL:        server.URL + "/v1/",
+		APIKey:         "secret",
+		AuthHeader:     "X-Api-Key",
+		AuthScheme:     &noScheme,
+		WordTimestamps: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create adapter: %v", err)
+	}
+
+	if err := adapter.PrepareEnvironment(context.Background()); err != nil {
+		t.Fatalf("prepare failed: %v", err)
+	}
+	if models := adapter.GetSupportedModels(); len(models) != 2 || models[0] != "distil-small.en" {
+		t.Errorf("expected discovered models, got %v", models)
+	}
+	capabilities := adapter.GetCapabilities()
+	if capabilities.ModelFamily != adapters.FamilyOpenAICompatible || capabilities.Metadata["models"] != "distil-small.en,whisper-large-v3" {
+		t.Errorf("unexpected capabilities: %+v", capabilities)
+	}
+
+	audio := filepath.Join(t.TempDir(), "audio.wav")
+	if err := os.WriteFile(audio, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	input := interfaces.AudioInput{FilePath: audio, Format: "wav", Size: 4}
+	procCtx := interfaces.ProcessingContext{JobID: "job", OutputDirectory: t.TempDir()}
+
+	result, err := adapter.Transcribe(context.Background(), input, map[string]interface{}{"language": "en"}, procCtx)
+	if err != nil {
+		t.Fatalf("transcription failed: %v", err)
+	}
+	if result.Text != "hello world" || len(result.WordSegments) != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	// Without a model in the profile the first discovered one is used
+	if got := received["model"]; len(got) != 1 || got[0] != "distil-small.en" {
+		t.Errorf("expected the default model to be sent, got %v", got)
+	}
+	if got := received["timestamp_granularities[]"]; len(got) != 2 {
+		t.Errorf("expected word timestamps to be requested, got %v", got)
+	}
+}
+
+func TestOpenAICompatibleConfigValidation(t *testing.T) {
+	if _, err := adapters.NewOpenAICompatibleAdapter(adapters.OpenAICompatibleConfig{BaseURL: "http://localhost:8000/v1"}); err == nil {
+		t.Error("expected an error without an id")
+	}
+	if _, err := adapters.NewOpenAICompatibleAdapter(adapters.OpenAICompatibleConfig{ID: "local", BaseURL: "localhost:8000"}); err == nil {
+		t.Error("expected an error for a base URL without scheme")
+	}
+	if _, err := adapters.NewOpenAICompatibleAdapter(adapters.OpenAICompatibleConfig{
+		ID: "local", BaseURL: "https://localhost:8000/v1", TLS: adapters.OpenAICompatibleTLS{CAFile: "/does/not/exist.pem"},
+	}); err == nil {
+		t.Error("expected an error for a missing CA file")
+	}
+
+	configs, err := adapters.LoadOpenAICompatibleConfigs(`[{"id": "localai", "base_url": "http://localai:8080/v1", "models": ["whisper-1"]}]`)
+	if err != nil || len(configs) != 1 || configs[0].Models[0] != "whisper-1" {
+		t.Errorf("failed to parse inline config: %v %+v", err, configs)
+	}
+	if _, err := adapters.LoadOpenAICompatibleConfigs("/does/not/exist.json"); err == nil {
+		t.Error("expected an error for a missing config file")
+	}
+}
+
+func TestOpenAICompatibleModelSelection(t *testing.T) {
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+
+	adapter, err := adapters.NewOpenAICompatibleAdapter(adapters.OpenAICompatibleConfig{
+		ID: "speaches", BaseURL: "http://localhost:8000/v1", Models: []string{"whisper-large-v3"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry.RegisterTranscriptionAdapter("speaches", adapter)
+
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), "data/temp", "data/transcripts")
+	params := models.WhisperXParams{ModelFamily: "speaches", Model: "whisper-large-v3", Language: stringPtr("de")}
+
+	modelID := service.transcriptionCandidates(context.Background(), params)[0].ModelID
+	if modelID != "speaches" {
+		t.Fatalf("expected the configured server to be selected, got %s", modelID)
+	}
+
+	paramMap := service.convertParametersForModel(params, modelID)
+	if paramMap["model"] != "whisper-large-v3" || paramMap["language"] != "de" {
+		t.Errorf("expected OpenAI parameters, got %v", paramMap)
+	}
+	if _, ok := service.GetSupportedModels()["speaches"]; !ok {
+		t.Error("expected the server to be listed with the supported models")
+	}
+
+	// Unknown families still fall back to WhisperX
+	if modelID := service.transcriptionCandidates(context.Background(), models.WhisperXParams{ModelFamily: "unknown"})[0].ModelID; modelID != ModelWhisperX {
+		t.Errorf("expected WhisperX fallback, got %s", modelID)
+	}
+}
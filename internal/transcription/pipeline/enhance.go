@@ -0,0 +1,373 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/splitter"
+	"scriberr/pkg/logger"
+)
+
+// Names of the enhancement steps a profile can chain
+const (
+	StepLoudnorm    = "loudnorm"
+	StepDenoise     = "denoise"
+	StepHighpass    = "highpass"
+	StepTrimSilence = "trim_silence"
+
+	// DenoiseFFT uses ffmpeg's FFT based denoiser, which needs no model file
+	DenoiseFFT = "afftdn"
+	// DenoiseRNN uses the RNNoise neural denoiser with a model file
+	DenoiseRNN = "arnndn"
+
+	// TrimOffsetKey is the AudioInput metadata key holding how many seconds were
+	// cut from the start of the audio, needed to map timestamps back
+	TrimOffsetKey = "trim_offset"
+
+	defaultLoudnessTarget    = -16.0
+	defaultHighpassFrequency = 80
+	// trimPaddingSeconds of silence is kept around speech so the first and last words are not clipped
+	trimPaddingSeconds = 0.2
+	// minTrimSeconds is the shortest edge silence worth re-encoding the audio for
+	minTrimSeconds = 0.5
+)
+
+// EnhancementOptions configures the enhancement chain of a profile
+type EnhancementOptions struct {
+	Steps             []string
+	LoudnessTarget    float64
+	DenoiseMethod     string
+	DenoiseModel      string
+	HighpassFrequency int
+	TempDirectory     string // Where enhanced audio is written
+}
+
+// ParseSteps splits a comma-separated list of step names. Each step may carry a
+// failure policy after a colon, as in "denoise:required".
+func ParseSteps(value string) []string {
+	var steps []string
+	for _, step := range strings.Split(value, ",") {
+		if step = strings.ToLower(strings.TrimSpace(step)); step != "" {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// BuildEnhancers creates the stages for the configured steps, in order.
+// Enhancement is optional unless a step asks for another policy, except that
+// RNNoise falls back to the FFT denoiser, which needs no model file.
+func BuildEnhancers(opts EnhancementOptions) ([]Stage, error) {
+	stages := make([]Stage, 0, len(opts.Steps))
+	for _, spec := range opts.Steps {
+		step, policyName, hasPolicy := strings.Cut(spec, ":")
+
+		stage := Stage{Policy: PolicyOptional}
+		switch step {
+		case StepLoudnorm:
+			stage.Preprocessor = &LoudnessNormalizationPreprocessor{Target: opts.LoudnessTarget, TempDir: opts.TempDirectory}
+			stage.Fallback = &LoudnessNormalizationPreprocessor{Dynamic: true, TempDir: opts.TempDirectory}
+		case StepDenoise:
+			method := opts.DenoiseMethod
+			if method == "" {
+				method = DenoiseFFT
+			}
+			if method != DenoiseFFT && method != DenoiseRNN {
+				return nil, fmt.Errorf("unknown denoise method %q", method)
+			}
+			if method == DenoiseRNN && opts.DenoiseModel == "" {
+				return nil, fmt.Errorf("denoise method %s requires a model file", DenoiseRNN)
+			}
+			stage.Preprocessor = &DenoisePreprocessor{Method: method, Model: opts.DenoiseModel, TempDir: opts.TempDirectory}
+			if method == DenoiseRNN {
+				stage.Policy = PolicyFallback
+				stage.Fallback = &DenoisePreprocessor{Method: DenoiseFFT, TempDir: opts.TempDirectory}
+			}
+		case StepHighpass:
+			stage.Preprocessor = &HighPassPreprocessor{Frequency: opts.HighpassFrequency, TempDir: opts.TempDirectory}
+		case StepTrimSilence:
+			stage.Preprocessor = &SilenceTrimPreprocessor{TempDir: opts.TempDirectory}
+		default:
+			return nil, fmt.Errorf("unknown preprocessor %q", step)
+		}
+
+		if hasPolicy {
+			policy, err := ParsePolicy(policyName)
+			if err != nil {
+				return nil, fmt.Errorf("preprocessor %s: %w", step, err)
+			}
+			if policy == PolicyFallback && stage.Fallback == nil {
+				return nil, fmt.Errorf("preprocessor %s has no alternative to fall back to", step)
+			}
+			stage.Policy = policy
+		}
+		if stage.Policy != PolicyFallback {
+			stage.Fallback = nil
+		}
+		stages = append(stages, stage)
+	}
+	return stages, nil
+}
+
+// LoudnessNormalizationPreprocessor normalizes loudness to an EBU R128 target,
+// or with the dynamic normalizer, which evens out loudness without a target
+type LoudnessNormalizationPreprocessor struct {
+	Target  float64 // Integrated loudness in LUFS
+	Dynamic bool    // Use dynaudnorm instead of loudnorm
+	TempDir string  // Where the output is written, the system default if empty
+}
+
+// Name identifies the step in execution data
+func (l *LoudnessNormalizationPreprocessor) Name() string {
+	if l.Dynamic {
+		return "dynaudnorm"
+	}
+	return StepLoudnorm
+}
+
+// AppliesTo returns true, as enhancement is chosen per profile rather than per model
+func (l *LoudnessNormalizationPreprocessor) AppliesTo(capabilities interfaces.ModelCapabilities) bool {
+	return true
+}
+
+// GetRequiredFormats returns the output formats this preprocessor can produce
+func (l *LoudnessNormalizationPreprocessor) GetRequiredFormats() []string {
+	return []string{"wav", "mp3", "flac", "m4a"}
+}
+
+// Process raises quiet recordings to the target loudness
+func (l *LoudnessNormalizationPreprocessor) Process(ctx context.Context, input interfaces.AudioInput) (interfaces.AudioInput, error) {
+	if l.Dynamic {
+		return applyFilter(ctx, input, l.TempDir, l.Name(), "dynaudnorm")
+	}
+
+	target := l.Target
+	if target == 0 {
+		target = defaultLoudnessTarget
+	}
+	filter := fmt.Sprintf("loudnorm=I=%s:TP=-1.5:LRA=11", formatSeconds(target))
+	return applyFilter(ctx, input, l.TempDir, l.Name(), filter)
+}
+
+// DenoisePreprocessor removes background noise
+type DenoisePreprocessor struct {
+	Method  string // DenoiseFFT or DenoiseRNN
+	Model   string // RNNoise model file for DenoiseRNN
+	TempDir string // Where the output is written, the system default if empty
+}
+
+// Name identifies the step in execution data by the filter it uses
+func (d *DenoisePreprocessor) Name() string {
+	if d.Method == DenoiseRNN {
+		return DenoiseRNN
+	}
+	return DenoiseFFT
+}
+
+// AppliesTo returns true, as enhancement is chosen per profile rather than per model
+func (d *DenoisePreprocessor) AppliesTo(capabilities interfaces.ModelCapabilities) bool {
+	return true
+}
+
+// GetRequiredFormats returns the output formats this preprocessor can produce
+func (d *DenoisePreprocessor) GetRequiredFormats() []string {
+	return []string{"wav", "mp3", "flac", "m4a"}
+}
+
+// Process applies the configured denoise filter
+func (d *DenoisePreprocessor) Process(ctx context.Context, input interfaces.AudioInput) (interfaces.AudioInput, error) {
+	filter := "afftdn=nf=-25"
+	if d.Method == DenoiseRNN {
+		filter = "arnndn=m=" + escapeFilterValue(d.Model)
+	}
+	return applyFilter(ctx, input, d.TempDir, StepDenoise, filter)
+}
+
+// HighPassPreprocessor removes rumble and hum below a cutoff frequency
+type HighPassPreprocessor struct {
+	Frequency int    // Cutoff in Hz
+	TempDir   string // Where the output is written, the system default if empty
+}
+
+// Name identifies the step in execution data
+func (h *HighPassPreprocessor) Name() string { return StepHighpass }
+
+// AppliesTo returns true, as enhancement is chosen per profile rather than per model
+func (h *HighPassPreprocessor) AppliesTo(capabilities interfaces.ModelCapabilities) bool {
+	return true
+}
+
+// GetRequiredFormats returns the output formats this preprocessor can produce
+func (h *HighPassPreprocessor) GetRequiredFormats() []string {
+	return []string{"wav", "mp3", "flac", "m4a"}
+}
+
+// Process applies the high-pass filter
+func (h *HighPassPreprocessor) Process(ctx context.Context, input interfaces.AudioInput) (interfaces.AudioInput, error) {
+	frequency := h.Frequency
+	if frequency <= 0 {
+		frequency = defaultHighpassFrequency
+	}
+	return applyFilter(ctx, input, h.TempDir, h.Name(), fmt.Sprintf("highpass=f=%d", frequency))
+}
+
+// SilenceTrimPreprocessor cuts leading and trailing silence. The seconds removed
+// from the start are added to the TrimOffsetKey metadata so RestoreTimestamps
+// can map the transcript back onto the original audio.
+type SilenceTrimPreprocessor struct {
+	TempDir string // Where the output is written, the system default if empty
+}
+
+// Name identifies the step in execution data
+func (s *SilenceTrimPreprocessor) Name() string { return StepTrimSilence }
+
+// AppliesTo returns true, as enhancement is chosen per profile rather than per model
+func (s *SilenceTrimPreprocessor) AppliesTo(capabilities interfaces.ModelCapabilities) bool {
+	return true
+}
+
+// GetRequiredFormats returns the output formats this preprocessor can produce
+func (s *SilenceTrimPreprocessor) GetRequiredFormats() []string {
+	return []string{"wav", "mp3", "flac", "m4a"}
+}
+
+// Process detects the silence at both ends of the audio and cuts it off
+func (s *SilenceTrimPreprocessor) Process(ctx context.Context, input interfaces.AudioInput) (interfaces.AudioInput, error) {
+	total := input.Duration.Seconds()
+	if total <= 0 {
+		return input, fmt.Errorf("audio duration unknown, cannot trim silence")
+	}
+
+	silences, err := splitter.DetectSilences(ctx, input.FilePath)
+	if err != nil {
+		return input, err
+	}
+
+	start, end := speechBounds(silences, total)
+	if start < minTrimSeconds && total-end < minTrimSeconds {
+		logger.Info("No edge silence to trim", "file", input.FilePath)
+		return input, nil
+	}
+	if end <= start {
+		return input, fmt.Errorf("audio contains no speech above the silence threshold")
+	}
+
+	output, err := runFilter(ctx, input, s.TempDir, s.Name(),
+		"-ss", formatSeconds(start),
+		"-to", formatSeconds(end))
+	if err != nil {
+		return input, err
+	}
+
+	output.Duration = time.Duration((end - start) * float64(time.Second))
+	output.Metadata[TrimOffsetKey] = formatSeconds(TrimOffset(input) + start)
+
+	logger.Info("Trimmed edge silence", "leading", start, "trailing", total-end)
+	return output, nil
+}
+
+// speechBounds returns where speech starts and ends, keeping a little padding
+// inside the leading and trailing silence
+func speechBounds(silences []splitter.Silence, total float64) (start, end float64) {
+	const edgeTolerance = 0.05
+
+	end = total
+	for _, silence := range silences {
+		if silence.Start <= edgeTolerance {
+			start = max(silence.End-trimPaddingSeconds, 0)
+		}
+		if silence.End >= total-edgeTolerance {
+			end = min(silence.Start+trimPaddingSeconds, total)
+		}
+	}
+	return start, end
+}
+
+// TrimOffset returns how many seconds were cut from the start of the audio
+func TrimOffset(input interfaces.AudioInput) float64 {
+	offset, _ := strconv.ParseFloat(input.Metadata[TrimOffsetKey], 64)
+	return offset
+}
+
+// RestoreTimestamps shifts a transcript of trimmed audio back onto the timeline
+// of the original recording
+func RestoreTimestamps(result *interfaces.TranscriptResult, offset float64) {
+	if result == nil || offset == 0 {
+		return
+	}
+	for i := range result.Segments {
+		result.Segments[i].Start += offset
+		result.Segments[i].End += offset
+	}
+	for i := range result.WordSegments {
+		result.WordSegments[i].Start += offset
+		result.WordSegments[i].End += offset
+	}
+}
+
+// applyFilter runs one ffmpeg audio filter over the input
+func applyFilter(ctx context.Context, input interfaces.AudioInput, tempDir, name, filter string) (interfaces.AudioInput, error) {
+	return runFilter(ctx, input, tempDir, name, "-af", filter)
+}
+
+// runFilter re-encodes the input with extra ffmpeg arguments into a new
+// temporary file in tempDir, keeping the container so size limits of remote
+// models still hold. The file is removed if ffmpeg fails; otherwise it is
+// marked as temporary for the caller to remove.
+func runFilter(ctx context.Context, input interfaces.AudioInput, tempDir, name string, args ...string) (interfaces.AudioInput, error) {
+	if tempDir != "" {
+		if err := os.MkdirAll(tempDir, 0755); err != nil {
+			return input, fmt.Errorf("failed to create temp directory: %w", err)
+		}
+	}
+	output, err := os.CreateTemp(tempDir, name+"_*"+filepath.Ext(input.FilePath))
+	if err != nil {
+		return input, fmt.Errorf("failed to create %s output: %w", name, err)
+	}
+	outputPath := output.Name()
+	_ = output.Close()
+
+	ffmpegArgs := append([]string{"-i", input.FilePath}, args...)
+	// loudnorm and arnndn resample internally, keep the original rate
+	if input.SampleRate > 0 {
+		ffmpegArgs = append(ffmpegArgs, "-ar", strconv.Itoa(input.SampleRate))
+	}
+	ffmpegArgs = append(ffmpegArgs, "-y", outputPath)
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", ffmpegArgs...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		_ = os.Remove(outputPath)
+		logger.Error("FFmpeg enhancement failed", "step", name, "output", string(output), "error", err)
+		return input, fmt.Errorf("%s failed: %w", name, err)
+	}
+
+	metadata := make(map[string]string, len(input.Metadata)+1)
+	for k, v := range input.Metadata {
+		metadata[k] = v
+	}
+
+	processed := input
+	processed.FilePath = outputPath
+	processed.TempFilePath = outputPath
+	processed.Metadata = metadata
+	if stat, err := os.Stat(outputPath); err == nil {
+		processed.Size = stat.Size()
+	}
+	return processed, nil
+}
+
+// escapeFilterValue quotes characters with a meaning in ffmpeg filter graphs
+func escapeFilterValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `:`, `\:`, `'`, `\'`, `,`, `\,`).Replace(value)
+}
+
+func formatSeconds(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,293 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/splitter"
+)
+
+func TestBuildEnhancersKeepsOrder(t *testing.T) {
+	enhancers, err := BuildEnhancers(EnhancementOptions{
+		Steps: ParseSteps(" highpass, Loudnorm,denoise ,,trim_silence"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, enhancer := range enhancers {
+		names = append(names, stepName(enhancer.Preprocessor))
+		if enhancer.Policy != PolicyOptional {
+			t.Errorf("expected enhancement to be optional by default, got %s", enhancer.Policy)
+		}
+	}
+	expected := []string{StepHighpass, StepLoudnorm, DenoiseFFT, StepTrimSilence}
+	if len(names) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, names)
+			break
+		}
+	}
+}
+
+func TestBuildEnhancersPolicies(t *testing.T) {
+	stages, err := BuildEnhancers(EnhancementOptions{
+		Steps:         ParseSteps("denoise,loudnorm:fallback,highpass:required"),
+		DenoiseMethod: DenoiseRNN,
+		DenoiseModel:  "/models/std.rnnn",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stages[0].Policy != PolicyFallback || stepName(stages[0].Fallback) != DenoiseFFT {
+		t.Errorf("expected RNNoise to fall back to afftdn, got %+v", stages[0])
+	}
+	if stages[1].Policy != PolicyFallback || stepName(stages[1].Fallback) != "dynaudnorm" {
+		t.Errorf("expected loudnorm to fall back to dynaudnorm, got %+v", stages[1])
+	}
+	if stages[2].Policy != PolicyRequired || stages[2].Fallback != nil {
+		t.Errorf("expected a required high-pass without fallback, got %+v", stages[2])
+	}
+}
+
+func TestBuildEnhancersRejectsInvalidSettings(t *testing.T) {
+	cases := map[string]EnhancementOptions{
+		"unknown step":          {Steps: []string{"reverb"}},
+		"unknown denoiser":      {Steps: []string{StepDenoise}, DenoiseMethod: "magic"},
+		"rnnoise without model": {Steps: []string{StepDenoise}, DenoiseMethod: DenoiseRNN},
+		"unknown policy":        {Steps: []string{"highpass:sometimes"}},
+		"nothing to fall back":  {Steps: []string{"trim_silence:fallback"}},
+	}
+	for name, opts := range cases {
+		if _, err := BuildEnhancers(opts); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSpeechBounds(t *testing.T) {
+	silences := []splitter.Silence{
+		{Start: 0, End: 3},
+		{Start: 20, End: 21},
+		{Start: 55, End: 60},
+	}
+
+	start, end := speechBounds(silences, 60)
+	if start != 2.8 || end != 55.2 {
+		t.Errorf("expected speech between 2.8 and 55.2, got %v and %v", start, end)
+	}
+
+	start, end = speechBounds([]splitter.Silence{{Start: 20, End: 21}}, 60)
+	if start != 0 || end != 60 {
+		t.Errorf("inner pauses must not be trimmed, got %v and %v", start, end)
+	}
+}
+
+func TestRestoreTimestamps(t *testing.T) {
+	result := &interfaces.TranscriptResult{
+		Segments:     []interfaces.TranscriptSegment{{Start: 0, End: 2}},
+		WordSegments: []interfaces.TranscriptWord{{Start: 0.5, End: 1}},
+	}
+	input := interfaces.AudioInput{Metadata: map[string]string{TrimOffsetKey: "2.8"}}
+
+	RestoreTimestamps(result, TrimOffset(input))
+
+	if result.Segments[0].Start != 2.8 || result.Segments[0].End != 4.8 {
+		t.Errorf("segment not shifted: %+v", result.Segments[0])
+	}
+	if result.WordSegments[0].Start != 3.3 || result.WordSegments[0].End != 3.8 {
+		t.Errorf("word not shifted: %+v", result.WordSegments[0])
+	}
+
+	// Nothing to do without a transcript or without trimming
+	RestoreTimestamps(nil, 1)
+	if TrimOffset(interfaces.AudioInput{}) != 0 {
+		t.Error("expected no offset for untrimmed audio")
+	}
+}
+
+// fakeStep writes a new temporary file, or fails
+type fakeStep struct {
+	name string
+	err  error
+}
+
+func (f *fakeStep) Name() string                                             { return f.name }
+func (f *fakeStep) AppliesTo(capabilities interfaces.ModelCapabilities) bool { return true }
+func (f *fakeStep) GetRequiredFormats() []string                             { return []string{"wav"} }
+
+func (f *fakeStep) Process(ctx context.Context, input interfaces.AudioInput) (interfaces.AudioInput, error) {
+	if f.err != nil {
+		return input, f.err
+	}
+	path := input.FilePath + "_" + f.name
+	if err := os.WriteFile(path, []byte("audio"), 0644); err != nil {
+		return input, err
+	}
+	input.FilePath = path
+	input.TempFilePath = path
+	return input, nil
+}
+
+func writeOriginal(t *testing.T) string {
+	t.Helper()
+	original := filepath.Join(t.TempDir(), "input.wav")
+	if err := os.WriteFile(original, []byte("audio"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return original
+}
+
+func TestProcessAudioReportsStepsAndRemovesIntermediates(t *testing.T) {
+	original := writeOriginal(t)
+
+	p := &ProcessingPipeline{}
+	p.RegisterPreprocessor(&fakeStep{name: "format"})
+
+	var reported []AppliedStep
+	output, steps, err := p.ProcessAudio(context.Background(), interfaces.AudioInput{FilePath: original}, interfaces.ModelCapabilities{},
+		[]Stage{
+			{Preprocessor: &fakeStep{name: "loudnorm"}, Policy: PolicyOptional},
+			{Preprocessor: &fakeStep{name: "denoise", err: errors.New("filter missing")}, Policy: PolicyOptional},
+			{Preprocessor: &fakeStep{name: "highpass"}, Policy: PolicyOptional},
+		},
+		func(step AppliedStep) { reported = append(reported, step) })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(steps) != 4 || len(reported) != 4 {
+		t.Fatalf("expected 4 steps returned and reported, got %+v and %+v", steps, reported)
+	}
+	if steps[1].Name != "denoise" || steps[1].Status != StepFailed || steps[1].Error == "" {
+		t.Errorf("expected the failed step to be recorded, got %+v", steps[1])
+	}
+	if steps[3].Name != "format" || steps[3].Policy != PolicyRequired || steps[3].Status != StepSucceeded {
+		t.Errorf("expected required format conversion to run last, got %+v", steps[3])
+	}
+
+	if output.FilePath != original+"_loudnorm_highpass_format" {
+		t.Errorf("unexpected output %s", output.FilePath)
+	}
+	for _, intermediate := range []string{original + "_loudnorm", original + "_loudnorm_highpass"} {
+		if _, err := os.Stat(intermediate); !os.IsNotExist(err) {
+			t.Errorf("intermediate %s was not removed", intermediate)
+		}
+	}
+	if _, err := os.Stat(original); err != nil {
+		t.Errorf("original audio must be kept: %v", err)
+	}
+}
+
+func TestProcessAudioRequiredFailureStopsJob(t *testing.T) {
+	original := writeOriginal(t)
+
+	p := &ProcessingPipeline{}
+	p.RegisterPreprocessor(&fakeStep{name: "format", err: errors.New("ffmpeg not found")})
+
+	_, steps, err := p.ProcessAudio(context.Background(), interfaces.AudioInput{FilePath: original}, interfaces.ModelCapabilities{}, nil, nil)
+
+	var stepErr *StepError
+	if !errors.As(err, &stepErr) || stepErr.Step != "format" {
+		t.Fatalf("expected a StepError for format, got %v", err)
+	}
+	if len(steps) != 1 || steps[0].Status != StepFailed {
+		t.Errorf("expected the failure to be recorded, got %+v", steps)
+	}
+}
+
+func TestProcessAudioFallback(t *testing.T) {
+	original := writeOriginal(t)
+	p := &ProcessingPipeline{}
+
+	output, steps, err := p.ProcessAudio(context.Background(), interfaces.AudioInput{FilePath: original}, interfaces.ModelCapabilities{},
+		[]Stage{{
+			Preprocessor: &fakeStep{name: "arnndn", err: errors.New("model missing")},
+			Policy:       PolicyFallback,
+			Fallback:     &fakeStep{name: "afftdn"},
+		}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.FilePath != original+"_afftdn" {
+		t.Errorf("expected the fallback output, got %s", output.FilePath)
+	}
+	if steps[0].Status != StepFellBack || steps[0].Fallback != "afftdn" {
+		t.Errorf("expected the fallback to be recorded, got %+v", steps[0])
+	}
+
+	// A failing fallback fails the job
+	_, _, err = p.ProcessAudio(context.Background(), interfaces.AudioInput{FilePath: original}, interfaces.ModelCapabilities{},
+		[]Stage{{
+			Preprocessor: &fakeStep{name: "arnndn", err: errors.New("model missing")},
+			Policy:       PolicyFallback,
+			Fallback:     &fakeStep{name: "afftdn", err: errors.New("filter missing")},
+		}}, nil)
+	if err == nil {
+		t.Error("expected an error when the fallback fails too")
+	}
+}
+
+func TestRunFilterWritesToTempDirectory(t *testing.T) {
+	uploads := t.TempDir()
+	tempDir := filepath.Join(t.TempDir(), "temp")
+	audio := filepath.Join(uploads, "upload.wav")
+	if err := os.WriteFile(audio, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	enhancers, err := BuildEnhancers(EnhancementOptions{Steps: []string{StepHighpass}, TempDirectory: tempDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The sample is no audio ffmpeg can read, so the filter fails
+	if _, err := enhancers[0].Preprocessor.Process(context.Background(), interfaces.AudioInput{FilePath: audio}); err == nil {
+		t.Fatal("expected the filter to fail")
+	}
+
+	// Nothing is left next to the upload or in the temp directory
+	for _, dir := range []string{uploads, tempDir} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dir == uploads && len(entries) != 1 || dir == tempDir && len(entries) != 0 {
+			t.Errorf("unexpected files in %s: %v", dir, entries)
+		}
+	}
+}
+
+func TestAudioFormatWritesToTempDirectory(t *testing.T) {
+	uploads := t.TempDir()
+	tempDir := filepath.Join(t.TempDir(), "temp")
+	audio := filepath.Join(uploads, "upload.mp3")
+	if err := os.WriteFile(audio, []byte("ID3"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewProcessingPipeline(tempDir)
+	// The sample is no audio ffmpeg can read, so the conversion fails
+	if _, err := p.preprocessors[0].Preprocessor.Process(context.Background(), interfaces.AudioInput{FilePath: audio, Format: "mp3"}); err == nil {
+		t.Fatal("expected the conversion to fail")
+	}
+
+	// Nothing is left next to the upload or in the temp directory
+	for _, dir := range []string{uploads, tempDir} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dir == uploads && len(entries) != 1 || dir == tempDir && len(entries) != 0 {
+			t.Errorf("unexpected files in %s: %v", dir, entries)
+		}
+	}
+}
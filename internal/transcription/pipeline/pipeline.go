@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"scriberr/internal/transcription/interfaces"
 	"scriberr/pkg/logger"
@@ -15,26 +15,33 @@ import (
 
 // ProcessingPipeline handles the full processing workflow with preprocessing
 type ProcessingPipeline struct {
-	preprocessors  []interfaces.Preprocessor
+	preprocessors  []Stage
 	postprocessors []interfaces.Postprocessor
 }
 
-// NewProcessingPipeline creates a new processing pipeline
-func NewProcessingPipeline() *ProcessingPipeline {
+// NewProcessingPipeline creates a new processing pipeline that writes
+// converted audio to tempDir
+func NewProcessingPipeline(tempDir string) *ProcessingPipeline {
 	pipeline := &ProcessingPipeline{
-		preprocessors:  make([]interfaces.Preprocessor, 0),
+		preprocessors:  make([]Stage, 0),
 		postprocessors: make([]interfaces.Postprocessor, 0),
 	}
 
-	// Register default preprocessors
-	pipeline.RegisterPreprocessor(&AudioFormatPreprocessor{})
+	// Register default preprocessors. Models rely on the converted format, so a
+	// failed conversion fails the job.
+	pipeline.RegisterPreprocessor(&AudioFormatPreprocessor{TempDir: tempDir})
 
 	return pipeline
 }
 
-// RegisterPreprocessor adds a preprocessor to the pipeline
+// RegisterPreprocessor adds a preprocessor to the pipeline that the job cannot do without
 func (p *ProcessingPipeline) RegisterPreprocessor(preprocessor interfaces.Preprocessor) {
-	p.preprocessors = append(p.preprocessors, preprocessor)
+	p.RegisterStage(Stage{Preprocessor: preprocessor, Policy: PolicyRequired})
+}
+
+// RegisterStage adds a preprocessor to the pipeline with its own failure policy
+func (p *ProcessingPipeline) RegisterStage(stage Stage) {
+	p.preprocessors = append(p.preprocessors, stage)
 }
 
 // RegisterPostprocessor adds a postprocessor to the pipeline
@@ -42,27 +49,107 @@ func (p *ProcessingPipeline) RegisterPostprocessor(postprocessor interfaces.Post
 	p.postprocessors = append(p.postprocessors, postprocessor)
 }
 
-// ProcessAudio applies all applicable preprocessors to the audio input
-func (p *ProcessingPipeline) ProcessAudio(ctx context.Context, input interfaces.AudioInput, capabilities interfaces.ModelCapabilities) (interfaces.AudioInput, error) {
+// AppliedStep records the outcome of a preprocessor on a job's audio
+type AppliedStep struct {
+	Name       string        `json:"name"`
+	Policy     FailurePolicy `json:"policy"`
+	Status     string        `json:"status"`             // StepSucceeded, StepFailed or StepFellBack
+	Fallback   string        `json:"fallback,omitempty"` // Alternative that ran instead
+	DurationMs int64         `json:"duration_ms"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// ProcessAudio applies the given enhancement stages followed by all applicable
+// registered preprocessors to the audio input. Each outcome is passed to report,
+// if set, as soon as the step finishes, and all of them are returned. A failing
+// step is handled according to its policy; when the job cannot continue a
+// *StepError is returned. Intermediate files are removed as soon as the next
+// step has replaced them.
+func (p *ProcessingPipeline) ProcessAudio(ctx context.Context, input interfaces.AudioInput, capabilities interfaces.ModelCapabilities, enhancers []Stage, report func(AppliedStep)) (interfaces.AudioInput, []AppliedStep, error) {
 	currentInput := input
+	var applied []AppliedStep
+
+	record := func(step AppliedStep) {
+		applied = append(applied, step)
+		if report != nil {
+			report(step)
+		}
+	}
+
+	stages := append(append([]Stage{}, enhancers...), p.preprocessors...)
+	for _, stage := range stages {
+		if !stage.Preprocessor.AppliesTo(capabilities) {
+			continue
+		}
 
-	for _, preprocessor := range p.preprocessors {
-		if preprocessor.AppliesTo(capabilities) {
-			logger.Info("Applying preprocessor", "type", fmt.Sprintf("%T", preprocessor))
-			processedInput, err := preprocessor.Process(ctx, currentInput)
-			if err != nil {
-				logger.Warn("Preprocessor failed, continuing with original input", "error", err)
+		name := stepName(stage.Preprocessor)
+		logger.Info("Applying preprocessor", "type", name, "policy", stage.Policy)
+		started := time.Now()
+		processedInput, err := stage.Preprocessor.Process(ctx, currentInput)
+		step := AppliedStep{Name: name, Policy: stage.Policy, Status: StepSucceeded}
+
+		if err != nil {
+			step.Error = err.Error()
+
+			switch {
+			case stage.Policy == PolicyFallback && stage.Fallback != nil:
+				step.Fallback = stepName(stage.Fallback)
+				logger.Warn("Preprocessor failed, running fallback", "type", name, "fallback", step.Fallback, "error", err)
+				processedInput, err = stage.Fallback.Process(ctx, currentInput)
+				if err != nil {
+					step.Status = StepFailed
+					step.Error = fmt.Sprintf("%s; fallback %s: %v", step.Error, step.Fallback, err)
+					step.DurationMs = time.Since(started).Milliseconds()
+					record(step)
+					return currentInput, applied, &StepError{Step: name, Err: err}
+				}
+				step.Status = StepFellBack
+
+			case stage.Policy == PolicyOptional:
+				logger.Warn("Optional preprocessor failed, continuing without it", "type", name, "error", err)
+				step.Status = StepFailed
+				step.DurationMs = time.Since(started).Milliseconds()
+				record(step)
 				continue
+
+			default:
+				logger.Error("Required preprocessor failed", "type", name, "error", err)
+				step.Status = StepFailed
+				step.DurationMs = time.Since(started).Milliseconds()
+				record(step)
+				return currentInput, applied, &StepError{Step: name, Err: err}
 			}
-			currentInput = processedInput
 		}
+
+		step.DurationMs = time.Since(started).Milliseconds()
+		record(step)
+
+		if previous := currentInput.TempFilePath; previous != "" && previous != input.FilePath && previous != processedInput.FilePath {
+			if err := os.Remove(previous); err != nil {
+				logger.Warn("Failed to remove intermediate audio", "file", previous, "error", err)
+			}
+		}
+		currentInput = processedInput
 	}
 
-	return currentInput, nil
+	return currentInput, applied, nil
+}
+
+// stepName returns the name a preprocessor reports, or its type
+func stepName(preprocessor interfaces.Preprocessor) string {
+	if named, ok := preprocessor.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("%T", preprocessor)
 }
 
 // AudioFormatPreprocessor converts audio to required formats
-type AudioFormatPreprocessor struct{}
+type AudioFormatPreprocessor struct {
+	TempDir string // Where the output is written, the system default if empty
+}
+
+// Name identifies the step in execution data
+func (a *AudioFormatPreprocessor) Name() string { return "format" }
 
 // AppliesTo checks if this preprocessor should be used for the given model
 func (a *AudioFormatPreprocessor) AppliesTo(capabilities interfaces.ModelCapabilities) bool {
@@ -102,8 +189,19 @@ func (a *AudioFormatPreprocessor) Process(ctx context.Context, input interfaces.
 		"from_channels", input.Channels,
 		"to_channels", requiredChannels)
 
-	// Create output path
-	outputPath := strings.TrimSuffix(input.FilePath, filepath.Ext(input.FilePath)) + "_converted.wav"
+	// Each conversion gets its own file, so runs on the same upload do not
+	// overwrite each other's output
+	if a.TempDir != "" {
+		if err := os.MkdirAll(a.TempDir, 0755); err != nil {
+			return input, fmt.Errorf("failed to create temp directory: %w", err)
+		}
+	}
+	outputFile, err := os.CreateTemp(a.TempDir, "converted_*.wav")
+	if err != nil {
+		return input, fmt.Errorf("failed to create converted audio file: %w", err)
+	}
+	outputPath := outputFile.Name()
+	_ = outputFile.Close()
 
 	// Build FFmpeg command
 	args := []string{
@@ -119,6 +217,7 @@ func (a *AudioFormatPreprocessor) Process(ctx context.Context, input interfaces.
 	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
+		_ = os.Remove(outputPath)
 		logger.Error("FFmpeg conversion failed", "output", string(output), "error", err)
 		return input, fmt.Errorf("audio conversion failed: %w", err)
 	}
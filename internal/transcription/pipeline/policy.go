@@ -0,0 +1,57 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"scriberr/internal/transcription/interfaces"
+)
+
+// FailurePolicy decides what happens to a job when a preprocessor fails
+type FailurePolicy string
+
+const (
+	// PolicyRequired fails the job
+	PolicyRequired FailurePolicy = "required"
+	// PolicyOptional continues with the audio as it was before the step
+	PolicyOptional FailurePolicy = "optional"
+	// PolicyFallback runs an alternative preprocessor instead, and fails the job if that fails too
+	PolicyFallback FailurePolicy = "fallback"
+)
+
+// Outcomes of a preprocessing step
+const (
+	StepSucceeded = "succeeded"
+	StepFailed    = "failed"
+	StepFellBack  = "fallback"
+)
+
+// Stage is a preprocessor together with its failure policy
+type Stage struct {
+	Preprocessor interfaces.Preprocessor
+	Policy       FailurePolicy
+	Fallback     interfaces.Preprocessor // Used with PolicyFallback
+}
+
+// ParsePolicy validates a failure policy name
+func ParsePolicy(value string) (FailurePolicy, error) {
+	switch policy := FailurePolicy(value); policy {
+	case PolicyRequired, PolicyOptional, PolicyFallback:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown failure policy %q", value)
+	}
+}
+
+// StepError is returned when a preprocessor the job cannot do without fails
+type StepError struct {
+	Step string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("preprocessor %s failed: %v", e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
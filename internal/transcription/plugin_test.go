@@ -0,0 +1,199 @@
+package transcription
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/adapters"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/registry"
+)
+
+// writePlugin creates a plugin directory with a manifest and files
+func writePlugin(t *testing.T, root, name string, files map[string]string) {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for file, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+const transcriptionPluginYAML = `
+id: echo_asr
+type: transcription
+capabilities:
+  display_name: Echo ASR
+  supported_formats: [wav]
+parameters:
+  - name: language
+    type: string
+  - name: model
+    type: string
+    default: tiny
+command: ["sh", "transcribe.sh", "{{.Request}}", "{{.Output}}", "{{if .Params.language}}--language={{.Params.language}}{{end}}"]
+setup:
+  check: ["test", "-f", "{{.PluginDir}}/ready"]
+  command: ["touch", "ready"]
+`
+
+const transcriptionPluginScript = `#!/bin/sh
+cp "$1" "$SCRIBERR_PLUGIN_DIR/last_request.json"
+echo "$3" > "$SCRIBERR_PLUGIN_DIR/last_flag.txt"
+echo "transcribing"
+cat > "$2" <<'EOF'
+{
+  "language": "de",
+  "segments": [{"start": 0, "end": 1.5, "text": " Hallo"}, {"start": 1.5, "end": 3, "text": " Welt"}],
+  "word_segments": [{"start": 0, "end": 1, "word": "Hallo", "score": 0.9}]
+}
+EOF
+`
+
+const diarizationPluginJSON = `{
+  "id": "echo_diarizer",
+  "type": "diarization",
+  "max_speakers": 3,
+  "command": ["sh", "-c", "printf '{\"segments\": [{\"start\": 0, \"end\": 2, \"speaker\": \"B\"}, {\"start\": 2, \"end\": 3, \"speaker\": \"A\"}]}' > {{.Output}}"]
+}`
+
+func TestPluginManifestsLoad(t *testing.T) {
+	root := t.TempDir()
+	writePlugin(t, root, "asr", map[string]string{"plugin.yaml": transcriptionPluginYAML})
+	writePlugin(t, root, "diarizer", map[string]string{"plugin.json": diarizationPluginJSON})
+	writePlugin(t, root, "broken", map[string]string{"plugin.json": `{"id": "broken", "type": "translation", "command": ["x"]}`})
+	writePlugin(t, root, "not_a_plugin", map[string]string{"notes.txt": "hello"})
+
+	manifests, errs := adapters.LoadPluginManifests(root)
+	if len(manifests) != 2 {
+		t.Fatalf("expected 2 plugins, got %+v", manifests)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "type must be") {
+		t.Errorf("expected the broken manifest to be reported, got %v", errs)
+	}
+
+	if manifests, errs := adapters.LoadPluginManifests(filepath.Join(root, "missing")); len(manifests) != 0 || len(errs) != 0 {
+		t.Errorf("a missing plugins directory must be empty, got %v %v", manifests, errs)
+	}
+}
+
+func TestPluginAdapters(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+
+	root := t.TempDir()
+	writePlugin(t, root, "asr", map[string]string{"plugin.yaml": transcriptionPluginYAML, "transcribe.sh": transcriptionPluginScript})
+	writePlugin(t, root, "diarizer", map[string]string{"plugin.json": diarizationPluginJSON})
+
+	manifests, errs := adapters.LoadPluginManifests(root)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+	byID := map[string]*adapters.PluginAdapter{}
+	for _, manifest := range manifests {
+		adapter := adapters.NewPluginAdapter(manifest)
+		byID[manifest.ID] = adapter
+		if manifest.Type == adapters.PluginTypeDiarization {
+			registry.RegisterDiarizationAdapter(manifest.ID, adapter)
+		} else {
+			registry.RegisterTranscriptionAdapter(manifest.ID, adapter)
+		}
+	}
+
+	asr := byID["echo_asr"]
+	if err := asr.PrepareEnvironment(context.Background()); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "asr", "ready")); err != nil {
+		t.Error("expected the setup command to run in the plugin directory")
+	}
+
+	// Profiles select plugins by ID
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), "data/temp", "data/transcripts")
+	jobParams := models.WhisperXParams{ModelFamily: "echo_asr", Language: stringPtr("de"), Diarize: true, DiarizeModel: "echo_diarizer", BatchSize: 8}
+	transcriptionID := service.transcriptionCandidates(context.Background(), jobParams)[0].ModelID
+	diarizers := service.diarizationCandidates(context.Background(), jobParams)
+	if transcriptionID != "echo_asr" || len(diarizers) == 0 || diarizers[0].ModelID != "echo_diarizer" {
+		t.Fatalf("expected the plugins to be selected, got %s and %+v", transcriptionID, diarizers)
+	}
+
+	audio := filepath.Join(root, "audio.wav")
+	if err := os.WriteFile(audio, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	input := interfaces.AudioInput{FilePath: audio, Format: "wav", Size: 4, SampleRate: 16000, Channels: 1}
+	procCtx := interfaces.ProcessingContext{JobID: "job", OutputDirectory: root, TempDirectory: root}
+
+	result, err := asr.Transcribe(context.Background(), input, service.convertParametersForModel(jobParams, transcriptionID), procCtx)
+	if err != nil {
+		t.Fatalf("transcription failed: %v", err)
+	}
+	if result.Text != "Hallo Welt" || result.Language != "de" || len(result.WordSegments) != 1 || result.ModelUsed != "tiny" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	request, _ := os.ReadFile(filepath.Join(root, "asr", "last_request.json"))
+	for _, expected := range []string{`"version": 1`, `"language": "de"`, `"model": "tiny"`, `"sample_rate": 16000`} {
+		if !strings.Contains(string(request), expected) {
+			t.Errorf("expected %s in request %s", expected, request)
+		}
+	}
+	if strings.Contains(string(request), "batch_size") {
+		t.Error("undeclared parameters must not be sent")
+	}
+	if flag, _ := os.ReadFile(filepath.Join(root, "asr", "last_flag.txt")); strings.TrimSpace(string(flag)) != "--language=de" {
+		t.Errorf("expected the templated flag, got %q", flag)
+	}
+	if log, _ := os.ReadFile(filepath.Join(root, "transcription.log")); !strings.Contains(string(log), "transcribing") {
+		t.Error("expected plugin output in the job log")
+	}
+
+	diarization, err := byID["echo_diarizer"].Diarize(context.Background(), input, nil, procCtx)
+	if err != nil {
+		t.Fatalf("diarization failed: %v", err)
+	}
+	if diarization.SpeakerCount != 2 || diarization.Speakers[0] != "A" || byID["echo_diarizer"].GetMaxSpeakers() != 3 {
+		t.Errorf("unexpected diarization: %+v", diarization)
+	}
+}
+
+func TestPluginReportsErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+
+	root := t.TempDir()
+	writePlugin(t, root, "failing", map[string]string{"plugin.json": `{
+  "id": "failing",
+  "type": "transcription",
+  "command": ["sh", "-c", "echo '{\"error\": \"model weights missing\"}' > {{.Output}}; exit 3"]
+}`})
+	manifests, _ := adapters.LoadPluginManifests(root)
+	if len(manifests) != 1 {
+		t.Fatal("expected the plugin to load")
+	}
+
+	audio := filepath.Join(root, "audio.mp3")
+	if err := os.WriteFile(audio, []byte("ID3"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := adapters.NewPluginAdapter(manifests[0]).Transcribe(context.Background(),
+		interfaces.AudioInput{FilePath: audio, Format: "mp3", Size: 3}, nil,
+		interfaces.ProcessingContext{JobID: "job", OutputDirectory: root, TempDirectory: root})
+	if err == nil || !strings.Contains(err.Error(), "model weights missing") {
+		t.Errorf("expected the plugin's error message, got %v", err)
+	}
+}
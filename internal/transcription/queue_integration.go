@@ -4,7 +4,9 @@ import (
 	"context"
 	"os/exec"
 
+	"scriberr/internal/models"
 	"scriberr/internal/repository"
+	"scriberr/internal/transcription/interfaces"
 	"scriberr/pkg/logger"
 )
 
@@ -55,6 +57,36 @@ func (u *UnifiedJobProcessor) SetAIPostprocessor(apiKey, model string, enabled b
 	u.unifiedService.SetAIPostprocessor(apiKey, model, enabled)
 }
 
+// DisableWebhooks stops the processor from calling the callback URLs of jobs
+func (u *UnifiedJobProcessor) DisableWebhooks() {
+	u.unifiedService.DisableWebhooks()
+}
+
+// SendWebhook calls the job's callback URL, if it has one, with its outcome
+func (u *UnifiedJobProcessor) SendWebhook(job *models.TranscriptionJob, execution *models.TranscriptionJobExecution, status models.JobStatus) {
+	u.unifiedService.SendWebhook(job, execution, status)
+}
+
+// SetTrackConcurrency sets how many tracks of a multi-track job are transcribed at once
+func (u *UnifiedJobProcessor) SetTrackConcurrency(n int) {
+	u.unifiedService.SetTrackConcurrency(n)
+}
+
+// SetTrackLimit sets how many tracks are transcribed at once across all multi-track jobs
+func (u *UnifiedJobProcessor) SetTrackLimit(n int) {
+	u.unifiedService.SetTrackLimit(n)
+}
+
+// SetVoiceLibrary enables naming diarized speakers after the voices of the speaker library
+func (u *UnifiedJobProcessor) SetVoiceLibrary(voices repository.VoiceRepository, speakerMappings repository.SpeakerMappingRepository, threshold float64) {
+	u.unifiedService.SetVoiceLibrary(voices, speakerMappings, threshold)
+}
+
+// JobsUsingModels returns the pending and processing jobs that may run on any of the models
+func (u *UnifiedJobProcessor) JobsUsingModels(ctx context.Context, modelIDs []string) ([]string, error) {
+	return u.unifiedService.JobsUsingModels(ctx, modelIDs)
+}
+
 // GetSupportedModels returns all supported models through the new architecture
 func (u *UnifiedJobProcessor) GetSupportedModels() map[string]interface{} {
 	capabilities := u.unifiedService.GetSupportedModels()
@@ -73,6 +105,14 @@ func (u *UnifiedJobProcessor) GetSupportedModels() map[string]interface{} {
 			"features":     cap.Features,
 			"memory_mb":    cap.MemoryRequirement,
 			"requires_gpu": cap.RequiresGPU,
+			"metadata":     cap.Metadata,
+		}
+		if adapter, err := u.unifiedService.registry.GetTranscriptionAdapter(modelID); err == nil {
+			entry := result[modelID].(map[string]interface{})
+			entry["models"] = adapter.GetSupportedModels()
+			if customizable, ok := adapter.(interfaces.CustomModelAdapter); ok {
+				entry["custom_models"] = customizable.GetCustomModels()
+			}
 		}
 	}
 
@@ -89,6 +129,56 @@ func (u *UnifiedJobProcessor) ValidateModelParameters(modelID string, params map
 	return u.unifiedService.ValidateModelParameters(modelID, params)
 }
 
+// ValidateProfileParameters checks that a profile's model can be run by its model family
+func (u *UnifiedJobProcessor) ValidateProfileParameters(params models.WhisperXParams) error {
+	return u.unifiedService.ValidateProfileParameters(params)
+}
+
+// CheckDiarizationModel returns an error if no adapter serves the named diarization model
+func (u *UnifiedJobProcessor) CheckDiarizationModel(name string) error {
+	return u.unifiedService.CheckDiarizationModel(name)
+}
+
+// CheckWordAligner returns an error unless a ready adapter aligns transcript words
+func (u *UnifiedJobProcessor) CheckWordAligner(ctx context.Context) error {
+	return u.unifiedService.CheckWordAligner(ctx)
+}
+
+// DiarizeTranscript diarizes a finished job's audio and merges the speakers into its transcript
+func (u *UnifiedJobProcessor) DiarizeTranscript(ctx context.Context, job *models.TranscriptionJob, params models.WhisperXParams) (*interfaces.TranscriptResult, *interfaces.DiarizationResult, error) {
+	return u.unifiedService.DiarizeTranscript(ctx, job, params)
+}
+
+// AlignTranscript times the words of a finished job's transcript against its audio
+func (u *UnifiedJobProcessor) AlignTranscript(ctx context.Context, job *models.TranscriptionJob, params models.WhisperXParams, language string) (*interfaces.TranscriptResult, error) {
+	return u.unifiedService.AlignTranscript(ctx, job, params, language)
+}
+
+// RetranscribeRange transcribes a time range of a finished job again and splices it into its transcript
+func (u *UnifiedJobProcessor) RetranscribeRange(ctx context.Context, job *models.TranscriptionJob, params models.WhisperXParams, start, end float64) (*RangeTranscription, error) {
+	return u.unifiedService.RetranscribeRange(ctx, job, params, start, end)
+}
+
+// EmbedVoice computes the voice embedding of a recording of one person
+func (u *UnifiedJobProcessor) EmbedVoice(ctx context.Context, audioPath string, hfToken string) (*models.VoiceEmbedding, error) {
+	return u.unifiedService.EmbedVoice(ctx, audioPath, hfToken)
+}
+
+// EmbedJobSpeaker returns the voice embedding of a job's speaker
+func (u *UnifiedJobProcessor) EmbedJobSpeaker(ctx context.Context, job *models.TranscriptionJob, speaker string) (*models.VoiceEmbedding, error) {
+	return u.unifiedService.EmbedJobSpeaker(ctx, job, speaker)
+}
+
+// IdentifySpeakers names the speakers of a transcript recognised from the speaker library
+func (u *UnifiedJobProcessor) IdentifySpeakers(ctx context.Context, job *models.TranscriptionJob, result *interfaces.TranscriptResult) ([]VoiceMatch, error) {
+	return u.unifiedService.IdentifySpeakers(ctx, job, result)
+}
+
+// ForgetVoiceMatches resets a job's automatic speaker matches and embeddings
+func (u *UnifiedJobProcessor) ForgetVoiceMatches(ctx context.Context, jobID string) error {
+	return u.unifiedService.ForgetVoiceMatches(ctx, jobID)
+}
+
 // InitEmbeddedPythonEnv initializes the Python environment for all adapters
 func (u *UnifiedJobProcessor) InitEmbeddedPythonEnv() error {
 	ctx := context.Background()
@@ -144,6 +234,11 @@ func (u *UnifiedJobProcessor) IsMultiTrackJob(jobID string) bool {
 	return u.unifiedService.IsMultiTrackJob(jobID)
 }
 
+// HasCheckpoint reports whether an interrupted job can resume from finished chunks
+func (u *UnifiedJobProcessor) HasCheckpoint(jobID string) bool {
+	return u.unifiedService.HasCheckpoint(jobID)
+}
+
 // ReprocessTranscript runs AI post-processing on an existing transcript
 func (u *UnifiedJobProcessor) ReprocessTranscript(ctx context.Context, jobID string) error {
 	return u.unifiedService.ReprocessTranscript(ctx, jobID)
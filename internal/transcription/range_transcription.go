@@ -0,0 +1,188 @@
+package transcription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/pipeline"
+	"scriberr/pkg/logger"
+)
+
+// ErrInvalidRange is returned for a time range that ends before it starts
+var ErrInvalidRange = errors.New("invalid time range")
+
+// RangeTranscription is a transcript with part of it transcribed again
+type RangeTranscription struct {
+	Result *interfaces.TranscriptResult
+
+	// The range transcribed again, widened to the segments it overlaps
+	Start float64
+	End   float64
+
+	ModelID string
+}
+
+// RetranscribeRange transcribes part of a finished job's audio again with
+// other parameters and splices the new segments into its current transcript
+// in place of the ones in the range. The rest of the transcript, including
+// edits, is kept and the new speech gets the speakers of the speech it
+// replaces. The result is not saved.
+func (u *UnifiedTranscriptionService) RetranscribeRange(ctx context.Context, job *models.TranscriptionJob, params models.WhisperXParams, start, end float64) (*RangeTranscription, error) {
+	if job.Transcript == nil || *job.Transcript == "" {
+		return nil, fmt.Errorf("no transcript found for job %s", job.ID)
+	}
+	var transcript interfaces.TranscriptResult
+	if err := json.Unmarshal([]byte(*job.Transcript), &transcript); err != nil {
+		return nil, fmt.Errorf("failed to parse existing transcript: %w", err)
+	}
+	if start < 0 || end <= start {
+		return nil, fmt.Errorf("%w: %.2f-%.2f", ErrInvalidRange, start, end)
+	}
+	start, end = widenRange(transcript.Segments, start, end)
+
+	procCtx := interfaces.ProcessingContext{
+		JobID:           job.ID,
+		OutputDirectory: filepath.Join(u.outputDirectory, job.ID),
+		TempDirectory:   u.tempDirectory,
+		Metadata:        map[string]string{},
+	}
+	if err := os.MkdirAll(procCtx.OutputDirectory, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	clipPath, err := u.extractRangeClip(ctx, job.AudioPath, job.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(clipPath) }()
+	clip, err := u.createAudioInput(clipPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audio input: %w", err)
+	}
+
+	// Speakers come from the transcript, so the model does not diarize
+	params.Diarize = false
+	// In auto mode, route the range by the language of its audio
+	if params.ModelFamily == FamilyAuto {
+		params, _ = u.routeAutoModel(ctx, params, clip, procCtx)
+	}
+	var attempts []ModelAttempt
+	replacement, candidate, err := u.transcribeWithFallbacks(ctx, u.transcriptionCandidates(ctx, params), clip, procCtx, &attempts)
+	if err != nil {
+		return nil, fmt.Errorf("transcription failed: %w", err)
+	}
+	u.alignTranscription(ctx, candidate.Params, replacement, clip, procCtx)
+	pipeline.RestoreTimestamps(replacement, start)
+
+	result := u.spliceTranscript(&transcript, replacement, start, end, params.SpeakerAssignment)
+	message := fmt.Sprintf("Transcribed %.1fs-%.1fs again with %s: %d segments", start, end, candidate.ModelID, len(replacement.Segments))
+	logger.Info(message, "job_id", job.ID)
+	appendJobLog(procCtx.OutputDirectory, message)
+
+	return &RangeTranscription{Result: result, Start: start, End: end, ModelID: candidate.ModelID}, nil
+}
+
+// extractRangeClip cuts a time range of the audio into a 16 kHz mono WAV
+func (u *UnifiedTranscriptionService) extractRangeClip(ctx context.Context, audioPath, jobID string, start, end float64) (string, error) {
+	if err := os.MkdirAll(u.tempDirectory, 0755); err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	clip, err := os.CreateTemp(u.tempDirectory, jobID+"_range_*.wav")
+	if err != nil {
+		return "", fmt.Errorf("failed to create range clip: %w", err)
+	}
+	clipPath := clip.Name()
+	_ = clip.Close()
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-y", "-v", "error",
+		"-ss", strconv.FormatFloat(start, 'f', 3, 64),
+		"-t", strconv.FormatFloat(end-start, 'f', 3, 64),
+		"-i", audioPath,
+		"-ac", "1", "-ar", "16000", "-c:a", "pcm_s16le",
+		clipPath)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		_ = os.Remove(clipPath)
+		return "", fmt.Errorf("failed to extract the time range: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return clipPath, nil
+}
+
+// widenRange extends a time range to the start of the first and the end of
+// the last segment it overlaps, so no segment is cut in two
+func widenRange(segments []interfaces.TranscriptSegment, start, end float64) (float64, float64) {
+	for _, segment := range segments {
+		if segment.End > start && segment.Start < end {
+			start = min(start, segment.Start)
+			end = max(end, segment.End)
+		}
+	}
+	return start, end
+}
+
+// spliceTranscript replaces the segments and words of a time range with the
+// ones of a transcript of that range. When the transcript has speakers, the
+// new speech is given the speakers of the speech it replaces.
+func (u *UnifiedTranscriptionService) spliceTranscript(transcript, replacement *interfaces.TranscriptResult, start, end float64, strategy string) *interfaces.TranscriptResult {
+	inRange := func(from, to float64) bool {
+		middle := (from + to) / 2
+		return middle >= start && middle < end
+	}
+
+	// The replaced segments serve as the diarization of the range
+	var turns []interfaces.DiarizationSegment
+	for _, segment := range transcript.Segments {
+		if inRange(segment.Start, segment.End) && segment.Speaker != nil {
+			turns = append(turns, interfaces.DiarizationSegment{Start: segment.Start, End: segment.End, Speaker: *segment.Speaker})
+		}
+	}
+
+	for i := range replacement.Segments {
+		replacement.Segments[i].Speaker = nil
+		replacement.Segments[i].OverlappingSpeakers = nil
+	}
+	for i := range replacement.WordSegments {
+		replacement.WordSegments[i].Speaker = nil
+		replacement.WordSegments[i].OverlappingSpeakers = nil
+	}
+	if len(turns) > 0 {
+		replacement = u.mergeDiarizationWithTranscription(replacement, &interfaces.DiarizationResult{Segments: turns}, strategy)
+	}
+
+	result := *transcript
+	result.Segments = nil
+	for _, segment := range transcript.Segments {
+		if !inRange(segment.Start, segment.End) {
+			result.Segments = append(result.Segments, segment)
+		}
+	}
+	result.Segments = append(result.Segments, replacement.Segments...)
+	sort.SliceStable(result.Segments, func(i, j int) bool { return result.Segments[i].Start < result.Segments[j].Start })
+
+	result.WordSegments = nil
+	for _, word := range transcript.WordSegments {
+		if !inRange(word.Start, word.End) {
+			result.WordSegments = append(result.WordSegments, word)
+		}
+	}
+	result.WordSegments = append(result.WordSegments, replacement.WordSegments...)
+	sort.SliceStable(result.WordSegments, func(i, j int) bool { return result.WordSegments[i].Start < result.WordSegments[j].Start })
+
+	texts := make([]string, 0, len(result.Segments))
+	for _, segment := range result.Segments {
+		if text := strings.TrimSpace(segment.Text); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	result.Text = strings.Join(texts, " ")
+	return &result
+}
@@ -0,0 +1,181 @@
+package transcription
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/registry"
+)
+
+func TestWidenRange(t *testing.T) {
+	segments := []interfaces.TranscriptSegment{
+		{Start: 0, End: 2, Text: "One."},
+		{Start: 2, End: 5, Text: "Two."},
+		{Start: 5, End: 7, Text: "Three."},
+	}
+	if start, end := widenRange(segments, 3, 4); start != 2 || end != 5 {
+		t.Errorf("range inside a segment = %v-%v", start, end)
+	}
+	if start, end := widenRange(segments, 1, 6); start != 0 || end != 7 {
+		t.Errorf("range over segment boundaries = %v-%v", start, end)
+	}
+	if start, end := widenRange(segments, 2, 5); start != 2 || end != 5 {
+		t.Errorf("range of a whole segment = %v-%v", start, end)
+	}
+}
+
+func TestSpliceTranscript(t *testing.T) {
+	// The first segment was edited after transcription
+	transcript := &interfaces.TranscriptResult{
+		Language: "en",
+		Text:     "Hello everyone. [crosstalk] Bye.",
+		Segments: []interfaces.TranscriptSegment{
+			{Start: 0, End: 2, Text: "Hello everyone.", Speaker: stringPtr("SPEAKER_00")},
+			{Start: 2, End: 5, Text: "[crosstalk]", Speaker: stringPtr("SPEAKER_01")},
+			{Start: 5, End: 7, Text: "Bye.", Speaker: stringPtr("SPEAKER_00")},
+		},
+		WordSegments: []interfaces.TranscriptWord{
+			{Start: 0.0, End: 0.8, Word: "Hello", Speaker: stringPtr("SPEAKER_00")},
+			{Start: 0.9, End: 1.8, Word: "all.", Speaker: stringPtr("SPEAKER_00")},
+			{Start: 2.0, End: 4.8, Word: "[crosstalk]", Speaker: stringPtr("SPEAKER_01")},
+			{Start: 5.0, End: 6.0, Word: "Bye.", Speaker: stringPtr("SPEAKER_00")},
+		},
+	}
+	// The range transcribed again, its timestamps already moved to the recording.
+	// The model's own speaker labels are ignored.
+	replacement := &interfaces.TranscriptResult{
+		Segments: []interfaces.TranscriptSegment{
+			{Start: 2.1, End: 4.9, Text: " I think we agree.", Speaker: stringPtr("SPEAKER_05")},
+		},
+		WordSegments: []interfaces.TranscriptWord{
+			{Start: 2.1, End: 2.3, Word: "I"},
+			{Start: 2.4, End: 3.0, Word: "think"},
+			{Start: 3.1, End: 3.5, Word: "we"},
+			{Start: 3.6, End: 4.9, Word: "agree."},
+		},
+	}
+
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), t.TempDir(), t.TempDir())
+	result := service.spliceTranscript(transcript, replacement, 2, 5, SpeakerAssignmentWord)
+
+	want := []string{"SPEAKER_00: Hello everyone.", "SPEAKER_01:  I think we agree.", "SPEAKER_00: Bye."}
+	if got := segmentLabels(result); !reflect.DeepEqual(got, want) {
+		t.Errorf("segments = %v, want %v", got, want)
+	}
+	wantWords := []string{"SPEAKER_00", "SPEAKER_00", "SPEAKER_01", "SPEAKER_01", "SPEAKER_01", "SPEAKER_01", "SPEAKER_00"}
+	if got := wordLabels(result); !reflect.DeepEqual(got, wantWords) {
+		t.Errorf("words = %v, want %v", got, wantWords)
+	}
+	if result.Text != "Hello everyone. I think we agree. Bye." {
+		t.Errorf("text = %q", result.Text)
+	}
+	if result.Language != "en" {
+		t.Errorf("language = %q", result.Language)
+	}
+
+	// The stored transcript is left as it was
+	if len(transcript.Segments) != 3 || transcript.Segments[1].Text != "[crosstalk]" || len(transcript.WordSegments) != 4 {
+		t.Errorf("transcript was changed: %+v", transcript)
+	}
+}
+
+func TestExtractRangeClipUsesUniqueFiles(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ffmpeg is a shell script")
+	}
+
+	// ffmpeg writes a WAV file to its last argument, and fails for a missing input
+	root := t.TempDir()
+	bin := filepath.Join(root, "bin")
+	if err := os.MkdirAll(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	ffmpeg := "#!/bin/sh\nfor last; do :; done\ncase \"$*\" in *missing*) exit 1;; esac\nprintf RIFF > \"$last\"\n"
+	if err := os.WriteFile(filepath.Join(bin, "ffmpeg"), []byte(ffmpeg), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	tempDir := filepath.Join(root, "temp")
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), tempDir, root)
+	first, err := service.extractRangeClip(context.Background(), "audio.wav", "job", 0, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := service.extractRangeClip(context.Background(), "audio.wav", "job", 5, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second {
+		t.Errorf("clips of the same job share the path %s", first)
+	}
+	if filepath.Dir(first) != tempDir {
+		t.Errorf("clip written outside the temp directory: %s", first)
+	}
+
+	// A failed cut leaves no file behind
+	if _, err := service.extractRangeClip(context.Background(), "missing.wav", "job", 0, 5); err == nil {
+		t.Fatal("expected extracting from missing audio to fail")
+	}
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("expected the two clips in the temp directory, got %v", entries)
+	}
+}
+
+func TestRetranscribeRangeRoutesAutoMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ffmpeg and plugins are shell scripts")
+	}
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+
+	root := t.TempDir()
+	registerRoutingPlugins(t, filepath.Join(root, "plugins"))
+
+	// ffmpeg writes a WAV file to its last argument
+	bin := filepath.Join(root, "bin")
+	if err := os.MkdirAll(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	ffmpeg := "#!/bin/sh\nfor last; do :; done\nprintf RIFF > \"$last\"\n"
+	if err := os.WriteFile(filepath.Join(bin, "ffmpeg"), []byte(ffmpeg), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	transcriptJSON, _ := json.Marshal(interfaces.TranscriptResult{
+		Language: "de",
+		Segments: []interfaces.TranscriptSegment{{Start: 0, End: 2, Text: "Hallo."}, {Start: 2, End: 4, Text: "Tschüss."}},
+	})
+	transcript := string(transcriptJSON)
+	job := &models.TranscriptionJob{
+		ID:         "auto-range-job",
+		AudioPath:  filepath.Join(root, "audio.wav"),
+		Transcript: &transcript,
+		Parameters: models.WhisperXParams{ModelFamily: FamilyAuto, Model: "small", Language: stringPtr("de")},
+	}
+
+	// The range goes to the German specialist instead of the default model
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), filepath.Join(root, "temp"), root)
+	result, err := service.RetranscribeRange(context.Background(), job, job.Parameters, 2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ModelID != "german_asr" {
+		t.Errorf("expected the range to be routed to german_asr, got %s", result.ModelID)
+	}
+	if len(result.Result.Segments) != 2 || result.Result.Segments[1].Text != "hallo" {
+		t.Errorf("expected the routed model's text to be spliced in, got %+v", result.Result.Segments)
+	}
+}
@@ -191,13 +191,14 @@ func (r *ModelRegistry) GetDiarizationModels() []string {
 
 // ModelScore represents a model's suitability score for given requirements
 type ModelScore struct {
-	ModelID string
-	Score   float64
-	Reasons []string
+	ModelID string   `json:"model_id"`
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
 }
 
-// SelectBestTranscriptionModel finds the best transcription model for given requirements
-func (r *ModelRegistry) SelectBestTranscriptionModel(requirements interfaces.ModelRequirements) (string, error) {
+// RankTranscriptionModels scores every transcription model against the
+// requirements, best first. Models that cannot serve the requirements are left out.
+func (r *ModelRegistry) RankTranscriptionModels(requirements interfaces.ModelRequirements) []ModelScore {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -225,15 +226,17 @@ func (r *ModelRegistry) SelectBestTranscriptionModel(requirements interfaces.Mod
 		}
 	}
 
+	sortScores(candidates)
+	return candidates
+}
+
+// SelectBestTranscriptionModel finds the best transcription model for given requirements
+func (r *ModelRegistry) SelectBestTranscriptionModel(requirements interfaces.ModelRequirements) (string, error) {
+	candidates := r.RankTranscriptionModels(requirements)
 	if len(candidates) == 0 {
 		return "", fmt.Errorf("no suitable transcription model found for requirements: %+v", requirements)
 	}
 
-	// Sort by score (highest first)
-	sort.Slice(candidates, func(i, j int) bool {
-		return candidates[i].Score > candidates[j].Score
-	})
-
 	bestModel := candidates[0]
 	logger.Info("Selected best transcription model",
 		"model_id", bestModel.ModelID,
@@ -243,6 +246,17 @@ func (r *ModelRegistry) SelectBestTranscriptionModel(requirements interfaces.Mod
 	return bestModel.ModelID, nil
 }
 
+// sortScores orders scores highest first, ties by model ID so the choice does
+// not depend on map order
+func sortScores(candidates []ModelScore) {
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].ModelID < candidates[j].ModelID
+	})
+}
+
 // SelectBestDiarizationModel finds the best diarization model for given requirements
 func (r *ModelRegistry) SelectBestDiarizationModel(requirements interfaces.ModelRequirements) (string, error) {
 	r.mu.RLock()
@@ -277,9 +291,7 @@ func (r *ModelRegistry) SelectBestDiarizationModel(requirements interfaces.Model
 	}
 
 	// Sort by score (highest first)
-	sort.Slice(candidates, func(i, j int) bool {
-		return candidates[i].Score > candidates[j].Score
-	})
+	sortScores(candidates)
 
 	bestModel := candidates[0]
 	logger.Info("Selected best diarization model",
@@ -300,10 +312,13 @@ func (r *ModelRegistry) scoreModel(capabilities interfaces.ModelCapabilities, re
 	// Language support (critical)
 	if requirements.Language != "" {
 		languageSupported := false
+		languageListed := false
 		for _, lang := range capabilities.SupportedLanguages {
+			if lang == requirements.Language {
+				languageListed = true
+			}
 			if lang == requirements.Language || lang == "auto" || lang == "*" {
 				languageSupported = true
-				break
 			}
 		}
 		if !languageSupported {
@@ -311,6 +326,12 @@ func (r *ModelRegistry) scoreModel(capabilities interfaces.ModelCapabilities, re
 		}
 		score += 20
 		reasons = append(reasons, "language supported")
+
+		// Models trained on a handful of languages usually do better on them
+		if languageListed && len(capabilities.SupportedLanguages) <= 20 {
+			score += 5
+			reasons = append(reasons, "language specialist")
+		}
 	}
 
 	// Required features (high priority)
@@ -356,14 +377,16 @@ func (r *ModelRegistry) scoreModel(capabilities interfaces.ModelCapabilities, re
 	// Quality preference
 	switch requirements.Quality {
 	case "fast":
-		if strings.Contains(strings.ToLower(capabilities.ModelID), "fast") ||
+		if capabilities.Features["fast_inference"] ||
+			strings.Contains(strings.ToLower(capabilities.ModelID), "fast") ||
 			strings.Contains(strings.ToLower(capabilities.ModelID), "tiny") ||
 			strings.Contains(strings.ToLower(capabilities.ModelID), "small") {
 			score += 10
 			reasons = append(reasons, "optimized for speed")
 		}
 	case "best":
-		if strings.Contains(strings.ToLower(capabilities.ModelID), "large") ||
+		if capabilities.Features["high_quality"] ||
+			strings.Contains(strings.ToLower(capabilities.ModelID), "large") ||
 			strings.Contains(strings.ToLower(capabilities.ModelID), "xl") ||
 			strings.Contains(strings.ToLower(capabilities.ModelID), "turbo") {
 			score += 10
@@ -439,6 +462,11 @@ func (r *ModelRegistry) InitializeModels(ctx context.Context) error {
 	wg.Wait()
 	close(initErrors)
 
+	// Preparing may change what an adapter reports, e.g. the models a server offers
+	for modelID, adapter := range r.transcriptionAdapters {
+		r.capabilities[modelID] = adapter.GetCapabilities()
+	}
+
 	// Collect any errors (but don't fail completely)
 	var errorList []error
 	for err := range initErrors {
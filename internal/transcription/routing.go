@@ -0,0 +1,290 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/registry"
+	"scriberr/pkg/logger"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+const (
+	// FamilyAuto routes each job to the best ready model for its language
+	FamilyAuto = "auto"
+
+	// languageDetectionDuration is the length of audio used to identify the language
+	languageDetectionDuration = 30 * time.Second
+)
+
+// RoutingDecision records how a job in auto mode was routed to a model
+type RoutingDecision struct {
+	Language       string                       `json:"language,omitempty"`
+	LanguageSource string                       `json:"language_source"` // profile, detected or unknown
+	Detector       string                       `json:"detector,omitempty"`
+	DetectionError string                       `json:"detection_error,omitempty"`
+	Requirements   interfaces.ModelRequirements `json:"requirements"`
+	Scores         []RoutingScore               `json:"scores"`
+	Selected       string                       `json:"selected"`
+	SelectedFamily string                       `json:"selected_family"`
+	SelectedModel  string                       `json:"selected_model,omitempty"`
+	FallbackModels string                       `json:"fallback_models,omitempty"`
+}
+
+// RoutingScore is a model's score for the job and whether it could run it
+type RoutingScore struct {
+	registry.ModelScore
+	Ready bool `json:"ready"`
+}
+
+// familyForModel returns the model family that selects an adapter
+func familyForModel(modelID string) string {
+	switch modelID {
+	case ModelParakeet:
+		return FamilyNvidiaParakeet
+	case ModelCanary:
+		return FamilyNvidiaCanary
+	case ModelWhisperX:
+		return FamilyWhisper
+	case ModelOpenAI:
+		return FamilyOpenAI
+	case ModelVoxtral:
+		return FamilyMistralVoxtral
+	case ModelWhisperCpp:
+		return FamilyWhisperCpp
+	}
+	// Adapters configured at runtime are selected by their model ID
+	return modelID
+}
+
+// modelNameFor picks the model name a profile in auto mode uses on an adapter.
+// Whisper sizes carry over between Whisper engines, other adapters use their default.
+func (u *UnifiedTranscriptionService) modelNameFor(modelID string, params models.WhisperXParams) string {
+	switch modelID {
+	case ModelWhisperX, ModelWhisperCpp:
+		if params.Model != "" {
+			return params.Model
+		}
+		return "small"
+	case ModelOpenAI:
+		return "whisper-1"
+	}
+	if capabilities, err := u.registry.GetCapabilities(modelID); err == nil && capabilities.Metadata["default_model"] != "" {
+		return capabilities.Metadata["default_model"]
+	}
+	return params.Model
+}
+
+// routeAutoModel identifies the language of the audio and rewrites the profile
+// to use the best ready model for it. The next best ready models become the
+// fallback chain unless the profile sets its own.
+func (u *UnifiedTranscriptionService) routeAutoModel(ctx context.Context, params models.WhisperXParams, input interfaces.AudioInput, procCtx interfaces.ProcessingContext) (models.WhisperXParams, *RoutingDecision) {
+	decision := &RoutingDecision{LanguageSource: "unknown"}
+
+	if params.Language != nil && *params.Language != "" && *params.Language != "auto" {
+		decision.Language = *params.Language
+		decision.LanguageSource = "profile"
+	} else {
+		detected, detector, err := u.detectLanguage(ctx, params, input, procCtx)
+		decision.Detector = detector
+		if err != nil {
+			logger.Warn("Language detection failed, routing without a language", "job_id", procCtx.JobID, "error", err)
+			decision.DetectionError = err.Error()
+		} else {
+			decision.Language = detected
+			decision.LanguageSource = "detected"
+		}
+	}
+
+	decision.Requirements = interfaces.ModelRequirements{
+		Language: decision.Language,
+		Features: []string{"timestamps", "word_level"},
+		Quality:  params.AutoQuality,
+	}
+	// A ready standalone diarizer labels any model's transcript, so built-in
+	// diarization only counts without one
+	if params.Diarize && !u.diarizerReady(ctx, params) {
+		decision.Requirements.Features = append(decision.Requirements.Features, "diarization")
+	}
+
+	var ready []string
+	for _, score := range u.registry.RankTranscriptionModels(decision.Requirements) {
+		adapter, err := u.registry.GetTranscriptionAdapter(score.ModelID)
+		routed := RoutingScore{ModelScore: score, Ready: err == nil && adapter.IsReady(ctx)}
+		decision.Scores = append(decision.Scores, routed)
+		if routed.Ready {
+			ready = append(ready, score.ModelID)
+		}
+	}
+
+	decision.Selected = ModelWhisperX // Default fallback
+	if len(ready) > 0 {
+		decision.Selected = ready[0]
+	} else {
+		logger.Warn("No ready model matches the job, using the default", "job_id", procCtx.JobID, "language", decision.Language)
+	}
+
+	routed := params
+	routed.ModelFamily = familyForModel(decision.Selected)
+	routed.Model = u.modelNameFor(decision.Selected, params)
+	if decision.Language != "" {
+		routed.Language = &decision.Language
+	}
+	if params.FallbackModels == nil && len(ready) > 1 {
+		var fallbacks []string
+		for _, modelID := range ready[1:] {
+			fallbacks = append(fallbacks, familyForModel(modelID)+":"+u.modelNameFor(modelID, params))
+		}
+		joined := strings.Join(fallbacks, ",")
+		routed.FallbackModels = &joined
+	}
+
+	decision.SelectedFamily = routed.ModelFamily
+	decision.SelectedModel = routed.Model
+	if routed.FallbackModels != nil {
+		decision.FallbackModels = *routed.FallbackModels
+	}
+
+	message := fmt.Sprintf("Auto mode: language %q (%s), routed to %s", decision.Language, decision.LanguageSource, decision.Selected)
+	logger.Info(message, "job_id", procCtx.JobID)
+	appendJobLog(procCtx.OutputDirectory, message)
+
+	return routed, decision
+}
+
+// diarizerReady reports whether the job's separate diarization chain has a
+// ready model
+func (u *UnifiedTranscriptionService) diarizerReady(ctx context.Context, params models.WhisperXParams) bool {
+	chain := u.diarizationCandidates(ctx, params)
+	return len(chain) > 0 && chain[0].Ready
+}
+
+// detectLanguage transcribes the start of the audio with a ready model that
+// identifies languages, preferring local ones
+func (u *UnifiedTranscriptionService) detectLanguage(ctx context.Context, params models.WhisperXParams, input interfaces.AudioInput, procCtx interfaces.ProcessingContext) (string, string, error) {
+	detectors := u.languageDetectors(ctx)
+	if len(detectors) == 0 {
+		return "", "", fmt.Errorf("no ready model supports language detection")
+	}
+
+	clip, err := u.extractDetectionClip(ctx, input, procCtx.JobID)
+	if err != nil {
+		return "", "", err
+	}
+	defer os.Remove(clip.FilePath)
+
+	var lastErr error
+	for _, modelID := range detectors {
+		detection := params
+		detection.ModelFamily = familyForModel(modelID)
+		detection.Model = u.modelNameFor(modelID, params)
+		detection.Language = nil
+		detection.Task = "transcribe"
+		detection.Diarize = false
+		detection.NoAlign = true
+
+		adapter, err := u.registry.GetTranscriptionAdapter(modelID)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		result, err := adapter.Transcribe(ctx, clip, u.convertParametersForModel(detection, modelID), procCtx)
+		if err != nil {
+			logger.Warn("Language detection failed", "model_id", modelID, "error", err)
+			lastErr = err
+			continue
+		}
+		if code := languageCode(result.Language); code != "" {
+			return code, modelID, nil
+		}
+		lastErr = fmt.Errorf("%s did not report a language", modelID)
+	}
+	return "", "", lastErr
+}
+
+// languageDetectors lists the ready models that identify languages, local
+// engines first
+func (u *UnifiedTranscriptionService) languageDetectors(ctx context.Context) []string {
+	preferred := []string{ModelWhisperCpp, ModelWhisperX, ModelOpenAI}
+	var detectors []string
+	seen := map[string]bool{}
+	add := func(modelID string) {
+		if seen[modelID] {
+			return
+		}
+		seen[modelID] = true
+		adapter, err := u.registry.GetTranscriptionAdapter(modelID)
+		if err == nil && adapter.GetCapabilities().Features["language_detection"] && adapter.IsReady(ctx) {
+			detectors = append(detectors, modelID)
+		}
+	}
+	for _, modelID := range preferred {
+		add(modelID)
+	}
+	for _, score := range u.registry.RankTranscriptionModels(interfaces.ModelRequirements{Features: []string{"language_detection"}}) {
+		add(score.ModelID)
+	}
+	return detectors
+}
+
+// extractDetectionClip cuts the start of the audio into a 16 kHz mono WAV
+func (u *UnifiedTranscriptionService) extractDetectionClip(ctx context.Context, input interfaces.AudioInput, jobID string) (interfaces.AudioInput, error) {
+	if err := os.MkdirAll(u.tempDirectory, 0755); err != nil {
+		return interfaces.AudioInput{}, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	clipPath := filepath.Join(u.tempDirectory, jobID+"_language.wav")
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-y", "-v", "error",
+		"-i", input.FilePath,
+		"-t", strconv.Itoa(int(languageDetectionDuration.Seconds())),
+		"-ac", "1", "-ar", "16000", "-c:a", "pcm_s16le",
+		clipPath)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return interfaces.AudioInput{}, fmt.Errorf("failed to extract audio for language detection: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	clip := interfaces.AudioInput{
+		FilePath:   clipPath,
+		Format:     "wav",
+		SampleRate: 16000,
+		Channels:   1,
+		Duration:   input.Duration,
+		Metadata:   map[string]string{},
+	}
+	if clip.Duration <= 0 || clip.Duration > languageDetectionDuration {
+		clip.Duration = languageDetectionDuration
+	}
+	if info, err := os.Stat(clipPath); err == nil {
+		clip.Size = info.Size()
+	}
+	return clip, nil
+}
+
+// languageCode turns a detected language into an ISO 639-1 code. The OpenAI
+// API reports English names such as "german" instead of codes.
+func languageCode(detected string) string {
+	detected = strings.ToLower(strings.TrimSpace(detected))
+	if detected == "" {
+		return ""
+	}
+	if len(detected) <= 3 {
+		return detected
+	}
+	namer := display.English.Languages()
+	for _, tag := range display.Supported.Tags() {
+		if base, confidence := tag.Base(); confidence == language.Exact && strings.ToLower(namer.Name(base)) == detected {
+			return base.String()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,182 @@
+package transcription
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/adapters"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/registry"
+)
+
+// registerRoutingPlugins registers a German specialist, a many-language model,
+// a specialist that is not ready and a language detector
+func registerRoutingPlugins(t *testing.T, root string) {
+	t.Helper()
+	succeed := `"command": ["sh", "-c", "printf '{\"language\": \"german\", \"segments\": [{\"start\": 0, \"end\": 1, \"text\": \"hallo\"}]}' > {{.Output}}"]`
+	features := `"features": {"timestamps": true, "word_level": true}`
+	manifests := map[string]string{
+		"german_asr": `{"id": "german_asr", "type": "transcription", "capabilities": {"supported_languages": ["de", "en"], ` + features + `}, ` + succeed + `}`,
+		"wide_asr":   `{"id": "wide_asr", "type": "transcription", "capabilities": {"supported_languages": ["de", "en", "yue", "auto"], ` + features + `}, ` + succeed + `}`,
+		"idle_asr":   `{"id": "idle_asr", "type": "transcription", "capabilities": {"supported_languages": ["de"], ` + features + `}, ` + succeed + `}`,
+		"lid":        `{"id": "lid", "type": "transcription", "capabilities": {"supported_languages": ["fr"], "features": {"language_detection": true}}, ` + succeed + `}`,
+	}
+	for name, manifest := range manifests {
+		writePlugin(t, root, name, map[string]string{"plugin.json": manifest})
+	}
+	loaded, errs := adapters.LoadPluginManifests(root)
+	if len(errs) > 0 || len(loaded) != len(manifests) {
+		t.Fatalf("failed to load plugins: %v", errs)
+	}
+	for _, manifest := range loaded {
+		adapter := adapters.NewPluginAdapter(manifest)
+		if manifest.ID != "idle_asr" {
+			if err := adapter.PrepareEnvironment(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		registry.RegisterTranscriptionAdapter(manifest.ID, adapter)
+	}
+}
+
+func TestAutoRoutingByLanguage(t *testing.T) {
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+	root := t.TempDir()
+	registerRoutingPlugins(t, root)
+
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), "data/temp", "data/transcripts")
+	procCtx := interfaces.ProcessingContext{JobID: "job", OutputDirectory: root, TempDirectory: root}
+	params := models.WhisperXParams{ModelFamily: FamilyAuto, Model: "small", AutoQuality: "good", Language: stringPtr("de")}
+
+	// The specialist wins for German, the model that is not ready is skipped
+	routed, decision := service.routeAutoModel(context.Background(), params, interfaces.AudioInput{}, procCtx)
+	if decision.Selected != "german_asr" || routed.ModelFamily != "german_asr" || decision.LanguageSource != "profile" {
+		t.Fatalf("expected German to be routed to the specialist, got %+v", decision)
+	}
+	if routed.FallbackModels == nil || *routed.FallbackModels != "wide_asr:small" {
+		t.Errorf("expected the other ready model as fallback, got %v", routed.FallbackModels)
+	}
+	var idle *RoutingScore
+	for i, score := range decision.Scores {
+		if score.ModelID == "idle_asr" {
+			idle = &decision.Scores[i]
+		}
+	}
+	if idle == nil || idle.Ready || idle.Score <= 0 {
+		t.Errorf("expected the unready model to be scored but not ready, got %+v", decision.Scores)
+	}
+	if _, err := json.Marshal(decision); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the model accepting any language serves Cantonese
+	params.Language = stringPtr("yue")
+	params.FallbackModels = stringPtr("german_asr")
+	routed, decision = service.routeAutoModel(context.Background(), params, interfaces.AudioInput{}, procCtx)
+	if decision.Selected != "wide_asr" || *routed.Language != "yue" || *routed.FallbackModels != "german_asr" {
+		t.Errorf("expected Cantonese on the wide model with the profile's fallbacks, got %+v", decision)
+	}
+	if log, _ := os.ReadFile(filepath.Join(root, "transcription.log")); !strings.Contains(string(log), "routed to wide_asr") {
+		t.Errorf("expected the routing decision in the job log, got %s", log)
+	}
+}
+
+func TestAutoRoutingDetectsLanguage(t *testing.T) {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		t.Skip("ffmpeg not installed")
+	}
+
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+	root := t.TempDir()
+	registerRoutingPlugins(t, root)
+
+	audio := filepath.Join(root, "audio.wav")
+	if output, err := exec.Command("ffmpeg", "-v", "error", "-f", "lavfi", "-i", "sine=frequency=440:duration=2", audio).CombinedOutput(); err != nil {
+		t.Fatalf("failed to create audio: %v: %s", err, output)
+	}
+
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), root, root)
+	procCtx := interfaces.ProcessingContext{JobID: "job", OutputDirectory: root, TempDirectory: root}
+	routed, decision := service.routeAutoModel(context.Background(), models.WhisperXParams{ModelFamily: FamilyAuto},
+		interfaces.AudioInput{FilePath: audio, Format: "wav"}, procCtx)
+
+	if decision.Detector != "lid" || decision.Language != "de" || decision.LanguageSource != "detected" || decision.Selected != "german_asr" {
+		t.Errorf("expected the detected language to route the job, got %+v", decision)
+	}
+	if routed.Language == nil || *routed.Language != "de" {
+		t.Errorf("expected the detected language to be passed on, got %v", routed.Language)
+	}
+}
+
+func TestAutoRoutingWithDiarization(t *testing.T) {
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+	root := t.TempDir()
+	registerRoutingPlugins(t, root)
+
+	// A model of many languages that diarizes by itself, like WhisperX, and a
+	// separate diarizer
+	languages := `"de", "en", "fr", "es", "it", "nl", "pt", "pl", "cs", "sv", "da", "fi", "no", "el", "tr", "ru", "uk", "ja", "zh", "ko", "ar"`
+	succeed := `"command": ["sh", "-c", "printf '{\"segments\": []}' > {{.Output}}"]`
+	writePlugin(t, root, "diarizing_asr", map[string]string{"plugin.json": `{"id": "diarizing_asr", "type": "transcription", "capabilities": ` +
+		`{"supported_languages": [` + languages + `], "features": {"timestamps": true, "word_level": true, "diarization": true}}, ` + succeed + `}`})
+	writePlugin(t, root, "local_diar", map[string]string{"plugin.json": `{"id": "local_diar", "type": "diarization", ` + succeed + `}`})
+	loaded, errs := adapters.LoadPluginManifests(root)
+	if len(errs) > 0 {
+		t.Fatalf("failed to load plugins: %v", errs)
+	}
+	var diarizer *adapters.PluginAdapter
+	for _, manifest := range loaded {
+		switch manifest.ID {
+		case "diarizing_asr":
+			adapter := adapters.NewPluginAdapter(manifest)
+			if err := adapter.PrepareEnvironment(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			registry.RegisterTranscriptionAdapter(manifest.ID, adapter)
+		case "local_diar":
+			diarizer = adapters.NewPluginAdapter(manifest)
+			registry.RegisterDiarizationAdapter(manifest.ID, diarizer)
+		}
+	}
+
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), "data/temp", "data/transcripts")
+	procCtx := interfaces.ProcessingContext{JobID: "job", OutputDirectory: root, TempDirectory: root}
+	params := models.WhisperXParams{ModelFamily: FamilyAuto, Model: "small", Language: stringPtr("de"), Diarize: true, DiarizeModel: "local_diar"}
+
+	// Without a ready diarizer, the model has to diarize itself
+	_, decision := service.routeAutoModel(context.Background(), params, interfaces.AudioInput{}, procCtx)
+	if decision.Selected != "diarizing_asr" {
+		t.Errorf("expected the diarizing model without a ready diarizer, got %+v", decision)
+	}
+
+	// With one, the German specialist wins again
+	if err := diarizer.PrepareEnvironment(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	_, decision = service.routeAutoModel(context.Background(), params, interfaces.AudioInput{}, procCtx)
+	if decision.Selected != "german_asr" {
+		t.Errorf("expected the German specialist with a ready diarizer, got %+v", decision)
+	}
+	for _, feature := range decision.Requirements.Features {
+		if feature == "diarization" {
+			t.Errorf("expected diarization not to be required, got %v", decision.Requirements.Features)
+		}
+	}
+}
+
+func TestLanguageCode(t *testing.T) {
+	for detected, expected := range map[string]string{"de": "de", "German": "de", "english": "en", " ja ": "ja", "klingon": "", "": ""} {
+		if code := languageCode(detected); code != expected {
+			t.Errorf("languageCode(%q) = %q, expected %q", detected, code, expected)
+		}
+	}
+}
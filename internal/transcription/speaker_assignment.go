@@ -0,0 +1,288 @@
+package transcription
+
+import (
+	"sort"
+	"strings"
+
+	"scriberr/internal/transcription/interfaces"
+)
+
+// Speaker assignment strategies used when a separate diarization is merged
+// into a transcript
+const (
+	SpeakerAssignmentWord    = "word"    // label each word and split segments where the speaker changes
+	SpeakerAssignmentSegment = "segment" // give each segment the speaker it overlaps most
+)
+
+// overlapShare is the share of a word another speaker must talk during for
+// the word to count as overlapping speech
+const overlapShare = 0.5
+
+// noSpaceLanguages are written without spaces between words
+var noSpaceLanguages = map[string]bool{
+	"zh": true, "yue": true, "ja": true, "th": true, "lo": true, "my": true, "km": true, "bo": true,
+}
+
+// speakerOverlaps returns how long each speaker talks between start and end.
+// A word without duration counts for the speakers talking at its start.
+func speakerOverlaps(start, end float64, turns []interfaces.DiarizationSegment) map[string]float64 {
+	overlaps := make(map[string]float64)
+	for _, turn := range turns {
+		if end <= start {
+			if _, ok := overlaps[turn.Speaker]; !ok && turn.Start <= start && start <= turn.End {
+				overlaps[turn.Speaker] = 0
+			}
+			continue
+		}
+		if overlap := min(end, turn.End) - max(start, turn.Start); overlap > 0 {
+			overlaps[turn.Speaker] += overlap
+		}
+	}
+	return overlaps
+}
+
+// nearestSpeaker returns the speaker of the turn closest to t
+func nearestSpeaker(t float64, turns []interfaces.DiarizationSegment) string {
+	speaker := ""
+	nearest := 0.0
+	for _, turn := range turns {
+		distance := max(turn.Start-t, t-turn.End)
+		if speaker == "" || distance < nearest {
+			speaker, nearest = turn.Speaker, distance
+		}
+	}
+	return speaker
+}
+
+// assignWordSpeakers labels each word with the speaker talking during it.
+// Where several speakers talk over the same word it keeps the previous word's
+// speaker if that one is among them, so an interjection does not cut a
+// sentence in half, and records the others as overlapping speakers. Words in
+// gaps between turns take the speaker of the nearest turn.
+func assignWordSpeakers(words []interfaces.TranscriptWord, turns []interfaces.DiarizationSegment) {
+	previous := ""
+	for i := range words {
+		word := &words[i]
+		word.Speaker, word.OverlappingSpeakers = nil, nil
+
+		overlaps := speakerOverlaps(word.Start, word.End, turns)
+		if len(overlaps) == 0 {
+			if speaker := nearestSpeaker((word.Start+word.End)/2, turns); speaker != "" {
+				word.Speaker = &speaker
+				previous = speaker
+			}
+			continue
+		}
+
+		speakers := make([]string, 0, len(overlaps))
+		for speaker := range overlaps {
+			speakers = append(speakers, speaker)
+		}
+		sort.Strings(speakers)
+
+		best := speakers[0]
+		var active []string
+		for _, speaker := range speakers {
+			if overlaps[speaker] > overlaps[best] {
+				best = speaker
+			}
+			if overlaps[speaker] >= overlapShare*(word.End-word.Start) {
+				active = append(active, speaker)
+			}
+		}
+
+		speaker := best
+		if len(active) > 1 {
+			for _, s := range active {
+				if s == previous {
+					speaker = previous
+				}
+			}
+			for _, s := range active {
+				if s != speaker {
+					word.OverlappingSpeakers = append(word.OverlappingSpeakers, s)
+				}
+			}
+		}
+		word.Speaker = &speaker
+		previous = speaker
+	}
+}
+
+// speakerRun is a stretch of consecutive words spoken by one speaker
+type speakerRun struct {
+	speaker string
+	words   []interfaces.TranscriptWord
+}
+
+// speakerRuns groups words into runs of the same speaker. Unlabelled words
+// join the run they are in.
+func speakerRuns(words []interfaces.TranscriptWord) []speakerRun {
+	var runs []speakerRun
+	for _, word := range words {
+		speaker := ""
+		if word.Speaker != nil {
+			speaker = *word.Speaker
+		}
+		if n := len(runs); n > 0 && (speaker == "" || runs[n-1].speaker == "" || runs[n-1].speaker == speaker) {
+			if runs[n-1].speaker == "" {
+				runs[n-1].speaker = speaker
+			}
+			runs[n-1].words = append(runs[n-1].words, word)
+			continue
+		}
+		runs = append(runs, speakerRun{speaker: speaker, words: []interfaces.TranscriptWord{word}})
+	}
+	return runs
+}
+
+// apply labels a segment with the run's speaker and overlapping speakers
+func (r speakerRun) apply(segment *interfaces.TranscriptSegment) {
+	segment.Speaker, segment.OverlappingSpeakers = nil, nil
+	if r.speaker != "" {
+		speaker := r.speaker
+		segment.Speaker = &speaker
+	}
+
+	seen := map[string]bool{r.speaker: true}
+	for _, word := range r.words {
+		for _, other := range word.OverlappingSpeakers {
+			if !seen[other] {
+				seen[other] = true
+				segment.OverlappingSpeakers = append(segment.OverlappingSpeakers, other)
+			}
+		}
+	}
+	sort.Strings(segment.OverlappingSpeakers)
+}
+
+// joinWords rebuilds the text of a run of words. Words that carry their own
+// leading space are concatenated, others are joined with spaces unless the
+// language is written without them.
+func joinWords(words []interfaces.TranscriptWord, language string) string {
+	separator := " "
+	if noSpaceLanguages[language] {
+		separator = ""
+	}
+
+	var text strings.Builder
+	for i, word := range words {
+		if i > 0 && !strings.HasPrefix(word.Word, " ") {
+			text.WriteString(separator)
+		}
+		text.WriteString(word.Word)
+	}
+	return strings.TrimSpace(text.String())
+}
+
+// sameText reports whether two texts differ only in whitespace
+func sameText(a, b string) bool {
+	return strings.Join(strings.Fields(a), "") == strings.Join(strings.Fields(b), "")
+}
+
+// dominantRun returns the run whose speaker talks longest. Runs of the same
+// speaker count together.
+func dominantRun(runs []speakerRun) speakerRun {
+	talk := make(map[string]float64)
+	for _, run := range runs {
+		for _, word := range run.words {
+			talk[run.speaker] += word.End - word.Start
+		}
+	}
+	dominant := runs[0]
+	for _, run := range runs[1:] {
+		if talk[run.speaker] > talk[dominant.speaker] {
+			dominant = run
+		}
+	}
+	return dominant
+}
+
+// splitSegmentsBySpeaker labels each segment with the speaker of its words
+// and splits it where the speaker changes. A word belongs to the last segment
+// starting before its midpoint. Segments without words get the speaker they
+// overlap most, segments whose text was edited get their main speaker.
+func (u *UnifiedTranscriptionService) splitSegmentsBySpeaker(segments []interfaces.TranscriptSegment, words []interfaces.TranscriptWord, turns []interfaces.DiarizationSegment, language string) []interfaces.TranscriptSegment {
+	segmentWords := wordsBySegment(segments, words)
+
+	split := make([]interfaces.TranscriptSegment, 0, len(segments))
+	for i, segment := range segments {
+		runs := speakerRuns(segmentWords[i])
+		switch len(runs) {
+		case 0:
+			segment.Speaker = nil
+			if speaker := u.findBestSpeakerForSegment(segment.Start, segment.End, turns); speaker != "" {
+				segment.Speaker = &speaker
+			}
+			split = append(split, segment)
+		case 1:
+			runs[0].apply(&segment)
+			split = append(split, segment)
+		default:
+			split = append(split, splitSegment(segment, segmentWords[i], runs, language)...)
+		}
+	}
+	return split
+}
+
+// wordsBySegment groups words by the segment they belong to
+func wordsBySegment(segments []interfaces.TranscriptSegment, words []interfaces.TranscriptWord) [][]interfaces.TranscriptWord {
+	segmentWords := make([][]interfaces.TranscriptWord, len(segments))
+	for i, segment := range segmentOfWords(segments, words) {
+		if segment >= 0 {
+			segmentWords[segment] = append(segmentWords[segment], words[i])
+		}
+	}
+	return segmentWords
+}
+
+// segmentOfWords returns the index of the segment each word belongs to: the
+// last segment starting before the word's midpoint, or -1 without segments
+func segmentOfWords(segments []interfaces.TranscriptSegment, words []interfaces.TranscriptWord) []int {
+	owners := make([]int, len(words))
+	current := 0
+	for i, word := range words {
+		if len(segments) == 0 {
+			owners[i] = -1
+			continue
+		}
+		midpoint := (word.Start + word.End) / 2
+		for current+1 < len(segments) && midpoint >= segments[current+1].Start {
+			current++
+		}
+		owners[i] = current
+	}
+	return owners
+}
+
+// splitSegment splits a segment into one part per run of its words. A segment
+// whose text was edited after transcription no longer matches its words, so
+// it is kept whole and given its main speaker.
+func splitSegment(segment interfaces.TranscriptSegment, words []interfaces.TranscriptWord, runs []speakerRun, language string) []interfaces.TranscriptSegment {
+	if segment.Language != nil {
+		language = *segment.Language
+	}
+	if !sameText(segment.Text, joinWords(words, language)) {
+		dominantRun(runs).apply(&segment)
+		return []interfaces.TranscriptSegment{segment}
+	}
+
+	parts := make([]interfaces.TranscriptSegment, 0, len(runs))
+	for r, run := range runs {
+		part := interfaces.TranscriptSegment{
+			Start:    run.words[0].Start,
+			End:      run.words[len(run.words)-1].End,
+			Text:     joinWords(run.words, language),
+			Language: segment.Language,
+		}
+		if r == 0 {
+			part.Start = min(part.Start, segment.Start)
+		}
+		if r == len(runs)-1 {
+			part.End = max(part.End, segment.End)
+		}
+		run.apply(&part)
+		parts = append(parts, part)
+	}
+	return parts
+}
@@ -0,0 +1,129 @@
+package transcription
+
+import (
+	"reflect"
+	"testing"
+
+	"scriberr/internal/transcription/interfaces"
+)
+
+// speakerOf returns a speaker label or "" for unlabelled segments and words
+func speakerOf(speaker *string) string {
+	if speaker == nil {
+		return ""
+	}
+	return *speaker
+}
+
+func TestWordLevelSpeakerAssignment(t *testing.T) {
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), "data/temp", "data/transcripts")
+
+	transcript := &interfaces.TranscriptResult{
+		Language: "en",
+		Segments: []interfaces.TranscriptSegment{
+			{Start: 0, End: 4, Text: "How are you? Fine thanks."},
+			{Start: 5, End: 8, Text: "So I was saying that"},
+			{Start: 9, End: 10, Text: "Okay."},
+		},
+		WordSegments: []interfaces.TranscriptWord{
+			{Start: 0.0, End: 0.4, Word: "How"},
+			{Start: 0.5, End: 0.8, Word: "are"},
+			{Start: 0.9, End: 1.5, Word: "you?"},
+			{Start: 2.5, End: 3.0, Word: "Fine"},
+			{Start: 3.1, End: 3.9, Word: "thanks."},
+			{Start: 5.0, End: 5.3, Word: "So"},
+			{Start: 5.4, End: 5.6, Word: "I"},
+			{Start: 5.7, End: 6.2, Word: "was"},
+			{Start: 6.3, End: 7.0, Word: "saying"},
+			{Start: 7.1, End: 7.9, Word: "that"},
+		},
+	}
+	diarization := &interfaces.DiarizationResult{
+		Segments: []interfaces.DiarizationSegment{
+			{Start: 0, End: 1.6, Speaker: "SPEAKER_00"},
+			{Start: 2.4, End: 4.0, Speaker: "SPEAKER_01"},
+			// SPEAKER_01 keeps talking while SPEAKER_00 interjects
+			{Start: 5.0, End: 8.0, Speaker: "SPEAKER_01"},
+			{Start: 6.2, End: 7.0, Speaker: "SPEAKER_00"},
+		},
+	}
+
+	merged := service.mergeDiarizationWithTranscription(transcript, diarization, SpeakerAssignmentWord)
+
+	// The first segment is split where the speaker changes
+	type labelled struct {
+		Start, End float64
+		Text       string
+		Speaker    string
+		Overlap    []string
+	}
+	var got []labelled
+	for _, segment := range merged.Segments {
+		got = append(got, labelled{segment.Start, segment.End, segment.Text, speakerOf(segment.Speaker), segment.OverlappingSpeakers})
+	}
+	want := []labelled{
+		{0, 1.5, "How are you?", "SPEAKER_00", nil},
+		{2.5, 4, "Fine thanks.", "SPEAKER_01", nil},
+		{5, 8, "So I was saying that", "SPEAKER_01", []string{"SPEAKER_00"}},
+		{9, 10, "Okay.", "", nil},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("segments = %+v, want %+v", got, want)
+	}
+
+	// The interjection is recorded as overlapping speech on the word
+	saying := merged.WordSegments[8]
+	if speakerOf(saying.Speaker) != "SPEAKER_01" || !reflect.DeepEqual(saying.OverlappingSpeakers, []string{"SPEAKER_00"}) {
+		t.Errorf("overlapped word = %s %v", speakerOf(saying.Speaker), saying.OverlappingSpeakers)
+	}
+
+	// The original transcript is left untouched
+	if len(transcript.Segments) != 3 || transcript.WordSegments[0].Speaker != nil {
+		t.Error("merge modified the original transcript")
+	}
+}
+
+func TestSegmentSpeakerAssignment(t *testing.T) {
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), "data/temp", "data/transcripts")
+
+	transcript := &interfaces.TranscriptResult{
+		Segments: []interfaces.TranscriptSegment{{Start: 0, End: 4, Text: "How are you? Fine thanks."}},
+		WordSegments: []interfaces.TranscriptWord{
+			{Start: 0.0, End: 1.5, Word: "How are you?"},
+			{Start: 2.5, End: 3.9, Word: "Fine thanks."},
+		},
+	}
+	diarization := &interfaces.DiarizationResult{
+		Segments: []interfaces.DiarizationSegment{
+			{Start: 0, End: 1.6, Speaker: "SPEAKER_00"},
+			{Start: 2.4, End: 4.0, Speaker: "SPEAKER_01"},
+		},
+	}
+
+	// The segment strategy keeps one segment with the speaker it overlaps most
+	merged := service.mergeDiarizationWithTranscription(transcript, diarization, SpeakerAssignmentSegment)
+	if len(merged.Segments) != 1 || speakerOf(merged.Segments[0].Speaker) != "SPEAKER_00" {
+		t.Fatalf("segments = %+v", merged.Segments)
+	}
+	if speakerOf(merged.WordSegments[1].Speaker) != "SPEAKER_01" {
+		t.Errorf("word speaker = %s", speakerOf(merged.WordSegments[1].Speaker))
+	}
+
+	// Without word timings the word strategy falls back to segments
+	transcript.WordSegments = nil
+	merged = service.mergeDiarizationWithTranscription(transcript, diarization, SpeakerAssignmentWord)
+	if len(merged.Segments) != 1 || speakerOf(merged.Segments[0].Speaker) != "SPEAKER_00" {
+		t.Fatalf("segments without words = %+v", merged.Segments)
+	}
+}
+
+func TestJoinWords(t *testing.T) {
+	words := []interfaces.TranscriptWord{{Word: "你好"}, {Word: "世界"}}
+	if got := joinWords(words, "zh"); got != "你好世界" {
+		t.Errorf("joinWords(zh) = %q", got)
+	}
+	words = []interfaces.TranscriptWord{{Word: " Hello"}, {Word: " world"}}
+	if got := joinWords(words, "en"); got != "Hello world" {
+		t.Errorf("joinWords with leading spaces = %q", got)
+	}
+}
@@ -0,0 +1,208 @@
+package transcription
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"scriberr/internal/transcription/interfaces"
+)
+
+// ErrUnknownSpeaker is returned for a speaker the transcript does not have
+var ErrUnknownSpeaker = errors.New("unknown speaker")
+
+// ErrInvalidSelection is returned for a selection that matches no speech of the transcript
+var ErrInvalidSelection = errors.New("invalid selection")
+
+// SpeakerSelection is the part of a transcript given to another speaker:
+// whole segments by index, or else the words between Start and End
+type SpeakerSelection struct {
+	Segments []int
+	Start    *float64
+	End      *float64
+}
+
+// MergeSpeakers relabels everything source said as target, for diarizations
+// that split one person into two speakers
+func MergeSpeakers(result *interfaces.TranscriptResult, source, target string) error {
+	if source == target {
+		return fmt.Errorf("%w: a speaker cannot be merged into itself", ErrInvalidSelection)
+	}
+	speakers := transcriptSpeakerSet(result)
+	for _, speaker := range []string{source, target} {
+		if !speakers[speaker] {
+			return fmt.Errorf("%w: %s", ErrUnknownSpeaker, speaker)
+		}
+	}
+
+	rename := func(speaker **string, overlapping *[]string) {
+		if *speaker != nil && **speaker == source {
+			*speaker = &target
+		}
+		for i, other := range *overlapping {
+			if other == source {
+				(*overlapping)[i] = target
+			}
+		}
+		*overlapping = withoutSpeaker(*overlapping, *speaker)
+	}
+	for i := range result.Segments {
+		rename(&result.Segments[i].Speaker, &result.Segments[i].OverlappingSpeakers)
+	}
+	for i := range result.WordSegments {
+		rename(&result.WordSegments[i].Speaker, &result.WordSegments[i].OverlappingSpeakers)
+	}
+	return nil
+}
+
+// ReassignSpeaker gives the selected part of a transcript to another of its
+// speakers, for diarizations that mixed up two people
+func ReassignSpeaker(result *interfaces.TranscriptResult, selection SpeakerSelection, speaker string) error {
+	if !transcriptSpeakerSet(result)[speaker] {
+		return fmt.Errorf("%w: %s", ErrUnknownSpeaker, speaker)
+	}
+	return assignSelection(result, selection, speaker)
+}
+
+// SplitSpeaker gives the selected part of a transcript to a new speaker and
+// returns the new speaker's label
+func SplitSpeaker(result *interfaces.TranscriptResult, selection SpeakerSelection) (string, error) {
+	speaker := newSpeakerLabel(result)
+	if err := assignSelection(result, selection, speaker); err != nil {
+		return "", err
+	}
+	return speaker, nil
+}
+
+// assignSelection labels the selected words and segments with a speaker.
+// Segments are split where only part of their words changed speaker, as when
+// merging a diarization, so words and their positions stay as they were.
+// Without word timings only whole segments can be reassigned; a time range
+// takes the segments it covers at least half of.
+func assignSelection(result *interfaces.TranscriptResult, selection SpeakerSelection, speaker string) error {
+	segments, words := result.Segments, result.WordSegments
+	selected := make(map[int]bool)
+	inRange := func(t float64) bool { return false }
+	switch {
+	case len(selection.Segments) > 0:
+		for _, index := range selection.Segments {
+			if index < 0 || index >= len(segments) {
+				return fmt.Errorf("%w: no segment %d", ErrInvalidSelection, index)
+			}
+			selected[index] = true
+		}
+	case selection.Start != nil && selection.End != nil && *selection.End > *selection.Start:
+		start, end := *selection.Start, *selection.End
+		inRange = func(t float64) bool { return t >= start && t <= end }
+	default:
+		return fmt.Errorf("%w: select segments or a time range", ErrInvalidSelection)
+	}
+
+	owners := segmentOfWords(segments, words)
+	hasWords := make(map[int]bool)
+	changed := make(map[int]bool)
+	for i := range words {
+		word := &words[i]
+		segment := owners[i]
+		if segment < 0 {
+			continue
+		}
+		hasWords[segment] = true
+		// Unlabelled words speak for their segment, so only the selection changes hands
+		if word.Speaker == nil && segments[segment].Speaker != nil {
+			label := *segments[segment].Speaker
+			word.Speaker = &label
+		}
+		if selected[segment] || inRange((word.Start+word.End)/2) {
+			word.Speaker = &speaker
+			word.OverlappingSpeakers = withoutSpeaker(word.OverlappingSpeakers, word.Speaker)
+			changed[segment] = true
+		}
+	}
+	if selection.Start != nil && len(selection.Segments) == 0 {
+		for i, segment := range segments {
+			if hasWords[i] {
+				continue
+			}
+			duration := segment.End - segment.Start
+			covered := min(segment.End, *selection.End) - max(segment.Start, *selection.Start)
+			if (duration > 0 && covered >= duration/2) || (duration <= 0 && inRange(segment.Start)) {
+				selected[i] = true
+			}
+		}
+	}
+	if len(changed) == 0 && len(selected) == 0 {
+		return fmt.Errorf("%w: the selection contains no speech", ErrInvalidSelection)
+	}
+
+	segmentWords := wordsBySegment(segments, words)
+	relabelled := make([]interfaces.TranscriptSegment, 0, len(segments))
+	for i, segment := range segments {
+		switch {
+		case changed[i]:
+			runs := speakerRuns(segmentWords[i])
+			if len(runs) == 1 {
+				runs[0].apply(&segment)
+				relabelled = append(relabelled, segment)
+				continue
+			}
+			relabelled = append(relabelled, splitSegment(segment, segmentWords[i], runs, result.Language)...)
+		case selected[i]:
+			segment.Speaker = &speaker
+			segment.OverlappingSpeakers = withoutSpeaker(segment.OverlappingSpeakers, segment.Speaker)
+			relabelled = append(relabelled, segment)
+		default:
+			relabelled = append(relabelled, segment)
+		}
+	}
+	result.Segments = relabelled
+	return nil
+}
+
+// newSpeakerLabel returns the first SPEAKER_NN label the transcript does not use
+func newSpeakerLabel(result *interfaces.TranscriptResult) string {
+	speakers := transcriptSpeakerSet(result)
+	for i := 0; ; i++ {
+		if label := fmt.Sprintf("SPEAKER_%02d", i); !speakers[label] {
+			return label
+		}
+	}
+}
+
+// transcriptSpeakerSet returns every speaker of a transcript, including
+// speakers only heard overlapping others
+func transcriptSpeakerSet(result *interfaces.TranscriptResult) map[string]bool {
+	speakers := make(map[string]bool)
+	add := func(speaker *string, overlapping []string) {
+		if speaker != nil {
+			speakers[*speaker] = true
+		}
+		for _, other := range overlapping {
+			speakers[other] = true
+		}
+	}
+	for _, segment := range result.Segments {
+		add(segment.Speaker, segment.OverlappingSpeakers)
+	}
+	for _, word := range result.WordSegments {
+		add(word.Speaker, word.OverlappingSpeakers)
+	}
+	return speakers
+}
+
+// withoutSpeaker removes the main speaker and duplicates from overlapping speakers
+func withoutSpeaker(overlapping []string, speaker *string) []string {
+	seen := make(map[string]bool)
+	if speaker != nil {
+		seen[*speaker] = true
+	}
+	var others []string
+	for _, other := range overlapping {
+		if !seen[other] {
+			seen[other] = true
+			others = append(others, other)
+		}
+	}
+	sort.Strings(others)
+	return others
+}
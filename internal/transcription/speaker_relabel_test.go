@@ -0,0 +1,150 @@
+package transcription
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"scriberr/internal/transcription/interfaces"
+)
+
+// relabelTranscript is two speakers, the second of them split in two by diarization
+func relabelTranscript() *interfaces.TranscriptResult {
+	return &interfaces.TranscriptResult{
+		Language: "en",
+		Segments: []interfaces.TranscriptSegment{
+			{Start: 0, End: 2, Text: "Hello there.", Speaker: stringPtr("SPEAKER_00")},
+			{Start: 2, End: 5, Text: "Hi. How are you?", Speaker: stringPtr("SPEAKER_01"), OverlappingSpeakers: []string{"SPEAKER_02"}},
+			{Start: 5, End: 7, Text: "Fine thanks.", Speaker: stringPtr("SPEAKER_02")},
+		},
+		WordSegments: []interfaces.TranscriptWord{
+			{Start: 0.0, End: 0.8, Word: "Hello", Speaker: stringPtr("SPEAKER_00")},
+			{Start: 0.9, End: 1.8, Word: "there.", Speaker: stringPtr("SPEAKER_00")},
+			{Start: 2.0, End: 2.5, Word: "Hi.", Speaker: stringPtr("SPEAKER_01"), OverlappingSpeakers: []string{"SPEAKER_02"}},
+			{Start: 3.0, End: 3.4, Word: "How", Speaker: stringPtr("SPEAKER_01")},
+			{Start: 3.5, End: 3.9, Word: "are", Speaker: stringPtr("SPEAKER_01")},
+			{Start: 4.0, End: 4.8, Word: "you?", Speaker: stringPtr("SPEAKER_01")},
+			{Start: 5.0, End: 5.6, Word: "Fine", Speaker: stringPtr("SPEAKER_02")},
+			{Start: 5.7, End: 6.8, Word: "thanks.", Speaker: stringPtr("SPEAKER_02")},
+		},
+	}
+}
+
+func segmentLabels(result *interfaces.TranscriptResult) []string {
+	labels := make([]string, len(result.Segments))
+	for i, segment := range result.Segments {
+		labels[i] = speakerOf(segment.Speaker) + ": " + segment.Text
+	}
+	return labels
+}
+
+func wordLabels(result *interfaces.TranscriptResult) []string {
+	labels := make([]string, len(result.WordSegments))
+	for i, word := range result.WordSegments {
+		labels[i] = speakerOf(word.Speaker)
+	}
+	return labels
+}
+
+func TestMergeSpeakers(t *testing.T) {
+	result := relabelTranscript()
+	if err := MergeSpeakers(result, "SPEAKER_02", "SPEAKER_01"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"SPEAKER_00: Hello there.", "SPEAKER_01: Hi. How are you?", "SPEAKER_01: Fine thanks."}
+	if got := segmentLabels(result); !reflect.DeepEqual(got, want) {
+		t.Errorf("segments = %v, want %v", got, want)
+	}
+	for _, word := range result.WordSegments {
+		if speakerOf(word.Speaker) == "SPEAKER_02" {
+			t.Errorf("word %q still spoken by the merged speaker", word.Word)
+		}
+	}
+	// Talking over yourself is not overlapping speech
+	if len(result.Segments[1].OverlappingSpeakers) != 0 || len(result.WordSegments[2].OverlappingSpeakers) != 0 {
+		t.Errorf("merged speaker still overlaps: %v %v", result.Segments[1].OverlappingSpeakers, result.WordSegments[2].OverlappingSpeakers)
+	}
+
+	if err := MergeSpeakers(result, "SPEAKER_02", "SPEAKER_01"); !errors.Is(err, ErrUnknownSpeaker) {
+		t.Errorf("merging a speaker that is gone: %v", err)
+	}
+	if err := MergeSpeakers(result, "SPEAKER_00", "SPEAKER_00"); !errors.Is(err, ErrInvalidSelection) {
+		t.Errorf("merging a speaker into itself: %v", err)
+	}
+}
+
+func TestReassignSpeakerTimeRange(t *testing.T) {
+	result := relabelTranscript()
+	start, end := 2.9, 5.0
+	if err := ReassignSpeaker(result, SpeakerSelection{Start: &start, End: &end}, "SPEAKER_00"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The segment is split where the reassigned words start
+	want := []string{"SPEAKER_00: Hello there.", "SPEAKER_01: Hi.", "SPEAKER_00: How are you?", "SPEAKER_02: Fine thanks."}
+	if got := segmentLabels(result); !reflect.DeepEqual(got, want) {
+		t.Errorf("segments = %v, want %v", got, want)
+	}
+	if result.Segments[2].Start != 3.0 || result.Segments[2].End != 5 {
+		t.Errorf("split segment spans %v-%v", result.Segments[2].Start, result.Segments[2].End)
+	}
+	if !reflect.DeepEqual(result.Segments[1].OverlappingSpeakers, []string{"SPEAKER_02"}) {
+		t.Errorf("overlap kept on the wrong part: %v", result.Segments[1].OverlappingSpeakers)
+	}
+
+	// Words keep their positions, so notes still point at the same text
+	wantWords := []string{"SPEAKER_00", "SPEAKER_00", "SPEAKER_01", "SPEAKER_00", "SPEAKER_00", "SPEAKER_00", "SPEAKER_02", "SPEAKER_02"}
+	if got := wordLabels(result); !reflect.DeepEqual(got, wantWords) {
+		t.Errorf("words = %v, want %v", got, wantWords)
+	}
+
+	if err := ReassignSpeaker(result, SpeakerSelection{Start: &start, End: &end}, "SPEAKER_09"); !errors.Is(err, ErrUnknownSpeaker) {
+		t.Errorf("reassigning to an unknown speaker: %v", err)
+	}
+	silence := 10.0
+	if err := ReassignSpeaker(result, SpeakerSelection{Start: &silence, End: &silence}, "SPEAKER_01"); !errors.Is(err, ErrInvalidSelection) {
+		t.Errorf("empty range: %v", err)
+	}
+}
+
+func TestReassignSpeakerEditedSegment(t *testing.T) {
+	result := relabelTranscript()
+	result.Segments[1].Text = "Hi! How are you doing?"
+	start, end := 3.9, 4.9
+	if err := ReassignSpeaker(result, SpeakerSelection{Start: &start, End: &end}, "SPEAKER_00"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Edited text no longer matches its words, so it keeps its main speaker
+	want := []string{"SPEAKER_00: Hello there.", "SPEAKER_01: Hi! How are you doing?", "SPEAKER_02: Fine thanks."}
+	if got := segmentLabels(result); !reflect.DeepEqual(got, want) {
+		t.Errorf("segments = %v, want %v", got, want)
+	}
+	if speakerOf(result.WordSegments[5].Speaker) != "SPEAKER_00" {
+		t.Errorf("word was not reassigned: %v", wordLabels(result))
+	}
+}
+
+func TestSplitSpeakerSegments(t *testing.T) {
+	result := relabelTranscript()
+	result.WordSegments = nil
+	speaker, err := SplitSpeaker(result, SpeakerSelection{Segments: []int{0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if speaker != "SPEAKER_03" {
+		t.Errorf("new speaker = %s", speaker)
+	}
+	want := []string{"SPEAKER_03: Hello there.", "SPEAKER_01: Hi. How are you?", "SPEAKER_02: Fine thanks."}
+	if got := segmentLabels(result); !reflect.DeepEqual(got, want) {
+		t.Errorf("segments = %v, want %v", got, want)
+	}
+
+	if _, err := SplitSpeaker(result, SpeakerSelection{Segments: []int{3}}); !errors.Is(err, ErrInvalidSelection) {
+		t.Errorf("unknown segment: %v", err)
+	}
+	if _, err := SplitSpeaker(result, SpeakerSelection{}); !errors.Is(err, ErrInvalidSelection) {
+		t.Errorf("empty selection: %v", err)
+	}
+}
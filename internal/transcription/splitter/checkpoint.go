@@ -0,0 +1,186 @@
+package splitter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/pkg/logger"
+)
+
+const checkpointManifestFile = "manifest.json"
+
+// Checkpoint stores the results of finished chunks on disk so an interrupted
+// job can resume from the last completed chunk instead of starting over.
+// Chunks may be saved concurrently.
+type Checkpoint struct {
+	mu             sync.Mutex
+	dir            string
+	manifest       checkpointManifest
+	results        map[int]*interfaces.TranscriptResult
+	SpeakerSamples []SpeakerSample // Samples extracted from the first chunk, if any
+}
+
+// checkpointManifest identifies the chunk layout and parameters a checkpoint belongs to
+type checkpointManifest struct {
+	Fingerprint    string          `json:"fingerprint"`
+	ChunkCount     int             `json:"chunk_count"`
+	SpeakerSamples []SpeakerSample `json:"speaker_samples,omitempty"`
+}
+
+// CheckpointDir returns where chunk checkpoints of a job are stored
+func CheckpointDir(outputDir, jobID string) string {
+	return filepath.Join(outputDir, jobID, "checkpoints")
+}
+
+// HasCheckpoint reports whether any chunk of a job has been checkpointed in dir
+func HasCheckpoint(dir string) bool {
+	matches, err := filepath.Glob(filepath.Join(dir, "chunk_*.json"))
+	return err == nil && len(matches) > 0
+}
+
+// Fingerprint identifies a chunk layout and parameter set. Checkpoints are only
+// reused when the audio splits into the same chunks and the parameters match.
+func Fingerprint(chunks []ChunkInfo, params map[string]interface{}) (string, error) {
+	layout := make([][2]int64, len(chunks))
+	for i, chunk := range chunks {
+		// Millisecond precision so float noise from ffprobe does not invalidate checkpoints
+		layout[i] = [2]int64{int64(chunk.StartTime * 1000), int64(chunk.Duration * 1000)}
+	}
+
+	data, err := json.Marshal(struct {
+		Layout [][2]int64             `json:"layout"`
+		Params map[string]interface{} `json:"params"`
+	}{layout, params})
+	if err != nil {
+		return "", fmt.Errorf("encode fingerprint: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// OpenCheckpoint loads the checkpoint in dir. Results saved for a different
+// fingerprint are discarded so a changed file or parameter set starts fresh.
+func OpenCheckpoint(dir, fingerprint string, chunkCount int) (*Checkpoint, error) {
+	cp := &Checkpoint{
+		dir:      dir,
+		manifest: checkpointManifest{Fingerprint: fingerprint, ChunkCount: chunkCount},
+		results:  make(map[int]*interfaces.TranscriptResult),
+	}
+
+	var existing checkpointManifest
+	data, err := os.ReadFile(filepath.Join(dir, checkpointManifestFile))
+	switch {
+	case err == nil && json.Unmarshal(data, &existing) == nil &&
+		existing.Fingerprint == fingerprint && existing.ChunkCount == chunkCount:
+		cp.manifest = existing
+		cp.SpeakerSamples = existing.SpeakerSamples
+		cp.loadResults()
+		return cp, nil
+	case err == nil:
+		logger.Info("Discarding checkpoint from a different chunk layout or parameters", "dir", dir)
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("read checkpoint manifest: %w", err)
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("clear checkpoint: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create checkpoint directory: %w", err)
+	}
+	if err := cp.writeManifest(); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// loadResults reads every readable chunk result. Unreadable ones are redone.
+func (c *Checkpoint) loadResults() {
+	for i := 0; i < c.manifest.ChunkCount; i++ {
+		data, err := os.ReadFile(c.chunkPath(i))
+		if err != nil {
+			continue
+		}
+		var result interfaces.TranscriptResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			logger.Warn("Ignoring corrupt chunk checkpoint", "file", c.chunkPath(i), "error", err)
+			continue
+		}
+		c.results[i] = &result
+	}
+}
+
+// Result returns the saved result of a chunk, or nil if it has not finished
+func (c *Checkpoint) Result(index int) *interfaces.TranscriptResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.results[index]
+}
+
+// Completed returns the number of chunks with a saved result
+func (c *Checkpoint) Completed() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.results)
+}
+
+// SaveChunk stores the result of a finished chunk
+func (c *Checkpoint) SaveChunk(index int, result *interfaces.TranscriptResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("encode chunk %d: %w", index, err)
+	}
+	if err := writeFileAtomic(c.chunkPath(index), data); err != nil {
+		return fmt.Errorf("save chunk %d: %w", index, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results[index] = result
+	return nil
+}
+
+// SaveSpeakerSamples stores the speaker samples used as references for later chunks
+func (c *Checkpoint) SaveSpeakerSamples(samples []SpeakerSample) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.SpeakerSamples = samples
+	c.manifest.SpeakerSamples = samples
+	return c.writeManifest()
+}
+
+// Remove deletes the checkpoint once the job no longer needs it
+func (c *Checkpoint) Remove() error {
+	return os.RemoveAll(c.dir)
+}
+
+func (c *Checkpoint) chunkPath(index int) string {
+	return filepath.Join(c.dir, fmt.Sprintf("chunk_%03d.json", index))
+}
+
+func (c *Checkpoint) writeManifest() error {
+	data, err := json.Marshal(c.manifest)
+	if err != nil {
+		return fmt.Errorf("encode checkpoint manifest: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(c.dir, checkpointManifestFile), data); err != nil {
+		return fmt.Errorf("save checkpoint manifest: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes through a temporary file so a crash never leaves a half-written checkpoint
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
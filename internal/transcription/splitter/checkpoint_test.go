@@ -0,0 +1,128 @@
+package splitter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"scriberr/internal/transcription/interfaces"
+)
+
+func testChunks() []ChunkInfo {
+	return []ChunkInfo{
+		{StartTime: 0, Duration: 300},
+		{StartTime: 300, Duration: 300},
+		{StartTime: 600, Duration: 120.5},
+	}
+}
+
+func TestFingerprintStableAndSensitive(t *testing.T) {
+	params := map[string]interface{}{"model": "whisper-1", "language": "en"}
+
+	first, err := Fingerprint(testChunks(), params)
+	if err != nil {
+		t.Fatalf("fingerprint: %v", err)
+	}
+	second, _ := Fingerprint(testChunks(), map[string]interface{}{"language": "en", "model": "whisper-1"})
+	if first != second {
+		t.Error("fingerprint should not depend on map order")
+	}
+
+	otherParams, _ := Fingerprint(testChunks(), map[string]interface{}{"model": "gpt-4o-transcribe", "language": "en"})
+	if first == otherParams {
+		t.Error("fingerprint should change with parameters")
+	}
+
+	chunks := testChunks()
+	chunks[2].Duration = 90
+	otherLayout, _ := Fingerprint(chunks, params)
+	if first == otherLayout {
+		t.Error("fingerprint should change with chunk layout")
+	}
+}
+
+func TestCheckpointResume(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "job", "checkpoints")
+
+	cp, err := OpenCheckpoint(dir, "abc", 3)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if cp.Completed() != 0 || HasCheckpoint(dir) {
+		t.Fatal("new checkpoint should be empty")
+	}
+
+	speaker := "A"
+	result := &interfaces.TranscriptResult{
+		Text:     "hello",
+		Language: "en",
+		Segments: []interfaces.TranscriptSegment{{Start: 0, End: 2, Text: "hello", Speaker: &speaker}},
+	}
+	if err := cp.SaveChunk(0, result); err != nil {
+		t.Fatalf("save chunk: %v", err)
+	}
+	samples := []SpeakerSample{{Speaker: "A", StartTime: 0, EndTime: 2, Base64Data: "data:audio/mp3;base64,AAAA"}}
+	if err := cp.SaveSpeakerSamples(samples); err != nil {
+		t.Fatalf("save samples: %v", err)
+	}
+	if !HasCheckpoint(dir) {
+		t.Fatal("checkpoint should report finished chunks")
+	}
+
+	// Reopening with the same fingerprint resumes
+	resumed, err := OpenCheckpoint(dir, "abc", 3)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if resumed.Completed() != 1 {
+		t.Fatalf("expected 1 completed chunk, got %d", resumed.Completed())
+	}
+	got := resumed.Result(0)
+	if got == nil || got.Text != "hello" || *got.Segments[0].Speaker != "A" {
+		t.Errorf("unexpected resumed result: %+v", got)
+	}
+	if resumed.Result(1) != nil {
+		t.Error("unfinished chunk should have no result")
+	}
+	if len(resumed.SpeakerSamples) != 1 || resumed.SpeakerSamples[0].Base64Data != samples[0].Base64Data {
+		t.Errorf("speaker samples not restored: %+v", resumed.SpeakerSamples)
+	}
+
+	// A different fingerprint discards saved chunks
+	fresh, err := OpenCheckpoint(dir, "def", 3)
+	if err != nil {
+		t.Fatalf("open with new fingerprint: %v", err)
+	}
+	if fresh.Completed() != 0 || len(fresh.SpeakerSamples) != 0 || HasCheckpoint(dir) {
+		t.Error("checkpoint for another fingerprint should be discarded")
+	}
+
+	if err := fresh.Remove(); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Error("checkpoint directory should be removed")
+	}
+}
+
+func TestCheckpointIgnoresCorruptChunk(t *testing.T) {
+	dir := t.TempDir()
+	cp, err := OpenCheckpoint(dir, "abc", 2)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if err := cp.SaveChunk(0, &interfaces.TranscriptResult{Text: "ok"}); err != nil {
+		t.Fatalf("save chunk: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "chunk_001.json"), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resumed, err := OpenCheckpoint(dir, "abc", 2)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if resumed.Completed() != 1 || resumed.Result(1) != nil {
+		t.Error("corrupt chunk should be redone")
+	}
+}
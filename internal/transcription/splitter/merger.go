@@ -30,6 +30,8 @@ func MergeResults(results []*interfaces.TranscriptResult, chunks []ChunkInfo, sp
 	var totalProcessingTime time.Duration
 	var totalConfidence float64
 
+	// Shift every chunk to global time first, so overlapping chunks can be reconciled
+	pieces := make([]*chunkPiece, len(results))
 	for i, result := range results {
 		if result == nil {
 			continue
@@ -46,7 +48,9 @@ func MergeResults(results []*interfaces.TranscriptResult, chunks []ChunkInfo, sp
 			textParts = append(textParts, strings.TrimSpace(result.Text))
 		}
 
-		// Adjust and append segments
+		piece := &chunkPiece{}
+
+		// Adjust segments
 		for _, seg := range result.Segments {
 			speaker := adjustSpeakerLabel(seg.Speaker, i, len(results), speakerRefsUsed)
 			adjustedSeg := interfaces.TranscriptSegment{
@@ -56,10 +60,10 @@ func MergeResults(results []*interfaces.TranscriptResult, chunks []ChunkInfo, sp
 				Speaker:  speaker,
 				Language: seg.Language,
 			}
-			merged.Segments = append(merged.Segments, adjustedSeg)
+			piece.segments = append(piece.segments, adjustedSeg)
 		}
 
-		// Adjust and append word segments
+		// Adjust word segments
 		for _, word := range result.WordSegments {
 			speaker := adjustSpeakerLabel(word.Speaker, i, len(results), speakerRefsUsed)
 			adjustedWord := interfaces.TranscriptWord{
@@ -69,8 +73,9 @@ func MergeResults(results []*interfaces.TranscriptResult, chunks []ChunkInfo, sp
 				Score:   word.Score,
 				Speaker: speaker,
 			}
-			merged.WordSegments = append(merged.WordSegments, adjustedWord)
+			piece.words = append(piece.words, adjustedWord)
 		}
+		pieces[i] = piece
 
 		// Accumulate processing time and confidence
 		totalProcessingTime += result.ProcessingTime
@@ -90,6 +95,35 @@ func MergeResults(results []*interfaces.TranscriptResult, chunks []ChunkInfo, sp
 		}
 	}
 
+	// Chunks that share audio with their predecessor transcribed the seam twice
+	overlapsReconciled := 0
+	for i := 1; i < len(pieces) && i < len(chunks); i++ {
+		if pieces[i] == nil || pieces[i-1] == nil || chunks[i].Overlap <= 0 {
+			continue
+		}
+		overlapStart := chunks[i].StartTime
+		reconcileOverlap(pieces[i-1], pieces[i], overlapStart, overlapStart+chunks[i].Overlap)
+		overlapsReconciled++
+	}
+
+	for _, piece := range pieces {
+		if piece == nil {
+			continue
+		}
+		merged.Segments = append(merged.Segments, piece.segments...)
+		merged.WordSegments = append(merged.WordSegments, piece.words...)
+	}
+
+	// Chunk texts repeat the overlapped audio, so rebuild the text from the reconciled segments
+	if overlapsReconciled > 0 && len(merged.Segments) > 0 {
+		textParts = textParts[:0]
+		for _, seg := range merged.Segments {
+			if text := strings.TrimSpace(seg.Text); text != "" {
+				textParts = append(textParts, text)
+			}
+		}
+	}
+
 	// Combine text
 	merged.Text = strings.Join(textParts, " ")
 
@@ -103,6 +137,9 @@ func MergeResults(results []*interfaces.TranscriptResult, chunks []ChunkInfo, sp
 	if speakerRefsUsed {
 		merged.Metadata["speaker_references_used"] = "true"
 	}
+	if overlapsReconciled > 0 {
+		merged.Metadata["overlaps_reconciled"] = fmt.Sprintf("%d", overlapsReconciled)
+	}
 
 	return merged
 }
@@ -0,0 +1,198 @@
+package splitter
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"scriberr/internal/transcription/interfaces"
+)
+
+// wordMatchTolerance is how far apart in time two transcriptions of the same
+// word may be placed by neighbouring chunks and still count as one word
+const wordMatchTolerance = 1.0
+
+// chunkPiece holds the segments and words of one chunk in global time
+type chunkPiece struct {
+	segments []interfaces.TranscriptSegment
+	words    []interfaces.TranscriptWord
+}
+
+// wordPair links a word of the earlier chunk to the same word in the later one
+type wordPair struct {
+	prev int
+	next int
+}
+
+// reconcileOverlap removes the double transcription of the audio between start
+// and end, which both chunks contain. Words are aligned by text and timing and
+// spliced at the matching word closest to the middle of the overlap, where
+// neither chunk is near its cut. Without matching words the overlap is split in
+// the middle. Segments are trimmed to the same splice point.
+func reconcileOverlap(prev, next *chunkPiece, start, end float64) {
+	splice := (start + end) / 2
+	if len(prev.words) > 0 && len(next.words) > 0 {
+		splice = spliceWords(prev, next, start, end)
+	}
+
+	prev.segments = trimSegments(prev.segments, prev.words, splice, true)
+	next.segments = trimSegments(next.segments, next.words, splice, false)
+}
+
+// spliceWords drops the words both chunks transcribed and returns the splice time
+func spliceWords(prev, next *chunkPiece, start, end float64) float64 {
+	mid := (start + end) / 2
+
+	// Words of each chunk that fall in the shared audio
+	prevFrom := len(prev.words)
+	for i, word := range prev.words {
+		if word.End > start {
+			prevFrom = i
+			break
+		}
+	}
+	nextTo := 0
+	for nextTo < len(next.words) && next.words[nextTo].Start < end {
+		nextTo++
+	}
+
+	pairs := alignWords(prev.words[prevFrom:], next.words[:nextTo])
+	if len(pairs) > 0 {
+		best := pairs[0]
+		bestDistance := math.Inf(1)
+		for _, pair := range pairs {
+			if d := math.Abs(wordCenter(prev.words[prevFrom+pair.prev]) - mid); d < bestDistance {
+				best = pair
+				bestDistance = d
+			}
+		}
+
+		splice := prev.words[prevFrom+best.prev].End
+		prev.words = prev.words[:prevFrom+best.prev+1]
+		next.words = next.words[best.next+1:]
+		return splice
+	}
+
+	// No word agrees between the chunks: split the overlap in the middle
+	prev.words = filterWords(prev.words, func(w interfaces.TranscriptWord) bool { return wordCenter(w) < mid })
+	next.words = filterWords(next.words, func(w interfaces.TranscriptWord) bool { return wordCenter(w) >= mid })
+	return mid
+}
+
+// alignWords finds the longest common subsequence of two word sequences,
+// matching words with the same normalized text at nearly the same time
+func alignWords(prev, next []interfaces.TranscriptWord) []wordPair {
+	n, m := len(prev), len(next)
+	if n == 0 || m == 0 {
+		return nil
+	}
+
+	prevTokens := make([]string, n)
+	for i, word := range prev {
+		prevTokens[i] = normalizeWord(word.Word)
+	}
+	nextTokens := make([]string, m)
+	for j, word := range next {
+		nextTokens[j] = normalizeWord(word.Word)
+	}
+
+	matches := func(i, j int) bool {
+		return prevTokens[i] != "" && prevTokens[i] == nextTokens[j] &&
+			math.Abs(wordCenter(prev[i])-wordCenter(next[j])) <= wordMatchTolerance
+	}
+
+	// lengths[i][j] is the LCS length of prev[i:] and next[j:]
+	lengths := make([][]int, n+1)
+	for i := range lengths {
+		lengths[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if matches(i, j) {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var pairs []wordPair
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case matches(i, j):
+			pairs = append(pairs, wordPair{prev: i, next: j})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return pairs
+}
+
+// trimSegments keeps the part of each segment before (or from) the splice point.
+// Segments crossing it are cut there and their text rebuilt from the kept words;
+// without words a crossing segment goes to whichever side holds its middle.
+func trimSegments(segments []interfaces.TranscriptSegment, words []interfaces.TranscriptWord, splice float64, before bool) []interfaces.TranscriptSegment {
+	kept := make([]interfaces.TranscriptSegment, 0, len(segments))
+	for _, seg := range segments {
+		if (before && seg.End <= splice) || (!before && seg.Start >= splice) {
+			kept = append(kept, seg)
+			continue
+		}
+		if (before && seg.Start >= splice) || (!before && seg.End <= splice) {
+			continue
+		}
+
+		// The segment crosses the splice point
+		if len(words) == 0 {
+			center := (seg.Start + seg.End) / 2
+			if (before && center < splice) || (!before && center >= splice) {
+				kept = append(kept, seg)
+			}
+			continue
+		}
+
+		var parts []string
+		for _, word := range words {
+			if center := wordCenter(word); center >= seg.Start && center <= seg.End {
+				parts = append(parts, strings.TrimSpace(word.Word))
+			}
+		}
+		if len(parts) == 0 {
+			continue
+		}
+
+		seg.Text = strings.Join(parts, " ")
+		if before {
+			seg.End = splice
+		} else {
+			seg.Start = splice
+		}
+		kept = append(kept, seg)
+	}
+	return kept
+}
+
+func filterWords(words []interfaces.TranscriptWord, keep func(interfaces.TranscriptWord) bool) []interfaces.TranscriptWord {
+	kept := make([]interfaces.TranscriptWord, 0, len(words))
+	for _, word := range words {
+		if keep(word) {
+			kept = append(kept, word)
+		}
+	}
+	return kept
+}
+
+func wordCenter(word interfaces.TranscriptWord) float64 {
+	return (word.Start + word.End) / 2
+}
+
+// normalizeWord lowercases a word and strips surrounding punctuation for comparison
+func normalizeWord(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}))
+}
@@ -0,0 +1,133 @@
+package splitter
+
+import (
+	"strings"
+	"testing"
+
+	"scriberr/internal/transcription/interfaces"
+)
+
+// words builds consecutive half-second words starting at start
+func words(start float64, text string) []interfaces.TranscriptWord {
+	var result []interfaces.TranscriptWord
+	for i, w := range strings.Fields(text) {
+		s := start + float64(i)*0.5
+		result = append(result, interfaces.TranscriptWord{Start: s, End: s + 0.4, Word: w})
+	}
+	return result
+}
+
+func wordText(ws []interfaces.TranscriptWord) string {
+	parts := make([]string, len(ws))
+	for i, w := range ws {
+		parts[i] = w.Word
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestMergeResultsDeduplicatesOverlap(t *testing.T) {
+	// Chunk 0 covers 0-10s, chunk 1 starts at 8s with a 2s overlap.
+	// The seam words from "brown" at 8s onwards are in both chunks.
+	first := &interfaces.TranscriptResult{
+		Text:         "the quick brown fox jumps ove",
+		WordSegments: words(7, "the quick brown fox jumps ove"),
+		Segments: []interfaces.TranscriptSegment{
+			{Start: 7, End: 10, Text: "the quick brown fox jumps ove"},
+		},
+	}
+	second := &interfaces.TranscriptResult{
+		Text:         "own fox jumps over the lazy dog",
+		WordSegments: words(0, "own fox jumps over the lazy dog"),
+		Segments: []interfaces.TranscriptSegment{
+			{Start: 0, End: 3.5, Text: "own fox jumps over the lazy dog"},
+		},
+	}
+	chunks := []ChunkInfo{
+		{StartTime: 0, Duration: 10},
+		{StartTime: 8, Duration: 10, Overlap: 2},
+	}
+
+	merged := MergeResults([]*interfaces.TranscriptResult{first, second}, chunks, true)
+
+	if got := wordText(merged.WordSegments); got != "the quick brown fox jumps over the lazy dog" {
+		t.Errorf("expected every word exactly once, got %q", got)
+	}
+	for i := 1; i < len(merged.WordSegments); i++ {
+		if merged.WordSegments[i].Start < merged.WordSegments[i-1].Start {
+			t.Errorf("words out of order at %d: %+v", i, merged.WordSegments)
+		}
+	}
+	if merged.Text != "the quick brown fox jumps over the lazy dog" {
+		t.Errorf("unexpected merged text %q", merged.Text)
+	}
+	if merged.Metadata["overlaps_reconciled"] != "1" {
+		t.Errorf("expected overlap to be recorded, got %v", merged.Metadata)
+	}
+}
+
+func TestMergeResultsOverlapWithoutMatchingWords(t *testing.T) {
+	// Nothing agrees in the overlap, so the words are split at its middle (9s)
+	first := &interfaces.TranscriptResult{
+		WordSegments: words(8, "alpha beta gamma delta"), // 8.0 8.5 9.0 9.5
+	}
+	second := &interfaces.TranscriptResult{
+		WordSegments: words(0, "one two three four five"), // 8.0 8.5 9.0 9.5 10.0 globally
+	}
+	chunks := []ChunkInfo{
+		{StartTime: 0, Duration: 10},
+		{StartTime: 8, Duration: 10, Overlap: 2},
+	}
+
+	merged := MergeResults([]*interfaces.TranscriptResult{first, second}, chunks, true)
+
+	if got := wordText(merged.WordSegments); got != "alpha beta three four five" {
+		t.Errorf("expected a split at the middle of the overlap, got %q", got)
+	}
+}
+
+func TestMergeResultsOverlapSegmentsOnly(t *testing.T) {
+	// Models without word timestamps keep each segment on the side of its middle
+	first := &interfaces.TranscriptResult{
+		Segments: []interfaces.TranscriptSegment{
+			{Start: 0, End: 7, Text: "first part"},
+			{Start: 7, End: 9.5, Text: "seam from first chunk"},
+		},
+	}
+	second := &interfaces.TranscriptResult{
+		Segments: []interfaces.TranscriptSegment{
+			{Start: 0, End: 0.5, Text: "seam tail"},
+			{Start: 1.5, End: 5, Text: "second part"},
+		},
+	}
+	chunks := []ChunkInfo{
+		{StartTime: 0, Duration: 10},
+		{StartTime: 8, Duration: 10, Overlap: 2},
+	}
+
+	merged := MergeResults([]*interfaces.TranscriptResult{first, second}, chunks, true)
+
+	if len(merged.Segments) != 3 {
+		t.Fatalf("expected 3 segments, got %+v", merged.Segments)
+	}
+	if merged.Text != "first part seam from first chunk second part" {
+		t.Errorf("unexpected merged text %q", merged.Text)
+	}
+}
+
+func TestAlignWordsIgnoresDistantRepeats(t *testing.T) {
+	prev := words(0, "the cat")
+	next := words(5, "the dog")
+
+	if pairs := alignWords(prev, next); len(pairs) != 0 {
+		t.Errorf("words far apart in time should not match, got %v", pairs)
+	}
+}
+
+func TestNormalizeWord(t *testing.T) {
+	if got := normalizeWord(" Hello,"); got != "hello" {
+		t.Errorf("expected hello, got %q", got)
+	}
+	if got := normalizeWord("..."); got != "" {
+		t.Errorf("expected empty token for punctuation, got %q", got)
+	}
+}
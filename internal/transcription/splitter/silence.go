@@ -0,0 +1,119 @@
+package splitter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// StrategyFixed cuts chunks at fixed intervals
+	StrategyFixed = "fixed"
+	// StrategySilence moves each cut to the nearest pause so words are not sliced in half
+	StrategySilence = "silence"
+
+	// DefaultSilenceWindowSeconds is how far from a fixed cut point a pause is searched for
+	DefaultSilenceWindowSeconds = 30.0
+	// silenceNoiseThreshold is the level below which audio counts as silence
+	silenceNoiseThreshold = "-30dB"
+	// silenceMinDurationSeconds is the shortest pause considered for a cut
+	silenceMinDurationSeconds = 0.3
+)
+
+// Silence is a pause found in the audio, in seconds from the start
+type Silence struct {
+	Start float64
+	End   float64
+}
+
+var (
+	silenceStartPattern = regexp.MustCompile(`silence_start:\s*(-?[0-9.]+)`)
+	silenceEndPattern   = regexp.MustCompile(`silence_end:\s*(-?[0-9.]+)`)
+)
+
+// DetectSilences finds pauses in an audio file using ffmpeg's silencedetect filter
+func DetectSilences(ctx context.Context, filePath string) ([]Silence, error) {
+	filter := fmt.Sprintf("silencedetect=noise=%s:d=%.2f", silenceNoiseThreshold, silenceMinDurationSeconds)
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-hide_banner",
+		"-nostats",
+		"-i", filePath,
+		"-af", filter,
+		"-f", "null",
+		"-")
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("ffmpeg silencedetect failed: %w", err)
+	}
+
+	return parseSilences(string(output)), nil
+}
+
+// parseSilences reads the silence_start and silence_end lines written by silencedetect.
+// A silence still open at the end of the file is dropped, as there is nothing after it to cut from.
+func parseSilences(output string) []Silence {
+	var silences []Silence
+	var start *float64
+
+	for _, line := range strings.Split(output, "\n") {
+		if m := silenceStartPattern.FindStringSubmatch(line); m != nil {
+			if v, err := strconv.ParseFloat(m[1], 64); err == nil {
+				v = math.Max(v, 0)
+				start = &v
+			}
+			continue
+		}
+		if m := silenceEndPattern.FindStringSubmatch(line); m != nil && start != nil {
+			if v, err := strconv.ParseFloat(m[1], 64); err == nil && v > *start {
+				silences = append(silences, Silence{Start: *start, End: v})
+			}
+			start = nil
+		}
+	}
+
+	return silences
+}
+
+// planCutPoints places a cut every chunkDuration seconds, moved back to the
+// middle of the nearest pause within window seconds before it. A cut is never
+// moved forward, so no chunk grows past chunkDuration; a pause that spans the
+// fixed cut point is cut at that point. Without a pause in reach the fixed cut
+// point is kept.
+func planCutPoints(total, chunkDuration, window float64, silences []Silence) []float64 {
+	if window <= 0 {
+		window = DefaultSilenceWindowSeconds
+	}
+	// Never move a cut so far that it passes the previous one
+	if window > chunkDuration/2 {
+		window = chunkDuration / 2
+	}
+
+	var cuts []float64
+	last := 0.0
+	for total-last > chunkDuration {
+		target := last + chunkDuration
+		cut := target
+		best := math.Inf(1)
+
+		for _, silence := range silences {
+			if silence.Start > target {
+				continue
+			}
+			point := math.Min((silence.Start+silence.End)/2, target)
+			if distance := target - point; distance <= window && distance < best {
+				best = distance
+				cut = point
+			}
+		}
+
+		cuts = append(cuts, cut)
+		last = cut
+	}
+
+	return cuts
+}
@@ -0,0 +1,107 @@
+package splitter
+
+import (
+	"testing"
+)
+
+func TestParseSilences(t *testing.T) {
+	output := `Input #0, wav, from 'input.wav':
+[silencedetect @ 0x5581] silence_start: 0
+[silencedetect @ 0x5581] silence_end: 1.25 | silence_duration: 1.25
+size=N/A time=00:05:00.00 bitrate=N/A speed= 900x
+[silencedetect @ 0x5581] silence_start: 298.4
+[silencedetect @ 0x5581] silence_end: 299.1 | silence_duration: 0.7
+[silencedetect @ 0x5581] silence_start: 612.9
+`
+
+	silences := parseSilences(output)
+	if len(silences) != 2 {
+		t.Fatalf("expected 2 closed silences, got %d", len(silences))
+	}
+	if silences[0] != (Silence{Start: 0, End: 1.25}) {
+		t.Errorf("unexpected first silence %+v", silences[0])
+	}
+	if silences[1] != (Silence{Start: 298.4, End: 299.1}) {
+		t.Errorf("unexpected second silence %+v", silences[1])
+	}
+}
+
+func TestPlanCutPointsUsesNearestSilence(t *testing.T) {
+	silences := []Silence{
+		{Start: 270, End: 272}, // 29s before the first target
+		{Start: 290, End: 292}, // 9s before the first target
+		{Start: 590, End: 592},
+	}
+
+	cuts := planCutPoints(700, 300, 30, silences)
+	if len(cuts) != 2 {
+		t.Fatalf("expected 2 cuts, got %v", cuts)
+	}
+	if cuts[0] != 291 {
+		t.Errorf("expected first cut in the nearest pause at 291, got %v", cuts[0])
+	}
+	if cuts[1] != 591 {
+		t.Errorf("expected second cut in the pause at 591, got %v", cuts[1])
+	}
+}
+
+func TestPlanCutPointsFallsBackToHardCuts(t *testing.T) {
+	// The only pause is far outside the window
+	silences := []Silence{{Start: 100, End: 101}}
+
+	cuts := planCutPoints(650, 300, 20, silences)
+	if len(cuts) != 2 || cuts[0] != 300 || cuts[1] != 600 {
+		t.Errorf("expected hard cuts at 300 and 600, got %v", cuts)
+	}
+}
+
+func TestPlanCutPointsNeverCutsAfterTarget(t *testing.T) {
+	silences := []Silence{
+		{Start: 303, End: 305}, // Just after the first target, nearer than the pause before it
+		{Start: 280, End: 282},
+		{Start: 576, End: 590}, // Spans the second target, 581
+	}
+
+	cuts := planCutPoints(900, 300, 30, silences)
+	if len(cuts) != 3 || cuts[0] != 281 || cuts[1] != 581 {
+		t.Fatalf("expected cuts at 281 and 581, got %v", cuts)
+	}
+	last := 0.0
+	for _, cut := range cuts {
+		if cut-last > 300 {
+			t.Errorf("chunk %v-%v is longer than the chunk duration", last, cut)
+		}
+		last = cut
+	}
+}
+
+func TestPlanCutPointsClampsWindow(t *testing.T) {
+	// A window wider than half a chunk must not pull a cut behind the previous one
+	silences := []Silence{{Start: 10, End: 12}}
+
+	cuts := planCutPoints(250, 100, 500, silences)
+	for i := 1; i < len(cuts); i++ {
+		if cuts[i] <= cuts[i-1] {
+			t.Fatalf("cut points must increase, got %v", cuts)
+		}
+	}
+	if len(cuts) == 0 || cuts[0] < 50 {
+		t.Errorf("first cut should stay within half a chunk of its target, got %v", cuts)
+	}
+}
+
+func TestEstimateChunkDurationsFromCuts(t *testing.T) {
+	chunks := make([]ChunkInfo, 3)
+	estimateChunkDurationsFromCuts(chunks, 700, []float64{291, 591})
+
+	expected := []ChunkInfo{
+		{StartTime: 0, Duration: 291},
+		{StartTime: 291, Duration: 300},
+		{StartTime: 591, Duration: 109},
+	}
+	for i, chunk := range chunks {
+		if chunk.StartTime != expected[i].StartTime || chunk.Duration != expected[i].Duration {
+			t.Errorf("chunk %d: expected %+v, got %+v", i, expected[i], chunk)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package splitter
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -17,17 +18,26 @@ import (
 // Note: MergeResults function is in merger.go
 
 const (
-	// MaxFileSizeBytes is the maximum file size before splitting (25MB)
-	MaxFileSizeBytes = 25 * 1024 * 1024
-	// MaxDurationMinutes is the maximum duration before splitting (5 minutes)
-	MaxDurationMinutes = 5
-	// ChunkDurationMinutes is the target chunk duration (5 minutes)
-	ChunkDurationMinutes = 5
+	// DefaultChunkDurationSeconds is the chunk length when a model has limits but no preference (5 minutes)
+	DefaultChunkDurationSeconds = 300.0
+	// MinSplitChunkSeconds is the shortest chunk the splitter plans (1 minute)
+	MinSplitChunkSeconds = 60.0
 	// MinChunkDurationSeconds is the minimum duration for a valid chunk (1 second)
 	// Chunks shorter than this are discarded to avoid OpenAI API errors
 	MinChunkDurationSeconds = 1.0
+	// MaxOverlapSeconds caps how much audio neighbouring chunks share
+	MaxOverlapSeconds = 30.0
 )
 
+// chunkEncodeArgs re-encode chunks for speech: 16kHz mono MP3 at 64kbps
+var chunkEncodeArgs = []string{
+	"-ar", "16000", // 16kHz - OpenAI's internal sample rate
+	"-ac", "1", // Mono - single channel
+	"-c:a", "libmp3lame", // Re-encode to MP3
+	"-b:a", "64k", // 64kbps - sufficient for speech
+	"-map", "0:a", // Only audio stream
+}
+
 // AudioSplitter handles splitting large audio files into chunks
 type AudioSplitter struct {
 	tempDirectory string
@@ -46,6 +56,19 @@ type ChunkInfo struct {
 	StartTime     float64 // Start time in seconds relative to original
 	Duration      float64 // Duration in seconds
 	OriginalIndex int     // Index in the chunk sequence
+	Overlap       float64 // Seconds at the start shared with the previous chunk
+}
+
+// SplitOptions controls when and how audio is cut into chunks. The limits come
+// from the model's ChunkLimits, possibly overridden by the profile.
+type SplitOptions struct {
+	MaxFileSizeBytes     int64   // Split files larger than this, 0 for no limit
+	MaxDurationSeconds   float64 // Split audio longer than this, 0 for no limit
+	ChunkDurationSeconds float64 // Preferred chunk length, 0 for DefaultChunkDurationSeconds
+
+	Strategy      string  // StrategyFixed (default) or StrategySilence
+	SilenceWindow float64 // Seconds around each fixed cut searched for a pause
+	Overlap       float64 // Seconds each chunk shares with the previous one, 0 for none
 }
 
 // SplitResult contains the result of splitting an audio file
@@ -55,31 +78,31 @@ type SplitResult struct {
 	NeedsSplit   bool
 }
 
-// NeedsSplitting checks if an audio file needs to be split
-func (s *AudioSplitter) NeedsSplitting(input interfaces.AudioInput) bool {
-	// Check file size (25MB limit)
-	if input.Size > MaxFileSizeBytes {
+// NeedsSplitting checks if an audio file exceeds the limits of the model it is for
+func (s *AudioSplitter) NeedsSplitting(input interfaces.AudioInput, opts SplitOptions) bool {
+	// Check file size
+	if opts.MaxFileSizeBytes > 0 && input.Size > opts.MaxFileSizeBytes {
 		logger.Info("Audio file exceeds size limit",
 			"size_mb", float64(input.Size)/(1024*1024),
-			"limit_mb", float64(MaxFileSizeBytes)/(1024*1024))
+			"limit_mb", float64(opts.MaxFileSizeBytes)/(1024*1024))
 		return true
 	}
 
-	// Check duration (25 minutes limit)
-	durationMinutes := input.Duration.Minutes()
-	if durationMinutes > MaxDurationMinutes {
+	// Check duration
+	if opts.MaxDurationSeconds > 0 && input.Duration.Seconds() > opts.MaxDurationSeconds {
 		logger.Info("Audio file exceeds duration limit",
-			"duration_min", durationMinutes,
-			"limit_min", MaxDurationMinutes)
+			"duration_min", input.Duration.Minutes(),
+			"limit_min", opts.MaxDurationSeconds/60)
 		return true
 	}
 
 	return false
 }
 
-// Split splits an audio file into chunks using ffmpeg
-func (s *AudioSplitter) Split(ctx context.Context, input interfaces.AudioInput, jobID string) (*SplitResult, error) {
-	if !s.NeedsSplitting(input) {
+// Split splits an audio file into chunks using ffmpeg. With StrategySilence the
+// cuts are moved into nearby pauses; otherwise they fall at fixed intervals.
+func (s *AudioSplitter) Split(ctx context.Context, input interfaces.AudioInput, jobID string, opts SplitOptions) (*SplitResult, error) {
+	if !s.NeedsSplitting(input, opts) {
 		return &SplitResult{
 			Chunks: []ChunkInfo{{
 				FilePath:      input.FilePath,
@@ -104,28 +127,94 @@ func (s *AudioSplitter) Split(ctx context.Context, input interfaces.AudioInput,
 	}
 
 	// Calculate chunk duration based on file characteristics
-	chunkDurationSec := s.calculateChunkDuration(input)
+	chunkDurationSec := s.calculateChunkDuration(input, opts)
 
 	// Always output as MP3 since we re-encode for clean frame boundaries
 	ext := ".mp3"
 
+	// Chunks after the first also carry the overlap, so the cuts are planned
+	// that much closer together to keep every chunk within the model's limits
+	overlap := clampOverlap(opts.Overlap, chunkDurationSec)
+	cutInterval := chunkDurationSec - overlap
+	cuts := s.silenceCutPoints(ctx, input, cutInterval, opts)
+
+	var chunks []ChunkInfo
+	var err error
+	if overlap > 0 && input.Duration > 0 {
+		if cuts == nil {
+			cuts = planCutPoints(input.Duration.Seconds(), cutInterval, 0, nil)
+		}
+		chunks, err = s.extractOverlappingChunks(ctx, input, chunkDir, ext, cuts, overlap)
+	} else {
+		chunks, err = s.segmentChunks(ctx, input, chunkDir, ext, chunkDurationSec, cuts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter out chunks that are too short (caused by audio duration slightly exceeding threshold)
+	validChunks := make([]ChunkInfo, 0, len(chunks))
+	for _, chunk := range chunks {
+		if chunk.Duration >= MinChunkDurationSeconds {
+			validChunks = append(validChunks, chunk)
+		} else {
+			logger.Warn("Skipping chunk that is too short",
+				"chunk", chunk.FilePath,
+				"duration_sec", chunk.Duration,
+				"min_duration_sec", MinChunkDurationSeconds)
+			// Clean up the invalid chunk file
+			if err := os.Remove(chunk.FilePath); err != nil {
+				logger.Warn("Failed to remove invalid chunk", "file", chunk.FilePath, "error", err)
+			}
+		}
+	}
+
+	// If all chunks were filtered out, return error
+	if len(validChunks) == 0 {
+		return nil, fmt.Errorf("no valid chunks after filtering (all chunks too short)")
+	}
+
+	logger.Info("Audio split complete",
+		"total_chunks", len(chunks),
+		"valid_chunks", len(validChunks),
+		"chunk_duration_sec", chunkDurationSec,
+		"silence_cuts", opts.Strategy == StrategySilence,
+		"overlap_sec", overlap)
+
+	return &SplitResult{
+		Chunks:       validChunks,
+		OriginalPath: input.FilePath,
+		NeedsSplit:   true,
+	}, nil
+}
+
+// segmentChunks cuts the audio in one ffmpeg pass with the segment muxer, at the
+// given cut points or every chunkDurationSec seconds when there are none
+func (s *AudioSplitter) segmentChunks(ctx context.Context, input interfaces.AudioInput, chunkDir, ext string, chunkDurationSec float64, cuts []float64) ([]ChunkInfo, error) {
 	// Build ffmpeg command for segmentation
 	outputPattern := filepath.Join(chunkDir, fmt.Sprintf("chunk_%%03d%s", ext))
 
+	segmentArgs := []string{"-segment_time", fmt.Sprintf("%.0f", chunkDurationSec)}
+	if len(cuts) > 0 {
+		times := make([]string, len(cuts))
+		for i, cut := range cuts {
+			times[i] = strconv.FormatFloat(cut, 'f', 3, 64)
+		}
+		segmentArgs = []string{"-segment_times", strings.Join(times, ",")}
+	}
+
 	// Re-encode to ensure clean MP3 frames at segment boundaries
 	// -c copy causes corrupted frames that slow down OpenAI processing
 	args := []string{
 		"-i", input.FilePath,
 		"-f", "segment",
-		"-segment_time", fmt.Sprintf("%.0f", chunkDurationSec),
-		"-ar", "16000",        // 16kHz - OpenAI's internal sample rate
-		"-ac", "1",            // Mono - single channel
-		"-c:a", "libmp3lame",  // Re-encode to MP3
-		"-b:a", "64k",         // 64kbps - sufficient for speech
+	}
+	args = append(args, segmentArgs...)
+	args = append(args, chunkEncodeArgs...)
+	args = append(args,
 		"-reset_timestamps", "1",
-		"-map", "0:a", // Only audio stream
 		outputPattern,
-	}
+	)
 
 	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
 	output, err := cmd.CombinedOutput()
@@ -147,53 +236,109 @@ func (s *AudioSplitter) Split(ctx context.Context, input interfaces.AudioInput,
 	// Get duration for each chunk using ffprobe
 	if err := s.populateChunkDurations(ctx, chunks); err != nil {
 		logger.Warn("Failed to get chunk durations, estimating", "error", err)
-		s.estimateChunkDurations(chunks, input.Duration.Seconds(), chunkDurationSec)
+		if len(cuts) > 0 {
+			estimateChunkDurationsFromCuts(chunks, input.Duration.Seconds(), cuts)
+		} else {
+			s.estimateChunkDurations(chunks, input.Duration.Seconds(), chunkDurationSec)
+		}
 	}
 
-	// Filter out chunks that are too short (caused by audio duration slightly exceeding threshold)
-	validChunks := make([]ChunkInfo, 0, len(chunks))
-	for _, chunk := range chunks {
-		if chunk.Duration >= MinChunkDurationSeconds {
-			validChunks = append(validChunks, chunk)
-		} else {
-			logger.Warn("Skipping chunk that is too short",
-				"chunk", chunk.FilePath,
-				"duration_sec", chunk.Duration,
-				"min_duration_sec", MinChunkDurationSeconds)
-			// Clean up the invalid chunk file
-			if err := os.Remove(chunk.FilePath); err != nil {
-				logger.Warn("Failed to remove invalid chunk", "file", chunk.FilePath, "error", err)
-			}
+	return chunks, nil
+}
+
+// extractOverlappingChunks cuts each chunk with its own ffmpeg call so that every
+// chunk after the first starts overlap seconds before its cut point. The merger
+// uses the shared audio to stitch words at the seams exactly once.
+func (s *AudioSplitter) extractOverlappingChunks(ctx context.Context, input interfaces.AudioInput, chunkDir, ext string, cuts []float64, overlap float64) ([]ChunkInfo, error) {
+	total := input.Duration.Seconds()
+	chunks := make([]ChunkInfo, 0, len(cuts)+1)
+
+	for i := 0; i <= len(cuts); i++ {
+		start := 0.0
+		if i > 0 {
+			start = cuts[i-1]
+		}
+		end := total
+		if i < len(cuts) {
+			end = cuts[i]
 		}
+
+		chunkOverlap := 0.0
+		if i > 0 {
+			chunkOverlap = math.Min(overlap, start)
+			start -= chunkOverlap
+		}
+
+		chunkPath := filepath.Join(chunkDir, fmt.Sprintf("chunk_%03d%s", i, ext))
+		args := []string{
+			"-y",
+			"-ss", strconv.FormatFloat(start, 'f', 3, 64),
+			"-t", strconv.FormatFloat(end-start, 'f', 3, 64),
+			"-i", input.FilePath,
+		}
+		args = append(args, chunkEncodeArgs...)
+		args = append(args, chunkPath)
+
+		cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			logger.Error("FFmpeg chunk extraction failed", "chunk", i, "error", err, "output", string(output))
+			return nil, fmt.Errorf("ffmpeg split failed: %w", err)
+		}
+
+		chunks = append(chunks, ChunkInfo{
+			FilePath:      chunkPath,
+			StartTime:     start,
+			Duration:      end - start,
+			OriginalIndex: i,
+			Overlap:       chunkOverlap,
+		})
 	}
 
-	// If all chunks were filtered out, return error
-	if len(validChunks) == 0 {
-		return nil, fmt.Errorf("no valid chunks after filtering (all chunks too short)")
+	return chunks, nil
+}
+
+// clampOverlap keeps the overlap well below the chunk length so chunks never
+// share more audio than they contribute on their own
+func clampOverlap(overlap, chunkDurationSec float64) float64 {
+	if overlap <= 0 {
+		return 0
 	}
+	return math.Min(overlap, math.Min(MaxOverlapSeconds, chunkDurationSec/4))
+}
 
-	logger.Info("Audio split complete",
-		"total_chunks", len(chunks),
-		"valid_chunks", len(validChunks),
-		"chunk_duration_sec", chunkDurationSec)
+// silenceCutPoints returns the cut points for StrategySilence, at most
+// cutInterval apart, or nil to cut at fixed intervals. Silence detection
+// failures fall back to fixed cuts.
+func (s *AudioSplitter) silenceCutPoints(ctx context.Context, input interfaces.AudioInput, cutInterval float64, opts SplitOptions) []float64 {
+	if opts.Strategy != StrategySilence || input.Duration <= 0 {
+		return nil
+	}
 
-	return &SplitResult{
-		Chunks:       validChunks,
-		OriginalPath: input.FilePath,
-		NeedsSplit:   true,
-	}, nil
+	silences, err := DetectSilences(ctx, input.FilePath)
+	if err != nil {
+		logger.Warn("Silence detection failed, using fixed cuts", "file", input.FilePath, "error", err)
+		return nil
+	}
+
+	cuts := planCutPoints(input.Duration.Seconds(), cutInterval, opts.SilenceWindow, silences)
+	logger.Info("Planned silence-aware cut points",
+		"silences", len(silences),
+		"cuts", len(cuts))
+	return cuts
 }
 
-// calculateChunkDuration determines optimal chunk duration
-func (s *AudioSplitter) calculateChunkDuration(input interfaces.AudioInput) float64 {
-	// Default to 10 minutes (600 seconds)
-	chunkDuration := float64(ChunkDurationMinutes * 60)
+// calculateChunkDuration determines optimal chunk duration within the model's limits
+func (s *AudioSplitter) calculateChunkDuration(input interfaces.AudioInput, opts SplitOptions) float64 {
+	chunkDuration := opts.ChunkDurationSeconds
+	if chunkDuration <= 0 {
+		chunkDuration = DefaultChunkDurationSeconds
+	}
 
-	// If we have bitrate info, calculate based on target size
-	if bitrateStr, ok := input.Metadata["bitrate"]; ok {
+	// If the model has a size limit and we have bitrate info, calculate based on target size
+	if bitrateStr, ok := input.Metadata["bitrate"]; ok && opts.MaxFileSizeBytes > 0 {
 		if bitrate, err := strconv.ParseFloat(bitrateStr, 64); err == nil && bitrate > 0 {
-			// Target 20MB per chunk (safe margin under 25MB)
-			targetSizeBytes := float64(20 * 1024 * 1024)
+			// Target 80% of the limit as a safe margin
+			targetSizeBytes := float64(opts.MaxFileSizeBytes) * 0.8
 			bytesPerSecond := bitrate / 8
 			calculatedDuration := targetSizeBytes / bytesPerSecond
 
@@ -205,11 +350,11 @@ func (s *AudioSplitter) calculateChunkDuration(input interfaces.AudioInput) floa
 	}
 
 	// Chunk duration limits
-	if chunkDuration < 60 {
-		chunkDuration = 60 // Minimum 1 minute
+	if chunkDuration < MinSplitChunkSeconds {
+		chunkDuration = MinSplitChunkSeconds
 	}
-	if chunkDuration > 300 {
-		chunkDuration = 300 // Maximum 5 minutes
+	if opts.MaxDurationSeconds > 0 && chunkDuration > opts.MaxDurationSeconds {
+		chunkDuration = opts.MaxDurationSeconds
 	}
 
 	return chunkDuration
@@ -293,6 +438,22 @@ func (s *AudioSplitter) estimateChunkDurations(chunks []ChunkInfo, totalDuration
 	}
 }
 
+// estimateChunkDurationsFromCuts derives chunk durations from the planned cut points when ffprobe fails
+func estimateChunkDurationsFromCuts(chunks []ChunkInfo, totalDuration float64, cuts []float64) {
+	for i := range chunks {
+		start := 0.0
+		if i > 0 && i-1 < len(cuts) {
+			start = cuts[i-1]
+		}
+		end := totalDuration
+		if i < len(cuts) {
+			end = cuts[i]
+		}
+		chunks[i].StartTime = start
+		chunks[i].Duration = end - start
+	}
+}
+
 // getAudioDuration gets the duration of an audio file using ffprobe
 func (s *AudioSplitter) getAudioDuration(ctx context.Context, filePath string) (float64, error) {
 	cmd := exec.CommandContext(ctx, "ffprobe",
@@ -0,0 +1,118 @@
+package splitter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"scriberr/internal/transcription/interfaces"
+)
+
+func TestNeedsSplittingUsesModelLimits(t *testing.T) {
+	s := NewAudioSplitter(t.TempDir())
+	hourLong := interfaces.AudioInput{Duration: time.Hour, Size: 100 * 1024 * 1024}
+
+	if s.NeedsSplitting(hourLong, SplitOptions{}) {
+		t.Error("models without limits should never need splitting")
+	}
+	if !s.NeedsSplitting(hourLong, SplitOptions{MaxDurationSeconds: 300}) {
+		t.Error("audio longer than the duration limit should be split")
+	}
+	if !s.NeedsSplitting(hourLong, SplitOptions{MaxFileSizeBytes: 25 * 1024 * 1024}) {
+		t.Error("files larger than the size limit should be split")
+	}
+	if s.NeedsSplitting(interfaces.AudioInput{Duration: time.Minute, Size: 1024}, SplitOptions{MaxDurationSeconds: 300, MaxFileSizeBytes: 25 * 1024 * 1024}) {
+		t.Error("audio within the limits should not be split")
+	}
+}
+
+func TestCalculateChunkDuration(t *testing.T) {
+	s := NewAudioSplitter(t.TempDir())
+	input := interfaces.AudioInput{}
+
+	if got := s.calculateChunkDuration(input, SplitOptions{}); got != DefaultChunkDurationSeconds {
+		t.Errorf("expected default chunk length, got %v", got)
+	}
+	if got := s.calculateChunkDuration(input, SplitOptions{ChunkDurationSeconds: 900}); got != 900 {
+		t.Errorf("expected preferred chunk length 900, got %v", got)
+	}
+	if got := s.calculateChunkDuration(input, SplitOptions{ChunkDurationSeconds: 900, MaxDurationSeconds: 600}); got != 600 {
+		t.Errorf("chunks must not exceed the duration limit, got %v", got)
+	}
+	if got := s.calculateChunkDuration(input, SplitOptions{ChunkDurationSeconds: 10}); got != MinSplitChunkSeconds {
+		t.Errorf("expected minimum chunk length, got %v", got)
+	}
+
+	// 1 Mbit/s audio against a 25MB limit: 80% of 25MB lasts about 168 seconds
+	withBitrate := interfaces.AudioInput{Metadata: map[string]string{"bitrate": "1000000"}}
+	got := s.calculateChunkDuration(withBitrate, SplitOptions{MaxFileSizeBytes: 25 * 1024 * 1024})
+	if got < 160 || got > 170 {
+		t.Errorf("expected a size-based chunk length near 168s, got %v", got)
+	}
+}
+
+func TestOverlappingChunksStayWithinLimits(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ffmpeg is a shell script")
+	}
+
+	// ffmpeg writes a chunk to its last argument
+	root := t.TempDir()
+	bin := filepath.Join(root, "bin")
+	if err := os.MkdirAll(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	ffmpeg := "#!/bin/sh\nfor last; do :; done\nprintf ID3 > \"$last\"\n"
+	if err := os.WriteFile(filepath.Join(bin, "ffmpeg"), []byte(ffmpeg), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	audio := filepath.Join(root, "upload.mp3")
+	if err := os.WriteFile(audio, []byte("ID3"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// 1 Mbit/s audio, so 80% of a 25MB limit lasts about 168 seconds
+	input := interfaces.AudioInput{
+		FilePath: audio,
+		Duration: 20 * time.Minute,
+		Size:     150 * 1024 * 1024,
+		Metadata: map[string]string{"bitrate": "1000000"},
+	}
+
+	s := NewAudioSplitter(filepath.Join(root, "temp"))
+	tests := []struct {
+		name  string
+		opts  SplitOptions
+		limit float64
+	}{
+		{"duration limit", SplitOptions{MaxDurationSeconds: 300, Overlap: 10}, 300},
+		{"size limit", SplitOptions{MaxFileSizeBytes: 25 * 1024 * 1024, Overlap: 10}, 168},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.Split(context.Background(), input, "job-"+filepath.Base(t.Name()), tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(result.Chunks) < 2 {
+				t.Fatalf("expected several chunks, got %d", len(result.Chunks))
+			}
+			for i, chunk := range result.Chunks {
+				if chunk.Duration > tt.limit {
+					t.Errorf("chunk %d lasts %.1fs, more than the %.0fs limit", i, chunk.Duration, tt.limit)
+				}
+				if i > 0 && chunk.Overlap != 10 {
+					t.Errorf("chunk %d shares %.1fs with the previous one, expected 10s", i, chunk.Overlap)
+				}
+			}
+			last := result.Chunks[len(result.Chunks)-1]
+			if end := last.StartTime + last.Duration; end != input.Duration.Seconds() {
+				t.Errorf("chunks end at %.1fs, expected the end of the audio", end)
+			}
+		})
+	}
+}
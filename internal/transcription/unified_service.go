@@ -14,6 +14,7 @@ import (
 	"scriberr/internal/models"
 	"scriberr/internal/repository"
 	"scriberr/internal/sse"
+	"scriberr/internal/transcription/adapters"
 	"scriberr/internal/transcription/interfaces"
 	"scriberr/internal/transcription/pipeline"
 	"scriberr/internal/transcription/postprocessor"
@@ -21,6 +22,8 @@ import (
 	"scriberr/internal/transcription/splitter"
 	"scriberr/internal/webhook"
 	"scriberr/pkg/logger"
+
+	"golang.org/x/sync/errgroup"
 )
 
 const (
@@ -31,12 +34,14 @@ const (
 	ModelSortformer      = "sortformer"
 	ModelOpenAI          = "openai_whisper"
 	ModelVoxtral         = "voxtral"
+	ModelWhisperCpp      = "whisper_cpp"
 	ModelDiarization31   = "pyannote/speaker-diarization-3.1"
 	FamilyNvidiaCanary   = "nvidia_canary"
 	FamilyNvidiaParakeet = "nvidia_parakeet"
 	FamilyWhisper        = "whisper"
 	FamilyOpenAI         = "openai"
 	FamilyMistralVoxtral = "mistral_voxtral"
+	FamilyWhisperCpp     = "whisper_cpp"
 	DiarizeSortformer    = "nvidia_sortformer"
 	OutputFormatJSON     = "json"
 )
@@ -53,16 +58,22 @@ type UnifiedTranscriptionService struct {
 	multiTrackTranscriber *MultiTrackTranscriber // For termination support
 	jobRepo               repository.JobRepository
 	webhookService        *webhook.Service
+	webhooksDisabled      bool // Set on remote workers; the server reports the outcome
 	broadcaster           *sse.Broadcaster
 	audioSplitter         *splitter.AudioSplitter // For splitting large audio files
 	aiPostprocessor       *postprocessor.AITextPostprocessor
+	trackConcurrency      int           // Tracks of a multi-track job transcribed at once
+	trackSlots            chan struct{} // Bounds the tracks of all multi-track jobs together; nil means unbounded
+	voiceRepo             repository.VoiceRepository
+	speakerMappingRepo    repository.SpeakerMappingRepository
+	voiceMatchThreshold   float64 // Cosine similarity needed to name a speaker after a voice
 }
 
 // NewUnifiedTranscriptionService creates a new unified transcription service
 func NewUnifiedTranscriptionService(jobRepo repository.JobRepository, tempDir, outputDir string) *UnifiedTranscriptionService {
 	return &UnifiedTranscriptionService{
 		registry:        registry.GetRegistry(),
-		pipeline:        pipeline.NewProcessingPipeline(),
+		pipeline:        pipeline.NewProcessingPipeline(tempDir),
 		preprocessors:   make(map[string]interfaces.Preprocessor),
 		postprocessors:  make(map[string]interfaces.Postprocessor),
 		tempDirectory:   tempDir,
@@ -71,9 +82,10 @@ func NewUnifiedTranscriptionService(jobRepo repository.JobRepository, tempDir, o
 			"transcription": ModelWhisperX,
 			"diarization":   ModelPyannote,
 		},
-		jobRepo:        jobRepo,
-		webhookService: webhook.NewService(),
-		audioSplitter:  splitter.NewAudioSplitter(tempDir),
+		jobRepo:          jobRepo,
+		webhookService:   webhook.NewService(),
+		audioSplitter:    splitter.NewAudioSplitter(tempDir),
+		trackConcurrency: 1,
 	}
 }
 
@@ -90,6 +102,101 @@ func (u *UnifiedTranscriptionService) SetAIPostprocessor(apiKey, model string, e
 	}
 }
 
+// DisableWebhooks stops the service from calling the callback URLs of jobs it
+// processes. Remote workers use it, since the server calls them once it has
+// stored the result.
+func (u *UnifiedTranscriptionService) DisableWebhooks() {
+	u.webhooksDisabled = true
+}
+
+// SendWebhook calls the job's callback URL, if it has one, with its outcome.
+// The execution may be nil. The webhook is sent in the background.
+func (u *UnifiedTranscriptionService) SendWebhook(job *models.TranscriptionJob, execution *models.TranscriptionJobExecution, status models.JobStatus) {
+	completedAt := time.Now()
+	if execution != nil && execution.CompletedAt != nil {
+		completedAt = *execution.CompletedAt
+	}
+	u.sendWebhook(job, execution, status, completedAt)
+}
+
+// sendWebhook calls the job's callback URL with its outcome in the background
+func (u *UnifiedTranscriptionService) sendWebhook(job *models.TranscriptionJob, execution *models.TranscriptionJobExecution, status models.JobStatus, completedAt time.Time) {
+	if job.Parameters.CallbackURL == nil || *job.Parameters.CallbackURL == "" {
+		return
+	}
+	payload := webhook.WebhookPayload{
+		JobID:        job.ID,
+		Status:       status,
+		AudioPath:    job.AudioPath,
+		Transcript:   job.Transcript,
+		Summary:      job.Summary,
+		ErrorMessage: job.ErrorMessage,
+		CompletedAt:  completedAt,
+		Metadata:     map[string]interface{}{},
+	}
+	if execution != nil {
+		payload.ErrorMessage = execution.ErrorMessage
+		payload.Metadata["model"] = execution.ActualParameters.Model
+		payload.Metadata["model_family"] = execution.ActualParameters.ModelFamily
+		payload.Metadata["duration_ms"] = execution.ProcessingDuration
+		if execution.TranscriptionModel != nil {
+			payload.Metadata["transcription_model"] = *execution.TranscriptionModel
+		}
+		if execution.DiarizationModel != nil {
+			payload.Metadata["diarization_model"] = *execution.DiarizationModel
+		}
+		if execution.ModelAttempts != nil {
+			payload.Metadata["model_attempts"] = json.RawMessage(*execution.ModelAttempts)
+		}
+		if execution.ModelRouting != nil {
+			payload.Metadata["model_routing"] = json.RawMessage(*execution.ModelRouting)
+		}
+	}
+
+	// Send webhook asynchronously to not block the main process
+	go func() {
+		// Create a new context with timeout for the webhook
+		webhookCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := u.webhookService.SendWebhook(webhookCtx, *job.Parameters.CallbackURL, payload); err != nil {
+			logger.Error("Failed to send webhook", "job_id", job.ID, "error", err)
+		}
+	}()
+}
+
+// SetTrackConcurrency sets how many tracks of a multi-track job are transcribed at once
+func (u *UnifiedTranscriptionService) SetTrackConcurrency(n int) {
+	if n < 1 {
+		n = 1
+	}
+	u.trackConcurrency = n
+}
+
+// SetTrackLimit sets how many tracks are transcribed at once across all
+// multi-track jobs. Tracks do not take queue workers, so they add to the jobs
+// the queue runs. Must be called before jobs are processed.
+func (u *UnifiedTranscriptionService) SetTrackLimit(n int) {
+	if n < 1 {
+		n = 1
+	}
+	u.trackSlots = make(chan struct{}, n)
+}
+
+// acquireTrackSlot waits until another track may be transcribed and returns
+// the function that frees the slot again
+func (u *UnifiedTranscriptionService) acquireTrackSlot(ctx context.Context) (func(), error) {
+	if u.trackSlots == nil {
+		return func() {}, nil
+	}
+	select {
+	case u.trackSlots <- struct{}{}:
+		return func() { <-u.trackSlots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // Initialize prepares all registered models for use
 func (u *UnifiedTranscriptionService) Initialize(ctx context.Context) error {
 	logger.Info("Initializing unified transcription service")
@@ -168,32 +275,8 @@ func (u *UnifiedTranscriptionService) ProcessJob(ctx context.Context, jobID stri
 		}
 
 		// Trigger webhook if callback URL is present
-		if job.Parameters.CallbackURL != nil && *job.Parameters.CallbackURL != "" {
-			payload := webhook.WebhookPayload{
-				JobID:        job.ID,
-				Status:       status,
-				AudioPath:    job.AudioPath,
-				Transcript:   job.Transcript,
-				Summary:      job.Summary,
-				ErrorMessage: execution.ErrorMessage,
-				CompletedAt:  completedAt,
-				Metadata: map[string]interface{}{
-					"model":        job.Parameters.Model,
-					"model_family": job.Parameters.ModelFamily,
-					"duration_ms":  execution.ProcessingDuration,
-				},
-			}
-
-			// Send webhook asynchronously to not block the main process
-			go func() {
-				// Create a new context with timeout for the webhook
-				webhookCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-				defer cancel()
-
-				if err := u.webhookService.SendWebhook(webhookCtx, *job.Parameters.CallbackURL, payload); err != nil {
-					logger.Error("Failed to send webhook", "job_id", job.ID, "error", err)
-				}
-			}()
+		if !u.webhooksDisabled {
+			u.sendWebhook(job, execution, status, completedAt)
 		}
 	}
 
@@ -207,7 +290,7 @@ func (u *UnifiedTranscriptionService) ProcessJob(ctx context.Context, jobID stri
 		}
 	} else {
 		// Process single track
-		if err := u.processSingleTrackJob(ctx, job); err != nil {
+		if err := u.processSingleTrackJob(ctx, job, execution); err != nil {
 			errMsg := fmt.Sprintf("single-track processing failed: %v", err)
 			updateExecutionStatus(models.StatusFailed, errMsg)
 			return fmt.Errorf("%s", errMsg)
@@ -220,10 +303,11 @@ func (u *UnifiedTranscriptionService) ProcessJob(ctx context.Context, jobID stri
 	return nil
 }
 
-// processSingleTrackJob handles single audio file transcription
+// processSingleTrackJob handles single audio file transcription. The preprocessing
+// steps that ran are recorded on the execution.
 //
 //nolint:gocyclo // Orchestrator function with multiple steps
-func (u *UnifiedTranscriptionService) processSingleTrackJob(ctx context.Context, job *models.TranscriptionJob) error {
+func (u *UnifiedTranscriptionService) processSingleTrackJob(ctx context.Context, job *models.TranscriptionJob, execution *models.TranscriptionJobExecution) error {
 	logger.Info("Processing single-track job", "job_id", job.ID, "model_family", job.Parameters.ModelFamily)
 
 	// Create processing context
@@ -245,12 +329,32 @@ func (u *UnifiedTranscriptionService) processSingleTrackJob(ctx context.Context,
 		return fmt.Errorf("failed to create audio input: %w", err)
 	}
 
-	// Determine models to use first
-	transcriptionModelID, diarizationModelID, err := u.selectModels(job.Parameters)
-	if err != nil {
-		return fmt.Errorf("failed to select models: %w", err)
+	// In auto mode, route the job by the language of the audio
+	params := job.Parameters
+	if params.ModelFamily == FamilyAuto {
+		var decision *RoutingDecision
+		params, decision = u.routeAutoModel(ctx, params, audioInput, procCtx)
+		execution.ActualParameters.Language = params.Language
+		if decisionJSON, err := json.Marshal(decision); err == nil {
+			decisionStr := string(decisionJSON)
+			execution.ModelRouting = &decisionStr
+		}
 	}
 
+	// Determine models to use first, ready ones of each fallback chain ahead
+	transcriptionChain := u.transcriptionCandidates(ctx, params)
+	diarizationChain := u.diarizationCandidates(ctx, params)
+	transcriptionModelID := transcriptionChain[0].ModelID
+	var diarizationModelID string
+	if len(diarizationChain) > 0 {
+		diarizationModelID = diarizationChain[0].ModelID
+	}
+	logger.Info("Selected models",
+		"transcription", transcriptionModelID,
+		"diarization", diarizationModelID,
+		"transcription_chain", len(transcriptionChain),
+		"diarization_chain", len(diarizationChain))
+
 	// Apply preprocessing to ensure audio is in correct format (mono 16kHz)
 	var preprocessedInput interfaces.AudioInput
 	var tempFilesToCleanup []string
@@ -267,25 +371,42 @@ func (u *UnifiedTranscriptionService) processSingleTrackJob(ctx context.Context,
 		}
 	}
 
-	// Apply preprocessing
-	preprocessedInput, err = u.pipeline.ProcessAudio(ctx, audioInput, capabilities)
+	// Apply the profile's enhancement chain, then format conversion
+	enhancementOpts := enhancementOptions(params)
+	enhancementOpts.TempDirectory = procCtx.TempDirectory
+	enhancers, err := pipeline.BuildEnhancers(enhancementOpts)
 	if err != nil {
-		logger.Warn("Audio preprocessing failed, using original", "error", err)
-		preprocessedInput = audioInput
-	} else {
-		// Track temporary file for cleanup if preprocessing created one
-		if preprocessedInput.TempFilePath != "" && preprocessedInput.TempFilePath != audioInput.FilePath {
-			tempFilesToCleanup = append(tempFilesToCleanup, preprocessedInput.TempFilePath)
-			logger.Info("Audio preprocessing completed",
-				"original", audioInput.FilePath,
-				"converted", preprocessedInput.TempFilePath,
-				"original_sr", audioInput.SampleRate,
-				"converted_sr", preprocessedInput.SampleRate,
-				"original_channels", audioInput.Channels,
-				"converted_channels", preprocessedInput.Channels)
+		return fmt.Errorf("invalid audio enhancement settings: %w", err)
+	}
+	preprocessedInput, appliedSteps, err := u.pipeline.ProcessAudio(ctx, audioInput, capabilities, enhancers,
+		func(step pipeline.AppliedStep) { u.reportPreprocessingStep(job.ID, procCtx, step) })
+	if len(appliedSteps) > 0 {
+		if stepsJSON, err := json.Marshal(appliedSteps); err == nil {
+			stepsStr := string(stepsJSON)
+			execution.Preprocessing = &stepsStr
 		}
 	}
 
+	// Track temporary file for cleanup if preprocessing created one
+	if preprocessedInput.TempFilePath != "" && preprocessedInput.TempFilePath != audioInput.FilePath {
+		tempFilesToCleanup = append(tempFilesToCleanup, preprocessedInput.TempFilePath)
+	}
+	if err != nil {
+		for _, tempFile := range tempFilesToCleanup {
+			_ = os.Remove(tempFile)
+		}
+		return fmt.Errorf("audio preprocessing failed: %w", err)
+	}
+	if len(tempFilesToCleanup) > 0 {
+		logger.Info("Audio preprocessing completed",
+			"original", audioInput.FilePath,
+			"converted", preprocessedInput.TempFilePath,
+			"original_sr", audioInput.SampleRate,
+			"converted_sr", preprocessedInput.SampleRate,
+			"original_channels", audioInput.Channels,
+			"converted_channels", preprocessedInput.Channels)
+	}
+
 	// Ensure cleanup of temporary files when function exits
 	defer func() {
 		for _, tempFile := range tempFilesToCleanup {
@@ -299,50 +420,40 @@ func (u *UnifiedTranscriptionService) processSingleTrackJob(ctx context.Context,
 
 	var transcriptResult *interfaces.TranscriptResult
 	var diarizationResult *interfaces.DiarizationResult
+	var attempts []ModelAttempt
+	defer u.recordModels(execution, &attempts)
 
-	// Perform transcription using the preprocessed audio
-	if transcriptionModelID != "" {
-		logger.Info("Running transcription", "model_id", transcriptionModelID)
-		transcriptionAdapter, err := u.registry.GetTranscriptionAdapter(transcriptionModelID)
-		if err != nil {
-			return fmt.Errorf("failed to get transcription adapter: %w", err)
-		}
+	// Perform transcription using the preprocessed audio, moving down the
+	// fallback chain when a model fails
+	transcriptResult, transcription, err := u.transcribeWithFallbacks(ctx, transcriptionChain, preprocessedInput, procCtx, &attempts)
+	if err != nil {
+		return fmt.Errorf("transcription failed: %w", err)
+	}
+	execution.ActualParameters.ModelFamily = transcription.Params.ModelFamily
+	execution.ActualParameters.Model = transcription.Params.Model
 
-		// Convert parameters for this specific model
-		params := u.convertParametersForModel(job.Parameters, transcriptionModelID)
+	// Models without word timings get them from forced alignment, before a
+	// separate diarization assigns speakers per word
+	u.alignTranscription(ctx, transcription.Params, transcriptResult, preprocessedInput, procCtx)
 
-		// Check if audio needs splitting (>25MB or >25min)
-		transcriptResult, err = u.transcribeWithSplitting(ctx, transcriptionAdapter, preprocessedInput, params, procCtx)
+	// Perform diarization if requested and not already done by transcription
+	if len(diarizationChain) > 0 && !u.transcriptionIncludesDiarization(transcription.ModelID, transcription.Params) {
+		var diarization modelCandidate
+		diarizationResult, diarization, err = u.diarizeWithFallbacks(ctx, diarizationChain, preprocessedInput, procCtx, &attempts)
 		if err != nil {
-			return fmt.Errorf("transcription failed: %w", err)
+			return fmt.Errorf("diarization failed: %w", err)
 		}
-	}
-
-	// Perform diarization if requested and not already done by transcription
-	if job.Parameters.Diarize && diarizationModelID != "" {
-		// Convert parameters for diarization model
-		diarizationParams := u.convertParametersForModel(job.Parameters, diarizationModelID)
-
-		if !u.transcriptionIncludesDiarization(transcriptionModelID, job.Parameters) {
-			logger.Info("Running separate diarization", "model_id", diarizationModelID)
-			diarizationAdapter, err := u.registry.GetDiarizationAdapter(diarizationModelID)
-			if err != nil {
-				return fmt.Errorf("failed to get diarization adapter: %w", err)
-			}
+		execution.ActualParameters.DiarizeModel = diarization.Params.DiarizeModel
 
-			// Use the same preprocessed audio for diarization
-			diarizationResult, err = diarizationAdapter.Diarize(ctx, preprocessedInput, diarizationParams, procCtx)
-			if err != nil {
-				return fmt.Errorf("diarization failed: %w", err)
-			}
-
-			// Merge diarization results with transcription
-			if transcriptResult != nil && diarizationResult != nil {
-				transcriptResult = u.mergeDiarizationWithTranscription(transcriptResult, diarizationResult)
-			}
+		// Merge diarization results with transcription
+		if transcriptResult != nil && diarizationResult != nil {
+			transcriptResult = u.mergeDiarizationWithTranscription(transcriptResult, diarizationResult, transcription.Params.SpeakerAssignment)
 		}
 	}
 
+	// Timestamps refer to the trimmed audio, map them back onto the recording
+	pipeline.RestoreTimestamps(transcriptResult, pipeline.TrimOffset(preprocessedInput))
+
 	// Apply AI post-processing if enabled
 	if transcriptResult != nil && u.aiPostprocessor != nil {
 		// Save original STT output before AI post-processing (for potential reprocessing)
@@ -363,11 +474,72 @@ func (u *UnifiedTranscriptionService) processSingleTrackJob(ctx context.Context,
 		if err := u.saveTranscriptionResults(job.ID, transcriptResult); err != nil {
 			return fmt.Errorf("failed to save transcription results: %w", err)
 		}
+
+		// Name the speakers recognised from the speaker library
+		matches, err := u.IdentifySpeakers(ctx, job, transcriptResult)
+		if err != nil {
+			logger.Warn("Failed to match speakers against the speaker library", "job_id", job.ID, "error", err)
+			appendJobLog(procCtx.OutputDirectory, fmt.Sprintf("Speaker library matching failed: %v", err))
+		} else if len(matches) > 0 {
+			appendJobLog(procCtx.OutputDirectory, fmt.Sprintf("Recognised %d speakers from the speaker library", len(matches)))
+		}
+	}
+
+	// The job is done, so its chunk checkpoints are no longer needed
+	if err := os.RemoveAll(splitter.CheckpointDir(u.outputDirectory, job.ID)); err != nil {
+		logger.Warn("Failed to remove chunk checkpoint", "job_id", job.ID, "error", err)
 	}
 
 	return nil
 }
 
+// runTranscription transcribes the audio with one model of the fallback chain
+func (u *UnifiedTranscriptionService) runTranscription(ctx context.Context, candidate modelCandidate, input interfaces.AudioInput, procCtx interfaces.ProcessingContext) (*interfaces.TranscriptResult, error) {
+	adapter, err := u.registry.GetTranscriptionAdapter(candidate.ModelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transcription adapter: %w", err)
+	}
+
+	// Convert parameters for this specific model
+	params := u.convertParametersForModel(candidate.Params, candidate.ModelID)
+
+	// Check if audio exceeds the model's chunking limits
+	splitOpts := splitOptions(candidate.Params, adapter.GetCapabilities().Chunking)
+	return u.transcribeWithSplitting(ctx, adapter, input, params, splitOpts, procCtx)
+}
+
+// runDiarization diarizes the audio with one model of the fallback chain
+func (u *UnifiedTranscriptionService) runDiarization(ctx context.Context, candidate modelCandidate, input interfaces.AudioInput, procCtx interfaces.ProcessingContext) (*interfaces.DiarizationResult, error) {
+	adapter, err := u.registry.GetDiarizationAdapter(candidate.ModelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diarization adapter: %w", err)
+	}
+	return adapter.Diarize(ctx, input, u.convertParametersForModel(candidate.Params, candidate.ModelID), procCtx)
+}
+
+// recordModels stores the models that produced the result on the execution,
+// along with every model tried
+func (u *UnifiedTranscriptionService) recordModels(execution *models.TranscriptionJobExecution, attempts *[]ModelAttempt) {
+	if len(*attempts) == 0 {
+		return
+	}
+	for _, attempt := range *attempts {
+		if attempt.Status != AttemptSucceeded {
+			continue
+		}
+		modelID := attempt.ModelID
+		if attempt.Stage == "transcription" {
+			execution.TranscriptionModel = &modelID
+		} else {
+			execution.DiarizationModel = &modelID
+		}
+	}
+	if attemptsJSON, err := json.Marshal(*attempts); err == nil {
+		attemptsStr := string(attemptsJSON)
+		execution.ModelAttempts = &attemptsStr
+	}
+}
+
 // processMultiTrackJob handles multi-track audio processing
 func (u *UnifiedTranscriptionService) processMultiTrackJob(ctx context.Context, job *models.TranscriptionJob) error {
 	logger.Info("Processing multi-track job", "job_id", job.ID, "track_count", len(job.MultiTrackFiles))
@@ -402,45 +574,6 @@ func (u *UnifiedTranscriptionService) IsMultiTrackJob(jobID string) bool {
 	return job.IsMultiTrack
 }
 
-// selectModels determines which models to use based on job parameters
-func (u *UnifiedTranscriptionService) selectModels(params models.WhisperXParams) (transcriptionModelID, diarizationModelID string, err error) {
-	// Determine transcription model
-	switch params.ModelFamily {
-	case FamilyNvidiaParakeet:
-		transcriptionModelID = ModelParakeet
-	case FamilyNvidiaCanary:
-		transcriptionModelID = ModelCanary
-	case FamilyWhisper:
-		transcriptionModelID = ModelWhisperX
-	case FamilyOpenAI:
-		transcriptionModelID = ModelOpenAI
-	case FamilyMistralVoxtral:
-		transcriptionModelID = ModelVoxtral
-	default:
-		transcriptionModelID = ModelWhisperX // Default fallback
-	}
-
-	// Determine diarization model if needed
-	if params.Diarize {
-		switch params.DiarizeModel {
-		case DiarizeSortformer:
-			diarizationModelID = ModelSortformer
-		case ModelPyannote, ModelDiarization31:
-			diarizationModelID = ModelPyannote
-		default:
-			diarizationModelID = ModelPyannote // Default fallback
-		}
-	}
-
-	logger.Info("Selected models",
-		"transcription", transcriptionModelID,
-		"diarization", diarizationModelID,
-		"original_family", params.ModelFamily,
-		"original_diarize_model", params.DiarizeModel)
-
-	return transcriptionModelID, diarizationModelID, nil
-}
-
 // transcriptionIncludesDiarization checks if the transcription model already includes diarization
 func (u *UnifiedTranscriptionService) transcriptionIncludesDiarization(modelID string, params models.WhisperXParams) bool {
 	// WhisperX includes diarization when enabled
@@ -458,16 +591,91 @@ func (u *UnifiedTranscriptionService) transcriptionIncludesDiarization(modelID s
 	return false
 }
 
+// reportPreprocessingStep writes the outcome of a preprocessing step to the job
+// log and sends it as a progress event
+func (u *UnifiedTranscriptionService) reportPreprocessingStep(jobID string, procCtx interfaces.ProcessingContext, step pipeline.AppliedStep) {
+	message := fmt.Sprintf("Preprocessing %s (%s): %s in %dms", step.Name, step.Policy, step.Status, step.DurationMs)
+	if step.Fallback != "" {
+		message += ", fell back to " + step.Fallback
+	}
+	if step.Error != "" {
+		message += ": " + step.Error
+	}
+	appendJobLog(procCtx.OutputDirectory, message)
+
+	if u.broadcaster != nil {
+		u.broadcaster.Broadcast(jobID, "job_progress", map[string]interface{}{
+			"job_id":        jobID,
+			"stage":         "preprocessing",
+			"preprocessing": step,
+			"message":       message,
+		})
+	}
+}
+
+// appendJobLog adds a timestamped line to the job's transcription.log
+func appendJobLog(outputDir, message string) {
+	logPath := filepath.Join(outputDir, "transcription.log")
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		logger.Error("Failed to open log file", "path", logPath, "error", err)
+		return
+	}
+	defer f.Close()
+
+	fmt.Fprintf(f, "[%s] %s\n", time.Now().Format("2006-01-02 15:04:05"), message)
+}
+
+// enhancementOptions reads the audio enhancement chain of a profile
+func enhancementOptions(params models.WhisperXParams) pipeline.EnhancementOptions {
+	opts := pipeline.EnhancementOptions{
+		LoudnessTarget:    params.LoudnessTarget,
+		DenoiseMethod:     params.DenoiseMethod,
+		HighpassFrequency: params.HighpassFrequency,
+	}
+	if params.Preprocessors != nil {
+		opts.Steps = pipeline.ParseSteps(*params.Preprocessors)
+	}
+	if params.DenoiseModel != nil {
+		opts.DenoiseModel = *params.DenoiseModel
+	}
+	return opts
+}
+
+// splitOptions combines the chunk limits a model declares with the splitting
+// settings of a profile. Limits set in the profile take precedence.
+func splitOptions(params models.WhisperXParams, limits interfaces.ChunkLimits) splitter.SplitOptions {
+	opts := splitter.SplitOptions{
+		MaxFileSizeBytes:     limits.MaxFileSizeBytes,
+		MaxDurationSeconds:   limits.MaxDurationSeconds,
+		ChunkDurationSeconds: limits.ChunkDurationSeconds,
+		Strategy:             params.SplitStrategy,
+		SilenceWindow:        params.SplitSilenceWindow,
+		Overlap:              params.SplitOverlap,
+	}
+	if params.SplitMaxFileSizeMB > 0 {
+		opts.MaxFileSizeBytes = int64(params.SplitMaxFileSizeMB * 1024 * 1024)
+	}
+	if params.SplitMaxDuration > 0 {
+		opts.MaxDurationSeconds = params.SplitMaxDuration
+	}
+	if params.SplitChunkDuration > 0 {
+		opts.ChunkDurationSeconds = params.SplitChunkDuration
+	}
+	return opts
+}
+
 // transcribeWithSplitting handles transcription with automatic audio splitting for large files
 func (u *UnifiedTranscriptionService) transcribeWithSplitting(
 	ctx context.Context,
 	adapter interfaces.TranscriptionAdapter,
 	input interfaces.AudioInput,
 	params map[string]interface{},
+	splitOpts splitter.SplitOptions,
 	procCtx interfaces.ProcessingContext,
 ) (*interfaces.TranscriptResult, error) {
 	// Check if splitting is needed
-	splitResult, err := u.audioSplitter.Split(ctx, input, procCtx.JobID)
+	splitResult, err := u.audioSplitter.Split(ctx, input, procCtx.JobID, splitOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to split audio: %w", err)
 	}
@@ -488,12 +696,36 @@ func (u *UnifiedTranscriptionService) transcribeWithSplitting(
 	modelName, _ := params["model"].(string)
 	useSpeakerRefs := strings.Contains(modelName, "diarize") && len(splitResult.Chunks) > 1
 
-	// Process each chunk
-	results := make([]*interfaces.TranscriptResult, 0, len(splitResult.Chunks))
+	// Resume from chunks finished by an earlier, interrupted run of this job
+	checkpoint := u.openCheckpoint(procCtx.JobID, splitResult.Chunks, params)
+
+	// API adapters transcribe several chunks at once, within their rate limit
+	modelID := adapter.GetCapabilities().ModelID
+	concurrency := chunkConcurrency(modelID)
+	limiter := chunkLimiter(modelID)
+
+	results := make([]*interfaces.TranscriptResult, len(splitResult.Chunks))
 	var speakerSamples []splitter.SpeakerSample
-	speakerRefsUsed := false
+	if checkpoint != nil {
+		speakerSamples = checkpoint.SpeakerSamples
+	}
+	disableSpeakerRefs := os.Getenv("DISABLE_SPEAKER_REFS") == "true"
+
+	// transcribeChunk transcribes one chunk into its slot of results, so chunks
+	// finishing out of order still merge in order
+	transcribeChunk := func(ctx context.Context, i int, chunkParams map[string]interface{}) (bool, error) {
+		chunk := splitResult.Chunks[i]
+		if checkpoint != nil {
+			if result := checkpoint.Result(i); result != nil {
+				logger.Info("Skipping chunk completed before restart",
+					"job_id", procCtx.JobID,
+					"chunk", i+1,
+					"total", len(splitResult.Chunks))
+				results[i] = result
+				return false, nil
+			}
+		}
 
-	for i, chunk := range splitResult.Chunks {
 		logger.Info("Processing chunk",
 			"job_id", procCtx.JobID,
 			"chunk", i+1,
@@ -516,35 +748,39 @@ func (u *UnifiedTranscriptionService) transcribeWithSplitting(
 			chunkInput.Size = fi.Size()
 		}
 
-		// For chunks after the first, add speaker references if available
-		// Can be disabled via DISABLE_SPEAKER_REFS=true for debugging
-		chunkParams := params
-		disableSpeakerRefs := os.Getenv("DISABLE_SPEAKER_REFS") == "true"
-		if i > 0 && len(speakerSamples) > 0 && !disableSpeakerRefs {
-			// Create copy of params with speaker references
-			chunkParams = make(map[string]interface{})
-			for k, v := range params {
-				chunkParams[k] = v
-			}
-			chunkParams["known_speaker_references"] = splitter.ToSpeakerReferences(speakerSamples)
-			speakerRefsUsed = true
-			logger.Debug("Using speaker references for chunk consistency", "chunk", i+1, "speakers", len(speakerSamples))
-		} else if i > 0 && disableSpeakerRefs {
-			logger.Info("Speaker references disabled via DISABLE_SPEAKER_REFS env var", "chunk", i+1)
+		if err := limiter.Wait(ctx); err != nil {
+			return false, err
 		}
 
 		// Transcribe this chunk
 		result, err := adapter.Transcribe(ctx, chunkInput, chunkParams, procCtx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to transcribe chunk %d: %w", i+1, err)
+			return false, fmt.Errorf("failed to transcribe chunk %d: %w", i+1, err)
 		}
 
-		results = append(results, result)
+		results[i] = result
+		if checkpoint != nil {
+			if err := checkpoint.SaveChunk(i, result); err != nil {
+				logger.Warn("Failed to checkpoint chunk", "job_id", procCtx.JobID, "chunk", i+1, "error", err)
+			}
+		}
+		return true, nil
+	}
+
+	// Diarize models label later chunks using speakers heard in the first one,
+	// so the first chunk is transcribed on its own before the rest fan out
+	first := 0
+	if useSpeakerRefs {
+		transcribed, err := transcribeChunk(ctx, 0, params)
+		if err != nil {
+			return nil, err
+		}
+		first = 1
 
 		// After first chunk, extract speaker samples for subsequent chunks
-		if i == 0 && useSpeakerRefs {
+		if transcribed {
 			sampleDir := filepath.Join(procCtx.TempDirectory, procCtx.JobID)
-			samples, err := splitter.ExtractSpeakerSamples(ctx, result, chunk.FilePath, sampleDir)
+			samples, err := splitter.ExtractSpeakerSamples(ctx, results[0], splitResult.Chunks[0].FilePath, sampleDir)
 			if err != nil {
 				logger.Warn("Failed to extract speaker samples, continuing without references",
 					"job_id", procCtx.JobID, "error", err)
@@ -555,10 +791,50 @@ func (u *UnifiedTranscriptionService) transcribeWithSplitting(
 					"speakers", len(samples))
 				// Cleanup samples when done
 				defer splitter.CleanupSpeakerSamples(samples)
+				if checkpoint != nil {
+					if err := checkpoint.SaveSpeakerSamples(samples); err != nil {
+						logger.Warn("Failed to checkpoint speaker samples", "job_id", procCtx.JobID, "error", err)
+					}
+				}
 			}
 		}
 	}
 
+	// For chunks after the first, add speaker references if available
+	// Can be disabled via DISABLE_SPEAKER_REFS=true for debugging
+	restParams := params
+	speakerRefsUsed := false
+	if len(speakerSamples) > 0 && !disableSpeakerRefs {
+		// Create copy of params with speaker references
+		restParams = make(map[string]interface{})
+		for k, v := range params {
+			restParams[k] = v
+		}
+		restParams["known_speaker_references"] = splitter.ToSpeakerReferences(speakerSamples)
+		speakerRefsUsed = true
+		logger.Debug("Using speaker references for chunk consistency", "speakers", len(speakerSamples))
+	} else if useSpeakerRefs && disableSpeakerRefs {
+		logger.Info("Speaker references disabled via DISABLE_SPEAKER_REFS env var", "job_id", procCtx.JobID)
+	}
+
+	if first < len(splitResult.Chunks) && concurrency > 1 {
+		logger.Info("Transcribing chunks in parallel",
+			"job_id", procCtx.JobID,
+			"concurrency", concurrency)
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(concurrency)
+	for i := first; i < len(splitResult.Chunks); i++ {
+		group.Go(func() error {
+			_, err := transcribeChunk(groupCtx, i, restParams)
+			return err
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
 	// Merge all results with speaker reference flag
 	merged := splitter.MergeResults(results, splitResult.Chunks, speakerRefsUsed)
 
@@ -570,6 +846,35 @@ func (u *UnifiedTranscriptionService) transcribeWithSplitting(
 	return merged, nil
 }
 
+// openCheckpoint opens the chunk checkpoint of a job. Chunking still works
+// without one, so failures are logged and nil is returned.
+func (u *UnifiedTranscriptionService) openCheckpoint(jobID string, chunks []splitter.ChunkInfo, params map[string]interface{}) *splitter.Checkpoint {
+	fingerprint, err := splitter.Fingerprint(chunks, params)
+	if err != nil {
+		logger.Warn("Chunk checkpoints disabled", "job_id", jobID, "error", err)
+		return nil
+	}
+
+	checkpoint, err := splitter.OpenCheckpoint(splitter.CheckpointDir(u.outputDirectory, jobID), fingerprint, len(chunks))
+	if err != nil {
+		logger.Warn("Chunk checkpoints disabled", "job_id", jobID, "error", err)
+		return nil
+	}
+
+	if completed := checkpoint.Completed(); completed > 0 {
+		logger.Info("Resuming job from chunk checkpoint",
+			"job_id", jobID,
+			"completed_chunks", completed,
+			"total", len(chunks))
+	}
+	return checkpoint
+}
+
+// HasCheckpoint reports whether an interrupted job left finished chunks to resume from
+func (u *UnifiedTranscriptionService) HasCheckpoint(jobID string) bool {
+	return splitter.HasCheckpoint(splitter.CheckpointDir(u.outputDirectory, jobID))
+}
+
 // ffprobeOutput represents the JSON output from ffprobe
 type ffprobeOutput struct {
 	Streams []struct {
@@ -586,6 +891,27 @@ type ffprobeOutput struct {
 	} `json:"format"`
 }
 
+// prepareRecordingAudio converts a job's recording for a model that works on
+// its finished transcript. The transcript's timestamps refer to the recording,
+// so the audio is only converted to the format the model needs, without
+// enhancement or trimming. The returned func removes the converted file.
+func (u *UnifiedTranscriptionService) prepareRecordingAudio(ctx context.Context, audioPath string, capabilities interfaces.ModelCapabilities) (interfaces.AudioInput, func(), error) {
+	audioInput, err := u.createAudioInput(audioPath)
+	if err != nil {
+		return interfaces.AudioInput{}, nil, fmt.Errorf("failed to create audio input: %w", err)
+	}
+	preprocessedInput, _, err := u.pipeline.ProcessAudio(ctx, audioInput, capabilities, nil, nil)
+	cleanup := func() {}
+	if preprocessedInput.TempFilePath != "" && preprocessedInput.TempFilePath != audioInput.FilePath {
+		cleanup = func() { _ = os.Remove(preprocessedInput.TempFilePath) }
+	}
+	if err != nil {
+		cleanup()
+		return interfaces.AudioInput{}, nil, fmt.Errorf("audio preprocessing failed: %w", err)
+	}
+	return preprocessedInput, cleanup, nil
+}
+
 // createAudioInput creates an AudioInput from a file path with real metadata
 func (u *UnifiedTranscriptionService) createAudioInput(audioPath string) (interfaces.AudioInput, error) {
 	// Get file info
@@ -706,10 +1032,17 @@ func (u *UnifiedTranscriptionService) convertParametersForModel(params models.Wh
 		return u.convertToOpenAIParams(params)
 	case ModelVoxtral:
 		return u.convertToVoxtralParams(params)
-	default:
-		// Fallback to legacy conversion
-		return u.parametersToMap(params)
+	case ModelWhisperCpp:
+		return u.convertToWhisperCppParams(params)
+	}
+
+	// Self-hosted servers accept the same parameters as OpenAI
+	if capabilities, err := u.registry.GetCapabilities(modelID); err == nil && capabilities.ModelFamily == adapters.FamilyOpenAICompatible {
+		return u.convertToOpenAIParams(params)
 	}
+
+	// Fallback to legacy conversion
+	return u.parametersToMap(params)
 }
 
 // convertToOpenAIParams converts to OpenAI-specific parameters
@@ -734,6 +1067,28 @@ func (u *UnifiedTranscriptionService) convertToOpenAIParams(params models.Whispe
 	return paramMap
 }
 
+// convertToWhisperCppParams converts to whisper.cpp-specific parameters
+func (u *UnifiedTranscriptionService) convertToWhisperCppParams(params models.WhisperXParams) map[string]interface{} {
+	paramMap := map[string]interface{}{
+		"model":                             params.Model,
+		"task":                              params.Task,
+		"threads":                           params.Threads,
+		"beam_size":                         params.BeamSize,
+		"best_of":                           params.BestOf,
+		"temperature":                       params.Temperature,
+		"temperature_increment_on_fallback": params.TemperatureIncrementOnFallback,
+	}
+
+	if params.Language != nil {
+		paramMap["language"] = *params.Language
+	}
+	if params.InitialPrompt != nil {
+		paramMap["initial_prompt"] = *params.InitialPrompt
+	}
+
+	return paramMap
+}
+
 // convertToVoxtralParams converts to Voxtral-specific parameters
 func (u *UnifiedTranscriptionService) convertToVoxtralParams(params models.WhisperXParams) map[string]interface{} {
 	paramMap := map[string]interface{}{}
@@ -755,13 +1110,25 @@ func (u *UnifiedTranscriptionService) convertToVoxtralParams(params models.Whisp
 
 // convertToParakeetParams converts to Parakeet-specific parameters
 func (u *UnifiedTranscriptionService) convertToParakeetParams(params models.WhisperXParams) map[string]interface{} {
-	return map[string]interface{}{
+	paramMap := map[string]interface{}{
 		"timestamps":         true,
 		"context_left":       params.AttentionContextLeft,
 		"context_right":      params.AttentionContextRight,
 		"output_format":      OutputFormatJSON,
 		"auto_convert_audio": true,
 	}
+
+	// Selects a custom model, the bundled one is used otherwise
+	if params.Model != "" {
+		paramMap["model"] = params.Model
+	}
+
+	// Parakeet chunks long audio itself, so the profile's chunk length goes to the model
+	if params.SplitChunkDuration > 0 {
+		paramMap["chunk_length"] = int(params.SplitChunkDuration)
+	}
+
+	return paramMap
 }
 
 // convertToCanaryParams converts to Canary-specific parameters
@@ -773,6 +1140,11 @@ func (u *UnifiedTranscriptionService) convertToCanaryParams(params models.Whispe
 		"task":               params.Task,
 	}
 
+	// Selects a custom model, the bundled one is used otherwise
+	if params.Model != "" {
+		paramMap["model"] = params.Model
+	}
+
 	// Set source language
 	if params.Language != nil {
 		paramMap["source_lang"] = *params.Language
@@ -963,14 +1335,32 @@ func (u *UnifiedTranscriptionService) parametersToMap(params models.WhisperXPara
 	return paramMap
 }
 
-// mergeDiarizationWithTranscription combines diarization results with transcription
-func (u *UnifiedTranscriptionService) mergeDiarizationWithTranscription(transcript *interfaces.TranscriptResult, diarization *interfaces.DiarizationResult) *interfaces.TranscriptResult {
+// mergeDiarizationWithTranscription combines diarization results with transcription.
+// The word strategy labels each word and splits segments where the speaker
+// changes, the segment strategy gives each segment the speaker it overlaps most.
+// Transcripts without word timings are always merged per segment.
+func (u *UnifiedTranscriptionService) mergeDiarizationWithTranscription(transcript *interfaces.TranscriptResult, diarization *interfaces.DiarizationResult, strategy string) *interfaces.TranscriptResult {
+	if strategy != SpeakerAssignmentSegment && len(transcript.WordSegments) == 0 {
+		strategy = SpeakerAssignmentSegment
+	}
 	logger.Info("Merging diarization with transcription",
 		"transcript_segments", len(transcript.Segments),
-		"diarization_segments", len(diarization.Segments))
+		"diarization_segments", len(diarization.Segments),
+		"strategy", strategy)
 
 	// Create a copy of the transcript to avoid modifying the original
 	mergedTranscript := *transcript
+	if len(transcript.WordSegments) > 0 {
+		mergedTranscript.WordSegments = make([]interfaces.TranscriptWord, len(transcript.WordSegments))
+		copy(mergedTranscript.WordSegments, transcript.WordSegments)
+	}
+
+	if strategy != SpeakerAssignmentSegment {
+		assignWordSpeakers(mergedTranscript.WordSegments, diarization.Segments)
+		mergedTranscript.Segments = u.splitSegmentsBySpeaker(transcript.Segments, mergedTranscript.WordSegments, diarization.Segments, transcript.Language)
+		return &mergedTranscript
+	}
+
 	mergedTranscript.Segments = make([]interfaces.TranscriptSegment, len(transcript.Segments))
 	copy(mergedTranscript.Segments, transcript.Segments)
 
@@ -984,16 +1374,11 @@ func (u *UnifiedTranscriptionService) mergeDiarizationWithTranscription(transcri
 	}
 
 	// Also assign speakers to words if available
-	if len(transcript.WordSegments) > 0 {
-		mergedTranscript.WordSegments = make([]interfaces.TranscriptWord, len(transcript.WordSegments))
-		copy(mergedTranscript.WordSegments, transcript.WordSegments)
-
-		for i := range mergedTranscript.WordSegments {
-			word := &mergedTranscript.WordSegments[i]
-			bestSpeaker := u.findBestSpeakerForSegment(word.Start, word.End, diarization.Segments)
-			if bestSpeaker != "" {
-				word.Speaker = &bestSpeaker
-			}
+	for i := range mergedTranscript.WordSegments {
+		word := &mergedTranscript.WordSegments[i]
+		bestSpeaker := u.findBestSpeakerForSegment(word.Start, word.End, diarization.Segments)
+		if bestSpeaker != "" {
+			word.Speaker = &bestSpeaker
 		}
 	}
 
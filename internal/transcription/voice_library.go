@@ -0,0 +1,388 @@
+package transcription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"scriberr/internal/models"
+	"scriberr/internal/repository"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/splitter"
+	"scriberr/pkg/logger"
+)
+
+// DefaultVoiceMatchThreshold is the cosine similarity a diarized speaker
+// needs with a voice of the speaker library to be named after it
+const DefaultVoiceMatchThreshold = 0.7
+
+// ErrNoSpeakerEmbedder is returned when no ready adapter computes voice embeddings
+var ErrNoSpeakerEmbedder = errors.New("no speaker embedding model is ready")
+
+// VoiceMatch is a diarized speaker recognised as a voice of the speaker library
+type VoiceMatch struct {
+	Speaker string  `json:"speaker"`
+	VoiceID string  `json:"voice_id"`
+	Score   float64 `json:"score"` // Cosine similarity with the voice
+}
+
+// SetVoiceLibrary enables naming diarized speakers after the voices of the
+// speaker library they match
+func (u *UnifiedTranscriptionService) SetVoiceLibrary(voices repository.VoiceRepository, speakerMappings repository.SpeakerMappingRepository, threshold float64) {
+	u.voiceRepo = voices
+	u.speakerMappingRepo = speakerMappings
+	u.voiceMatchThreshold = threshold
+}
+
+// speakerEmbedder returns a ready adapter that computes voice embeddings,
+// PyAnnote ahead of others
+func (u *UnifiedTranscriptionService) speakerEmbedder(ctx context.Context) (interfaces.DiarizationAdapter, interfaces.SpeakerEmbedder, error) {
+	modelIDs := u.registry.GetDiarizationModels()
+	sort.SliceStable(modelIDs, func(i, j int) bool { return modelIDs[i] == ModelPyannote && modelIDs[j] != ModelPyannote })
+	for _, modelID := range modelIDs {
+		adapter, err := u.registry.GetDiarizationAdapter(modelID)
+		if err != nil {
+			continue
+		}
+		if embedder, ok := adapter.(interfaces.SpeakerEmbedder); ok && adapter.IsReady(ctx) {
+			return adapter, embedder, nil
+		}
+	}
+	return nil, nil, ErrNoSpeakerEmbedder
+}
+
+// EmbedVoice computes the voice embedding of a recording of one person
+func (u *UnifiedTranscriptionService) EmbedVoice(ctx context.Context, audioPath string, hfToken string) (*models.VoiceEmbedding, error) {
+	adapter, embedder, err := u.speakerEmbedder(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	audioInput, err := u.createAudioInput(audioPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audio input: %w", err)
+	}
+	preprocessedInput, _, err := u.pipeline.ProcessAudio(ctx, audioInput, adapter.GetCapabilities(), nil, nil)
+	if preprocessedInput.TempFilePath != "" && preprocessedInput.TempFilePath != audioInput.FilePath {
+		defer func() { _ = os.Remove(preprocessedInput.TempFilePath) }()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("audio preprocessing failed: %w", err)
+	}
+
+	procCtx := interfaces.ProcessingContext{TempDirectory: u.tempDirectory, Metadata: map[string]string{}}
+	vectors, err := embedder.EmbedSpeakers(ctx, []string{preprocessedInput.FilePath}, map[string]interface{}{"hf_token": hfToken}, procCtx)
+	if err != nil {
+		return nil, err
+	}
+	embedding := &models.VoiceEmbedding{Model: embedder.EmbeddingModel()}
+	if err := embedding.SetValues(vectors[0]); err != nil {
+		return nil, fmt.Errorf("failed to encode embedding: %w", err)
+	}
+	return embedding, nil
+}
+
+// embedJobSpeakers computes an embedding of each speaker of a transcript from
+// a reference clip of the job's audio and stores them for the job
+func (u *UnifiedTranscriptionService) embedJobSpeakers(ctx context.Context, job *models.TranscriptionJob, result *interfaces.TranscriptResult, embedder interfaces.SpeakerEmbedder) (map[string]models.VoiceEmbedding, error) {
+	sampleDir := filepath.Join(u.tempDirectory, job.ID, "voices")
+	samples, err := splitter.ExtractSpeakerSamples(ctx, result, job.AudioPath, sampleDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract speaker samples: %w", err)
+	}
+	defer splitter.CleanupSpeakerSamples(samples)
+	if len(samples) == 0 {
+		return nil, nil
+	}
+
+	clips := make([]string, len(samples))
+	for i, sample := range samples {
+		clips[i] = sample.FilePath
+	}
+	params := map[string]interface{}{}
+	if job.Parameters.HfToken != nil {
+		params["hf_token"] = *job.Parameters.HfToken
+	}
+	procCtx := interfaces.ProcessingContext{
+		JobID:           job.ID,
+		OutputDirectory: filepath.Join(u.outputDirectory, job.ID),
+		TempDirectory:   u.tempDirectory,
+		Metadata:        map[string]string{},
+	}
+	vectors, err := embedder.EmbedSpeakers(ctx, clips, params, procCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings := make([]models.VoiceEmbedding, len(samples))
+	for i, sample := range samples {
+		speaker := sample.Speaker
+		embeddings[i] = models.VoiceEmbedding{
+			TranscriptionJobID: &job.ID,
+			Speaker:            &speaker,
+			Model:              embedder.EmbeddingModel(),
+		}
+		if err := embeddings[i].SetValues(vectors[i]); err != nil {
+			return nil, fmt.Errorf("failed to encode embedding: %w", err)
+		}
+	}
+	if err := u.voiceRepo.ReplaceJobEmbeddings(ctx, job.ID, embeddings); err != nil {
+		return nil, fmt.Errorf("failed to save speaker embeddings: %w", err)
+	}
+
+	bySpeaker := make(map[string]models.VoiceEmbedding, len(embeddings))
+	for _, embedding := range embeddings {
+		bySpeaker[*embedding.Speaker] = embedding
+	}
+	return bySpeaker, nil
+}
+
+// EmbedJobSpeaker returns the stored embedding of a job's speaker, computing
+// the embeddings of the job's speakers first if there is none
+func (u *UnifiedTranscriptionService) EmbedJobSpeaker(ctx context.Context, job *models.TranscriptionJob, speaker string) (*models.VoiceEmbedding, error) {
+	if u.voiceRepo == nil {
+		return nil, fmt.Errorf("speaker library is not configured")
+	}
+	if embedding, err := u.voiceRepo.FindJobEmbedding(ctx, job.ID, speaker); err == nil {
+		return embedding, nil
+	}
+
+	if job.Transcript == nil || *job.Transcript == "" {
+		return nil, fmt.Errorf("no transcript found for job %s", job.ID)
+	}
+	var result interfaces.TranscriptResult
+	if err := json.Unmarshal([]byte(*job.Transcript), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse transcript: %w", err)
+	}
+	_, embedder, err := u.speakerEmbedder(ctx)
+	if err != nil {
+		return nil, err
+	}
+	embeddings, err := u.embedJobSpeakers(ctx, job, &result, embedder)
+	if err != nil {
+		return nil, err
+	}
+	embedding, ok := embeddings[speaker]
+	if !ok {
+		return nil, fmt.Errorf("speaker %s has no speech long enough for a voice sample", speaker)
+	}
+	return &embedding, nil
+}
+
+// IdentifySpeakers matches the speakers of a job's transcript against the
+// speaker library and names the recognised ones in the job's speaker
+// mappings. Speakers the user confirmed or named are left alone.
+func (u *UnifiedTranscriptionService) IdentifySpeakers(ctx context.Context, job *models.TranscriptionJob, result *interfaces.TranscriptResult) ([]VoiceMatch, error) {
+	if u.voiceRepo == nil || u.speakerMappingRepo == nil || len(transcriptSpeakerLabels(result)) == 0 {
+		return nil, nil
+	}
+	voices, err := u.voiceRepo.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list voices: %w", err)
+	}
+	if len(voices) == 0 {
+		return nil, nil
+	}
+
+	_, embedder, err := u.speakerEmbedder(ctx)
+	if err != nil {
+		return nil, err
+	}
+	library, err := u.voiceRepo.ListLibraryEmbeddings(ctx, embedder.EmbeddingModel())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list voice embeddings: %w", err)
+	}
+	if len(library) == 0 {
+		return nil, nil
+	}
+
+	embeddings, err := u.embedJobSpeakers(ctx, job, result, embedder)
+	if err != nil {
+		return nil, err
+	}
+	speakers := make(map[string][]float64, len(embeddings))
+	for speaker, embedding := range embeddings {
+		if values, err := embedding.Values(); err == nil {
+			speakers[speaker] = values
+		}
+	}
+
+	matches := matchVoices(speakers, library, u.voiceMatchThreshold)
+	names := make(map[string]string, len(voices))
+	for _, voice := range voices {
+		names[voice.ID] = voice.Name
+	}
+	if err := u.applyVoiceMatches(ctx, job.ID, matches, names); err != nil {
+		return nil, err
+	}
+	logger.Info("Matched speakers against the speaker library", "job_id", job.ID, "speakers", len(speakers), "matches", len(matches))
+	return matches, nil
+}
+
+// ForgetVoiceMatches drops what the speaker library knew about a job whose
+// transcript was replaced without matching its speakers, as remote workers
+// do. Automatic matches are reset to the speaker's label and the job's
+// speaker embeddings are deleted; confirmed and hand-named speakers are kept.
+func (u *UnifiedTranscriptionService) ForgetVoiceMatches(ctx context.Context, jobID string) error {
+	if u.voiceRepo == nil || u.speakerMappingRepo == nil {
+		return nil
+	}
+	if err := u.voiceRepo.DeleteUnassignedByJobID(ctx, jobID); err != nil {
+		return fmt.Errorf("failed to delete speaker embeddings: %w", err)
+	}
+	return u.applyVoiceMatches(ctx, jobID, nil, nil)
+}
+
+// applyVoiceMatches names matched speakers after their voice. Earlier
+// automatic matches that no longer hold are reset to the speaker's label,
+// mappings the user confirmed or named by hand are kept.
+func (u *UnifiedTranscriptionService) applyVoiceMatches(ctx context.Context, jobID string, matches []VoiceMatch, names map[string]string) error {
+	existing, err := u.speakerMappingRepo.ListByJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to list speaker mappings: %w", err)
+	}
+	pending := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		pending[match.Speaker] = true
+	}
+
+	var mappings []models.SpeakerMapping
+	for _, mapping := range existing {
+		automatic := mapping.VoiceID != nil && !mapping.Confirmed
+		replaceable := automatic || (mapping.VoiceID == nil && mapping.CustomName == mapping.OriginalSpeaker)
+		switch {
+		case pending[mapping.OriginalSpeaker] && replaceable:
+			continue
+		case pending[mapping.OriginalSpeaker]:
+			pending[mapping.OriginalSpeaker] = false
+		case automatic:
+			mapping.CustomName = mapping.OriginalSpeaker
+			mapping.VoiceID, mapping.MatchScore = nil, nil
+		}
+		mapping.ID = 0
+		mappings = append(mappings, mapping)
+	}
+	for _, match := range matches {
+		if !pending[match.Speaker] {
+			continue
+		}
+		voiceID, score := match.VoiceID, match.Score
+		mappings = append(mappings, models.SpeakerMapping{
+			TranscriptionJobID: jobID,
+			OriginalSpeaker:    match.Speaker,
+			CustomName:         names[voiceID],
+			VoiceID:            &voiceID,
+			MatchScore:         &score,
+		})
+	}
+	return u.speakerMappingRepo.UpdateMappings(ctx, jobID, mappings)
+}
+
+// matchVoices pairs speakers with the voices they are most similar to, above
+// the threshold. Each voice is given to one speaker at most, best pairs first.
+// A voice is compared by the mean of its embeddings.
+func matchVoices(speakers map[string][]float64, library []models.VoiceEmbedding, threshold float64) []VoiceMatch {
+	sums := make(map[string][]float64)
+	for _, embedding := range library {
+		values, err := embedding.Values()
+		if err != nil || embedding.VoiceID == nil {
+			continue
+		}
+		values = normalize(values)
+		sum, ok := sums[*embedding.VoiceID]
+		if !ok {
+			sums[*embedding.VoiceID] = values
+			continue
+		}
+		if len(sum) == len(values) {
+			for i := range sum {
+				sum[i] += values[i]
+			}
+		}
+	}
+
+	var candidates []VoiceMatch
+	for speaker, values := range speakers {
+		for voiceID, centroid := range sums {
+			if score := cosineSimilarity(values, centroid); score >= threshold {
+				candidates = append(candidates, VoiceMatch{Speaker: speaker, VoiceID: voiceID, Score: score})
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Speaker < candidates[j].Speaker
+	})
+
+	usedSpeakers := make(map[string]bool)
+	usedVoices := make(map[string]bool)
+	var matches []VoiceMatch
+	for _, candidate := range candidates {
+		if usedSpeakers[candidate.Speaker] || usedVoices[candidate.VoiceID] {
+			continue
+		}
+		usedSpeakers[candidate.Speaker] = true
+		usedVoices[candidate.VoiceID] = true
+		matches = append(matches, candidate)
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Speaker < matches[j].Speaker })
+	return matches
+}
+
+// cosineSimilarity returns the cosine of the angle between two vectors, or 0
+// if they cannot be compared
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// normalize scales a vector to unit length
+func normalize(values []float64) []float64 {
+	var norm float64
+	for _, v := range values {
+		norm += v * v
+	}
+	normalized := make([]float64, len(values))
+	if norm == 0 {
+		return normalized
+	}
+	norm = math.Sqrt(norm)
+	for i, v := range values {
+		normalized[i] = v / norm
+	}
+	return normalized
+}
+
+// transcriptSpeakerLabels returns the distinct speakers of a transcript's segments
+func transcriptSpeakerLabels(result *interfaces.TranscriptResult) []string {
+	if result == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var speakers []string
+	for _, segment := range result.Segments {
+		if segment.Speaker != nil && *segment.Speaker != "" && !seen[*segment.Speaker] {
+			seen[*segment.Speaker] = true
+			speakers = append(speakers, *segment.Speaker)
+		}
+	}
+	return speakers
+}
@@ -0,0 +1,78 @@
+package transcription
+
+import (
+	"math"
+	"testing"
+
+	"scriberr/internal/models"
+)
+
+func voiceEmbedding(t *testing.T, voiceID string, values ...float64) models.VoiceEmbedding {
+	t.Helper()
+	embedding := models.VoiceEmbedding{VoiceID: &voiceID, Model: "test"}
+	if err := embedding.SetValues(values); err != nil {
+		t.Fatal(err)
+	}
+	return embedding
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	if got := cosineSimilarity([]float64{1, 0}, []float64{2, 0}); math.Abs(got-1) > 1e-9 {
+		t.Errorf("parallel vectors: got %v", got)
+	}
+	if got := cosineSimilarity([]float64{1, 0}, []float64{0, 3}); math.Abs(got) > 1e-9 {
+		t.Errorf("orthogonal vectors: got %v", got)
+	}
+	if got := cosineSimilarity([]float64{1, 0}, []float64{1, 0, 0}); got != 0 {
+		t.Errorf("mismatched lengths should not compare, got %v", got)
+	}
+	if got := cosineSimilarity([]float64{0, 0}, []float64{1, 0}); got != 0 {
+		t.Errorf("zero vector should not compare, got %v", got)
+	}
+}
+
+func TestMatchVoices(t *testing.T) {
+	library := []models.VoiceEmbedding{
+		voiceEmbedding(t, "alice", 1, 0, 0),
+		voiceEmbedding(t, "alice", 0.9, 0.1, 0),
+		voiceEmbedding(t, "bob", 0, 1, 0),
+	}
+	speakers := map[string][]float64{
+		"SPEAKER_00": {0, 0.95, 0.05},
+		"SPEAKER_01": {1, 0.05, 0},
+		"SPEAKER_02": {0, 0, 1}, // Nobody enrolled
+	}
+
+	matches := matchVoices(speakers, library, DefaultVoiceMatchThreshold)
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %+v", matches)
+	}
+	if matches[0].Speaker != "SPEAKER_00" || matches[0].VoiceID != "bob" {
+		t.Errorf("SPEAKER_00 should be bob, got %+v", matches[0])
+	}
+	if matches[1].Speaker != "SPEAKER_01" || matches[1].VoiceID != "alice" {
+		t.Errorf("SPEAKER_01 should be alice, got %+v", matches[1])
+	}
+	for _, match := range matches {
+		if match.Score < DefaultVoiceMatchThreshold || match.Score > 1+1e-9 {
+			t.Errorf("unexpected score %+v", match)
+		}
+	}
+}
+
+func TestMatchVoicesAssignsEachVoiceOnce(t *testing.T) {
+	library := []models.VoiceEmbedding{voiceEmbedding(t, "alice", 1, 0)}
+	speakers := map[string][]float64{
+		"SPEAKER_00": {0.8, 0.2},
+		"SPEAKER_01": {1, 0.01},
+	}
+
+	matches := matchVoices(speakers, library, 0.5)
+	if len(matches) != 1 || matches[0].Speaker != "SPEAKER_01" {
+		t.Fatalf("alice should only go to the closest speaker, got %+v", matches)
+	}
+
+	if matches := matchVoices(speakers, library, 0.9999999); len(matches) != 0 {
+		t.Errorf("no speaker is above the threshold, got %+v", matches)
+	}
+}
@@ -0,0 +1,180 @@
+package transcription
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/adapters"
+	"scriberr/internal/transcription/interfaces"
+)
+
+// fakeWhisperCli records its arguments and writes a --output-json-full document
+const fakeWhisperCli = `#!/bin/sh
+echo "$@" > "$(dirname "$0")/args.txt"
+while [ $# -gt 0 ]; do
+  if [ "$1" = "--output-file" ]; then out="$2"; fi
+  shift
+done
+cat > "$out.json" <<'EOF'
+{
+  "params": {"model": "ggml-small.bin", "language": "auto", "translate": false},
+  "result": {"language": "de"},
+  "transcription": [
+    {
+      "offsets": {"from": 0, "to": 2000},
+      "text": " Guten Morgen",
+      "tokens": [
+        {"text": "[_BEG_]", "offsets": {"from": 0, "to": 0}, "p": 0.9},
+        {"text": " Gut", "offsets": {"from": 0, "to": 400}, "p": 0.9},
+        {"text": "en", "offsets": {"from": 400, "to": 700}, "p": 0.6},
+        {"text": " Morgen", "offsets": {"from": 800, "to": 1900}, "p": 0.95},
+        {"text": "[_TT_100]", "offsets": {"from": 2000, "to": 2000}, "p": 0.1}
+      ]
+    },
+    {
+      "offsets": {"from": 2000, "to": 3000},
+      "text": "!",
+      "tokens": [{"text": "!", "offsets": {"from": 2000, "to": 2100}, "p": 0.8}]
+    }
+  ]
+}
+EOF
+`
+
+func TestWhisperCppAdapter(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake binary is a shell script")
+	}
+
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "whisper-cli")
+	if err := os.WriteFile(binary, []byte(fakeWhisperCli), 0755); err != nil {
+		t.Fatal(err)
+	}
+	modelsDir := filepath.Join(dir, "models")
+	if err := os.MkdirAll(modelsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modelsDir, "ggml-small.bin"), []byte("ggml"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	adapter := adapters.NewWhisperCppAdapter(binary, modelsDir)
+	if adapter.IsReady(context.Background()) {
+		t.Error("adapter must not be ready before the binary is resolved")
+	}
+	if err := adapter.PrepareEnvironment(context.Background()); err != nil {
+		t.Fatalf("prepare failed: %v", err)
+	}
+	if !adapter.IsReady(context.Background()) {
+		t.Error("expected the adapter to be ready")
+	}
+	if models := adapter.GetSupportedModels(); len(models) != 1 || models[0] != "small" {
+		t.Errorf("expected the installed model, got %v", models)
+	}
+
+	audio := filepath.Join(dir, "audio.wav")
+	if err := os.WriteFile(audio, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), "data/temp", "data/transcripts")
+	params := service.convertToWhisperCppParams(models.WhisperXParams{
+		Model: "small", Task: "translate", Threads: 2, BeamSize: 5, BestOf: 5,
+		InitialPrompt: stringPtr("Meeting notes"),
+	})
+
+	result, err := adapter.Transcribe(context.Background(),
+		interfaces.AudioInput{FilePath: audio, Format: "wav", Size: 4}, params,
+		interfaces.ProcessingContext{JobID: "job", OutputDirectory: dir, TempDirectory: dir})
+	if err != nil {
+		t.Fatalf("transcription failed: %v", err)
+	}
+
+	if result.Language != "de" || result.Text != "Guten Morgen !" || len(result.Segments) != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	// Sub-word tokens are joined, special tokens dropped, and a segment never continues the previous word
+	if len(result.WordSegments) != 3 {
+		t.Fatalf("expected 3 words, got %+v", result.WordSegments)
+	}
+	first := result.WordSegments[0]
+	if first.Word != "Guten" || first.Start != 0 || first.End != 0.7 || first.Score != 0.6 {
+		t.Errorf("unexpected first word: %+v", first)
+	}
+	if result.WordSegments[2].Word != "!" {
+		t.Errorf("unexpected last word: %+v", result.WordSegments[2])
+	}
+
+	args, err := os.ReadFile(filepath.Join(dir, "args.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"--model " + filepath.Join(modelsDir, "ggml-small.bin"), "--language auto", "--translate", "--threads 2", "--beam-size 5", "--prompt Meeting notes"} {
+		if !strings.Contains(string(args), expected) {
+			t.Errorf("expected %q in arguments %q", expected, string(args))
+		}
+	}
+}
+
+func TestWhisperCppConcurrentFirstJobs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake binary is a shell script")
+	}
+
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "whisper-cli")
+	if err := os.WriteFile(binary, []byte(fakeWhisperCli), 0755); err != nil {
+		t.Fatal(err)
+	}
+	modelsDir := filepath.Join(dir, "models")
+	if err := os.MkdirAll(modelsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modelsDir, "ggml-small.bin"), []byte("ggml"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	audio := filepath.Join(dir, "audio.wav")
+	if err := os.WriteFile(audio, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Jobs that start before the adapter is prepared resolve the binary themselves
+	adapter := adapters.NewWhisperCppAdapter(binary, modelsDir)
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = adapter.Transcribe(context.Background(),
+				interfaces.AudioInput{FilePath: audio, Format: "wav", Size: 4},
+				map[string]interface{}{"model": "small"},
+				interfaces.ProcessingContext{JobID: "job" + string(rune('a'+i)), OutputDirectory: dir, TempDirectory: dir})
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("job %d failed: %v", i, err)
+		}
+	}
+}
+
+func TestWhisperCppModelSelection(t *testing.T) {
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), "data/temp", "data/transcripts")
+	modelID := service.transcriptionCandidates(context.Background(), models.WhisperXParams{ModelFamily: FamilyWhisperCpp})[0].ModelID
+	if modelID != ModelWhisperCpp {
+		t.Errorf("expected whisper.cpp to be selected, got %s", modelID)
+	}
+
+	adapter := adapters.NewWhisperCppAdapter(filepath.Join(t.TempDir(), "missing"), t.TempDir())
+	if err := adapter.PrepareEnvironment(context.Background()); err == nil || adapter.IsReady(context.Background()) {
+		t.Error("expected a missing binary to leave the adapter not ready")
+	}
+}
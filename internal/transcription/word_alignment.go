@@ -0,0 +1,203 @@
+package transcription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/pkg/logger"
+)
+
+// ErrNoWordAligner is returned when no ready adapter aligns transcript words
+var ErrNoWordAligner = errors.New("no word alignment model is ready")
+
+// wordAligner returns a ready adapter that aligns transcript words, WhisperX
+// ahead of others
+func (u *UnifiedTranscriptionService) wordAligner(ctx context.Context) (interfaces.TranscriptionAdapter, interfaces.WordAligner, error) {
+	modelIDs := u.registry.GetTranscriptionModels()
+	sort.SliceStable(modelIDs, func(i, j int) bool { return modelIDs[i] == ModelWhisperX && modelIDs[j] != ModelWhisperX })
+	for _, modelID := range modelIDs {
+		adapter, err := u.registry.GetTranscriptionAdapter(modelID)
+		if err != nil {
+			continue
+		}
+		if aligner, ok := adapter.(interfaces.WordAligner); ok && adapter.IsReady(ctx) {
+			return adapter, aligner, nil
+		}
+	}
+	return nil, nil, ErrNoWordAligner
+}
+
+// CheckWordAligner returns ErrNoWordAligner unless a ready adapter aligns
+// transcript words
+func (u *UnifiedTranscriptionService) CheckWordAligner(ctx context.Context) error {
+	_, _, err := u.wordAligner(ctx)
+	return err
+}
+
+// alignmentParams returns the aligner's parameters: the transcript's
+// language, else the job's, and the job's alignment settings
+func alignmentParams(params models.WhisperXParams, language string) map[string]interface{} {
+	if language == "" && params.Language != nil {
+		language = *params.Language
+	}
+	paramMap := map[string]interface{}{
+		"language": language,
+		"device":   params.Device,
+	}
+	if params.AlignModel != nil && *params.AlignModel != "" {
+		paramMap["align_model"] = *params.AlignModel
+	}
+	if params.InterpolateMethod != "" {
+		paramMap["interpolate_method"] = params.InterpolateMethod
+	}
+	return paramMap
+}
+
+// needsWordAlignment reports whether a transcript lacks the word timings
+// forced alignment adds
+func needsWordAlignment(params models.WhisperXParams, result *interfaces.TranscriptResult) bool {
+	return !params.NoAlign && result != nil && len(result.Segments) > 0 && len(result.WordSegments) == 0
+}
+
+// alignTranscriptWords replaces a transcript's words with ones timed against
+// the audio. Segments are left as they are.
+func (u *UnifiedTranscriptionService) alignTranscriptWords(ctx context.Context, aligner interfaces.WordAligner, result *interfaces.TranscriptResult, input interfaces.AudioInput, params map[string]interface{}, procCtx interfaces.ProcessingContext) error {
+	aligned, err := aligner.AlignWords(ctx, input, result.Segments, params, procCtx)
+	if err != nil {
+		return err
+	}
+	if len(aligned) != len(result.Segments) {
+		return fmt.Errorf("expected words of %d segments, got %d", len(result.Segments), len(aligned))
+	}
+	result.WordSegments = alignedWords(result.Segments, aligned)
+	return nil
+}
+
+// alignTranscription adds word timings to a new transcript whose model did
+// not produce them. Failing to align leaves the transcript without words.
+func (u *UnifiedTranscriptionService) alignTranscription(ctx context.Context, params models.WhisperXParams, result *interfaces.TranscriptResult, input interfaces.AudioInput, procCtx interfaces.ProcessingContext) {
+	if !needsWordAlignment(params, result) {
+		return
+	}
+	_, aligner, err := u.wordAligner(ctx)
+	if err != nil {
+		appendJobLog(procCtx.OutputDirectory, fmt.Sprintf("Word alignment skipped: %v", err))
+		return
+	}
+
+	started := time.Now()
+	if err := u.alignTranscriptWords(ctx, aligner, result, input, alignmentParams(params, result.Language), procCtx); err != nil {
+		logger.Warn("Word alignment failed", "job_id", procCtx.JobID, "error", err)
+		appendJobLog(procCtx.OutputDirectory, fmt.Sprintf("Word alignment failed: %v", err))
+		return
+	}
+	message := fmt.Sprintf("Aligned %d words in %dms", len(result.WordSegments), time.Since(started).Milliseconds())
+	logger.Info(message, "job_id", procCtx.JobID)
+	appendJobLog(procCtx.OutputDirectory, message)
+}
+
+// AlignTranscript times the words of a finished job's current transcript
+// against its audio, for transcripts whose model gave no word timings or
+// whose text was edited. Segments, their text and speakers are kept; the
+// words are replaced. A language overrides the transcript's. The result is
+// not saved.
+func (u *UnifiedTranscriptionService) AlignTranscript(ctx context.Context, job *models.TranscriptionJob, params models.WhisperXParams, language string) (*interfaces.TranscriptResult, error) {
+	if job.Transcript == nil || *job.Transcript == "" {
+		return nil, fmt.Errorf("no transcript found for job %s", job.ID)
+	}
+	var transcript interfaces.TranscriptResult
+	if err := json.Unmarshal([]byte(*job.Transcript), &transcript); err != nil {
+		return nil, fmt.Errorf("failed to parse existing transcript: %w", err)
+	}
+	if language != "" {
+		transcript.Language = language
+	}
+
+	adapter, aligner, err := u.wordAligner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	procCtx := interfaces.ProcessingContext{
+		JobID:           job.ID,
+		OutputDirectory: filepath.Join(u.outputDirectory, job.ID),
+		TempDirectory:   u.tempDirectory,
+		Metadata:        map[string]string{},
+	}
+	if err := os.MkdirAll(procCtx.OutputDirectory, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	preprocessedInput, cleanup, err := u.prepareRecordingAudio(ctx, job.AudioPath, adapter.GetCapabilities())
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	started := time.Now()
+	if err := u.alignTranscriptWords(ctx, aligner, &transcript, preprocessedInput, alignmentParams(params, transcript.Language), procCtx); err != nil {
+		appendJobLog(procCtx.OutputDirectory, fmt.Sprintf("Aligning the transcript failed: %v", err))
+		return nil, fmt.Errorf("alignment failed: %w", err)
+	}
+	message := fmt.Sprintf("Aligned the transcript's %d words in %dms", len(transcript.WordSegments), time.Since(started).Milliseconds())
+	logger.Info(message, "job_id", job.ID)
+	appendJobLog(procCtx.OutputDirectory, message)
+
+	return &transcript, nil
+}
+
+// alignedWords joins the aligned words of each segment into the transcript's
+// words, spoken by the segment's speaker. The words of a segment that could
+// not be aligned are spread evenly over it, so every segment keeps its words.
+func alignedWords(segments []interfaces.TranscriptSegment, aligned [][]interfaces.TranscriptWord) []interfaces.TranscriptWord {
+	var words []interfaces.TranscriptWord
+	for i, segment := range segments {
+		segmentWords := aligned[i]
+		if len(segmentWords) == 0 {
+			segmentWords = spreadWords(segment)
+		}
+		for _, word := range segmentWords {
+			if word.End < word.Start {
+				word.End = word.Start
+			}
+			if segment.Speaker != nil {
+				speaker := *segment.Speaker
+				word.Speaker = &speaker
+			}
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// spreadWords splits a segment's text into words timed by their share of
+// its characters
+func spreadWords(segment interfaces.TranscriptSegment) []interfaces.TranscriptWord {
+	fields := strings.Fields(segment.Text)
+	total := 0
+	for _, field := range fields {
+		total += len(field)
+	}
+	words := make([]interfaces.TranscriptWord, len(fields))
+	duration := segment.End - segment.Start
+	position := 0
+	for i, field := range fields {
+		start := segment.Start + duration*float64(position)/float64(total)
+		position += len(field)
+		words[i] = interfaces.TranscriptWord{
+			Start: start,
+			End:   segment.Start + duration*float64(position)/float64(total),
+			Word:  field,
+		}
+	}
+	return words
+}
@@ -0,0 +1,112 @@
+package transcription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/adapters"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/registry"
+)
+
+// fakeAligner times the words of the first segment only, one per second
+type fakeAligner struct {
+	*adapters.BaseAdapter
+	params map[string]interface{}
+}
+
+func (f *fakeAligner) Transcribe(ctx context.Context, input interfaces.AudioInput, params map[string]interface{}, procCtx interfaces.ProcessingContext) (*interfaces.TranscriptResult, error) {
+	return &interfaces.TranscriptResult{}, nil
+}
+
+func (f *fakeAligner) GetSupportedModels() []string { return nil }
+
+func (f *fakeAligner) AlignWords(ctx context.Context, input interfaces.AudioInput, segments []interfaces.TranscriptSegment, params map[string]interface{}, procCtx interfaces.ProcessingContext) ([][]interfaces.TranscriptWord, error) {
+	f.params = params
+	aligned := make([][]interfaces.TranscriptWord, len(segments))
+	aligned[0] = []interfaces.TranscriptWord{
+		{Start: 0, End: 1, Word: "Hello", Score: 0.9},
+		{Start: 1, End: 2, Word: "world.", Score: 0.8},
+	}
+	return aligned, nil
+}
+
+func TestAlignTranscript(t *testing.T) {
+	root := t.TempDir()
+	audio := filepath.Join(root, "audio.wav")
+	if err := os.WriteFile(audio, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The text was edited, so the words no longer match it
+	transcript := interfaces.TranscriptResult{
+		Language: "en",
+		Segments: []interfaces.TranscriptSegment{
+			{Start: 0, End: 2, Text: "Hello world.", Speaker: stringPtr("SPEAKER_00")},
+			{Start: 2, End: 4, Text: "Hi you", Speaker: stringPtr("SPEAKER_01")},
+		},
+		WordSegments: []interfaces.TranscriptWord{{Start: 0, End: 1, Word: "Hullo"}},
+	}
+	transcriptJSON, _ := json.Marshal(transcript)
+	stored := string(transcriptJSON)
+	job := &models.TranscriptionJob{ID: "job", AudioPath: audio, Transcript: &stored}
+
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+	service := NewUnifiedTranscriptionService(new(MockJobRepository), root, root)
+	if _, err := service.AlignTranscript(context.Background(), job, models.WhisperXParams{}, ""); !errors.Is(err, ErrNoWordAligner) {
+		t.Errorf("expected no aligner, got %v", err)
+	}
+
+	capabilities := interfaces.ModelCapabilities{ModelID: "fake_aligner", ModelFamily: "fake", SupportedFormats: []string{"wav"}}
+	aligner := &fakeAligner{BaseAdapter: adapters.NewBaseAdapter("fake_aligner", "", capabilities, nil)}
+	if err := aligner.PrepareEnvironment(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	registry.RegisterTranscriptionAdapter("fake_aligner", aligner)
+
+	alignModel := "custom/align"
+	aligned, err := service.AlignTranscript(context.Background(), job, models.WhisperXParams{AlignModel: &alignModel}, "")
+	if err != nil {
+		t.Fatalf("alignment failed: %v", err)
+	}
+	if aligner.params["language"] != "en" || aligner.params["align_model"] != alignModel {
+		t.Errorf("aligner params = %v", aligner.params)
+	}
+	if !reflect.DeepEqual(aligned.Segments, transcript.Segments) {
+		t.Errorf("segments changed: %+v", aligned.Segments)
+	}
+
+	// The unaligned segment's words are spread over it by length
+	var got []string
+	for _, word := range aligned.WordSegments {
+		got = append(got, speakerOf(word.Speaker)+" "+word.Word)
+	}
+	want := []string{"SPEAKER_00 Hello", "SPEAKER_00 world.", "SPEAKER_01 Hi", "SPEAKER_01 you"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("words = %v, want %v", got, want)
+	}
+	if hi := aligned.WordSegments[2]; hi.Start != 2 || hi.End != 2.8 {
+		t.Errorf("spread word spans %v-%v", hi.Start, hi.End)
+	}
+}
+
+func TestNeedsWordAlignment(t *testing.T) {
+	segments := []interfaces.TranscriptSegment{{Start: 0, End: 1, Text: "Hi"}}
+	if !needsWordAlignment(models.WhisperXParams{}, &interfaces.TranscriptResult{Segments: segments}) {
+		t.Error("a transcript without words needs alignment")
+	}
+	if needsWordAlignment(models.WhisperXParams{NoAlign: true}, &interfaces.TranscriptResult{Segments: segments}) {
+		t.Error("no_align turns alignment off")
+	}
+	words := []interfaces.TranscriptWord{{Start: 0, End: 1, Word: "Hi"}}
+	if needsWordAlignment(models.WhisperXParams{}, &interfaces.TranscriptResult{Segments: segments, WordSegments: words}) {
+		t.Error("a transcript with words needs no alignment")
+	}
+}
@@ -0,0 +1,166 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrLeaseLost is returned when the server no longer considers a job leased to this worker
+var ErrLeaseLost = fmt.Errorf("lease lost")
+
+// Client talks to the worker endpoints of a Scriberr server
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the server at baseURL authenticating with a worker token
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		// No overall timeout: audio downloads can be large. Requests are bounded by their context.
+		httpClient: &http.Client{},
+	}
+}
+
+// Lease asks the server for a job. It returns nil when no job is available.
+func (c *Client) Lease(ctx context.Context) (*LeaseResponse, error) {
+	resp, err := c.do(ctx, http.MethodPost, "/api/v1/worker/lease", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var lease LeaseResponse
+	if err := json.NewDecoder(resp.Body).Decode(&lease); err != nil {
+		return nil, fmt.Errorf("failed to decode lease: %w", err)
+	}
+	return &lease, nil
+}
+
+// DownloadAudio saves the audio of a leased job to dest
+func (c *Client) DownloadAudio(ctx context.Context, jobID, dest string) error {
+	resp, err := c.do(ctx, http.MethodGet, "/api/v1/worker/jobs/"+jobID+"/audio", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("failed to create audio file: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return fmt.Errorf("failed to download audio: %w", err)
+	}
+	return nil
+}
+
+// Heartbeat renews the lease on a job and reports progress
+func (c *Client) Heartbeat(ctx context.Context, jobID, message string) (*HeartbeatResponse, error) {
+	var out HeartbeatResponse
+	if err := c.doJSON(ctx, "/api/v1/worker/jobs/"+jobID+"/heartbeat", HeartbeatRequest{Message: message}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Complete uploads the outcome of a job and releases its lease
+func (c *Client) Complete(ctx context.Context, jobID string, req CompleteRequest) error {
+	return c.doJSON(ctx, "/api/v1/worker/jobs/"+jobID+"/complete", req, nil)
+}
+
+// Release hands a job back to the server's queue without finishing it
+func (c *Client) Release(ctx context.Context, jobID string) error {
+	return c.doJSON(ctx, "/api/v1/worker/jobs/"+jobID+"/release", nil, nil)
+}
+
+// doJSON posts a JSON body and decodes the JSON response into out when it is not nil
+func (c *Client) doJSON(ctx context.Context, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(TokenHeader, c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.httpClient.Do(req)
+}
+
+// checkResponse turns a non-2xx response into an error
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	var errResp struct {
+		Error string `json:"error"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if json.Unmarshal(body, &errResp) != nil || errResp.Error == "" {
+		errResp.Error = strings.TrimSpace(string(body))
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		return fmt.Errorf("%w: %s", ErrLeaseLost, errResp.Error)
+	}
+	return fmt.Errorf("server returned %d: %s", resp.StatusCode, errResp.Error)
+}
+
+// heartbeatInterval is how often a worker renews its lease given the server's TTL
+func heartbeatInterval(leaseTTLSeconds int) time.Duration {
+	if leaseTTLSeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(leaseTTLSeconds) * time.Second / 4
+}
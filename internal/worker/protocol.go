@@ -0,0 +1,36 @@
+// Package worker implements remote transcription workers. A worker runs on a
+// separate machine, leases jobs from the main server over HTTP, processes them
+// with the local transcription pipeline and uploads the results.
+package worker
+
+import "scriberr/internal/models"
+
+// TokenHeader is the header remote workers authenticate with
+const TokenHeader = "X-Worker-Token"
+
+// LeaseResponse is returned when the server hands a job to a worker
+type LeaseResponse struct {
+	Job             models.TranscriptionJob `json:"job"`
+	LeaseTTLSeconds int                     `json:"lease_ttl_seconds"`
+	CustomModels    []models.CustomModel    `json:"custom_models,omitempty"` // Custom models registered on the server
+}
+
+// HeartbeatRequest keeps a lease alive and reports progress
+type HeartbeatRequest struct {
+	Message string `json:"message,omitempty"` // Latest progress line from the job log
+}
+
+// HeartbeatResponse tells the worker whether to keep going
+type HeartbeatResponse struct {
+	Cancel bool `json:"cancel"` // The job was killed on the server
+}
+
+// CompleteRequest reports the outcome of a leased job
+type CompleteRequest struct {
+	Status             models.JobStatus                  `json:"status" binding:"required"`
+	ErrorMessage       string                            `json:"error_message,omitempty"`
+	Transcript         *string                           `json:"transcript,omitempty"`
+	OriginalTranscript *string                           `json:"original_transcript,omitempty"`
+	Execution          *models.TranscriptionJobExecution `json:"execution,omitempty"`
+	Logs               string                            `json:"logs,omitempty"`
+}
@@ -0,0 +1,260 @@
+package worker
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"scriberr/internal/models"
+	"scriberr/internal/queue"
+	"scriberr/internal/repository"
+	"scriberr/pkg/logger"
+)
+
+// defaultPollInterval is how long an idle worker waits before asking for another job
+const defaultPollInterval = 5 * time.Second
+
+// Runner leases jobs from a server and processes them locally. Leased jobs are
+// copied into the worker's own database so the unmodified transcription
+// pipeline can run against them.
+type Runner struct {
+	client         *Client
+	processor      queue.JobProcessor
+	jobRepo        repository.JobRepository
+	audioDir       string
+	transcriptsDir string
+	PollInterval   time.Duration
+
+	// ApplyCustomModels registers the server's custom models before a job is
+	// processed, so jobs can run on models that were added on the server
+	ApplyCustomModels func([]models.CustomModel)
+}
+
+// NewRunner creates a runner. transcriptsDir must be the output directory the
+// processor writes job logs to.
+func NewRunner(client *Client, processor queue.JobProcessor, jobRepo repository.JobRepository, audioDir, transcriptsDir string) *Runner {
+	return &Runner{
+		client:         client,
+		processor:      processor,
+		jobRepo:        jobRepo,
+		audioDir:       audioDir,
+		transcriptsDir: transcriptsDir,
+		PollInterval:   defaultPollInterval,
+	}
+}
+
+// Run leases and processes jobs until ctx is cancelled. A job in progress when
+// ctx is cancelled is handed back to the server.
+func (r *Runner) Run(ctx context.Context) error {
+	if err := os.MkdirAll(r.audioDir, 0755); err != nil {
+		return fmt.Errorf("failed to create audio directory: %w", err)
+	}
+
+	logger.Info("Remote worker started", "server", r.client.baseURL)
+	for {
+		lease, err := r.client.Lease(ctx)
+		if ctx.Err() != nil {
+			logger.Info("Remote worker stopped")
+			return nil
+		}
+		if err != nil {
+			logger.Warn("Failed to lease job", "error", err)
+		}
+		if lease != nil {
+			r.runJob(ctx, lease)
+			continue
+		}
+
+		select {
+		case <-time.After(r.PollInterval):
+		case <-ctx.Done():
+			logger.Info("Remote worker stopped")
+			return nil
+		}
+	}
+}
+
+// runJob processes a single leased job and reports its outcome
+func (r *Runner) runJob(ctx context.Context, lease *LeaseResponse) {
+	jobID := lease.Job.ID
+	logger.Info("Leased job", "job_id", jobID)
+
+	audioPath := filepath.Join(r.audioDir, jobID+filepath.Ext(lease.Job.AudioPath))
+	defer os.Remove(audioPath)
+
+	if r.ApplyCustomModels != nil {
+		r.ApplyCustomModels(lease.CustomModels)
+	}
+
+	if err := r.prepareJob(ctx, lease.Job, audioPath); err != nil {
+		if ctx.Err() != nil {
+			r.release(jobID)
+			return
+		}
+		logger.Error("Failed to prepare job", "job_id", jobID, "error", err)
+		r.complete(jobID, CompleteRequest{Status: models.StatusFailed, ErrorMessage: err.Error()})
+		return
+	}
+
+	jobCtx, cancelJob := context.WithCancel(ctx)
+	defer cancelJob()
+
+	killed := make(chan struct{})
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		r.heartbeat(jobCtx, jobID, heartbeatInterval(lease.LeaseTTLSeconds), func() {
+			close(killed)
+			cancelJob()
+		})
+	}()
+
+	err := r.processor.ProcessJobWithProcess(jobCtx, jobID, func(*exec.Cmd) {})
+	cancelJob()
+	<-heartbeatDone
+
+	select {
+	case <-killed:
+		logger.Info("Job was killed on the server", "job_id", jobID)
+		r.complete(jobID, CompleteRequest{Status: models.StatusFailed, Logs: r.readLogs(jobID)})
+		return
+	default:
+	}
+	if ctx.Err() != nil {
+		r.release(jobID)
+		return
+	}
+
+	r.complete(jobID, r.buildResult(jobID, err))
+}
+
+// prepareJob downloads the job's audio and stores a local copy of the job
+func (r *Runner) prepareJob(ctx context.Context, job models.TranscriptionJob, audioPath string) error {
+	if err := r.client.DownloadAudio(ctx, job.ID, audioPath); err != nil {
+		return err
+	}
+
+	job.AudioPath = audioPath
+	job.Status = models.StatusProcessing
+	job.Transcript = nil
+	job.OriginalTranscript = nil
+	job.ErrorMessage = nil
+	job.MultiTrackFiles = nil
+
+	// Save inserts the job or overwrites the copy left by an earlier lease
+	if err := r.jobRepo.Update(ctx, &job); err != nil {
+		return fmt.Errorf("failed to store job locally: %w", err)
+	}
+	return nil
+}
+
+// heartbeat renews the lease until ctx ends, reporting the latest log line.
+// onKilled is called once if the server says the job was killed or the lease was lost.
+func (r *Runner) heartbeat(ctx context.Context, jobID string, interval time.Duration, onKilled func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		resp, err := r.client.Heartbeat(ctx, jobID, r.lastLogLine(jobID))
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, ErrLeaseLost) || (err == nil && resp.Cancel) {
+			onKilled()
+			return
+		}
+		if err != nil {
+			logger.Warn("Heartbeat failed", "job_id", jobID, "error", err)
+		}
+	}
+}
+
+// buildResult collects the local outcome of a processed job
+func (r *Runner) buildResult(jobID string, processErr error) CompleteRequest {
+	ctx := context.Background()
+	result := CompleteRequest{
+		Status: models.StatusCompleted,
+		Logs:   r.readLogs(jobID),
+	}
+	if processErr != nil {
+		result.Status = models.StatusFailed
+		result.ErrorMessage = processErr.Error()
+	}
+
+	if job, err := r.jobRepo.FindByID(ctx, jobID); err == nil {
+		result.Transcript = job.Transcript
+		result.OriginalTranscript = job.OriginalTranscript
+	}
+	if execution, err := r.jobRepo.FindLatestExecution(ctx, jobID); err == nil {
+		result.Execution = execution
+	}
+	return result
+}
+
+// complete reports a job's outcome. It uses a fresh context so results are
+// still delivered while the worker is shutting down.
+func (r *Runner) complete(jobID string, result CompleteRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	if err := r.client.Complete(ctx, jobID, result); err != nil {
+		logger.Error("Failed to report job result", "job_id", jobID, "error", err)
+		return
+	}
+	logger.Info("Reported job result", "job_id", jobID, "status", result.Status)
+}
+
+// release hands an unfinished job back to the server
+func (r *Runner) release(jobID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := r.client.Release(ctx, jobID); err != nil {
+		logger.Warn("Failed to release job", "job_id", jobID, "error", err)
+		return
+	}
+	logger.Info("Released job back to the server", "job_id", jobID)
+}
+
+// logPath is where the transcription adapters write a job's log
+func (r *Runner) logPath(jobID string) string {
+	return filepath.Join(r.transcriptsDir, jobID, "transcription.log")
+}
+
+func (r *Runner) readLogs(jobID string) string {
+	data, err := os.ReadFile(r.logPath(jobID))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// lastLogLine returns the last non-empty line of a job's log
+func (r *Runner) lastLogLine(jobID string) string {
+	f, err := os.Open(r.logPath(jobID))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	var last string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			last = line
+		}
+	}
+	return last
+}
@@ -100,12 +100,13 @@ func (pt *progressTracker) printProgress() {
 		fmt.Printf("\r\033[KDownloading %s: %d%% (%s / %s)",
 			pt.Filename,
 			percent,
-			formatBytes(pt.Current),
-			formatBytes(pt.Total))
+			FormatBytes(pt.Current),
+			FormatBytes(pt.Total))
 	}
 }
 
-func formatBytes(b int64) string {
+// FormatBytes renders a byte count with a binary unit, e.g. "1.5 GB"
+func FormatBytes(b int64) string {
 	const unit = 1024
 	if b < unit {
 		return fmt.Sprintf("%d B", b)
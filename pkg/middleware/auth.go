@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
 	"strings"
 	"time"
@@ -133,3 +135,28 @@ func JWTOnlyMiddleware(authService *auth.AuthService) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// WorkerAuthMiddleware authenticates remote transcription workers by their token
+func WorkerAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader("X-Worker-Token")
+		if token == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Worker token required"})
+			c.Abort()
+			return
+		}
+
+		sum := sha256.Sum256([]byte(token))
+		var worker models.Worker
+		result := database.DB.Where("token_hash = ? AND is_active = ?", hex.EncodeToString(sum[:]), true).First(&worker)
+		if result.Error != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid worker token"})
+			c.Abort()
+			return
+		}
+
+		c.Set("auth_type", "worker")
+		c.Set("worker_id", worker.ID)
+		c.Next()
+	}
+}
@@ -42,6 +42,23 @@ func (suite *APIHandlerTestSuite) SetupSuite() {
 	suite.helper = NewTestHelper(suite.T(), "api_handlers_test.db")
 	suite.mockOpenAI = NewMockOpenAIServer()
 
+	jobRepo := repository.NewJobRepository(suite.helper.DB)
+	speakerMappingRepo := repository.NewSpeakerMappingRepository(suite.helper.DB)
+	voiceRepo := repository.NewVoiceRepository(suite.helper.DB)
+
+	// Initialize services
+	suite.unifiedProcessor = transcription.NewUnifiedJobProcessor(jobRepo, suite.helper.Config.TempDir, suite.helper.Config.TranscriptsDir)
+	suite.unifiedProcessor.SetVoiceLibrary(voiceRepo, speakerMappingRepo, transcription.DefaultVoiceMatchThreshold)
+	var err error
+	suite.quickTranscription, err = transcription.NewQuickTranscriptionService(suite.helper.Config, suite.unifiedProcessor, jobRepo)
+	assert.NoError(suite.T(), err)
+
+	suite.taskQueue = queue.NewTaskQueue(1, suite.unifiedProcessor, jobRepo)
+	suite.handler, suite.router = suite.newRouter(suite.taskQueue)
+}
+
+// newRouter creates a handler using the given task queue and its routes
+func (suite *APIHandlerTestSuite) newRouter(taskQueue *queue.TaskQueue) (*api.Handler, *gin.Engine) {
 	// Initialize repositories
 	jobRepo := repository.NewJobRepository(suite.helper.DB)
 	userRepo := repository.NewUserRepository(suite.helper.DB)
@@ -53,24 +70,19 @@ func (suite *APIHandlerTestSuite) SetupSuite() {
 	noteRepo := repository.NewNoteRepository(suite.helper.DB)
 	speakerMappingRepo := repository.NewSpeakerMappingRepository(suite.helper.DB)
 	refreshTokenRepo := repository.NewRefreshTokenRepository(suite.helper.DB)
+	workerRepo := repository.NewWorkerRepository(suite.helper.DB)
+	customModelRepo := repository.NewCustomModelRepository(suite.helper.DB)
+	voiceRepo := repository.NewVoiceRepository(suite.helper.DB)
 
 	// Initialize services
 	userService := service.NewUserService(userRepo, suite.helper.AuthService)
 	fileService := service.NewFileService()
 
-	// Initialize services
-	suite.unifiedProcessor = transcription.NewUnifiedJobProcessor(jobRepo, suite.helper.Config.TempDir, suite.helper.Config.TranscriptsDir)
-	var err error
-	suite.quickTranscription, err = transcription.NewQuickTranscriptionService(suite.helper.Config, suite.unifiedProcessor, jobRepo)
-	assert.NoError(suite.T(), err)
-
-	suite.taskQueue = queue.NewTaskQueue(1, suite.unifiedProcessor, jobRepo)
-
 	broadcaster := sse.NewBroadcaster()
 
 	multiTrackProcessor := processing.NewMultiTrackProcessor(suite.helper.DB, jobRepo)
 
-	suite.handler = api.NewHandler(
+	handler := api.NewHandler(
 		suite.helper.Config,
 		suite.helper.AuthService,
 		userService,
@@ -85,7 +97,10 @@ func (suite *APIHandlerTestSuite) SetupSuite() {
 		noteRepo,
 		speakerMappingRepo,
 		refreshTokenRepo,
-		suite.taskQueue,
+		workerRepo,
+		customModelRepo,
+		voiceRepo,
+		taskQueue,
 		suite.unifiedProcessor,
 		suite.quickTranscription,
 		multiTrackProcessor,
@@ -93,7 +108,7 @@ func (suite *APIHandlerTestSuite) SetupSuite() {
 	)
 
 	// Set up router
-	suite.router = api.SetupRoutes(suite.handler, suite.helper.AuthService)
+	return handler, api.SetupRoutes(handler, suite.helper.AuthService)
 }
 
 func (suite *APIHandlerTestSuite) TearDownSuite() {
@@ -576,6 +591,31 @@ func (suite *APIHandlerTestSuite) TestGetQueueStats() {
 	assert.Contains(suite.T(), response, "failed_jobs")
 }
 
+// Test queue control endpoints
+func (suite *APIHandlerTestSuite) TestQueueControl() {
+	w := suite.makeAuthenticatedRequest("POST", "/api/v1/admin/queue/pause", nil, false)
+	assert.Equal(suite.T(), 200, w.Code)
+
+	var state api.QueueStateResponse
+	err := json.Unmarshal(w.Body.Bytes(), &state)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), queue.StatePaused, state.State)
+
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/admin/queue/reorder", map[string]interface{}{
+		"job_ids": []string{"not-queued"},
+	}, false)
+	assert.Equal(suite.T(), 409, w.Code)
+
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/admin/queue/reorder", map[string]interface{}{}, false)
+	assert.Equal(suite.T(), 400, w.Code)
+
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/admin/queue/resume", nil, false)
+	assert.Equal(suite.T(), 200, w.Code)
+	err = json.Unmarshal(w.Body.Bytes(), &state)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), queue.StateRunning, state.State)
+}
+
 // Test multipart file upload (transcription submit)
 func (suite *APIHandlerTestSuite) TestTranscriptionSubmit() {
 	// Create a dummy audio file
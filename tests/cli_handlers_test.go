@@ -46,6 +46,9 @@ func (suite *CLIHandlerTestSuite) SetupSuite() {
 	noteRepo := repository.NewNoteRepository(suite.helper.DB)
 	speakerMappingRepo := repository.NewSpeakerMappingRepository(suite.helper.DB)
 	refreshTokenRepo := repository.NewRefreshTokenRepository(suite.helper.DB)
+	workerRepo := repository.NewWorkerRepository(suite.helper.DB)
+	customModelRepo := repository.NewCustomModelRepository(suite.helper.DB)
+	voiceRepo := repository.NewVoiceRepository(suite.helper.DB)
 
 	// Initialize services
 	userService := service.NewUserService(userRepo, suite.helper.AuthService)
@@ -78,6 +81,9 @@ func (suite *CLIHandlerTestSuite) SetupSuite() {
 		noteRepo,
 		speakerMappingRepo,
 		refreshTokenRepo,
+		workerRepo,
+		customModelRepo,
+		voiceRepo,
 		suite.taskQueue,
 		suite.unifiedProcessor,
 		suite.quickTranscription,
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"scriberr/internal/models"
+	"scriberr/internal/transcription"
+	"scriberr/internal/transcription/adapters"
+	"scriberr/internal/transcription/registry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test registering custom models and selecting them in profiles
+func (suite *APIHandlerTestSuite) TestCustomModelEndpoints() {
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+	defer transcription.ApplyCustomModels(nil)
+
+	dir := suite.T().TempDir()
+	registry.RegisterTranscriptionAdapter("whisper_cpp", adapters.NewWhisperCppAdapter("sh", filepath.Join(dir, "models")))
+	registry.RegisterTranscriptionAdapter("openai_whisper", adapters.NewOpenAIAdapter(""))
+	checkpoint := filepath.Join(dir, "ggml-medical.bin")
+	require.NoError(suite.T(), os.WriteFile(checkpoint, make([]byte, 16), 0644))
+
+	// Invalid registrations
+	invalid := []map[string]interface{}{
+		{"name": "medical", "base_adapter": "openai_whisper", "source": checkpoint},
+		{"name": "medical", "base_adapter": "whisper_cpp", "source": "org/whisper-medical"},
+		{"name": "medical", "base_adapter": "whisper_cpp", "source": filepath.Join(dir, "missing.bin")},
+		{"name": "medical", "base_adapter": "whisper_cpp", "source": dir},
+		{"name": "small", "base_adapter": "whisper_cpp", "source": checkpoint},
+		{"name": "bad name", "base_adapter": "whisper_cpp", "source": checkpoint},
+	}
+	for _, body := range invalid {
+		w := suite.makeAuthenticatedRequest("POST", "/api/v1/admin/custom-models/", body, false)
+		assert.Equal(suite.T(), http.StatusBadRequest, w.Code, body)
+	}
+
+	body := map[string]interface{}{
+		"name":         "medical",
+		"display_name": "Medical (fine-tuned)",
+		"base_adapter": "whisper_cpp",
+		"source":       checkpoint,
+		"languages":    []string{"en", " de "},
+	}
+	w := suite.makeAuthenticatedRequest("POST", "/api/v1/admin/custom-models/", body, false)
+	require.Equal(suite.T(), http.StatusCreated, w.Code, w.Body.String())
+	var custom models.CustomModel
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &custom))
+	assert.Equal(suite.T(), []string{"en", "de"}, custom.LanguageList())
+
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/admin/custom-models/", body, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+
+	// The adapter offers the custom model
+	w = suite.makeAuthenticatedRequest("GET", "/api/v1/transcription/models", nil, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code)
+	var supported struct {
+		Models map[string]struct {
+			Models       []string `json:"models"`
+			CustomModels []struct {
+				Name string `json:"name"`
+			} `json:"custom_models"`
+		} `json:"models"`
+	}
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &supported))
+	assert.Contains(suite.T(), supported.Models["whisper_cpp"].Models, "medical")
+	require.Len(suite.T(), supported.Models["whisper_cpp"].CustomModels, 1)
+	assert.Equal(suite.T(), "medical", supported.Models["whisper_cpp"].CustomModels[0].Name)
+
+	// Profiles may select it for its own adapter and languages only
+	profile := func(family, model, language string) map[string]interface{} {
+		return map[string]interface{}{
+			"name": "Custom " + family + " " + language,
+			"parameters": map[string]interface{}{
+				"model_family": family,
+				"model":        model,
+				"language":     language,
+			},
+		}
+	}
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/profiles/", profile("whisper_cpp", "medical", "fr"), false)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/profiles/", profile("openai", "medical", "en"), false)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/profiles/", profile("whisper_cpp", "huge", "en"), false)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/profiles/", profile("whisper_cpp", "medical", "de"), false)
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+	var created models.TranscriptionProfile
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &created))
+
+	// A model in use cannot be renamed or deleted
+	renamed := map[string]interface{}{"name": "medical-v2", "base_adapter": "whisper_cpp", "source": checkpoint}
+	w = suite.makeAuthenticatedRequest("PUT", "/api/v1/admin/custom-models/"+custom.ID, renamed, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	w = suite.makeAuthenticatedRequest("DELETE", "/api/v1/admin/custom-models/"+custom.ID, nil, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+
+	w = suite.makeAuthenticatedRequest("DELETE", "/api/v1/profiles/"+created.ID, nil, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code)
+	w = suite.makeAuthenticatedRequest("DELETE", "/api/v1/admin/custom-models/"+custom.ID, nil, false)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	w = suite.makeAuthenticatedRequest("GET", "/api/v1/admin/custom-models/", nil, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code)
+	var remaining []models.CustomModel
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &remaining))
+	assert.Empty(suite.T(), remaining)
+}
@@ -0,0 +1,170 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"scriberr/internal/api"
+	"scriberr/internal/transcription/adapters"
+	"scriberr/internal/transcription/environments"
+	"scriberr/internal/transcription/registry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getModelEnvironment fetches one model environment through the admin API
+func (suite *APIHandlerTestSuite) getModelEnvironment(modelID string) environments.Environment {
+	w := suite.makeAuthenticatedRequest("GET", "/api/v1/admin/models/"+modelID, nil, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+
+	var env environments.Environment
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &env))
+	return env
+}
+
+// Test listing, installing and removing model environments
+func (suite *APIHandlerTestSuite) TestModelEnvironmentEndpoints() {
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+
+	// Two adapters sharing a models directory, with one model downloaded
+	modelsDir := filepath.Join(suite.T().TempDir(), "whisper-cpp")
+	require.NoError(suite.T(), os.MkdirAll(modelsDir, 0755))
+	require.NoError(suite.T(), os.WriteFile(filepath.Join(modelsDir, "ggml-tiny.bin"), make([]byte, 2048), 0644))
+	registry.RegisterTranscriptionAdapter("whisper_cpp", adapters.NewWhisperCppAdapter("sh", modelsDir))
+	registry.RegisterTranscriptionAdapter("whisper_cpp_alt", adapters.NewWhisperCppAdapter("sh", modelsDir))
+
+	w := suite.makeAuthenticatedRequest("GET", "/api/v1/admin/models/", nil, false)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var envs []environments.Environment
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &envs))
+	require.Len(suite.T(), envs, 2)
+	env := envs[0]
+	assert.Equal(suite.T(), "whisper_cpp", env.ModelID)
+	assert.Equal(suite.T(), []string{"whisper_cpp_alt"}, env.SharedWith)
+	assert.True(suite.T(), env.Exists)
+	assert.False(suite.T(), env.Ready)
+	assert.Equal(suite.T(), int64(2048), env.DiskUsageBytes)
+	require.Len(suite.T(), env.InstalledModels, 1)
+	assert.Equal(suite.T(), "tiny", env.InstalledModels[0].Name)
+
+	w = suite.makeAuthenticatedRequest("GET", "/api/v1/admin/models/missing", nil, false)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+
+	// Installing runs in the background
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/admin/models/whisper_cpp/install", nil, false)
+	assert.Equal(suite.T(), http.StatusAccepted, w.Code)
+	var task environments.Task
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &task))
+	assert.Equal(suite.T(), "whisper_cpp", task.ModelID)
+
+	require.Eventually(suite.T(), func() bool {
+		env := suite.getModelEnvironment("whisper_cpp")
+		return env.Task != nil && env.Task.Status == environments.TaskCompleted
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.True(suite.T(), suite.getModelEnvironment("whisper_cpp").Ready)
+
+	// Removing a variant keeps the environment
+	w = suite.makeAuthenticatedRequest("DELETE", "/api/v1/admin/models/whisper_cpp?model=tiny", nil, false)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Empty(suite.T(), suite.getModelEnvironment("whisper_cpp").InstalledModels)
+	w = suite.makeAuthenticatedRequest("DELETE", "/api/v1/admin/models/whisper_cpp?model=tiny", nil, false)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+
+	// A shared environment is only removed with force
+	w = suite.makeAuthenticatedRequest("DELETE", "/api/v1/admin/models/whisper_cpp", nil, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.DirExists(suite.T(), modelsDir)
+
+	w = suite.makeAuthenticatedRequest("DELETE", "/api/v1/admin/models/whisper_cpp?force=true", nil, false)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &env))
+	assert.False(suite.T(), env.Exists)
+	assert.False(suite.T(), env.Ready)
+	assert.NoDirExists(suite.T(), modelsDir)
+}
+
+// Test that environments queued or running jobs may use are kept
+func (suite *APIHandlerTestSuite) TestModelEnvironmentInUse() {
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+
+	modelsDir := filepath.Join(suite.T().TempDir(), "whisper-cpp")
+	require.NoError(suite.T(), os.MkdirAll(modelsDir, 0755))
+	require.NoError(suite.T(), os.WriteFile(filepath.Join(modelsDir, "ggml-tiny.bin"), make([]byte, 2048), 0644))
+	registry.RegisterTranscriptionAdapter("whisper_cpp", adapters.NewWhisperCppAdapter("sh", modelsDir))
+	registry.RegisterTranscriptionAdapter("whisper_cpp_alt", adapters.NewWhisperCppAdapter("sh", modelsDir))
+
+	// A queued job for the model the other one shares its environment with
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Queued whisper.cpp job")
+	require.NoError(suite.T(), suite.helper.DB.Model(job).Updates(map[string]interface{}{
+		"model_family": "whisper_cpp",
+		"model":        "tiny",
+	}).Error)
+
+	w := suite.makeAuthenticatedRequest("DELETE", "/api/v1/admin/models/whisper_cpp_alt?force=true", nil, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code, w.Body.String())
+	assert.Contains(suite.T(), w.Body.String(), job.ID)
+	w = suite.makeAuthenticatedRequest("DELETE", "/api/v1/admin/models/whisper_cpp?model=tiny", nil, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/admin/models/whisper_cpp_alt/install", api.InstallModelRequest{Reinstall: true}, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.DirExists(suite.T(), modelsDir)
+	assert.FileExists(suite.T(), filepath.Join(modelsDir, "ggml-tiny.bin"))
+
+	// Installing without deleting anything is allowed
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/admin/models/whisper_cpp_alt/install", nil, false)
+	assert.Equal(suite.T(), http.StatusAccepted, w.Code)
+	require.Eventually(suite.T(), func() bool {
+		env := suite.getModelEnvironment("whisper_cpp_alt")
+		return env.Task != nil && env.Task.Status == environments.TaskCompleted
+	}, 5*time.Second, 20*time.Millisecond)
+
+	// Once the job finished the environment can go
+	require.NoError(suite.T(), suite.helper.DB.Model(job).Update("status", "completed").Error)
+	w = suite.makeAuthenticatedRequest("DELETE", "/api/v1/admin/models/whisper_cpp_alt?force=true", nil, false)
+	assert.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+	assert.NoDirExists(suite.T(), modelsDir)
+}
+
+// Test that jobs are held from starting while an environment is checked and removed
+func TestModelEnvironmentRemovalHoldsDispatch(t *testing.T) {
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+
+	modelsDir := filepath.Join(t.TempDir(), "whisper-cpp")
+	require.NoError(t, os.MkdirAll(modelsDir, 0755))
+	registry.RegisterTranscriptionAdapter("whisper_cpp", adapters.NewWhisperCppAdapter("sh", modelsDir))
+
+	var held atomic.Int32
+	manager := environments.NewManager(nil)
+	manager.SetDispatchHold(func() func() {
+		held.Add(1)
+		return func() { held.Add(-1) }
+	})
+	manager.SetUsageChecker(func(ctx context.Context, modelIDs []string) ([]string, error) {
+		assert.Equal(t, int32(1), held.Load(), "usage must be checked while jobs are held")
+		return nil, nil
+	})
+
+	require.NoError(t, manager.Remove(context.Background(), "whisper_cpp", "", false))
+	assert.NoDirExists(t, modelsDir)
+	assert.Equal(t, int32(0), held.Load())
+
+	// A reinstall releases the jobs once the old environment is gone
+	require.NoError(t, os.MkdirAll(modelsDir, 0755))
+	_, err := manager.Install(context.Background(), "whisper_cpp", "", true)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		env, err := manager.Get(context.Background(), "whisper_cpp")
+		return err == nil && env.Task != nil && env.Task.Status != environments.TaskRunning
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Equal(t, int32(0), held.Load())
+}
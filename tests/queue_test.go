@@ -442,3 +442,337 @@ func (suite *QueueTestSuite) TestResetZombieJobs() {
 	assert.Equal(suite.T(), models.StatusFailed, updatedJob.Status)
 	assert.Contains(suite.T(), *updatedJob.ErrorMessage, "interrupted by server restart")
 }
+
+// ResumableMockJobProcessor reports saved progress for a fixed set of jobs
+type ResumableMockJobProcessor struct {
+	MockJobProcessor
+	checkpointed map[string]bool
+}
+
+func (m *ResumableMockJobProcessor) HasCheckpoint(jobID string) bool {
+	return m.checkpointed[jobID]
+}
+
+// Test that interrupted jobs with checkpoints are resumed instead of failed
+func (suite *QueueTestSuite) TestResetZombieJobsResumesCheckpointedJobs() {
+	resumable := suite.helper.CreateTestTranscriptionJob(suite.T(), "Checkpointed Job")
+	zombie := suite.helper.CreateTestTranscriptionJob(suite.T(), "Zombie Job")
+	err := suite.helper.DB.Model(&models.TranscriptionJob{}).Where("id IN ?", []string{resumable.ID, zombie.ID}).Update("status", models.StatusProcessing).Error
+	assert.NoError(suite.T(), err)
+
+	mockProcessor := &ResumableMockJobProcessor{checkpointed: map[string]bool{resumable.ID: true}}
+	mockProcessor.On("ProcessJobWithProcess", mock.Anything, resumable.ID).Return(nil)
+
+	tq := queue.NewTaskQueue(1, mockProcessor, suite.jobRepo)
+	tq.Start()
+	defer tq.Stop()
+
+	assert.Eventually(suite.T(), func() bool {
+		updatedJob, err := tq.GetJobStatus(resumable.ID)
+		return err == nil && updatedJob.Status == models.StatusCompleted
+	}, 2*time.Second, 50*time.Millisecond, "Checkpointed job should be requeued and processed")
+
+	updatedZombie, err := tq.GetJobStatus(zombie.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), models.StatusFailed, updatedZombie.Status)
+}
+
+// Test pausing and resuming dispatch
+func (suite *QueueTestSuite) TestPauseAndResume() {
+	mockProcessor := &MockJobProcessor{}
+	mockProcessor.On("ProcessJobWithProcess", mock.Anything, mock.Anything).Return(nil)
+
+	tq := queue.NewTaskQueue(1, mockProcessor, suite.jobRepo)
+	tq.Start()
+	defer tq.Stop()
+
+	tq.Pause()
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Paused Job")
+	assert.Equal(suite.T(), queue.StatePaused, tq.State())
+
+	err := tq.EnqueueJob(job.ID)
+	assert.NoError(suite.T(), err)
+
+	// Job must stay pending while paused
+	time.Sleep(100 * time.Millisecond)
+	mockProcessor.AssertNotCalled(suite.T(), "ProcessJobWithProcess", mock.Anything, job.ID, mock.Anything)
+	assert.Equal(suite.T(), []string{job.ID}, tq.PendingJobs())
+
+	tq.Resume()
+	assert.Equal(suite.T(), queue.StateRunning, tq.State())
+
+	assert.Eventually(suite.T(), func() bool {
+		updatedJob, err := tq.GetJobStatus(job.ID)
+		return err == nil && updatedJob.Status == models.StatusCompleted
+	}, 2*time.Second, 50*time.Millisecond, "Job should run after resume")
+}
+
+// Test draining lets running jobs finish without starting new ones
+func (suite *QueueTestSuite) TestDrain() {
+	mockProcessor := &MockJobProcessor{}
+	mockProcessor.processDelay = 200 * time.Millisecond
+	mockProcessor.On("ProcessJobWithProcess", mock.Anything, mock.Anything).Return(nil)
+
+	tq := queue.NewTaskQueue(1, mockProcessor, suite.jobRepo)
+	tq.Start()
+	defer tq.Stop()
+
+	running := suite.helper.CreateTestTranscriptionJob(suite.T(), "Running Job")
+	waiting := suite.helper.CreateTestTranscriptionJob(suite.T(), "Waiting Job")
+
+	assert.NoError(suite.T(), tq.EnqueueJob(running.ID))
+	assert.Eventually(suite.T(), func() bool {
+		return tq.IsJobRunning(running.ID)
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NoError(suite.T(), tq.EnqueueJob(waiting.ID))
+	tq.Drain()
+	assert.Equal(suite.T(), queue.StateDraining, tq.State())
+
+	assert.Eventually(suite.T(), func() bool {
+		return tq.State() == queue.StatePaused
+	}, 2*time.Second, 50*time.Millisecond, "Queue should be drained once the running job finishes")
+
+	updatedJob, err := tq.GetJobStatus(running.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), models.StatusCompleted, updatedJob.Status)
+	assert.Equal(suite.T(), []string{waiting.ID}, tq.PendingJobs())
+	mockProcessor.AssertNotCalled(suite.T(), "ProcessJobWithProcess", mock.Anything, waiting.ID, mock.Anything)
+}
+
+// Test tasks wait while paused and hold off draining until they finish
+func (suite *QueueTestSuite) TestSubmitTask() {
+	tq := queue.NewTaskQueue(1, &MockJobProcessor{}, suite.jobRepo)
+	tq.Start()
+	defer tq.Stop()
+
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	tq.Pause()
+	assert.NoError(suite.T(), tq.SubmitTask(func(ctx context.Context) {
+		close(started)
+		<-finish
+	}))
+
+	// Task must not start while paused
+	select {
+	case <-started:
+		suite.T().Fatal("Task started while the queue was paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	tq.Resume()
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		suite.T().Fatal("Task should run after resume")
+	}
+
+	tq.Drain()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(suite.T(), queue.StateDraining, tq.State())
+
+	close(finish)
+	assert.Eventually(suite.T(), func() bool {
+		return tq.State() == queue.StatePaused
+	}, 2*time.Second, 20*time.Millisecond, "Queue should be drained once the task finishes")
+}
+
+// Test cancelling pending jobs
+func (suite *QueueTestSuite) TestCancelPending() {
+	mockProcessor := &MockJobProcessor{}
+	tq := queue.NewTaskQueue(1, mockProcessor, suite.jobRepo)
+
+	first := suite.helper.CreateTestTranscriptionJob(suite.T(), "First Job")
+	second := suite.helper.CreateTestTranscriptionJob(suite.T(), "Second Job")
+	assert.NoError(suite.T(), tq.EnqueueJob(first.ID))
+	assert.NoError(suite.T(), tq.EnqueueJob(second.ID))
+
+	// Unknown jobs are rejected without cancelling anything
+	_, err := tq.CancelPending([]string{first.ID, "missing-job"})
+	assert.ErrorIs(suite.T(), err, queue.ErrJobNotPending)
+	assert.Len(suite.T(), tq.PendingJobs(), 2)
+
+	cancelled, err := tq.CancelPending([]string{first.ID})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{first.ID}, cancelled)
+	assert.Equal(suite.T(), []string{second.ID}, tq.PendingJobs())
+
+	updatedJob, err := tq.GetJobStatus(first.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), models.StatusFailed, updatedJob.Status)
+	assert.Contains(suite.T(), *updatedJob.ErrorMessage, "cancelled before it started")
+
+	// An empty list cancels everything that is pending
+	cancelled, err = tq.CancelPending(nil)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{second.ID}, cancelled)
+	assert.Empty(suite.T(), tq.PendingJobs())
+}
+
+// Test reordering pending jobs
+func (suite *QueueTestSuite) TestReorder() {
+	mockProcessor := &MockJobProcessor{}
+	tq := queue.NewTaskQueue(1, mockProcessor, suite.jobRepo)
+
+	for _, jobID := range []string{"job-a", "job-b", "job-c", "job-d"} {
+		assert.NoError(suite.T(), tq.EnqueueJob(jobID))
+	}
+
+	err := tq.Reorder([]string{"job-c", "job-a"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"job-c", "job-a", "job-b", "job-d"}, tq.PendingJobs())
+
+	err = tq.Reorder([]string{"job-x"})
+	assert.ErrorIs(suite.T(), err, queue.ErrJobNotPending)
+
+	err = tq.Reorder([]string{"job-b", "job-b"})
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), []string{"job-c", "job-a", "job-b", "job-d"}, tq.PendingJobs())
+}
+
+// Test leasing jobs to remote workers
+func (suite *QueueTestSuite) TestRemoteLease() {
+	mockProcessor := &MockJobProcessor{}
+	tq := queue.NewTaskQueue(1, mockProcessor, suite.jobRepo)
+	tq.DisableLocalWorkers()
+	tq.Start()
+	defer tq.Stop()
+
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Remote Job")
+	assert.NoError(suite.T(), tq.EnqueueJob(job.ID))
+
+	jobID, err := tq.LeaseJob("worker-1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), job.ID, jobID)
+	assert.Empty(suite.T(), tq.PendingJobs())
+	assert.True(suite.T(), tq.IsJobRunning(job.ID))
+
+	updatedJob, err := tq.GetJobStatus(job.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), models.StatusProcessing, updatedJob.Status)
+
+	// Only the holder can renew the lease
+	_, err = tq.RenewLease(job.ID, "worker-2")
+	assert.ErrorIs(suite.T(), err, queue.ErrLeaseNotFound)
+	cancel, err := tq.RenewLease(job.ID, "worker-1")
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), cancel)
+
+	// Released jobs go back to the front of the queue
+	other := suite.helper.CreateTestTranscriptionJob(suite.T(), "Other Job")
+	assert.NoError(suite.T(), tq.EnqueueJob(other.ID))
+	assert.NoError(suite.T(), tq.ReleaseLease(job.ID, "worker-1"))
+	assert.Equal(suite.T(), []string{job.ID, other.ID}, tq.PendingJobs())
+	updatedJob, err = tq.GetJobStatus(job.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), models.StatusPending, updatedJob.Status)
+
+	// Killing a leased job tells the worker to stop and discards its result
+	jobID, err = tq.LeaseJob("worker-1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), job.ID, jobID)
+	assert.NoError(suite.T(), tq.KillJob(job.ID))
+
+	cancel, err = tq.RenewLease(job.ID, "worker-1")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), cancel)
+	killed, err := tq.CompleteLease(job.ID, "worker-1")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), killed)
+	assert.False(suite.T(), tq.IsJobRunning(job.ID))
+
+	updatedJob, err = tq.GetJobStatus(job.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), models.StatusFailed, updatedJob.Status)
+	mockProcessor.AssertNotCalled(suite.T(), "ProcessJobWithProcess", mock.Anything, mock.Anything)
+}
+
+// Test that multi-track jobs and paused queues are not leased
+func (suite *QueueTestSuite) TestRemoteLeaseSkipsIneligibleJobs() {
+	// Not started, so the local multi-track worker does not take the job first
+	tq := queue.NewTaskQueue(1, &MockJobProcessor{}, suite.jobRepo)
+	tq.DisableLocalWorkers()
+
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Multi-track Job")
+	suite.helper.DB.Model(job).Update("is_multi_track", true)
+	assert.NoError(suite.T(), tq.EnqueueJob(job.ID))
+
+	jobID, err := tq.LeaseJob("worker-1")
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), jobID)
+	assert.Equal(suite.T(), []string{job.ID}, tq.PendingJobs())
+
+	single := suite.helper.CreateTestTranscriptionJob(suite.T(), "Single Job")
+	assert.NoError(suite.T(), tq.EnqueueJob(single.ID))
+	tq.Pause()
+
+	jobID, err = tq.LeaseJob("worker-1")
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), jobID)
+
+	tq.Resume()
+	jobID, err = tq.LeaseJob("worker-1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), single.ID, jobID)
+}
+
+// Test that multi-track jobs still run locally when local workers are disabled
+func (suite *QueueTestSuite) TestDisabledLocalWorkersRunMultiTrackJobs() {
+	mockProcessor := &MockJobProcessor{}
+	tq := queue.NewTaskQueue(2, mockProcessor, suite.jobRepo)
+	tq.DisableLocalWorkers()
+	assert.Equal(suite.T(), 1, tq.MaxWorkers())
+
+	single := suite.helper.CreateTestTranscriptionJob(suite.T(), "Single Job")
+	multi := suite.helper.CreateTestTranscriptionJob(suite.T(), "Multi-track Job")
+	suite.helper.DB.Model(multi).Update("is_multi_track", true)
+	mockProcessor.On("ProcessJobWithProcess", mock.Anything, multi.ID).Return(nil)
+
+	tq.Start()
+	defer tq.Stop()
+	assert.NoError(suite.T(), tq.EnqueueJob(single.ID))
+	assert.NoError(suite.T(), tq.EnqueueJob(multi.ID))
+
+	assert.Eventually(suite.T(), func() bool {
+		job, err := tq.GetJobStatus(multi.ID)
+		return err == nil && job.Status == models.StatusCompleted
+	}, 5*time.Second, 20*time.Millisecond)
+
+	// The single-track job is left to remote workers
+	assert.Equal(suite.T(), []string{single.ID}, tq.PendingJobs())
+	mockProcessor.AssertNotCalled(suite.T(), "ProcessJobWithProcess", mock.Anything, single.ID)
+
+	jobID, err := tq.LeaseJob("worker-1")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), single.ID, jobID)
+}
+
+// Test that held dispatch keeps jobs from starting without changing the state
+func (suite *QueueTestSuite) TestHoldDispatch() {
+	mockProcessor := &MockJobProcessor{}
+	tq := queue.NewTaskQueue(1, mockProcessor, suite.jobRepo)
+
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Held Job")
+	mockProcessor.On("ProcessJobWithProcess", mock.Anything, job.ID).Return(nil)
+
+	release := tq.HoldDispatch()
+	tq.Start()
+	defer tq.Stop()
+	assert.NoError(suite.T(), tq.EnqueueJob(job.ID))
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(suite.T(), queue.StateRunning, tq.State())
+	assert.Equal(suite.T(), []string{job.ID}, tq.PendingJobs())
+	jobID, err := tq.LeaseJob("worker-1")
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), jobID)
+
+	// Releasing twice counts once
+	release()
+	release()
+	assert.Eventually(suite.T(), func() bool {
+		job, err := tq.GetJobStatus(job.ID)
+		return err == nil && job.Status == models.StatusCompleted
+	}, 5*time.Second, 20*time.Millisecond)
+}
@@ -72,6 +72,9 @@ func (suite *SecurityTestSuite) SetupSuite() {
 	noteRepo := repository.NewNoteRepository(database.DB)
 	speakerMappingRepo := repository.NewSpeakerMappingRepository(database.DB)
 	refreshTokenRepo := repository.NewRefreshTokenRepository(database.DB)
+	workerRepo := repository.NewWorkerRepository(database.DB)
+	customModelRepo := repository.NewCustomModelRepository(database.DB)
+	voiceRepo := repository.NewVoiceRepository(database.DB)
 
 	// Initialize services
 	userService := service.NewUserService(userRepo, suite.authService)
@@ -105,6 +108,9 @@ func (suite *SecurityTestSuite) SetupSuite() {
 		noteRepo,
 		speakerMappingRepo,
 		refreshTokenRepo,
+		workerRepo,
+		customModelRepo,
+		voiceRepo,
 		suite.taskQueue,
 		suite.unifiedProcessor,
 		suite.quickTranscriptionService,
@@ -326,6 +332,18 @@ func (suite *SecurityTestSuite) TestAdminEndpointsUnauthorized() {
 		body   interface{}
 	}{
 		{"GET", "/api/v1/admin/queue/stats", nil},
+		{"GET", "/api/v1/admin/queue/pending", nil},
+		{"POST", "/api/v1/admin/queue/pause", nil},
+		{"POST", "/api/v1/admin/queue/resume", nil},
+		{"POST", "/api/v1/admin/queue/drain", nil},
+		{"POST", "/api/v1/admin/queue/cancel", map[string]interface{}{"job_ids": []string{}}},
+		{"POST", "/api/v1/admin/queue/reorder", map[string]interface{}{"job_ids": []string{"job"}}},
+		{"GET", "/api/v1/admin/workers/", nil},
+		{"POST", "/api/v1/admin/workers/", map[string]interface{}{"name": "worker"}},
+		{"DELETE", "/api/v1/admin/workers/worker-id", nil},
+		{"POST", "/api/v1/worker/lease", nil},
+		{"POST", "/api/v1/worker/jobs/job-id/heartbeat", nil},
+		{"POST", "/api/v1/worker/jobs/job-id/complete", map[string]interface{}{"status": "completed"}},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"scriberr/internal/api"
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/interfaces"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test merging, reassigning and splitting the speakers of a transcript
+func (suite *APIHandlerTestSuite) TestSpeakerRelabel() {
+	speaker := func(label string) *string { return &label }
+	transcript := interfaces.TranscriptResult{
+		Language: "en",
+		Segments: []interfaces.TranscriptSegment{
+			{Start: 0, End: 2, Text: "Hello there.", Speaker: speaker("SPEAKER_00")},
+			{Start: 2, End: 4, Text: "Hi. Welcome.", Speaker: speaker("SPEAKER_01")},
+			{Start: 4, End: 6, Text: "Thanks.", Speaker: speaker("SPEAKER_02")},
+		},
+		WordSegments: []interfaces.TranscriptWord{
+			{Start: 0.0, End: 0.8, Word: "Hello", Speaker: speaker("SPEAKER_00")},
+			{Start: 0.9, End: 1.8, Word: "there.", Speaker: speaker("SPEAKER_00")},
+			{Start: 2.0, End: 2.6, Word: "Hi.", Speaker: speaker("SPEAKER_01")},
+			{Start: 2.8, End: 3.8, Word: "Welcome.", Speaker: speaker("SPEAKER_01")},
+			{Start: 4.0, End: 5.0, Word: "Thanks.", Speaker: speaker("SPEAKER_02")},
+		},
+	}
+	transcriptJSON, _ := json.Marshal(transcript)
+
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Relabel Speakers")
+	base := "/api/v1/transcription/" + job.ID + "/speakers/"
+	merge := map[string]interface{}{"source": "SPEAKER_02", "target": "SPEAKER_00"}
+
+	// Only finished transcripts can be relabelled
+	w := suite.makeAuthenticatedRequest("POST", base+"merge", merge, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/missing/speakers/merge", merge, false)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+
+	require.NoError(suite.T(), suite.helper.DB.Model(job).Updates(map[string]interface{}{
+		"status":      models.StatusCompleted,
+		"diarization": true,
+		"transcript":  string(transcriptJSON),
+	}).Error)
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/"+job.ID+"/speakers", map[string]interface{}{"mappings": []map[string]string{
+		{"original_speaker": "SPEAKER_00", "custom_name": "Alice"},
+		{"original_speaker": "SPEAKER_02", "custom_name": "Alice again"},
+	}}, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+
+	// Merging removes the merged speaker and its name
+	w = suite.makeAuthenticatedRequest("POST", base+"merge", map[string]interface{}{"source": "SPEAKER_09", "target": "SPEAKER_00"}, false)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", base+"merge", merge, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+	var response api.TranscriptRevisionResponse
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), 2, response.Revision.Revision)
+	assert.Equal(suite.T(), models.RevisionSourceSpeakerEdit, response.Revision.Source)
+	require.Len(suite.T(), response.Speakers, 1)
+	assert.Equal(suite.T(), "Alice", response.Speakers[0].CustomName)
+	var merged interfaces.TranscriptResult
+	require.NoError(suite.T(), json.Unmarshal([]byte(response.Revision.Transcript), &merged))
+	assert.Equal(suite.T(), "SPEAKER_00", *merged.Segments[2].Speaker)
+
+	// Reassigning a time range splits the segment it falls in
+	w = suite.makeAuthenticatedRequest("POST", base+"reassign", map[string]interface{}{"speaker": "SPEAKER_00"}, false)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", base+"reassign", map[string]interface{}{"speaker": "SPEAKER_00", "start": 2.7, "end": 4.0}, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	var reassigned interfaces.TranscriptResult
+	require.NoError(suite.T(), json.Unmarshal([]byte(response.Revision.Transcript), &reassigned))
+	require.Len(suite.T(), reassigned.Segments, 4)
+	assert.Equal(suite.T(), "Hi.", reassigned.Segments[1].Text)
+	assert.Equal(suite.T(), "SPEAKER_01", *reassigned.Segments[1].Speaker)
+	assert.Equal(suite.T(), "Welcome.", reassigned.Segments[2].Text)
+	assert.Equal(suite.T(), "SPEAKER_00", *reassigned.Segments[2].Speaker)
+	assert.Len(suite.T(), reassigned.WordSegments, len(transcript.WordSegments))
+
+	// Splitting off segments creates a named speaker
+	w = suite.makeAuthenticatedRequest("POST", base+"split", map[string]interface{}{"segments": []int{9}}, false)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", base+"split", map[string]interface{}{"segments": []int{3}, "name": "Carol"}, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	names := map[string]string{}
+	for _, mapping := range response.Speakers {
+		names[mapping.OriginalSpeaker] = mapping.CustomName
+	}
+	assert.Equal(suite.T(), map[string]string{"SPEAKER_00": "Alice", "SPEAKER_02": "Carol"}, names)
+
+	// The job's transcript is the latest revision, the original is kept
+	var updated models.TranscriptionJob
+	require.NoError(suite.T(), suite.helper.DB.First(&updated, "id = ?", job.ID).Error)
+	assert.Equal(suite.T(), response.Revision.Transcript, *updated.Transcript)
+	w = suite.makeAuthenticatedRequest("GET", "/api/v1/transcription/"+job.ID+"/revisions", nil, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code)
+	var revisions []models.TranscriptRevision
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &revisions))
+	require.Len(suite.T(), revisions, 4)
+	assert.Equal(suite.T(), string(transcriptJSON), revisions[0].Transcript)
+}
+
+// Test that splitting a speaker off a transcript without diarization marks it as diarized
+func (suite *APIHandlerTestSuite) TestSpeakerSplitMarksJobDiarized() {
+	transcript := interfaces.TranscriptResult{
+		Language: "en",
+		Segments: []interfaces.TranscriptSegment{
+			{Start: 0, End: 2, Text: "Hello there."},
+			{Start: 2, End: 4, Text: "Hi."},
+		},
+	}
+	transcriptJSON, _ := json.Marshal(transcript)
+
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Split Undiarized")
+	require.NoError(suite.T(), suite.helper.DB.Model(job).Updates(map[string]interface{}{
+		"status":     models.StatusCompleted,
+		"transcript": string(transcriptJSON),
+	}).Error)
+
+	w := suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/"+job.ID+"/speakers/split", map[string]interface{}{"segments": []int{1}, "name": "Bob"}, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+	var response api.TranscriptRevisionResponse
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(suite.T(), response.Speakers, 1)
+	assert.Equal(suite.T(), "Bob", response.Speakers[0].CustomName)
+
+	// The revision, the diarization flag and the mapping are saved together
+	var updated models.TranscriptionJob
+	require.NoError(suite.T(), suite.helper.DB.First(&updated, "id = ?", job.ID).Error)
+	assert.True(suite.T(), updated.Diarization)
+	assert.Equal(suite.T(), response.Revision.Transcript, *updated.Transcript)
+	assert.Equal(suite.T(), job.Title, updated.Title)
+	assert.Equal(suite.T(), models.StatusCompleted, updated.Status)
+}
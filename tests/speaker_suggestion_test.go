@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"unicode/utf8"
+
+	"scriberr/internal/api"
+	"scriberr/internal/llm"
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/interfaces"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// longSuggestedName is longer than a speaker name may be, in characters that
+// take two bytes
+var longSuggestedName = "Anna " + strings.Repeat("ö", 120)
+
+// speakerSuggestionReply is what the mock LLM answers: a good suggestion, one
+// for a speaker that does not exist, one with a made-up quote and one that is
+// unsure with a long name, wrapped in a code block
+var speakerSuggestionReply = "```json\n" + `{"suggestions": [
+  {"speaker": "SPEAKER_00", "name": "Maria", "role": "Finance", "confidence": 0.9, "quotes": ["Hi, this is Maria from finance."]},
+  {"speaker": "SPEAKER_07", "name": "Ghost", "confidence": 0.9, "quotes": ["Hi, this is Maria from finance"]},
+  {"speaker": "SPEAKER_01", "name": "Tom", "confidence": 0.8, "quotes": ["I am Tom"]},
+  {"speaker": "SPEAKER_02", "name": "` + longSuggestedName + `", "confidence": 0.3, "quotes": ["thanks maria"]}
+]}` + "\n```"
+
+// Test suggesting speaker names with the configured LLM
+func (suite *APIHandlerTestSuite) TestSuggestSpeakerNames() {
+	var prompt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var chatReq llm.ChatRequest
+		require.NoError(suite.T(), json.NewDecoder(r.Body).Decode(&chatReq))
+		prompt = chatReq.Messages[len(chatReq.Messages)-1].Content
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices": [{"index": 0, "message": {"role": "assistant", "content": ` + jsonString(speakerSuggestionReply) + `}, "finish_reason": "stop"}]}`))
+	}))
+	defer server.Close()
+	require.NoError(suite.T(), suite.helper.DB.Model(&models.LLMConfig{}).Where("is_active = ?", true).Update("OpenAIBaseURL", server.URL).Error)
+
+	speaker := func(label string) *string { return &label }
+	transcript := interfaces.TranscriptResult{
+		Segments: []interfaces.TranscriptSegment{
+			{Start: 0, End: 3, Text: "Hi, this is Maria from finance.", Speaker: speaker("SPEAKER_00")},
+			{Start: 3, End: 5, Text: "Thanks Maria, let's start.", Speaker: speaker("SPEAKER_02")},
+			{Start: 5, End: 7, Text: "Sounds good.", Speaker: speaker("SPEAKER_01")},
+		},
+	}
+	transcriptJSON, _ := json.Marshal(transcript)
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Suggest Speakers")
+	path := "/api/v1/transcription/" + job.ID + "/speakers/suggestions"
+	request := map[string]interface{}{"model": "gpt-4o", "min_confidence": 0.5}
+
+	w := suite.makeAuthenticatedRequest("POST", path, request, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", path, map[string]interface{}{}, false)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+
+	require.NoError(suite.T(), suite.helper.DB.Model(job).Updates(map[string]interface{}{
+		"status":      models.StatusCompleted,
+		"diarization": true,
+		"transcript":  string(transcriptJSON),
+	}).Error)
+	require.NoError(suite.T(), suite.helper.DB.Create(&models.SpeakerMapping{TranscriptionJobID: job.ID, OriginalSpeaker: "SPEAKER_02", CustomName: "Host"}).Error)
+
+	w = suite.makeAuthenticatedRequest("POST", path, request, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(suite.T(), prompt, "[SPEAKER_00] [00:00:00 - 00:00:03] Hi, this is Maria from finance.")
+	assert.Contains(suite.T(), prompt, `SPEAKER_02 (currently named "Host")`)
+
+	var response api.SpeakerSuggestionsResponse
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.False(suite.T(), response.Truncated)
+	require.Len(suite.T(), response.Suggestions, 1)
+	assert.Equal(suite.T(), api.SpeakerSuggestion{
+		OriginalSpeaker: "SPEAKER_00",
+		CurrentName:     "SPEAKER_00",
+		Name:            "Maria",
+		Role:            "Finance",
+		Confidence:      0.9,
+		Quotes:          []string{"Hi, this is Maria from finance."},
+	}, response.Suggestions[0])
+
+	// Accepting the proposed mappings names the speakers in bulk
+	assert.Equal(suite.T(), []api.SpeakerMappingRequest{
+		{OriginalSpeaker: "SPEAKER_00", CustomName: "Maria"},
+		{OriginalSpeaker: "SPEAKER_02", CustomName: "Host"},
+		{OriginalSpeaker: "SPEAKER_01", CustomName: "SPEAKER_01"},
+	}, response.Mappings)
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/"+job.ID+"/speakers", map[string]interface{}{"mappings": response.Mappings}, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+	var mappings []api.SpeakerMappingResponse
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &mappings))
+	assert.Len(suite.T(), mappings, 3)
+
+	// Less certain suggestions are included when asked for
+	w = suite.makeAuthenticatedRequest("POST", path, map[string]interface{}{"model": "gpt-4o"}, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+	response = api.SpeakerSuggestionsResponse{}
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(suite.T(), response.Suggestions, 2)
+	// Long names are cut to 100 characters, not bytes
+	assert.Equal(suite.T(), string([]rune(longSuggestedName)[:100]), response.Suggestions[1].Name)
+	assert.True(suite.T(), utf8.ValidString(response.Suggestions[1].Name))
+	assert.Equal(suite.T(), "Host", response.Suggestions[1].CurrentName)
+}
+
+// jsonString encodes a string as a JSON string literal
+func jsonString(s string) string {
+	encoded, _ := json.Marshal(s)
+	return string(encoded)
+}
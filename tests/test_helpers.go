@@ -100,6 +100,9 @@ func (h *TestHelper) ResetDB(t *testing.T) {
 		&models.SummaryTemplate{},
 		&models.LLMConfig{},
 		&models.APIKey{},
+		&models.Worker{},
+		&models.VoiceEmbedding{},
+		&models.Voice{},
 		&models.User{},
 	}
 
@@ -352,6 +355,19 @@ func (m *MockJobRepository) FindLatestCompletedExecution(ctx context.Context, jo
 	return args.Get(0).(*models.TranscriptionJobExecution), args.Error(1)
 }
 
+func (m *MockJobRepository) UpdateOriginalTranscript(ctx context.Context, jobID string, transcript string) error {
+	args := m.Called(ctx, jobID, transcript)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) FindLatestExecution(ctx context.Context, jobID string) (*models.TranscriptionJobExecution, error) {
+	args := m.Called(ctx, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TranscriptionJobExecution), args.Error(1)
+}
+
 func (m *MockJobRepository) UpdateStatus(ctx context.Context, jobID string, status models.JobStatus) error {
 	args := m.Called(ctx, jobID, status)
 	return args.Error(0)
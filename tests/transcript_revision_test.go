@@ -0,0 +1,414 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"scriberr/internal/api"
+	"scriberr/internal/models"
+	"scriberr/internal/queue"
+	"scriberr/internal/repository"
+	"scriberr/internal/transcription/adapters"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/registry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const revisionDiarizerJSON = `{
+  "id": "revision_diarizer",
+  "type": "diarization",
+  "command": ["sh", "-c", "printf '{\"segments\": [{\"start\": 0, \"end\": 2, \"speaker\": \"SPEAKER_01\"}, {\"start\": 2, \"end\": 4, \"speaker\": \"SPEAKER_00\"}]}' > {{.Output}}"]
+}`
+
+// useStartedQueue sends the test's requests to a handler whose task queue
+// runs, so that queued transcript tasks are processed. The suite's own queue
+// is never started, as other tests lease its pending jobs. Pending jobs are
+// picked up by the started queue, so jobs must be completed before.
+func (suite *APIHandlerTestSuite) useStartedQueue() {
+	taskQueue := queue.NewTaskQueue(1, suite.unifiedProcessor, repository.NewJobRepository(suite.helper.DB))
+	taskQueue.Start()
+
+	suiteQueue, handler, router := suite.taskQueue, suite.handler, suite.router
+	suite.taskQueue = taskQueue
+	suite.handler, suite.router = suite.newRouter(taskQueue)
+	suite.T().Cleanup(func() {
+		taskQueue.Stop()
+		suite.taskQueue, suite.handler, suite.router = suiteQueue, handler, router
+	})
+}
+
+// waitForTranscriptTask polls a job's transcript task until it is done
+func (suite *APIHandlerTestSuite) waitForTranscriptTask(jobID string) api.TranscriptTask {
+	var task api.TranscriptTask
+	require.Eventually(suite.T(), func() bool {
+		w := suite.makeAuthenticatedRequest("GET", "/api/v1/transcription/"+jobID+"/transcript-task", nil, false)
+		if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &task) != nil {
+			return false
+		}
+		return task.Status == api.TranscriptTaskCompleted || task.Status == api.TranscriptTaskFailed
+	}, 10*time.Second, 20*time.Millisecond, "Transcript task did not finish")
+	return task
+}
+
+// Test diarizing a completed transcript into a new revision
+func (suite *APIHandlerTestSuite) TestDiarizeTranscript() {
+	if runtime.GOOS == "windows" {
+		suite.T().Skip("plugins are shell scripts")
+	}
+
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+	dir := suite.T().TempDir()
+	require.NoError(suite.T(), os.MkdirAll(filepath.Join(dir, "diarizer"), 0755))
+	require.NoError(suite.T(), os.WriteFile(filepath.Join(dir, "diarizer", "plugin.json"), []byte(revisionDiarizerJSON), 0644))
+	manifests, errs := adapters.LoadPluginManifests(dir)
+	require.Empty(suite.T(), errs)
+	require.Len(suite.T(), manifests, 1)
+	registry.RegisterDiarizationAdapter(manifests[0].ID, adapters.NewPluginAdapter(manifests[0]))
+
+	audioPath := filepath.Join(dir, "audio.wav")
+	require.NoError(suite.T(), os.WriteFile(audioPath, []byte("RIFF"), 0644))
+
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Diarize Later")
+	// The suite's processor writes job output relative to the working directory
+	defer func() {
+		_ = os.RemoveAll(job.ID)
+		_ = os.RemoveAll(manifests[0].ID)
+	}()
+	request := map[string]interface{}{"diarize_model": "revision_diarizer"}
+
+	// Only finished transcripts can be diarized
+	w := suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/"+job.ID+"/diarize", request, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/missing/diarize", request, false)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+
+	// An edited transcript without speakers
+	transcript := interfaces.TranscriptResult{
+		Language: "en",
+		Segments: []interfaces.TranscriptSegment{{Start: 0, End: 4, Text: "Hello there. Hi!"}},
+		WordSegments: []interfaces.TranscriptWord{
+			{Start: 0.2, End: 0.8, Word: "Hello"},
+			{Start: 0.9, End: 1.6, Word: "there."},
+			{Start: 2.3, End: 3.0, Word: "Hi."},
+		},
+	}
+	transcriptJSON, _ := json.Marshal(transcript)
+	require.NoError(suite.T(), suite.helper.DB.Model(job).Updates(map[string]interface{}{
+		"status":     models.StatusCompleted,
+		"audio_path": audioPath,
+		"transcript": string(transcriptJSON),
+	}).Error)
+
+	suite.useStartedQueue()
+	w = suite.makeAuthenticatedRequest("GET", "/api/v1/transcription/"+job.ID+"/transcript-task", nil, false)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/"+job.ID+"/diarize", map[string]interface{}{"diarize_model": "unknown"}, false)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/"+job.ID+"/diarize", request, false)
+	require.Equal(suite.T(), http.StatusAccepted, w.Code, w.Body.String())
+	var queued api.TranscriptTask
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &queued))
+	assert.Equal(suite.T(), "diarize", queued.Kind)
+	assert.Equal(suite.T(), api.TranscriptTaskQueued, queued.Status)
+
+	task := suite.waitForTranscriptTask(job.ID)
+	require.Equal(suite.T(), api.TranscriptTaskCompleted, task.Status, task.Error)
+	require.NotNil(suite.T(), task.Revision)
+	assert.Equal(suite.T(), 2, task.Revision.Revision)
+	assert.Equal(suite.T(), models.RevisionSourceDiarization, task.Revision.Source)
+	var mappings int64
+	suite.helper.DB.Model(&models.SpeakerMapping{}).Where("transcription_job_id = ?", job.ID).Count(&mappings)
+	assert.Equal(suite.T(), int64(2), mappings)
+
+	// The edited segment keeps its text and gets its main speaker
+	var diarized interfaces.TranscriptResult
+	require.NoError(suite.T(), json.Unmarshal([]byte(task.Revision.Transcript), &diarized))
+	require.Len(suite.T(), diarized.Segments, 1)
+	assert.Equal(suite.T(), "Hello there. Hi!", diarized.Segments[0].Text)
+	assert.Equal(suite.T(), "SPEAKER_01", *diarized.Segments[0].Speaker)
+
+	w = suite.makeAuthenticatedRequest("GET", "/api/v1/transcription/"+job.ID, nil, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code)
+	var updated models.TranscriptionJob
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.True(suite.T(), updated.Diarization)
+	assert.Equal(suite.T(), task.Revision.Transcript, *updated.Transcript)
+
+	// The original transcript is kept as the first revision
+	w = suite.makeAuthenticatedRequest("GET", "/api/v1/transcription/"+job.ID+"/revisions", nil, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code)
+	var revisions []models.TranscriptRevision
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &revisions))
+	require.Len(suite.T(), revisions, 2)
+	assert.Equal(suite.T(), models.RevisionSourceTranscription, revisions[0].Source)
+	assert.Equal(suite.T(), string(transcriptJSON), revisions[0].Transcript)
+}
+
+// Test that a job is diarized once at a time, not while the queue is paused,
+// and without losing changes made to the job meanwhile
+func (suite *APIHandlerTestSuite) TestDiarizeTranscriptConcurrentChanges() {
+	if runtime.GOOS == "windows" {
+		suite.T().Skip("plugins are shell scripts")
+	}
+
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+	dir := suite.T().TempDir()
+	started := filepath.Join(dir, "started")
+	release := filepath.Join(dir, "release")
+	// The diarizer waits until the test releases it
+	manifest := strings.Replace(revisionDiarizerJSON, `"sh", "-c", "`, `"sh", "-c", "touch `+started+`; while [ ! -f `+release+` ]; do sleep 0.05; done; `, 1)
+	require.NoError(suite.T(), os.MkdirAll(filepath.Join(dir, "diarizer"), 0755))
+	require.NoError(suite.T(), os.WriteFile(filepath.Join(dir, "diarizer", "plugin.json"), []byte(manifest), 0644))
+	manifests, errs := adapters.LoadPluginManifests(dir)
+	require.Empty(suite.T(), errs)
+	require.Len(suite.T(), manifests, 1)
+	registry.RegisterDiarizationAdapter(manifests[0].ID, adapters.NewPluginAdapter(manifests[0]))
+
+	audioPath := filepath.Join(dir, "audio.wav")
+	require.NoError(suite.T(), os.WriteFile(audioPath, []byte("RIFF"), 0644))
+
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Diarize Once")
+	defer func() {
+		_ = os.RemoveAll(job.ID)
+		_ = os.RemoveAll(manifests[0].ID)
+	}()
+	transcriptJSON, _ := json.Marshal(interfaces.TranscriptResult{
+		Language:     "en",
+		Segments:     []interfaces.TranscriptSegment{{Start: 0, End: 4, Text: "Hello there."}},
+		WordSegments: []interfaces.TranscriptWord{{Start: 0.2, End: 0.8, Word: "Hello"}, {Start: 0.9, End: 1.6, Word: "there."}},
+	})
+	require.NoError(suite.T(), suite.helper.DB.Model(job).Updates(map[string]interface{}{
+		"status":     models.StatusCompleted,
+		"audio_path": audioPath,
+		"transcript": string(transcriptJSON),
+	}).Error)
+	request := map[string]interface{}{"diarize_model": "revision_diarizer"}
+	suite.useStartedQueue()
+
+	suite.taskQueue.Pause()
+	w := suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/"+job.ID+"/diarize", request, false)
+	suite.taskQueue.Resume()
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code)
+
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/"+job.ID+"/diarize", request, false)
+	require.Equal(suite.T(), http.StatusAccepted, w.Code, w.Body.String())
+	require.Eventually(suite.T(), func() bool {
+		_, err := os.Stat(started)
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond, "Diarization should start")
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/"+job.ID+"/diarize", request, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+
+	// The job is renamed while it is diarized
+	require.NoError(suite.T(), suite.helper.DB.Model(job).Update("title", "Renamed").Error)
+	require.NoError(suite.T(), os.WriteFile(release, nil, 0644))
+	task := suite.waitForTranscriptTask(job.ID)
+	assert.Equal(suite.T(), api.TranscriptTaskCompleted, task.Status, task.Error)
+
+	var updated models.TranscriptionJob
+	require.NoError(suite.T(), suite.helper.DB.First(&updated, "id = ?", job.ID).Error)
+	assert.True(suite.T(), updated.Diarization)
+	assert.Equal(suite.T(), "revision_diarizer", updated.Parameters.DiarizeModel)
+	require.NotNil(suite.T(), updated.Title)
+	assert.Equal(suite.T(), "Renamed", *updated.Title)
+	var mappings int64
+	suite.helper.DB.Model(&models.SpeakerMapping{}).Where("transcription_job_id = ?", job.ID).Count(&mappings)
+	assert.Equal(suite.T(), int64(1), mappings)
+}
+
+// revisionAligner times each word of a segment one after another, a tenth of
+// a second each. With release set it closes started and waits for release first.
+type revisionAligner struct {
+	*adapters.BaseAdapter
+	started chan struct{}
+	release chan struct{}
+}
+
+func (a *revisionAligner) Transcribe(ctx context.Context, input interfaces.AudioInput, params map[string]interface{}, procCtx interfaces.ProcessingContext) (*interfaces.TranscriptResult, error) {
+	return &interfaces.TranscriptResult{}, nil
+}
+
+func (a *revisionAligner) GetSupportedModels() []string { return nil }
+
+func (a *revisionAligner) AlignWords(ctx context.Context, input interfaces.AudioInput, segments []interfaces.TranscriptSegment, params map[string]interface{}, procCtx interfaces.ProcessingContext) ([][]interfaces.TranscriptWord, error) {
+	if a.release != nil {
+		close(a.started)
+		<-a.release
+	}
+	aligned := make([][]interfaces.TranscriptWord, len(segments))
+	for i, segment := range segments {
+		for j, word := range strings.Fields(segment.Text) {
+			start := segment.Start + float64(j)/10
+			aligned[i] = append(aligned[i], interfaces.TranscriptWord{Start: start, End: start + 0.1, Word: word})
+		}
+	}
+	return aligned, nil
+}
+
+// Test aligning the words of an edited transcript into a new revision
+func (suite *APIHandlerTestSuite) TestAlignTranscript() {
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+	dir := suite.T().TempDir()
+	audioPath := filepath.Join(dir, "audio.wav")
+	require.NoError(suite.T(), os.WriteFile(audioPath, []byte("RIFF"), 0644))
+
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Align Later")
+	defer func() { _ = os.RemoveAll(job.ID) }()
+	path := "/api/v1/transcription/" + job.ID + "/align"
+
+	w := suite.makeAuthenticatedRequest("POST", path, nil, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/missing/align", nil, false)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+
+	// The text was edited, so the words no longer match it
+	speaker := "SPEAKER_00"
+	transcript := interfaces.TranscriptResult{
+		Language:     "en",
+		Segments:     []interfaces.TranscriptSegment{{Start: 1, End: 2, Text: "Good morning all", Speaker: &speaker}},
+		WordSegments: []interfaces.TranscriptWord{{Start: 1, End: 2, Word: "Morning"}},
+	}
+	transcriptJSON, _ := json.Marshal(transcript)
+	require.NoError(suite.T(), suite.helper.DB.Model(job).Updates(map[string]interface{}{
+		"status":     models.StatusCompleted,
+		"audio_path": audioPath,
+		"transcript": string(transcriptJSON),
+	}).Error)
+
+	note := models.Note{ID: "align-note", TranscriptionID: job.ID, StartWordIndex: 0, EndWordIndex: 0, StartTime: 1.12, EndTime: 1.18, Quote: "morning", Content: "Greeting"}
+	require.NoError(suite.T(), suite.helper.DB.Create(&note).Error)
+
+	// Without a ready alignment model
+	suite.useStartedQueue()
+	w = suite.makeAuthenticatedRequest("POST", path, nil, false)
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code)
+
+	capabilities := interfaces.ModelCapabilities{ModelID: "revision_aligner", ModelFamily: "fake", SupportedFormats: []string{"wav"}}
+	aligner := &revisionAligner{BaseAdapter: adapters.NewBaseAdapter("revision_aligner", "", capabilities, nil)}
+	require.NoError(suite.T(), aligner.PrepareEnvironment(context.Background()))
+	registry.RegisterTranscriptionAdapter("revision_aligner", aligner)
+
+	w = suite.makeAuthenticatedRequest("POST", path, map[string]interface{}{"language": "en"}, false)
+	require.Equal(suite.T(), http.StatusAccepted, w.Code, w.Body.String())
+	task := suite.waitForTranscriptTask(job.ID)
+	require.Equal(suite.T(), api.TranscriptTaskCompleted, task.Status, task.Error)
+	require.NotNil(suite.T(), task.Revision)
+	assert.Equal(suite.T(), 2, task.Revision.Revision)
+	assert.Equal(suite.T(), models.RevisionSourceAlignment, task.Revision.Source)
+
+	var aligned interfaces.TranscriptResult
+	require.NoError(suite.T(), json.Unmarshal([]byte(task.Revision.Transcript), &aligned))
+	assert.Equal(suite.T(), transcript.Segments, aligned.Segments)
+	require.Len(suite.T(), aligned.WordSegments, 3)
+	assert.Equal(suite.T(), "morning", aligned.WordSegments[1].Word)
+	assert.InDelta(suite.T(), 1.1, aligned.WordSegments[1].Start, 1e-9)
+	assert.Equal(suite.T(), "SPEAKER_00", *aligned.WordSegments[1].Speaker)
+
+	// Notes point at the aligned words in their time span
+	require.NoError(suite.T(), suite.helper.DB.First(&note, "id = ?", note.ID).Error)
+	assert.Equal(suite.T(), 1, note.StartWordIndex)
+	assert.Equal(suite.T(), 1, note.EndWordIndex)
+}
+
+// Test that alignment waits for the queue and runs alone on its job
+func (suite *APIHandlerTestSuite) TestAlignTranscriptConcurrentChanges() {
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+	dir := suite.T().TempDir()
+	audioPath := filepath.Join(dir, "audio.wav")
+	require.NoError(suite.T(), os.WriteFile(audioPath, []byte("RIFF"), 0644))
+
+	capabilities := interfaces.ModelCapabilities{ModelID: "revision_aligner", ModelFamily: "fake", SupportedFormats: []string{"wav"}}
+	aligner := &revisionAligner{
+		BaseAdapter: adapters.NewBaseAdapter("revision_aligner", "", capabilities, nil),
+		started:     make(chan struct{}),
+		release:     make(chan struct{}),
+	}
+	require.NoError(suite.T(), aligner.PrepareEnvironment(context.Background()))
+	registry.RegisterTranscriptionAdapter("revision_aligner", aligner)
+
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Align Once")
+	defer func() { _ = os.RemoveAll(job.ID) }()
+	transcriptJSON, _ := json.Marshal(interfaces.TranscriptResult{
+		Language: "en",
+		Segments: []interfaces.TranscriptSegment{{Start: 0, End: 2, Text: "Hello there"}},
+	})
+	require.NoError(suite.T(), suite.helper.DB.Model(job).Updates(map[string]interface{}{
+		"status":     models.StatusCompleted,
+		"audio_path": audioPath,
+		"transcript": string(transcriptJSON),
+	}).Error)
+	path := "/api/v1/transcription/" + job.ID + "/align"
+	request := map[string]interface{}{"language": "en"}
+	suite.useStartedQueue()
+
+	suite.taskQueue.Pause()
+	w := suite.makeAuthenticatedRequest("POST", path, request, false)
+	suite.taskQueue.Resume()
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code)
+
+	w = suite.makeAuthenticatedRequest("POST", path, request, false)
+	require.Equal(suite.T(), http.StatusAccepted, w.Code, w.Body.String())
+	select {
+	case <-aligner.started:
+	case <-time.After(5 * time.Second):
+		suite.T().Fatal("Alignment should start")
+	}
+
+	// Neither another alignment nor a range transcription of the job may run alongside it
+	w = suite.makeAuthenticatedRequest("POST", path, request, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/"+job.ID+"/retranscribe", map[string]interface{}{"start": 0.0, "end": 1.0}, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "still queued or running")
+	// Speaker edits would be lost when the alignment is saved
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/"+job.ID+"/speakers/split", map[string]interface{}{"segments": []int{0}}, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+
+	close(aligner.release)
+	task := suite.waitForTranscriptTask(job.ID)
+	assert.Equal(suite.T(), api.TranscriptTaskCompleted, task.Status, task.Error)
+}
+
+// Test the checks made before transcribing a time range again
+func (suite *APIHandlerTestSuite) TestRetranscribeRangeValidation() {
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Retranscribe Range")
+	path := "/api/v1/transcription/" + job.ID + "/retranscribe"
+	request := map[string]interface{}{"start": 2.0, "end": 5.0, "language": "de"}
+
+	w := suite.makeAuthenticatedRequest("POST", path, request, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", "/api/v1/transcription/missing/retranscribe", request, false)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+
+	require.NoError(suite.T(), suite.helper.DB.Model(job).Updates(map[string]interface{}{
+		"status":     models.StatusCompleted,
+		"transcript": `{"segments": [{"start": 0, "end": 2, "text": "Hello."}]}`,
+	}).Error)
+
+	w = suite.makeAuthenticatedRequest("POST", path, map[string]interface{}{"start": 5.0, "end": 2.0}, false)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", path, map[string]interface{}{"start": 2.0}, false)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	w = suite.makeAuthenticatedRequest("POST", path, map[string]interface{}{"start": 2.0, "end": 5.0, "model_family": "openai"}, false)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "model is required")
+
+	// No model is run while the queue is paused
+	suite.taskQueue.Pause()
+	w = suite.makeAuthenticatedRequest("POST", path, request, false)
+	suite.taskQueue.Resume()
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code)
+}
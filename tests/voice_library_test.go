@@ -0,0 +1,198 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"scriberr/internal/api"
+	"scriberr/internal/models"
+	"scriberr/internal/transcription/adapters"
+	"scriberr/internal/transcription/interfaces"
+	"scriberr/internal/transcription/registry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEmbedder is a diarization adapter computing voice embeddings from the
+// clip's contents: recordings mentioning "alice" point one way, others the other
+type fakeEmbedder struct {
+	*adapters.BaseAdapter
+}
+
+func newFakeEmbedder() *fakeEmbedder {
+	capabilities := interfaces.ModelCapabilities{ModelID: "fake_embedder", ModelFamily: "fake", SupportedFormats: []string{"wav"}}
+	return &fakeEmbedder{BaseAdapter: adapters.NewBaseAdapter("fake_embedder", "", capabilities, nil)}
+}
+
+func (f *fakeEmbedder) Diarize(ctx context.Context, input interfaces.AudioInput, params map[string]interface{}, procCtx interfaces.ProcessingContext) (*interfaces.DiarizationResult, error) {
+	return &interfaces.DiarizationResult{}, nil
+}
+
+func (f *fakeEmbedder) GetMaxSpeakers() int { return 10 }
+func (f *fakeEmbedder) GetMinSpeakers() int { return 1 }
+
+func (f *fakeEmbedder) EmbedSpeakers(ctx context.Context, clips []string, params map[string]interface{}, procCtx interfaces.ProcessingContext) ([][]float64, error) {
+	embeddings := make([][]float64, len(clips))
+	for i, clip := range clips {
+		data, err := os.ReadFile(clip)
+		if err != nil {
+			return nil, err
+		}
+		embeddings[i] = []float64{0, 1}
+		if strings.Contains(string(data), "alice") {
+			embeddings[i] = []float64{1, 0}
+		}
+	}
+	return embeddings, nil
+}
+
+func (f *fakeEmbedder) EmbeddingModel() string { return "fake" }
+
+// uploadVoice posts a voice recording to the speaker library
+func (suite *APIHandlerTestSuite) uploadVoice(path, name, content string) *httptest.ResponseRecorder {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if name != "" {
+		require.NoError(suite.T(), writer.WriteField("name", name))
+	}
+	if content != "" {
+		part, err := writer.CreateFormFile("audio", "voice.wav")
+		require.NoError(suite.T(), err)
+		_, err = part.Write([]byte("RIFF" + content))
+		require.NoError(suite.T(), err)
+	}
+	require.NoError(suite.T(), writer.Close())
+
+	req, _ := http.NewRequest("POST", path, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("X-API-Key", suite.helper.TestAPIKey)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+// Test enrolling voices and confirming the voices of a job's speakers
+func (suite *APIHandlerTestSuite) TestVoiceLibrary() {
+	registry.ClearRegistry()
+	defer registry.ClearRegistry()
+
+	// Enrolling needs an adapter that computes embeddings
+	w := suite.uploadVoice("/api/v1/voices/", "Alice", "alice")
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code)
+
+	embedder := newFakeEmbedder()
+	require.NoError(suite.T(), embedder.PrepareEnvironment(context.Background()))
+	registry.RegisterDiarizationAdapter("fake_embedder", embedder)
+
+	w = suite.uploadVoice("/api/v1/voices/", "", "alice")
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	w = suite.uploadVoice("/api/v1/voices/", "Alice", "")
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+
+	w = suite.uploadVoice("/api/v1/voices/", "Alice", "alice")
+	require.Equal(suite.T(), http.StatusCreated, w.Code, w.Body.String())
+	var alice api.VoiceResponse
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &alice))
+	assert.Equal(suite.T(), "Alice", alice.Name)
+	assert.Equal(suite.T(), int64(1), alice.EmbeddingCount)
+
+	w = suite.uploadVoice("/api/v1/voices/", "Alice", "alice")
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+
+	w = suite.uploadVoice("/api/v1/voices/"+alice.ID+"/samples", "", "alice again")
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &alice))
+	assert.Equal(suite.T(), int64(2), alice.EmbeddingCount)
+	w = suite.uploadVoice("/api/v1/voices/missing/samples", "", "alice")
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+
+	// A diarized job whose speaker embedding was computed when it finished
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Voice Library")
+	job.Status = models.StatusCompleted
+	job.Diarization = true
+	require.NoError(suite.T(), suite.helper.DB.Save(job).Error)
+	speaker := "SPEAKER_00"
+	embedding := models.VoiceEmbedding{TranscriptionJobID: &job.ID, Speaker: &speaker, Model: "fake"}
+	require.NoError(suite.T(), embedding.SetValues([]float64{0, 1}))
+	require.NoError(suite.T(), suite.helper.DB.Create(&embedding).Error)
+
+	voicePath := "/api/v1/transcription/" + job.ID + "/speakers/" + speaker + "/voice"
+	w = suite.makeAuthenticatedRequest("PUT", voicePath, map[string]interface{}{}, false)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	w = suite.makeAuthenticatedRequest("PUT", voicePath, map[string]interface{}{"voice_id": "missing"}, false)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+
+	// Naming the speaker enrolls a new voice from the job
+	w = suite.makeAuthenticatedRequest("PUT", voicePath, map[string]interface{}{"name": "Bob"}, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+	var confirmed api.SpeakerVoiceResponse
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &confirmed))
+	assert.True(suite.T(), confirmed.LibraryUpdated)
+	assert.True(suite.T(), confirmed.Mapping.Confirmed)
+	assert.Equal(suite.T(), "Bob", confirmed.Mapping.CustomName)
+	require.NotNil(suite.T(), confirmed.Mapping.VoiceID)
+	bobID := *confirmed.Mapping.VoiceID
+
+	w = suite.makeAuthenticatedRequest("GET", "/api/v1/voices/", nil, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code)
+	var voices []api.VoiceResponse
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &voices))
+	require.Len(suite.T(), voices, 2)
+	assert.Equal(suite.T(), "Alice", voices[0].Name)
+	assert.Equal(suite.T(), "Bob", voices[1].Name)
+	assert.Equal(suite.T(), int64(1), voices[1].EmbeddingCount)
+
+	// Renaming a voice renames its speakers
+	w = suite.makeAuthenticatedRequest("PUT", "/api/v1/voices/"+bobID, map[string]interface{}{"name": "Alice"}, false)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	w = suite.makeAuthenticatedRequest("PUT", "/api/v1/voices/"+bobID, map[string]interface{}{"name": "Robert"}, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+
+	w = suite.makeAuthenticatedRequest("GET", "/api/v1/transcription/"+job.ID+"/speakers", nil, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code)
+	var mappings []api.SpeakerMappingResponse
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &mappings))
+	require.Len(suite.T(), mappings, 1)
+	assert.Equal(suite.T(), "Robert", mappings[0].CustomName)
+	assert.True(suite.T(), mappings[0].Confirmed)
+
+	// Correcting the speaker moves its embedding to the other voice
+	w = suite.makeAuthenticatedRequest("PUT", voicePath, map[string]interface{}{"voice_id": alice.ID}, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &confirmed))
+	assert.Equal(suite.T(), "Alice", confirmed.Mapping.CustomName)
+	var stored models.VoiceEmbedding
+	require.NoError(suite.T(), suite.helper.DB.First(&stored, embedding.ID).Error)
+	require.NotNil(suite.T(), stored.VoiceID)
+	assert.Equal(suite.T(), alice.ID, *stored.VoiceID)
+
+	// Rejecting it takes the embedding out of the library
+	w = suite.makeAuthenticatedRequest("DELETE", voicePath, nil, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+	require.NoError(suite.T(), suite.helper.DB.First(&stored, embedding.ID).Error)
+	assert.Nil(suite.T(), stored.VoiceID)
+	w = suite.makeAuthenticatedRequest("GET", "/api/v1/transcription/"+job.ID+"/speakers", nil, false)
+	mappings = nil
+	require.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &mappings))
+	require.Len(suite.T(), mappings, 1)
+	assert.Equal(suite.T(), speaker, mappings[0].CustomName)
+	assert.Nil(suite.T(), mappings[0].VoiceID)
+	w = suite.makeAuthenticatedRequest("DELETE", voicePath, nil, false)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+
+	// Deleting a voice removes its enrolled samples
+	w = suite.makeAuthenticatedRequest("DELETE", "/api/v1/voices/"+alice.ID, nil, false)
+	require.Equal(suite.T(), http.StatusOK, w.Code)
+	w = suite.makeAuthenticatedRequest("DELETE", "/api/v1/voices/"+alice.ID, nil, false)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	var remaining int64
+	require.NoError(suite.T(), suite.helper.DB.Model(&models.VoiceEmbedding{}).Where("voice_id = ?", alice.ID).Count(&remaining).Error)
+	assert.Zero(suite.T(), remaining)
+}
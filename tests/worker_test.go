@@ -0,0 +1,248 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"scriberr/internal/api"
+	"scriberr/internal/models"
+	"scriberr/internal/repository"
+	"scriberr/internal/webhook"
+	"scriberr/internal/worker"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// registerTestWorker creates a remote worker and returns its token
+func (suite *APIHandlerTestSuite) registerTestWorker() api.CreateWorkerResponse {
+	w := suite.makeAuthenticatedRequest("POST", "/api/v1/admin/workers/", map[string]string{"name": "gpu-box"}, false)
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+
+	var created api.CreateWorkerResponse
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &created))
+	assert.NotEmpty(suite.T(), created.Token)
+	return created
+}
+
+// createRemoteTestJob creates a pending job with a real audio file and queues it
+func (suite *APIHandlerTestSuite) createRemoteTestJob() *models.TranscriptionJob {
+	audioPath := filepath.Join(suite.T().TempDir(), "remote.mp3")
+	assert.NoError(suite.T(), os.WriteFile(audioPath, []byte("remote audio data"), 0644))
+
+	job := suite.helper.CreateTestTranscriptionJob(suite.T(), "Remote Job")
+	suite.helper.DB.Model(job).Update("audio_path", audioPath)
+	assert.NoError(suite.T(), suite.taskQueue.EnqueueJob(job.ID))
+	return job
+}
+
+func (suite *APIHandlerTestSuite) makeWorkerRequest(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	var reader *bytes.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, _ := http.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set(worker.TokenHeader, token)
+	}
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+// Test the worker lease, heartbeat and complete endpoints
+func (suite *APIHandlerTestSuite) TestRemoteWorkerEndpoints() {
+	transcriptsDir := suite.helper.Config.TranscriptsDir
+	suite.helper.Config.TranscriptsDir = suite.T().TempDir()
+	defer func() { suite.helper.Config.TranscriptsDir = transcriptsDir }()
+
+	created := suite.registerTestWorker()
+	job := suite.createRemoteTestJob()
+	custom := models.CustomModel{Name: "remote-custom", BaseAdapter: "whisperx", Source: "org/remote-custom"}
+	assert.NoError(suite.T(), suite.helper.DB.Create(&custom).Error)
+	defer suite.helper.DB.Delete(&custom)
+
+	w := suite.makeWorkerRequest("POST", "/api/v1/worker/lease", "", nil)
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+
+	w = suite.makeWorkerRequest("POST", "/api/v1/worker/lease", created.Token, nil)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var lease worker.LeaseResponse
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &lease))
+	assert.Equal(suite.T(), job.ID, lease.Job.ID)
+	assert.Greater(suite.T(), lease.LeaseTTLSeconds, 0)
+	if assert.Len(suite.T(), lease.CustomModels, 1) {
+		assert.Equal(suite.T(), "remote-custom", lease.CustomModels[0].Name)
+	}
+
+	w = suite.makeWorkerRequest("POST", "/api/v1/worker/lease", created.Token, nil)
+	assert.Equal(suite.T(), http.StatusNoContent, w.Code)
+
+	w = suite.makeWorkerRequest("GET", "/api/v1/worker/jobs/"+job.ID+"/audio", created.Token, nil)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "remote audio data", w.Body.String())
+
+	w = suite.makeWorkerRequest("POST", "/api/v1/worker/jobs/"+job.ID+"/heartbeat", created.Token, worker.HeartbeatRequest{Message: "Transcribing"})
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var heartbeat worker.HeartbeatResponse
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &heartbeat))
+	assert.False(suite.T(), heartbeat.Cancel)
+
+	// Speaker library matches of an earlier run of the job
+	voiceID := "remote-voice"
+	score := 0.9
+	speaker := "SPEAKER_00"
+	assert.NoError(suite.T(), suite.helper.DB.Create(&[]models.SpeakerMapping{
+		{TranscriptionJobID: job.ID, OriginalSpeaker: "SPEAKER_00", CustomName: "Alice", VoiceID: &voiceID, MatchScore: &score},
+		{TranscriptionJobID: job.ID, OriginalSpeaker: "SPEAKER_01", CustomName: "Bob", Confirmed: true},
+	}).Error)
+	embedding := models.VoiceEmbedding{TranscriptionJobID: &job.ID, Speaker: &speaker, Model: "test"}
+	assert.NoError(suite.T(), embedding.SetValues([]float64{1, 0}))
+	assert.NoError(suite.T(), suite.helper.DB.Create(&embedding).Error)
+
+	// The server sends the job's webhook once the result is stored
+	webhooks := make(chan webhook.WebhookPayload, 1)
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload webhook.WebhookPayload
+		assert.NoError(suite.T(), json.NewDecoder(r.Body).Decode(&payload))
+		webhooks <- payload
+	}))
+	defer callback.Close()
+	suite.helper.DB.Model(job).Update("callback_url", callback.URL)
+
+	transcript := `{"text":"hello"}`
+	w = suite.makeWorkerRequest("POST", "/api/v1/worker/jobs/"+job.ID+"/complete", created.Token, worker.CompleteRequest{
+		Status:     models.StatusCompleted,
+		Transcript: &transcript,
+		Execution: &models.TranscriptionJobExecution{
+			StartedAt: time.Now(),
+			Status:    models.StatusCompleted,
+		},
+		Logs: "remote log line\n",
+	})
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var updatedJob models.TranscriptionJob
+	suite.helper.DB.First(&updatedJob, "id = ?", job.ID)
+	assert.Equal(suite.T(), models.StatusCompleted, updatedJob.Status)
+	assert.Equal(suite.T(), transcript, *updatedJob.Transcript)
+
+	var executions int64
+	suite.helper.DB.Model(&models.TranscriptionJobExecution{}).Where("transcription_job_id = ?", job.ID).Count(&executions)
+	assert.Equal(suite.T(), int64(1), executions)
+
+	// The worker did not match speakers, so the earlier automatic match is reset
+	var mappings []models.SpeakerMapping
+	suite.helper.DB.Where("transcription_job_id = ?", job.ID).Order("original_speaker").Find(&mappings)
+	if assert.Len(suite.T(), mappings, 2) {
+		assert.Equal(suite.T(), "SPEAKER_00", mappings[0].CustomName)
+		assert.Nil(suite.T(), mappings[0].VoiceID)
+		assert.Equal(suite.T(), "Bob", mappings[1].CustomName)
+	}
+	var embeddings int64
+	suite.helper.DB.Model(&models.VoiceEmbedding{}).Where("transcription_job_id = ?", job.ID).Count(&embeddings)
+	assert.Equal(suite.T(), int64(0), embeddings)
+
+	select {
+	case payload := <-webhooks:
+		assert.Equal(suite.T(), job.ID, payload.JobID)
+		assert.Equal(suite.T(), models.StatusCompleted, payload.Status)
+		if assert.NotNil(suite.T(), payload.Transcript) {
+			assert.Equal(suite.T(), transcript, *payload.Transcript)
+		}
+	case <-time.After(5 * time.Second):
+		suite.T().Error("webhook was not sent")
+	}
+
+	logs, err := os.ReadFile(filepath.Join(suite.helper.Config.TranscriptsDir, job.ID, "transcription.log"))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "remote log line\n", string(logs))
+
+	// The lease is gone once the job is complete
+	w = suite.makeWorkerRequest("POST", "/api/v1/worker/jobs/"+job.ID+"/heartbeat", created.Token, nil)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+
+	// Revoked workers can no longer authenticate
+	w = suite.makeAuthenticatedRequest("DELETE", "/api/v1/admin/workers/"+created.Worker.ID, nil, false)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	w = suite.makeWorkerRequest("POST", "/api/v1/worker/lease", created.Token, nil)
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+}
+
+// Test a worker runner processing a job from a server over HTTP
+func (suite *APIHandlerTestSuite) TestRemoteWorkerRunner() {
+	transcriptsDir := suite.helper.Config.TranscriptsDir
+	suite.helper.Config.TranscriptsDir = suite.T().TempDir()
+	defer func() { suite.helper.Config.TranscriptsDir = transcriptsDir }()
+
+	server := httptest.NewServer(suite.router)
+	defer server.Close()
+
+	created := suite.registerTestWorker()
+	job := suite.createRemoteTestJob()
+
+	// The worker keeps its own database, like a separate process would
+	workerDataDir := suite.T().TempDir()
+	workerDB, err := gorm.Open(sqlite.Open(filepath.Join(workerDataDir, "worker.db")), &gorm.Config{})
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), workerDB.AutoMigrate(&models.TranscriptionJob{}, &models.TranscriptionJobExecution{}))
+	defer func() {
+		if sqlDB, err := workerDB.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}()
+
+	mockProcessor := &MockJobProcessor{}
+	mockProcessor.On("ProcessJobWithProcess", mock.Anything, job.ID).Return(nil)
+
+	runner := worker.NewRunner(
+		worker.NewClient(server.URL, created.Token),
+		mockProcessor,
+		repository.NewJobRepository(workerDB),
+		filepath.Join(workerDataDir, "audio"),
+		filepath.Join(workerDataDir, "transcripts"),
+	)
+	runner.PollInterval = 50 * time.Millisecond
+	applied := make(chan []models.CustomModel, 1)
+	runner.ApplyCustomModels = func(customModels []models.CustomModel) { applied <- customModels }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- runner.Run(ctx) }()
+
+	assert.Eventually(suite.T(), func() bool {
+		var updatedJob models.TranscriptionJob
+		return suite.helper.DB.First(&updatedJob, "id = ?", job.ID).Error == nil &&
+			updatedJob.Status == models.StatusCompleted
+	}, 5*time.Second, 50*time.Millisecond, "Remote worker should complete the job")
+
+	cancel()
+	assert.NoError(suite.T(), <-done)
+	mockProcessor.AssertExpectations(suite.T())
+	select {
+	case <-applied:
+	default:
+		suite.T().Error("Runner should apply the server's custom models")
+	}
+
+	// The local copy pointed at the downloaded audio, which is removed afterwards
+	localJob, err := repository.NewJobRepository(workerDB).FindByID(context.Background(), job.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), filepath.Join(workerDataDir, "audio", job.ID+".mp3"), localJob.AudioPath)
+	_, err = os.Stat(localJob.AudioPath)
+	assert.True(suite.T(), os.IsNotExist(err))
+}
@@ -35,6 +35,7 @@ import { FormField, Section, InfoBanner } from "@/components/transcription/FormH
 
 export interface WhisperXParams {
     model_family: string;
+    auto_quality?: string;
     model: string;
     model_cache_only: boolean;
     model_dir?: string;
@@ -59,6 +60,7 @@ export interface WhisperXParams {
     min_speakers?: number;
     max_speakers?: number;
     diarize_model: string;
+    speaker_assignment: string;
     speaker_embeddings: boolean;
     temperature: number;
     best_of: number;
@@ -82,11 +84,48 @@ export interface WhisperXParams {
     print_progress: boolean;
     attention_context_left: number;
     attention_context_right: number;
+    split_strategy: string;
+    split_silence_window: number;
+    split_overlap: number;
+    split_max_duration: number;
+    split_chunk_duration: number;
+    split_max_file_size_mb: number;
+    preprocessors?: string;
+    fallback_models?: string;
+    fallback_diarize_models?: string;
+    loudness_target: number;
+    denoise_method: string;
+    denoise_model?: string;
+    highpass_frequency: number;
     is_multi_track_enabled: boolean;
     api_key?: string;
     max_new_tokens?: number;
 }
 
+// Capabilities of a model as returned by /api/v1/transcription/models
+interface ModelCapabilities {
+    id: string;
+    family: string;
+    name: string;
+    metadata?: Record<string, string>;
+    custom_models?: CustomModel[];
+}
+
+// Fine-tuned checkpoint registered by an admin as a model variant of a local adapter
+interface CustomModel {
+    name: string;
+    display_name: string;
+    languages?: string[];
+}
+
+// Self-hosted server speaking the OpenAI transcription API, or a transcription plugin
+interface CompatibleServer {
+    id: string;
+    name: string;
+    models: string[];
+    defaultModel: string;
+}
+
 interface TranscriptionConfigDialogProps {
     open: boolean;
     onOpenChange: (open: boolean) => void;
@@ -121,6 +160,7 @@ const DEFAULT_PARAMS: WhisperXParams = {
     chunk_size: 30,
     diarize: false,
     diarize_model: "pyannote",
+    speaker_assignment: "word",
     speaker_embeddings: false,
     temperature: 0,
     best_of: 5,
@@ -139,6 +179,15 @@ const DEFAULT_PARAMS: WhisperXParams = {
     print_progress: false,
     attention_context_left: 256,
     attention_context_right: 256,
+    split_strategy: "fixed",
+    split_silence_window: 30,
+    split_overlap: 0,
+    split_max_duration: 0,
+    split_chunk_duration: 0,
+    split_max_file_size_mb: 0,
+    loudness_target: -16,
+    denoise_method: "afftdn",
+    highpass_frequency: 80,
     is_multi_track_enabled: false,
     api_key: "",
 };
@@ -148,6 +197,11 @@ const WHISPER_MODELS = [
     "medium", "medium.en", "large", "large-v1", "large-v2", "large-v3"
 ];
 
+const WHISPER_CPP_MODELS = [
+    "tiny", "tiny.en", "base", "base.en", "small", "small.en",
+    "medium", "medium.en", "large-v2", "large-v3", "large-v3-turbo", "large-v3-turbo-q5_0"
+];
+
 const LANGUAGES = [
     { value: "auto", label: "Auto-detect" },
     { value: "af", label: "Afrikaans" },
@@ -251,6 +305,7 @@ const PARAM_DESCRIPTIONS = {
     batch_size: "Segments processed at once. Higher = faster but more memory.",
     diarize: "Identify and separate different speakers.",
     diarize_model: "Pyannote (accurate, needs HF token) or NVIDIA Sortformer (up to 4 speakers).",
+    speaker_assignment: "Per word splits segments where the speaker changes. Per segment labels each segment with one speaker.",
     temperature: "0 = deterministic, higher = more creative.",
     beam_size: "Search beams. Higher = better quality but slower.",
     vad_method: "Voice detection: Pyannote (accurate) or Silero (fast).",
@@ -313,6 +368,34 @@ export const TranscriptionConfigDialog = memo(function TranscriptionConfigDialog
     const [validationMessage, setValidationMessage] = useState("");
     const { getAuthHeaders } = useAuth();
     const [availableModels, setAvailableModels] = useState<string[]>(["whisper-1"]);
+    const [compatibleServers, setCompatibleServers] = useState<CompatibleServer[]>([]);
+    const [customModels, setCustomModels] = useState<Record<string, CustomModel[]>>({});
+
+    // Load the OpenAI-compatible servers and transcription plugins configured on the backend
+    useEffect(() => {
+        if (!open) return;
+        fetch('/api/v1/transcription/models', { headers: getAuthHeaders() })
+            .then((response) => (response.ok ? response.json() : null))
+            .then((data) => {
+                const capabilities = Object.values(data?.models || {}) as ModelCapabilities[];
+                setCompatibleServers(capabilities
+                    .filter((c) => c.family === "openai_compatible"
+                        || (c.metadata?.plugin === "true" && c.metadata?.plugin_type === "transcription"))
+                    .map((c) => ({
+                        id: c.id,
+                        name: c.name || c.id,
+                        models: (c.metadata?.models || "").split(",").filter(Boolean),
+                        defaultModel: c.metadata?.default_model || "",
+                    })));
+                setCustomModels(Object.fromEntries(capabilities.map((c) => [c.id, c.custom_models || []])));
+            })
+            .catch(() => {
+                setCompatibleServers([]);
+                setCustomModels({});
+            });
+    }, [open, getAuthHeaders]);
+
+    const selectedServer = compatibleServers.find((s) => s.id === params.model_family);
 
     // Reset when dialog opens
     useEffect(() => {
@@ -334,6 +417,14 @@ export const TranscriptionConfigDialog = memo(function TranscriptionConfigDialog
             if (key === 'model_family' && value === 'whisper') {
                 newParams.diarize_model = 'pyannote';
             }
+            if (key === 'model_family' && value === 'whisper_cpp' && !WHISPER_CPP_MODELS.includes(newParams.model)
+                && !customModels.whisper_cpp?.some((m) => m.name === newParams.model)) {
+                newParams.model = 'small';
+            }
+            const server = compatibleServers.find((s) => s.id === value);
+            if (key === 'model_family' && server && !server.models.includes(newParams.model)) {
+                newParams.model = server.defaultModel;
+            }
             return newParams;
         });
     };
@@ -438,6 +529,9 @@ export const TranscriptionConfigDialog = memo(function TranscriptionConfigDialog
                                 <SelectValue />
                             </SelectTrigger>
                             <SelectContent className={selectContentClassName}>
+                                <SelectItem value="auto" className={selectItemClassName}>
+                                    Auto (by language)
+                                </SelectItem>
                                 <SelectItem value="whisper" className={selectItemClassName}>
                                     Whisper
                                 </SelectItem>
@@ -450,9 +544,17 @@ export const TranscriptionConfigDialog = memo(function TranscriptionConfigDialog
                                 <SelectItem value="mistral_voxtral" className={selectItemClassName}>
                                     Mistral Voxtral
                                 </SelectItem>
+                                <SelectItem value="whisper_cpp" className={selectItemClassName}>
+                                    whisper.cpp (CPU)
+                                </SelectItem>
                                 <SelectItem value="openai" className={selectItemClassName}>
                                     OpenAI
                                 </SelectItem>
+                                {compatibleServers.map((server) => (
+                                    <SelectItem key={server.id} value={server.id} className={selectItemClassName}>
+                                        {server.name}
+                                    </SelectItem>
+                                ))}
                             </SelectContent>
                         </Select>
                     </FormField>
@@ -465,11 +567,20 @@ export const TranscriptionConfigDialog = memo(function TranscriptionConfigDialog
                     )}
 
                     {/* Model-Specific Configuration */}
+                    {params.model_family === "auto" && (
+                        <AutoConfig
+                            params={params}
+                            updateParam={updateParam}
+                            isMultiTrack={isMultiTrack}
+                        />
+                    )}
+
                     {params.model_family === "whisper" && (
                         <WhisperConfig
                             params={params}
                             updateParam={updateParam}
                             isMultiTrack={isMultiTrack}
+                            customModels={customModels.whisperx}
                         />
                     )}
 
@@ -478,6 +589,7 @@ export const TranscriptionConfigDialog = memo(function TranscriptionConfigDialog
                             params={params}
                             updateParam={updateParam}
                             isMultiTrack={isMultiTrack}
+                            customModels={customModels.parakeet}
                         />
                     )}
 
@@ -486,6 +598,7 @@ export const TranscriptionConfigDialog = memo(function TranscriptionConfigDialog
                             params={params}
                             updateParam={updateParam}
                             isMultiTrack={isMultiTrack}
+                            customModels={customModels.canary}
                         />
                     )}
 
@@ -501,12 +614,45 @@ export const TranscriptionConfigDialog = memo(function TranscriptionConfigDialog
                         />
                     )}
 
+                    {params.model_family === "whisper_cpp" && (
+                        <WhisperCppConfig
+                            params={params}
+                            updateParam={updateParam}
+                            customModels={customModels.whisper_cpp}
+                        />
+                    )}
+
+                    {selectedServer && (
+                        <CompatibleServerConfig
+                            params={params}
+                            updateParam={updateParam}
+                            server={selectedServer}
+                        />
+                    )}
+
                     {params.model_family === "mistral_voxtral" && (
                         <VoxtralConfig
                             params={params}
                             updateParam={updateParam}
                         />
                     )}
+
+                    <EnhancementConfig
+                        params={params}
+                        updateParam={updateParam}
+                    />
+
+                    {!isMultiTrack && (
+                        <SplittingConfig
+                            params={params}
+                            updateParam={updateParam}
+                        />
+                    )}
+
+                    <FallbackConfig
+                        params={params}
+                        updateParam={updateParam}
+                    />
                 </div>
 
                 {/* Footer */}
@@ -546,9 +692,93 @@ interface ConfigProps {
     params: WhisperXParams;
     updateParam: <K extends keyof WhisperXParams>(key: K, value: WhisperXParams[K]) => void;
     isMultiTrack?: boolean;
+    customModels?: CustomModel[];
 }
 
-function WhisperConfig({ params, updateParam, isMultiTrack }: ConfigProps) {
+// Select items for the custom models of an adapter
+function CustomModelItems({ customModels }: { customModels?: CustomModel[] }) {
+    return (
+        <>
+            {(customModels || []).map((m) => (
+                <SelectItem key={m.name} value={m.name} className={selectItemClassName}>{m.display_name || m.name}</SelectItem>
+            ))}
+        </>
+    );
+}
+
+// Model picker for adapters with one bundled model, shown once custom models are registered
+function BundledModelSelect({ params, updateParam, customModels }: ConfigProps) {
+    if (!customModels?.length) return null;
+    const selected = customModels.some((m) => m.name === params.model) ? params.model : "bundled";
+    return (
+        <FormField label="Model" description="Bundled model or a registered fine-tuned variant">
+            <Select value={selected} onValueChange={(v) => updateParam('model', v === "bundled" ? "" : v)}>
+                <SelectTrigger className={selectTriggerClassName}>
+                    <SelectValue />
+                </SelectTrigger>
+                <SelectContent className={selectContentClassName}>
+                    <SelectItem value="bundled" className={selectItemClassName}>Bundled model</SelectItem>
+                    <CustomModelItems customModels={customModels} />
+                </SelectContent>
+            </Select>
+        </FormField>
+    );
+}
+
+function AutoConfig({ params, updateParam, isMultiTrack }: ConfigProps) {
+    return (
+        <div className="space-y-6">
+            <InfoBanner variant="info" title="Automatic Model Selection">
+                The language is identified from the first 30 seconds, then the best ready model for it is used. The other suitable models become fallbacks.
+            </InfoBanner>
+
+            <Section title="Routing">
+                <div className="space-y-4">
+                    <FormField label="Quality" description="Prefer faster or more accurate models">
+                        <Select value={params.auto_quality || "good"} onValueChange={(v) => updateParam('auto_quality', v)}>
+                            <SelectTrigger className={selectTriggerClassName}>
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent className={selectContentClassName}>
+                                <SelectItem value="fast" className={selectItemClassName}>Fast</SelectItem>
+                                <SelectItem value="good" className={selectItemClassName}>Balanced</SelectItem>
+                                <SelectItem value="best" className={selectItemClassName}>Best</SelectItem>
+                            </SelectContent>
+                        </Select>
+                    </FormField>
+
+                    <FormField label="Language" description="Set a language to skip detection">
+                        <Select value={params.language || "auto"} onValueChange={(v) => updateParam('language', v === "auto" ? undefined : v)}>
+                            <SelectTrigger className={selectTriggerClassName}>
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent className={selectContentClassName}>
+                                {LANGUAGES.map((l) => (
+                                    <SelectItem key={l.value} value={l.value} className={selectItemClassName}>{l.label}</SelectItem>
+                                ))}
+                            </SelectContent>
+                        </Select>
+                    </FormField>
+
+                    {!isMultiTrack && (
+                        <div className="flex items-center gap-3">
+                            <Switch
+                                id="auto_diarize"
+                                checked={params.diarize}
+                                onCheckedChange={(v) => updateParam('diarize', v)}
+                            />
+                            <label htmlFor="auto_diarize" className="text-sm text-[var(--text-primary)] cursor-pointer">
+                                Enable speaker identification
+                            </label>
+                        </div>
+                    )}
+                </div>
+            </Section>
+        </div>
+    );
+}
+
+function WhisperConfig({ params, updateParam, isMultiTrack, customModels }: ConfigProps) {
     return (
         <div className="space-y-6">
             {/* Essential Settings */}
@@ -563,6 +793,7 @@ function WhisperConfig({ params, updateParam, isMultiTrack }: ConfigProps) {
                                 {WHISPER_MODELS.map((m) => (
                                     <SelectItem key={m} value={m} className={selectItemClassName}>{m}</SelectItem>
                                 ))}
+                                <CustomModelItems customModels={customModels} />
                             </SelectContent>
                         </Select>
                     </FormField>
@@ -799,9 +1030,15 @@ function WhisperConfig({ params, updateParam, isMultiTrack }: ConfigProps) {
     );
 }
 
-function ParakeetConfig({ params, updateParam, isMultiTrack }: ConfigProps) {
+function ParakeetConfig({ params, updateParam, isMultiTrack, customModels }: ConfigProps) {
     return (
         <div className="space-y-6">
+            {customModels?.length ? (
+                <Section title="Model Settings">
+                    <BundledModelSelect params={params} updateParam={updateParam} customModels={customModels} />
+                </Section>
+            ) : null}
+
             {/* Long-form Audio Settings */}
             <Section title="Audio Context" description="Configure how much context the model uses for long audio files">
                 <div className="grid grid-cols-1 sm:grid-cols-2 gap-6">
@@ -872,6 +1109,18 @@ function ParakeetConfig({ params, updateParam, isMultiTrack }: ConfigProps) {
                                     </Select>
                                 </FormField>
 
+                                <FormField label="Speaker Assignment" description={PARAM_DESCRIPTIONS.speaker_assignment}>
+                                    <Select value={params.speaker_assignment || "word"} onValueChange={(v) => updateParam('speaker_assignment', v)}>
+                                        <SelectTrigger className={selectTriggerClassName}>
+                                            <SelectValue />
+                                        </SelectTrigger>
+                                        <SelectContent className={selectContentClassName}>
+                                            <SelectItem value="word" className={selectItemClassName}>Per word</SelectItem>
+                                            <SelectItem value="segment" className={selectItemClassName}>Per segment</SelectItem>
+                                        </SelectContent>
+                                    </Select>
+                                </FormField>
+
                                 <div className="grid grid-cols-2 gap-4">
                                     <FormField label="Min Speakers" optional>
                                         <Input
@@ -947,10 +1196,11 @@ function ParakeetConfig({ params, updateParam, isMultiTrack }: ConfigProps) {
     );
 }
 
-function CanaryConfig({ params, updateParam, isMultiTrack }: ConfigProps) {
+function CanaryConfig({ params, updateParam, isMultiTrack, customModels }: ConfigProps) {
     return (
         <div className="space-y-6">
             <Section title="Language Settings">
+                <BundledModelSelect params={params} updateParam={updateParam} customModels={customModels} />
                 <FormField label="Source Language">
                     <Select value={params.language || "en"} onValueChange={(v) => updateParam('language', v)}>
                         <SelectTrigger className={selectTriggerClassName}>
@@ -994,6 +1244,18 @@ function CanaryConfig({ params, updateParam, isMultiTrack }: ConfigProps) {
                                     </Select>
                                 </FormField>
 
+                                <FormField label="Speaker Assignment" description={PARAM_DESCRIPTIONS.speaker_assignment}>
+                                    <Select value={params.speaker_assignment || "word"} onValueChange={(v) => updateParam('speaker_assignment', v)}>
+                                        <SelectTrigger className={selectTriggerClassName}>
+                                            <SelectValue />
+                                        </SelectTrigger>
+                                        <SelectContent className={selectContentClassName}>
+                                            <SelectItem value="word" className={selectItemClassName}>Per word</SelectItem>
+                                            <SelectItem value="segment" className={selectItemClassName}>Per segment</SelectItem>
+                                        </SelectContent>
+                                    </Select>
+                                </FormField>
+
                                 <div className="grid grid-cols-2 gap-4">
                                     <FormField label="Min Speakers" optional>
                                         <Input
@@ -1156,6 +1418,155 @@ function OpenAIConfig({
     );
 }
 
+function WhisperCppConfig({ params, updateParam, customModels }: ConfigProps) {
+    return (
+        <div className="space-y-6">
+            <Section title="Model Settings" description="GGML models are downloaded on first use">
+                <div className="grid grid-cols-1 sm:grid-cols-2 gap-4">
+                    <FormField label="Model" description={PARAM_DESCRIPTIONS.model}>
+                        <Select value={params.model} onValueChange={(v) => updateParam('model', v)}>
+                            <SelectTrigger className={selectTriggerClassName}>
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent className={selectContentClassName}>
+                                {WHISPER_CPP_MODELS.map((m) => (
+                                    <SelectItem key={m} value={m} className={selectItemClassName}>{m}</SelectItem>
+                                ))}
+                                <CustomModelItems customModels={customModels} />
+                            </SelectContent>
+                        </Select>
+                    </FormField>
+
+                    <FormField label="Language" description={PARAM_DESCRIPTIONS.language}>
+                        <Select value={params.language || "auto"} onValueChange={(v) => updateParam('language', v === "auto" ? undefined : v)}>
+                            <SelectTrigger className={selectTriggerClassName}>
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent className={selectContentClassName}>
+                                {LANGUAGES.map((l) => (
+                                    <SelectItem key={l.value} value={l.value} className={selectItemClassName}>{l.label}</SelectItem>
+                                ))}
+                            </SelectContent>
+                        </Select>
+                    </FormField>
+
+                    <FormField label="Task" description={PARAM_DESCRIPTIONS.task}>
+                        <Select value={params.task} onValueChange={(v) => updateParam('task', v)}>
+                            <SelectTrigger className={selectTriggerClassName}>
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent className={selectContentClassName}>
+                                <SelectItem value="transcribe" className={selectItemClassName}>Transcribe</SelectItem>
+                                <SelectItem value="translate" className={selectItemClassName}>Translate to English</SelectItem>
+                            </SelectContent>
+                        </Select>
+                    </FormField>
+
+                    <FormField label="Threads" description="CPU threads, 0 for the whisper.cpp default">
+                        <Input
+                            type="number"
+                            min={0}
+                            max={64}
+                            value={params.threads}
+                            onChange={(e) => updateParam('threads', parseInt(e.target.value) || 0)}
+                            className={inputClassName}
+                        />
+                    </FormField>
+                </div>
+            </Section>
+
+            <Section title="Decoding">
+                <div className="space-y-4">
+                    <div className="grid grid-cols-2 gap-4">
+                        <FormField label="Beam Size">
+                            <Input
+                                type="number"
+                                min={1}
+                                max={16}
+                                value={params.beam_size}
+                                onChange={(e) => updateParam('beam_size', parseInt(e.target.value) || 5)}
+                                className={inputClassName}
+                            />
+                        </FormField>
+                        <FormField label="Temperature">
+                            <Input
+                                type="number"
+                                min={0}
+                                max={1}
+                                step={0.1}
+                                value={params.temperature}
+                                onChange={(e) => updateParam('temperature', parseFloat(e.target.value) || 0)}
+                                className={inputClassName}
+                            />
+                        </FormField>
+                    </div>
+
+                    <FormField label="Initial Prompt" description={PARAM_DESCRIPTIONS.initial_prompt} optional>
+                        <Textarea
+                            placeholder="Optional context to guide transcription..."
+                            value={params.initial_prompt || ""}
+                            onChange={(e) => updateParam('initial_prompt', e.target.value || undefined)}
+                            className={`${inputClassName} resize-none min-h-[80px]`}
+                            rows={2}
+                        />
+                    </FormField>
+                </div>
+            </Section>
+        </div>
+    );
+}
+
+interface CompatibleServerConfigProps extends ConfigProps {
+    server: CompatibleServer;
+}
+
+function CompatibleServerConfig({ params, updateParam, server }: CompatibleServerConfigProps) {
+    const model = server.models.includes(params.model) ? params.model : server.defaultModel;
+
+    return (
+        <div className="space-y-6">
+            <Section title="Model Configuration">
+                <div className="space-y-4">
+                    <FormField label="Model" description="Models offered by this server or plugin.">
+                        {server.models.length > 0 ? (
+                            <Select value={model} onValueChange={(v) => updateParam('model', v)}>
+                                <SelectTrigger className={selectTriggerClassName}>
+                                    <SelectValue placeholder="Server default" />
+                                </SelectTrigger>
+                                <SelectContent className={selectContentClassName}>
+                                    {server.models.map((m) => (
+                                        <SelectItem key={m} value={m} className={selectItemClassName}>{m}</SelectItem>
+                                    ))}
+                                </SelectContent>
+                            </Select>
+                        ) : (
+                            <Input
+                                value={params.model || ""}
+                                onChange={(e) => updateParam('model', e.target.value)}
+                                placeholder="Model name"
+                                className={inputClassName}
+                            />
+                        )}
+                    </FormField>
+
+                    <FormField label="Language">
+                        <Select value={params.language || "auto"} onValueChange={(v) => updateParam('language', v === "auto" ? undefined : v)}>
+                            <SelectTrigger className={selectTriggerClassName}>
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent className={selectContentClassName}>
+                                {LANGUAGES.map((l) => (
+                                    <SelectItem key={l.value} value={l.value} className={selectItemClassName}>{l.label}</SelectItem>
+                                ))}
+                            </SelectContent>
+                        </Select>
+                    </FormField>
+                </div>
+            </Section>
+        </div>
+    );
+}
+
 function VoxtralConfig({ params, updateParam }: ConfigProps) {
     return (
         <div className="space-y-6">
@@ -1202,3 +1613,205 @@ function VoxtralConfig({ params, updateParam }: ConfigProps) {
         </div>
     );
 }
+
+const ENHANCEMENT_STEPS = [
+    { id: "highpass", label: "High-pass filter (remove rumble and hum)" },
+    { id: "denoise", label: "Reduce background noise" },
+    { id: "loudnorm", label: "Normalize loudness (EBU R128)" },
+    { id: "trim_silence", label: "Trim leading and trailing silence" },
+];
+
+function EnhancementConfig({ params, updateParam }: ConfigProps) {
+    // Entries are "step" or "step:policy"
+    const entries = (params.preprocessors || "").split(",").map((s) => s.trim()).filter(Boolean);
+    const enabled = entries.map((e) => e.split(":")[0]);
+    const required = entries.length > 0 && entries.every((e) => e.endsWith(":required"));
+
+    // Steps always run in the order listed above
+    const setSteps = (steps: string[], failJob: boolean) => {
+        const value = steps.map((s) => (failJob ? `${s}:required` : s)).join(",");
+        updateParam('preprocessors', value || undefined);
+    };
+    const toggleStep = (id: string, on: boolean) => {
+        const next = ENHANCEMENT_STEPS.map((s) => s.id).filter((s) => (s === id ? on : enabled.includes(s)));
+        setSteps(next, required);
+    };
+
+    return (
+        <Section title="Audio Enhancement" description="Clean up quiet or noisy recordings before transcription">
+            <div className="space-y-4">
+                {ENHANCEMENT_STEPS.map((step) => (
+                    <div key={step.id} className="flex items-center gap-3">
+                        <Switch
+                            id={`enhance_${step.id}`}
+                            checked={enabled.includes(step.id)}
+                            onCheckedChange={(v) => toggleStep(step.id, v)}
+                        />
+                        <label htmlFor={`enhance_${step.id}`} className="text-sm text-[var(--text-primary)] cursor-pointer">
+                            {step.label}
+                        </label>
+                    </div>
+                ))}
+
+                {enabled.length > 0 && (
+                    <FormField label="If a Step Fails" description="Continuing keeps the audio as it was before the failed step">
+                        <Select value={required ? "required" : "optional"} onValueChange={(v) => setSteps(enabled, v === "required")}>
+                            <SelectTrigger className={selectTriggerClassName}>
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent className={selectContentClassName}>
+                                <SelectItem value="optional" className={selectItemClassName}>Continue without it</SelectItem>
+                                <SelectItem value="required" className={selectItemClassName}>Fail the job</SelectItem>
+                            </SelectContent>
+                        </Select>
+                    </FormField>
+                )}
+
+                {enabled.includes("highpass") && (
+                    <FormField label="High-pass Cutoff (Hz)" description="Frequencies below this are removed">
+                        <Input
+                            type="number"
+                            min={20}
+                            max={400}
+                            value={params.highpass_frequency || 80}
+                            onChange={(e) => updateParam('highpass_frequency', parseInt(e.target.value) || 80)}
+                            className={inputClassName}
+                        />
+                    </FormField>
+                )}
+
+                {enabled.includes("denoise") && (
+                    <>
+                        <FormField label="Denoiser" description="RNNoise works better on speech but needs a model file">
+                            <Select value={params.denoise_method || "afftdn"} onValueChange={(v) => updateParam('denoise_method', v)}>
+                                <SelectTrigger className={selectTriggerClassName}>
+                                    <SelectValue />
+                                </SelectTrigger>
+                                <SelectContent className={selectContentClassName}>
+                                    <SelectItem value="afftdn" className={selectItemClassName}>FFT denoiser (afftdn)</SelectItem>
+                                    <SelectItem value="arnndn" className={selectItemClassName}>RNNoise (arnndn)</SelectItem>
+                                </SelectContent>
+                            </Select>
+                        </FormField>
+
+                        {params.denoise_method === "arnndn" && (
+                            <FormField label="RNNoise Model" description="Path to an .rnnn model file on the server">
+                                <Input
+                                    placeholder="/models/rnnoise/std.rnnn"
+                                    value={params.denoise_model || ""}
+                                    onChange={(e) => updateParam('denoise_model', e.target.value || undefined)}
+                                    className={inputClassName}
+                                />
+                            </FormField>
+                        )}
+                    </>
+                )}
+
+                {enabled.includes("loudnorm") && (
+                    <FormField label="Target Loudness (LUFS)" description="Integrated loudness the audio is normalized to">
+                        <Input
+                            type="number"
+                            min={-40}
+                            max={-5}
+                            value={params.loudness_target || -16}
+                            onChange={(e) => updateParam('loudness_target', parseFloat(e.target.value) || -16)}
+                            className={inputClassName}
+                        />
+                    </FormField>
+                )}
+            </div>
+        </Section>
+    );
+}
+
+function SplittingConfig({ params, updateParam }: ConfigProps) {
+    return (
+        <Section title="Long Audio" description="How recordings longer than the model accepts are cut into chunks">
+            <div className="space-y-4">
+                <FormField label="Cut Points" description="Cutting in pauses avoids words being split across chunks">
+                    <Select value={params.split_strategy || "fixed"} onValueChange={(v) => updateParam('split_strategy', v)}>
+                        <SelectTrigger className={selectTriggerClassName}>
+                            <SelectValue />
+                        </SelectTrigger>
+                        <SelectContent className={selectContentClassName}>
+                            <SelectItem value="fixed" className={selectItemClassName}>Fixed intervals</SelectItem>
+                            <SelectItem value="silence" className={selectItemClassName}>Nearest pause</SelectItem>
+                        </SelectContent>
+                    </Select>
+                </FormField>
+
+                {params.split_strategy === "silence" && (
+                    <FormField label="Search Window (seconds)" description="How far from each fixed cut point to look for a pause">
+                        <Input
+                            type="number"
+                            min={1}
+                            max={150}
+                            value={params.split_silence_window || 30}
+                            onChange={(e) => updateParam('split_silence_window', parseFloat(e.target.value) || 30)}
+                            className={inputClassName}
+                        />
+                    </FormField>
+                )}
+
+                <FormField label="Split Above (seconds)" description="Longest recording sent to the model in one piece. 0 uses the model's own limit.">
+                    <Input
+                        type="number"
+                        min={0}
+                        value={params.split_max_duration || 0}
+                        onChange={(e) => updateParam('split_max_duration', parseFloat(e.target.value) || 0)}
+                        className={inputClassName}
+                    />
+                </FormField>
+
+                <FormField label="Chunk Length (seconds)" description="Preferred chunk length once a recording is split. 0 uses the model default.">
+                    <Input
+                        type="number"
+                        min={0}
+                        value={params.split_chunk_duration || 0}
+                        onChange={(e) => updateParam('split_chunk_duration', parseFloat(e.target.value) || 0)}
+                        className={inputClassName}
+                    />
+                </FormField>
+
+                <FormField label="Overlap (seconds)" description="Audio shared by neighbouring chunks. Words at the seams are transcribed twice and kept once.">
+                    <Input
+                        type="number"
+                        min={0}
+                        max={30}
+                        value={params.split_overlap || 0}
+                        onChange={(e) => updateParam('split_overlap', parseFloat(e.target.value) || 0)}
+                        className={inputClassName}
+                    />
+                </FormField>
+            </div>
+        </Section>
+    );
+}
+
+function FallbackConfig({ params, updateParam }: ConfigProps) {
+    return (
+        <Section title="Fallback Models" description="Tried in order when the selected model is not ready or fails">
+            <div className="space-y-4">
+                <FormField label="Transcription" description="Comma-separated model families, optionally with a model, e.g. openai:whisper-1, whisper_cpp:small">
+                    <Input
+                        value={params.fallback_models || ""}
+                        onChange={(e) => updateParam('fallback_models', e.target.value || undefined)}
+                        placeholder="None"
+                        className={inputClassName}
+                    />
+                </FormField>
+
+                {params.diarize && (
+                    <FormField label="Diarization" description="Comma-separated diarization models, e.g. nvidia_sortformer">
+                        <Input
+                            value={params.fallback_diarize_models || ""}
+                            onChange={(e) => updateParam('fallback_diarize_models', e.target.value || undefined)}
+                            placeholder="None"
+                            className={inputClassName}
+                        />
+                    </FormField>
+                )}
+            </div>
+        </Section>
+    );
+}